@@ -0,0 +1,167 @@
+package auction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const LotDetailsFastBatchSize = 100
+
+// Get all lot details using multicall
+func GetLotsFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, opts *bind.CallOpts) ([]LotDetails, error) {
+	return getAllLotDetailsFast(rp, multicallerAddress, nil, opts)
+}
+
+// Get all lot details with bids from an address using multicall
+func GetLotsWithBidsFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, bidder common.Address, opts *bind.CallOpts) ([]LotDetails, error) {
+	return getAllLotDetailsFast(rp, multicallerAddress, &bidder, opts)
+}
+
+// Get the details of specific lots using multicall; if bidder is nil, the address bid amounts are left empty
+func GetLotDetailsFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, lotIndices []uint64, bidder *common.Address, opts *bind.CallOpts) ([]LotDetails, error) {
+	rocketAuctionManager, err := getRocketAuctionManager(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Load lot details in batches
+	count := len(lotIndices)
+	details := make([]LotDetails, count)
+	raw := make([]lotDetailsRaw, count)
+	for bsi := 0; bsi < count; bsi += LotDetailsFastBatchSize {
+
+		// Get batch start & end index
+		lsi := bsi
+		lei := bsi + LotDetailsFastBatchSize
+		if lei > count {
+			lei = count
+		}
+
+		// Add the calls
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, err
+		}
+		for li := lsi; li < lei; li++ {
+			index := big.NewInt(0).SetUint64(lotIndices[li])
+			details[li].Index = lotIndices[li]
+			mc.AddCall(rocketAuctionManager, &details[li].Exists, "getLotExists", index)
+			mc.AddCall(rocketAuctionManager, &raw[li].startBlock, "getLotStartBlock", index)
+			mc.AddCall(rocketAuctionManager, &raw[li].endBlock, "getLotEndBlock", index)
+			mc.AddCall(rocketAuctionManager, &details[li].StartPrice, "getLotStartPrice", index)
+			mc.AddCall(rocketAuctionManager, &details[li].ReservePrice, "getLotReservePrice", index)
+			mc.AddCall(rocketAuctionManager, &details[li].PriceAtCurrentBlock, "getLotPriceAtCurrentBlock", index)
+			mc.AddCall(rocketAuctionManager, &details[li].PriceByTotalBids, "getLotPriceByTotalBids", index)
+			mc.AddCall(rocketAuctionManager, &details[li].CurrentPrice, "getLotCurrentPrice", index)
+			mc.AddCall(rocketAuctionManager, &details[li].TotalRPLAmount, "getLotTotalRPLAmount", index)
+			mc.AddCall(rocketAuctionManager, &details[li].ClaimedRPLAmount, "getLotClaimedRPLAmount", index)
+			mc.AddCall(rocketAuctionManager, &details[li].RemainingRPLAmount, "getLotRemainingRPLAmount", index)
+			mc.AddCall(rocketAuctionManager, &details[li].TotalBidAmount, "getLotTotalBidAmount", index)
+			mc.AddCall(rocketAuctionManager, &details[li].Cleared, "getLotIsCleared", index)
+			mc.AddCall(rocketAuctionManager, &details[li].RPLRecovered, "getLotRPLRecovered", index)
+			if bidder != nil {
+				mc.AddCall(rocketAuctionManager, &details[li].AddressBidAmount, "getLotAddressBidAmount", index, *bidder)
+			}
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, fmt.Errorf("error getting lot details: %w", err)
+		}
+
+	}
+
+	// Convert the block numbers
+	for i := range details {
+		details[i].StartBlock = raw[i].startBlock.Uint64()
+		details[i].EndBlock = raw[i].endBlock.Uint64()
+	}
+
+	// Return
+	return details, nil
+
+}
+
+// Get a transaction that creates a new lot
+func CreateLotTransaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: "create lot",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateCreateLotGas(rp, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			_, hash, err := CreateLot(rp, opts)
+			return hash, err
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketAuctionManager", "createLot"),
+	}
+}
+
+// Get a transaction that places a bid on a lot; the bid amount is the value of the transact options
+func PlaceBidTransaction(rp *rocketpool.RocketPool, lotIndex uint64) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("place bid on lot %d", lotIndex),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimatePlaceBidGas(rp, lotIndex, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return PlaceBid(rp, lotIndex, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketAuctionManager", "placeBid", big.NewInt(int64(lotIndex))),
+	}
+}
+
+// Get a transaction that claims the RPL from a bid on a lot
+func ClaimBidTransaction(rp *rocketpool.RocketPool, lotIndex uint64) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("claim bid on lot %d", lotIndex),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateClaimBidGas(rp, lotIndex, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ClaimBid(rp, lotIndex, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketAuctionManager", "claimBid", big.NewInt(int64(lotIndex))),
+	}
+}
+
+// Get a transaction that recovers the unclaimed RPL from a lot
+func RecoverUnclaimedRPLTransaction(rp *rocketpool.RocketPool, lotIndex uint64) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("recover unclaimed RPL from lot %d", lotIndex),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateRecoverUnclaimedRPLGas(rp, lotIndex, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return RecoverUnclaimedRPL(rp, lotIndex, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketAuctionManager", "recoverUnclaimedRPL", big.NewInt(int64(lotIndex))),
+	}
+}
+
+// Raw lot values that need converting after a multicall
+type lotDetailsRaw struct {
+	startBlock *big.Int
+	endBlock   *big.Int
+}
+
+// Get the details of every lot using multicall
+func getAllLotDetailsFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, bidder *common.Address, opts *bind.CallOpts) ([]LotDetails, error) {
+	lotCount, err := GetLotCount(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	lotIndices := make([]uint64, lotCount)
+	for i := range lotIndices {
+		lotIndices[i] = uint64(i)
+	}
+	return GetLotDetailsFast(rp, multicallerAddress, lotIndices, bidder, opts)
+}
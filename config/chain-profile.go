@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The addresses and chain parameters the library needs for a network
+// Custom profiles can be used for forks and private networks; zero addresses mean the contract isn't available
+type ChainProfile struct {
+	Name                   string         `json:"name"`
+	ChainID                uint64         `json:"chainId"`
+	RocketStorageAddress   common.Address `json:"rocketStorageAddress"`
+	MulticallAddress       common.Address `json:"multicallAddress"`
+	BalanceBatcherAddress  common.Address `json:"balanceBatcherAddress"`
+	DepositContractAddress common.Address `json:"depositContractAddress"`
+	GenesisTime            uint64         `json:"genesisTime"` // The Beacon Chain genesis time
+	GenesisForkVersion     [4]byte        `json:"genesisForkVersion"`
+	GenesisValidatorsRoot  common.Hash    `json:"genesisValidatorsRoot"`
+}
+
+// Ethereum mainnet
+var Mainnet = ChainProfile{
+	Name:                   "mainnet",
+	ChainID:                1,
+	RocketStorageAddress:   common.HexToAddress("0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"),
+	MulticallAddress:       common.HexToAddress("0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696"),
+	BalanceBatcherAddress:  common.HexToAddress("0xb1F8e55c7f64D203C1400B9D8555d050F94aDF39"),
+	DepositContractAddress: common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+	GenesisTime:            1606824023,
+	GenesisForkVersion:     [4]byte{0x00, 0x00, 0x00, 0x00},
+	GenesisValidatorsRoot:  common.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+}
+
+// The Holesky testnet
+var Holesky = ChainProfile{
+	Name:                   "holesky",
+	ChainID:                17000,
+	RocketStorageAddress:   common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
+	MulticallAddress:       common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"),
+	BalanceBatcherAddress:  common.HexToAddress("0xfAa2e7C84eD801dd9D27Ac1ed957274530796140"),
+	DepositContractAddress: common.HexToAddress("0x4242424242424242424242424242424242424242"),
+	GenesisTime:            1695902400,
+	GenesisForkVersion:     [4]byte{0x01, 0x01, 0x70, 0x00},
+	GenesisValidatorsRoot:  common.HexToHash("0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"),
+}
+
+// A local Hardhat network with the Rocket Pool contracts deployed from the test mnemonic
+// The utility contracts aren't part of the Rocket Pool deployment, so their addresses must be set after deploying them
+var Hardhat = ChainProfile{
+	Name:                 "hardhat",
+	ChainID:              31337,
+	RocketStorageAddress: common.HexToAddress("0x70a5F2eB9e4C003B105399b471DAeDbC8d00B1c5"),
+}
+
+// The built-in profiles by name
+var builtInProfiles = map[string]ChainProfile{
+	Mainnet.Name: Mainnet,
+	Holesky.Name: Holesky,
+	Hardhat.Name: Hardhat,
+}
+
+// Get the names of the built-in profiles
+func GetChainProfileNames() []string {
+	names := make([]string, 0, len(builtInProfiles))
+	for name := range builtInProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get a built-in profile by name (e.g. mainnet or holesky)
+func GetChainProfile(name string) (ChainProfile, error) {
+	profile, exists := builtInProfiles[strings.ToLower(name)]
+	if !exists {
+		return ChainProfile{}, fmt.Errorf("no chain profile for %s (known profiles: %s)", name, strings.Join(GetChainProfileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// Get a built-in profile by chain ID
+func GetChainProfileByChainID(chainID uint64) (ChainProfile, error) {
+	for _, profile := range builtInProfiles {
+		if profile.ChainID == chainID {
+			return profile, nil
+		}
+	}
+	return ChainProfile{}, fmt.Errorf("no chain profile for chain ID %d", chainID)
+}
@@ -2,12 +2,59 @@ package dao
 
 import (
 	"fmt"
+	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
+// A recurring payment from the Protocol DAO treasury, created by a recurring treasury spend proposal
+type PaymentContract struct {
+	Name            string         `json:"name"`
+	Recipient       common.Address `json:"recipient"`
+	AmountPerPeriod *big.Int       `json:"amountPerPeriod"`
+	PeriodLength    time.Duration  `json:"periodLength"`
+	LastPaymentTime time.Time      `json:"lastPaymentTime"`
+	NumPeriods      uint64         `json:"numPeriods"`
+	PeriodsPaid     uint64         `json:"periodsPaid"`
+}
+
+// The raw payment contract returned by RocketClaimDAO
+type paymentContractRaw struct {
+	Recipient       common.Address
+	AmountPerPeriod *big.Int
+	PeriodLength    *big.Int
+	LastPaymentTime *big.Int
+	NumPeriods      *big.Int
+	PeriodsPaid     *big.Int
+}
+
+// Get the number of periods that have completed but haven't been paid out yet at the given time
+func (c PaymentContract) GetPendingPeriods(at time.Time) uint64 {
+	if c.PeriodLength <= 0 || !at.After(c.LastPaymentTime) || c.PeriodsPaid >= c.NumPeriods {
+		return 0
+	}
+	periods := uint64(at.Sub(c.LastPaymentTime) / c.PeriodLength)
+	if remaining := c.NumPeriods - c.PeriodsPaid; periods > remaining {
+		return remaining
+	}
+	return periods
+}
+
+// Get the amount that would be added to the recipient's balance if the contract was paid out at the given time
+func (c PaymentContract) GetPendingAmount(at time.Time) *big.Int {
+	amount := new(big.Int).SetUint64(c.GetPendingPeriods(at))
+	return amount.Mul(amount, c.AmountPerPeriod)
+}
+
+// Check if every period of the contract has been paid out
+func (c PaymentContract) IsComplete() bool {
+	return c.PeriodsPaid >= c.NumPeriods
+}
+
 func GetContractExists(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (bool, error) {
 	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
 	if err != nil {
@@ -20,6 +67,149 @@ func GetContractExists(rp *rocketpool.RocketPool, contractName string, opts *bin
 	return *result, nil
 }
 
+// Get a recurring treasury payment contract by name
+func GetContract(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (PaymentContract, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
+	if err != nil {
+		return PaymentContract{}, err
+	}
+	raw := new(paymentContractRaw)
+	if err := rocketClaimDAO.Call(opts, raw, "getContract", contractName); err != nil {
+		return PaymentContract{}, fmt.Errorf("error getting contract %s: %w", contractName, err)
+	}
+	return PaymentContract{
+		Name:            contractName,
+		Recipient:       raw.Recipient,
+		AmountPerPeriod: raw.AmountPerPeriod,
+		PeriodLength:    time.Duration(raw.PeriodLength.Uint64()) * time.Second,
+		LastPaymentTime: time.Unix(raw.LastPaymentTime.Int64(), 0),
+		NumPeriods:      raw.NumPeriods.Uint64(),
+		PeriodsPaid:     raw.PeriodsPaid.Uint64(),
+	}, nil
+}
+
+// Get the RPL that has been paid out to a recipient by the treasury but not withdrawn yet
+func GetBalance(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	balance := new(*big.Int)
+	if err := rocketClaimDAO.Call(opts, balance, "getBalance", recipient); err != nil {
+		return nil, fmt.Errorf("error getting treasury balance of %s: %w", recipient.Hex(), err)
+	}
+	return *balance, nil
+}
+
+// Estimate the gas of PayOutContracts
+func EstimatePayOutContractsGas(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "payOutContracts", contractNames)
+}
+
+// Pay the completed periods of recurring treasury payment contracts into their recipients' balances; callable by anyone
+func PayOutContracts(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "payOutContracts", contractNames)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error paying out treasury contracts: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that pays out recurring treasury payment contracts
+func PayOutContractsTransaction(rp *rocketpool.RocketPool, contractNames []string) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("pay out %d treasury contracts", len(contractNames)),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimatePayOutContractsGas(rp, contractNames, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return PayOutContracts(rp, contractNames, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketClaimDAO", "payOutContracts", contractNames),
+	}
+}
+
+// Estimate the gas of PayOutContractsAndWithdraw
+func EstimatePayOutContractsAndWithdrawGas(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "payOutContractsAndWithdraw", contractNames)
+}
+
+// Pay out recurring treasury payment contracts and withdraw the caller's balance; the caller must be the recipient of
+// every contract
+func PayOutContractsAndWithdraw(rp *rocketpool.RocketPool, contractNames []string, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "payOutContractsAndWithdraw", contractNames)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error paying out and withdrawing treasury contracts: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that pays out recurring treasury payment contracts and withdraws the recipient's balance
+func PayOutContractsAndWithdrawTransaction(rp *rocketpool.RocketPool, contractNames []string) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("pay out and withdraw %d treasury contracts", len(contractNames)),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimatePayOutContractsAndWithdrawGas(rp, contractNames, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return PayOutContractsAndWithdraw(rp, contractNames, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketClaimDAO", "payOutContractsAndWithdraw", contractNames),
+	}
+}
+
+// Estimate the gas of WithdrawBalance
+func EstimateWithdrawBalanceGas(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketClaimDAO.GetTransactionGasInfo(opts, "withdrawBalance", recipient)
+}
+
+// Withdraw a recipient's treasury balance to the recipient; only callable by the recipient
+func WithdrawBalance(rp *rocketpool.RocketPool, recipient common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketClaimDAO, err := getRocketClaimDAO(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketClaimDAO.Transact(opts, "withdrawBalance", recipient)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error withdrawing treasury balance of %s: %w", recipient.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that withdraws a recipient's treasury balance
+func WithdrawBalanceTransaction(rp *rocketpool.RocketPool, recipient common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("withdraw treasury balance of %s", recipient.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateWithdrawBalanceGas(rp, recipient, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return WithdrawBalance(rp, recipient, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketClaimDAO", "withdrawBalance", recipient),
+	}
+}
+
 // Get contracts
 var rocketClaimDAOLock sync.Mutex
 
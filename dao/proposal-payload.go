@@ -3,6 +3,7 @@ package dao
 import (
 	"encoding/hex"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -29,6 +30,13 @@ func GetProposalPayloadString(rp *rocketpool.RocketPool, daoName string, payload
 		return "", fmt.Errorf("error getting '%s' DAO contract ABI: %w", daoName, err)
 	}
 
+	return FormatProposalPayload(daoContractAbi, payload)
+}
+
+// Format a proposal payload as a human-readable method call using the ABI of the DAO it was made to
+// The payload comes from the chain and is untrusted, so malformed payloads produce errors rather than panics
+func FormatProposalPayload(daoContractAbi *abi.ABI, payload []byte) (string, error) {
+
 	// Get proposal payload method
 	method, err := daoContractAbi.MethodById(payload)
 	if err != nil {
@@ -50,7 +58,10 @@ func GetProposalPayloadString(rp *rocketpool.RocketPool, daoName string, payload
 		case abi.HashTy:
 			argStrs = append(argStrs, arg.(common.Hash).Hex())
 		case abi.FixedBytesTy:
-			fallthrough
+			value := reflect.ValueOf(arg)
+			fixedBytes := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(fixedBytes), value)
+			argStrs = append(argStrs, hex.EncodeToString(fixedBytes))
 		case abi.BytesTy:
 			argStrs = append(argStrs, hex.EncodeToString(arg.([]byte)))
 		default:
@@ -637,6 +637,26 @@ func GetProposalMemberSupported(rp *rocketpool.RocketPool, proposalId uint64, me
 	return *supported, nil
 }
 
+// Check that a proposal can be executed
+// Returns an error wrapping rocketpool.ErrOutsideWindow if it has expired, or rocketpool.ErrConsensusNotReached if it
+// has not succeeded
+func CheckProposalExecutable(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) error {
+	state, err := GetProposalState(rp, proposalId, opts)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case rptypes.Succeeded:
+		return nil
+	case rptypes.Expired:
+		return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d has expired", proposalId)
+	case rptypes.Pending, rptypes.Active, rptypes.Defeated:
+		return rocketpool.NewError(rocketpool.ErrConsensusNotReached, "proposal %d has not succeeded (state: %s)", proposalId, state.String())
+	default:
+		return fmt.Errorf("proposal %d cannot be executed (state: %s)", proposalId, state.String())
+	}
+}
+
 // Get contracts
 var rocketDAOProposalLock sync.Mutex
 
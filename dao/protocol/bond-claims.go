@@ -0,0 +1,186 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The tree index of a proposal's root, which the proposer claims to unlock their proposal bond
+const proposalRootIndex uint64 = 1
+
+// Bonds a node can claim from a single proposal
+type ProposalBondClaim struct {
+	ProposalID uint64             `json:"proposalId"`
+	Actor      ProposalActor      `json:"actor"` // Either the proposer or a challenger
+	Indices    []uint64           `json:"indices"`
+	Lifecycle  *ProposalLifecycle `json:"-"`
+}
+
+// Get the transaction info for claiming the bonds
+func (c ProposalBondClaim) ClaimTransaction() (rocketpool.BatchTransaction, error) {
+	if c.Actor == ProposalActor_Proposer {
+		return c.Lifecycle.ClaimBondProposerTransaction(c.Indices)
+	}
+	return c.Lifecycle.ClaimBondChallengerTransaction(c.Indices)
+}
+
+// Find the proposer and challenger bonds a node can claim across all Protocol DAO proposals
+// Challenges are found by scanning the verifier's events from startBlock; verifierAddresses are previous verifier
+// contracts to scan in addition to the current one
+func GetClaimableBonds(rp *rocketpool.RocketPool, multicallAddress common.Address, nodeAddress common.Address, startBlock *big.Int, intervalSize *big.Int, verifierAddresses []common.Address, currentTime time.Time, opts *bind.CallOpts) ([]ProposalBondClaim, error) {
+	// Get the proposals
+	proposals, err := GetProposals(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposals: %w", err)
+	}
+	if len(proposals) == 0 {
+		return []ProposalBondClaim{}, nil
+	}
+	lifecycles := make(map[uint64]*ProposalLifecycle, len(proposals))
+	proposalIds := make([]uint64, len(proposals))
+	for i, proposal := range proposals {
+		lifecycles[proposal.ID] = NewProposalLifecycle(rp, proposal, currentTime)
+		proposalIds[i] = proposal.ID
+	}
+
+	// Get the challenged indices of the node's proposals, and the node's own challenges
+	challenges, err := GetChallengeSubmittedEvents(rp, proposalIds, intervalSize, startBlock, nil, verifierAddresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submitted challenges: %w", err)
+	}
+	type claimKey struct {
+		proposalId uint64
+		actor      ProposalActor
+	}
+	candidates := map[claimKey]*ProposalBondClaim{}
+	addCandidate := func(proposalId uint64, actor ProposalActor, index uint64) {
+		key := claimKey{proposalId, actor}
+		claim, exists := candidates[key]
+		if !exists {
+			claim = &ProposalBondClaim{
+				ProposalID: proposalId,
+				Actor:      actor,
+				Indices:    []uint64{},
+				Lifecycle:  lifecycles[proposalId],
+			}
+			candidates[key] = claim
+		}
+		claim.Indices = append(claim.Indices, index)
+	}
+	for _, proposal := range proposals {
+		if proposal.ProposerAddress == nodeAddress {
+			addCandidate(proposal.ID, ProposalActor_Proposer, proposalRootIndex)
+		}
+	}
+	for _, challenge := range challenges {
+		proposalId := challenge.ProposalID.Uint64()
+		lifecycle, exists := lifecycles[proposalId]
+		if !exists {
+			continue
+		}
+		if lifecycle.Details.ProposerAddress == nodeAddress {
+			addCandidate(proposalId, ProposalActor_Proposer, challenge.Index.Uint64())
+		}
+		if challenge.Challenger == nodeAddress {
+			addCandidate(proposalId, ProposalActor_Challenger, challenge.Index.Uint64())
+		}
+	}
+
+	// Drop the proposals that are still pending, since no bonds can be claimed until the challenge phase is over
+	claims := []*ProposalBondClaim{}
+	for _, claim := range candidates {
+		if claim.Lifecycle.Details.State != types.ProtocolDaoProposalState_Pending {
+			claims = append(claims, claim)
+		}
+	}
+	if len(claims) == 0 {
+		return []ProposalBondClaim{}, nil
+	}
+
+	// Get the states of the indices
+	stateProposalIds := []uint64{}
+	stateIndices := []uint64{}
+	for _, claim := range claims {
+		for _, index := range claim.Indices {
+			stateProposalIds = append(stateProposalIds, claim.ProposalID)
+			stateIndices = append(stateIndices, index)
+		}
+	}
+	states, err := GetMultiChallengeStatesFast(rp, multicallAddress, stateProposalIds, stateIndices, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting challenge states: %w", err)
+	}
+
+	// Keep the indices that can be claimed in their current state
+	claimable := []ProposalBondClaim{}
+	stateIndex := 0
+	for _, claim := range claims {
+		indices := []uint64{}
+		for _, index := range claim.Indices {
+			state := states[stateIndex]
+			stateIndex++
+			if isBondClaimable(claim.Lifecycle, claim.Actor, index, state) {
+				indices = append(indices, index)
+			}
+		}
+		if len(indices) > 0 {
+			sort.Slice(indices, func(i int, j int) bool { return indices[i] < indices[j] })
+			claim.Indices = indices
+			claimable = append(claimable, *claim)
+		}
+	}
+
+	// Return
+	sort.Slice(claimable, func(i int, j int) bool {
+		if claimable[i].ProposalID == claimable[j].ProposalID {
+			return claimable[i].Actor == ProposalActor_Proposer
+		}
+		return claimable[i].ProposalID < claimable[j].ProposalID
+	})
+	return claimable, nil
+}
+
+// Check if a bond at a tree index can be claimed by an actor, given the index's challenge state
+// Proposers can claim their proposal bond unless it was destroyed or vetoed, along with the bonds of the challenges
+// they answered; challengers get the bonds of unanswered challenges back (plus a reward if the proposal was destroyed)
+// on any proposal that's left the pending state
+func isBondClaimable(lifecycle *ProposalLifecycle, actor ProposalActor, index uint64, state types.ChallengeState) bool {
+	switch actor {
+	case ProposalActor_Proposer:
+		if _, exists := lifecycle.GetAction(ProposalAction_ClaimBondProposer); !exists {
+			return false
+		}
+		if index == proposalRootIndex {
+			return state != types.ChallengeState_Paid
+		}
+		return state == types.ChallengeState_Responded
+
+	case ProposalActor_Challenger:
+		if _, exists := lifecycle.GetAction(ProposalAction_ClaimBondChallenger); !exists {
+			return false
+		}
+		return state == types.ChallengeState_Challenged
+	}
+	return false
+}
+
+// Get the transactions that claim all of the given bonds, one per proposal and role
+func GetClaimBondTransactions(claims []ProposalBondClaim) ([]rocketpool.BatchTransaction, error) {
+	txs := make([]rocketpool.BatchTransaction, len(claims))
+	for i, claim := range claims {
+		tx, err := claim.ClaimTransaction()
+		if err != nil {
+			return nil, err
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
@@ -0,0 +1,189 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+)
+
+// Estimate the gas of BootstrapBool
+func EstimateBootstrapBoolGas(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapSettingBool", contractName, settingPath, value)
+}
+
+// Set a bool Protocol DAO setting directly; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapBool(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapSettingBool", contractName, settingPath, value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping %s setting %s: %w", contractName, settingPath, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapUint
+func EstimateBootstrapUintGas(rp *rocketpool.RocketPool, contractName, settingPath string, value *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapSettingUint", contractName, settingPath, value)
+}
+
+// Set a uint Protocol DAO setting directly; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapUint(rp *rocketpool.RocketPool, contractName, settingPath string, value *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapSettingUint", contractName, settingPath, value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping %s setting %s: %w", contractName, settingPath, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapAddress
+func EstimateBootstrapAddressGas(rp *rocketpool.RocketPool, contractName, settingPath string, value common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapSettingAddress", contractName, settingPath, value)
+}
+
+// Set an address Protocol DAO setting directly; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapAddress(rp *rocketpool.RocketPool, contractName, settingPath string, value common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapSettingAddress", contractName, settingPath, value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping %s setting %s: %w", contractName, settingPath, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapSpendTreasury
+func EstimateBootstrapSpendTreasuryGas(rp *rocketpool.RocketPool, invoiceID string, recipient common.Address, amount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapSpendTreasury", invoiceID, recipient, amount)
+}
+
+// Spend RPL from the treasury one time; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapSpendTreasury(rp *rocketpool.RocketPool, invoiceID string, recipient common.Address, amount *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapSpendTreasury", invoiceID, recipient, amount)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping treasury spend %s: %w", invoiceID, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapTreasuryNewContract
+func EstimateBootstrapTreasuryNewContractGas(rp *rocketpool.RocketPool, contractName string, recipient common.Address, amountPerPeriod *big.Int, periodLength time.Duration, startTime time.Time, numberOfPeriods uint64, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapTreasuryNewContract", contractName, recipient, amountPerPeriod, big.NewInt(int64(periodLength.Seconds())), big.NewInt(startTime.Unix()), new(big.Int).SetUint64(numberOfPeriods))
+}
+
+// Create a recurring treasury payment contract; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapTreasuryNewContract(rp *rocketpool.RocketPool, contractName string, recipient common.Address, amountPerPeriod *big.Int, periodLength time.Duration, startTime time.Time, numberOfPeriods uint64, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapTreasuryNewContract", contractName, recipient, amountPerPeriod, big.NewInt(int64(periodLength.Seconds())), big.NewInt(startTime.Unix()), new(big.Int).SetUint64(numberOfPeriods))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping treasury contract %s: %w", contractName, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapTreasuryUpdateContract
+func EstimateBootstrapTreasuryUpdateContractGas(rp *rocketpool.RocketPool, contractName string, recipient common.Address, amountPerPeriod *big.Int, periodLength time.Duration, numberOfPeriods uint64, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapTreasuryUpdateContract", contractName, recipient, amountPerPeriod, big.NewInt(int64(periodLength.Seconds())), new(big.Int).SetUint64(numberOfPeriods))
+}
+
+// Update a recurring treasury payment contract; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapTreasuryUpdateContract(rp *rocketpool.RocketPool, contractName string, recipient common.Address, amountPerPeriod *big.Int, periodLength time.Duration, numberOfPeriods uint64, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocol.Transact(opts, "bootstrapTreasuryUpdateContract", contractName, recipient, amountPerPeriod, big.NewInt(int64(periodLength.Seconds())), new(big.Int).SetUint64(numberOfPeriods))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping update to treasury contract %s: %w", contractName, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Check that bootstrap transactions are sent by the guardian, so they fail with a clear error instead of reverting
+func checkGuardian(rp *rocketpool.RocketPool, opts *bind.TransactOpts) error {
+	if opts == nil {
+		return fmt.Errorf("transact options are required to check the guardian")
+	}
+	return storage.CheckGuardian(rp, opts.From, nil)
+}
@@ -0,0 +1,331 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// An action that can be taken on a Protocol DAO proposal
+type ProposalAction string
+
+const (
+	ProposalAction_Defeat              ProposalAction = "defeat"
+	ProposalAction_Vote                ProposalAction = "vote"
+	ProposalAction_OverrideVote        ProposalAction = "overrideVote"
+	ProposalAction_Execute             ProposalAction = "execute"
+	ProposalAction_Finalize            ProposalAction = "finalize"
+	ProposalAction_ClaimBondProposer   ProposalAction = "claimBondProposer"
+	ProposalAction_ClaimBondChallenger ProposalAction = "claimBondChallenger"
+)
+
+// Who can take an action on a Protocol DAO proposal
+type ProposalActor string
+
+const (
+	ProposalActor_Anyone     ProposalActor = "anyone"
+	ProposalActor_Voter      ProposalActor = "voter"      // Nodes with voting power, or their delegates
+	ProposalActor_Proposer   ProposalActor = "proposer"   // The node that raised the proposal
+	ProposalActor_Challenger ProposalActor = "challenger" // Nodes that challenged the proposal's voting tree
+)
+
+// The window in which an action can be taken on a proposal
+// A zero OpensAt or ClosesAt means the window has no start or end
+type ProposalActionWindow struct {
+	Action    ProposalAction `json:"action"`
+	Actor     ProposalActor  `json:"actor"`
+	OpensAt   time.Time      `json:"opensAt"`
+	ClosesAt  time.Time      `json:"closesAt"`
+	Available bool           `json:"available"` // Whether the window is open at the lifecycle's current time
+}
+
+// The current state of a Protocol DAO proposal and the actions that can be taken on it
+// Protocol DAO proposals can't be cancelled, so there's no cancel action; see trustednode.ProposalLifecycle for oDAO proposals
+type ProposalLifecycle struct {
+	Details        ProtocolDaoProposalDetails `json:"details"`
+	CurrentTime    time.Time                  `json:"currentTime"`
+	NextTransition time.Time                  `json:"nextTransition"` // Zero if the proposal won't change state on its own
+	Actions        []ProposalActionWindow     `json:"actions"`
+
+	rp *rocketpool.RocketPool
+}
+
+// Get the lifecycle of a proposal at the given time
+func GetProposalLifecycle(rp *rocketpool.RocketPool, proposalId uint64, currentTime time.Time, opts *bind.CallOpts) (*ProposalLifecycle, error) {
+	details, err := GetProposalDetails(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewProposalLifecycle(rp, details, currentTime), nil
+}
+
+// Create the lifecycle of a proposal from its details
+func NewProposalLifecycle(rp *rocketpool.RocketPool, details ProtocolDaoProposalDetails, currentTime time.Time) *ProposalLifecycle {
+	l := &ProposalLifecycle{
+		Details:     details,
+		CurrentTime: currentTime,
+		Actions:     []ProposalActionWindow{},
+		rp:          rp,
+	}
+
+	switch details.State {
+	case types.ProtocolDaoProposalState_Pending:
+		// Each unanswered challenge can be used to defeat the proposal once its own challenge period has passed,
+		// so this window opens when the earliest possible challenge could; use GetDefeatWindow for a specific challenge
+		l.NextTransition = details.VotingStartTime
+		l.addAction(ProposalAction_Defeat, ProposalActor_Anyone, details.CreatedTime.Add(details.ChallengeWindow), details.VotingStartTime)
+
+	case types.ProtocolDaoProposalState_ActivePhase1:
+		l.NextTransition = details.Phase1EndTime
+		l.addAction(ProposalAction_Vote, ProposalActor_Voter, details.VotingStartTime, details.Phase1EndTime)
+
+	case types.ProtocolDaoProposalState_ActivePhase2:
+		l.NextTransition = details.Phase2EndTime
+		l.addAction(ProposalAction_OverrideVote, ProposalActor_Voter, details.Phase1EndTime, details.Phase2EndTime)
+
+	case types.ProtocolDaoProposalState_Succeeded:
+		l.NextTransition = details.ExpiryTime
+		l.addAction(ProposalAction_Execute, ProposalActor_Anyone, details.Phase2EndTime, details.ExpiryTime)
+		l.addAction(ProposalAction_ClaimBondProposer, ProposalActor_Proposer, details.Phase2EndTime, time.Time{})
+
+	case types.ProtocolDaoProposalState_Vetoed:
+		// Vetoed proposals burn the proposer's bond once finalized
+		if !details.IsFinalized {
+			l.addAction(ProposalAction_Finalize, ProposalActor_Anyone, time.Time{}, time.Time{})
+		}
+
+	case types.ProtocolDaoProposalState_QuorumNotMet,
+		types.ProtocolDaoProposalState_Defeated,
+		types.ProtocolDaoProposalState_Expired,
+		types.ProtocolDaoProposalState_Executed:
+		l.addAction(ProposalAction_ClaimBondProposer, ProposalActor_Proposer, time.Time{}, time.Time{})
+	}
+
+	// Challengers can reclaim the bonds of unanswered challenges (and a share of the proposer's bond if it was destroyed)
+	// once the proposal has left the pending state
+	if details.State != types.ProtocolDaoProposalState_Pending {
+		l.addAction(ProposalAction_ClaimBondChallenger, ProposalActor_Challenger, time.Time{}, time.Time{})
+	}
+	return l
+}
+
+// Get the time until the proposal changes state on its own, or zero if it won't
+func (l *ProposalLifecycle) GetTimeUntilTransition() time.Duration {
+	if l.NextTransition.IsZero() || !l.NextTransition.After(l.CurrentTime) {
+		return 0
+	}
+	return l.NextTransition.Sub(l.CurrentTime)
+}
+
+// Get the window for an action, if it applies to the proposal's current state
+func (l *ProposalLifecycle) GetAction(action ProposalAction) (ProposalActionWindow, bool) {
+	for _, window := range l.Actions {
+		if window.Action == action {
+			return window, true
+		}
+	}
+	return ProposalActionWindow{}, false
+}
+
+// Get the actions that can be taken now
+func (l *ProposalLifecycle) GetAvailableActions() []ProposalActionWindow {
+	available := []ProposalActionWindow{}
+	for _, window := range l.Actions {
+		if window.Available {
+			available = append(available, window)
+		}
+	}
+	return available
+}
+
+// Get the window for defeating the proposal with the challenge at the given tree index
+// The window opens once the challenge period has passed since the challenge was made, and is only available if the
+// challenge hasn't been answered
+func (l *ProposalLifecycle) GetDefeatWindow(index uint64, opts *bind.CallOpts) (ProposalActionWindow, error) {
+	if _, exists := l.GetAction(ProposalAction_Defeat); !exists {
+		return ProposalActionWindow{}, rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can't %s while it's %s", l.Details.ID, ProposalAction_Defeat, types.ProtocolDaoProposalStates[l.Details.State])
+	}
+	challenge, err := GetChallenge(l.rp, l.Details.ID, index, opts)
+	if err != nil {
+		return ProposalActionWindow{}, err
+	}
+
+	// The verifier requires the current time to be strictly after the end of the challenge period
+	opensAt := challenge.Time.Add(l.Details.ChallengeWindow)
+	return ProposalActionWindow{
+		Action:    ProposalAction_Defeat,
+		Actor:     ProposalActor_Anyone,
+		OpensAt:   opensAt,
+		ClosesAt:  l.Details.VotingStartTime,
+		Available: challenge.State == types.ChallengeState_Challenged && l.CurrentTime.After(opensAt) && l.CurrentTime.Before(l.Details.VotingStartTime),
+	}, nil
+}
+
+// Get the transaction info for defeating the proposal with an unanswered challenge at the given tree index
+func (l *ProposalLifecycle) DefeatTransaction(index uint64, opts *bind.CallOpts) (rocketpool.BatchTransaction, error) {
+	window, err := l.GetDefeatWindow(index, opts)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	if !window.Available {
+		return rocketpool.BatchTransaction{}, rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can only be defeated with an unanswered challenge at index %d between %s and %s", l.Details.ID, index, window.OpensAt.String(), window.ClosesAt.String())
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("defeat proposal %d with challenge %d", proposalId, index),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDefeatProposalGas(l.rp, proposalId, index, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return DefeatProposal(l.rp, proposalId, index, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolVerifier", "defeatProposal", big.NewInt(int64(proposalId)), big.NewInt(int64(index))),
+	}, nil
+}
+
+// Get the transaction info for voting on the proposal
+func (l *ProposalLifecycle) VoteTransaction(voteDirection types.VoteDirection, votingPower *big.Int, nodeIndex uint64, witness []types.VotingTreeNode) (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Vote); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("vote %s on proposal %d", types.VoteDirections[voteDirection], proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateVoteOnProposalGas(l.rp, proposalId, voteDirection, votingPower, nodeIndex, witness, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return VoteOnProposal(l.rp, proposalId, voteDirection, votingPower, nodeIndex, witness, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolProposal", "vote", big.NewInt(int64(proposalId)), voteDirection, votingPower, big.NewInt(int64(nodeIndex)), witness),
+	}, nil
+}
+
+// Get the transaction info for overriding a delegate's vote on the proposal
+func (l *ProposalLifecycle) OverrideVoteTransaction(voteDirection types.VoteDirection) (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_OverrideVote); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("override vote with %s on proposal %d", types.VoteDirections[voteDirection], proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateOverrideVoteGas(l.rp, proposalId, voteDirection, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return OverrideVote(l.rp, proposalId, voteDirection, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolProposal", "overrideVote", big.NewInt(int64(proposalId)), voteDirection),
+	}, nil
+}
+
+// Get the transaction info for executing the proposal
+func (l *ProposalLifecycle) ExecuteTransaction() (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Execute); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("execute proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateExecuteProposalGas(l.rp, proposalId, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ExecuteProposal(l.rp, proposalId, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolProposal", "execute", big.NewInt(int64(proposalId))),
+	}, nil
+}
+
+// Get the transaction info for finalizing the vetoed proposal
+func (l *ProposalLifecycle) FinalizeTransaction() (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Finalize); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("finalize proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateFinalizeGas(l.rp, proposalId, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return Finalize(l.rp, proposalId, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolProposal", "finalise", big.NewInt(int64(proposalId))),
+	}, nil
+}
+
+// Get the transaction info for the proposer to claim their bond and the bonds of the challenges they answered
+func (l *ProposalLifecycle) ClaimBondProposerTransaction(indices []uint64) (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_ClaimBondProposer); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("claim proposer bond for proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateClaimBondProposerGas(l.rp, proposalId, indices, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ClaimBondProposer(l.rp, proposalId, indices, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolVerifier", "claimBondProposer", big.NewInt(int64(proposalId)), getBigIndices(indices)),
+	}, nil
+}
+
+// Get the transaction info for a challenger to claim their bonds and rewards for the given challenge indices
+func (l *ProposalLifecycle) ClaimBondChallengerTransaction(indices []uint64) (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_ClaimBondChallenger); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	proposalId := l.Details.ID
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("claim challenger bonds for proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateClaimBondChallengerGas(l.rp, proposalId, indices, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ClaimBondChallenger(l.rp, proposalId, indices, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(l.rp, "rocketDAOProtocolVerifier", "claimBondChallenger", big.NewInt(int64(proposalId)), getBigIndices(indices)),
+	}, nil
+}
+
+// Add an action window, marking whether it's open at the current time
+func (l *ProposalLifecycle) addAction(action ProposalAction, actor ProposalActor, opensAt time.Time, closesAt time.Time) {
+	available := (opensAt.IsZero() || !l.CurrentTime.Before(opensAt)) && (closesAt.IsZero() || l.CurrentTime.Before(closesAt))
+	l.Actions = append(l.Actions, ProposalActionWindow{
+		Action:    action,
+		Actor:     actor,
+		OpensAt:   opensAt,
+		ClosesAt:  closesAt,
+		Available: available,
+	})
+}
+
+// Check that an action can be taken at the current time
+func (l *ProposalLifecycle) checkAction(action ProposalAction) error {
+	window, exists := l.GetAction(action)
+	if !exists {
+		return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can't %s while it's %s", l.Details.ID, action, types.ProtocolDaoProposalStates[l.Details.State])
+	}
+	if !window.Available {
+		return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can only %s between %s and %s", l.Details.ID, action, window.OpensAt.String(), window.ClosesAt.String())
+	}
+	return nil
+}
+
+// Convert tree node indices to the contract's argument type
+func getBigIndices(indices []uint64) []*big.Int {
+	indicesBig := make([]*big.Int, len(indices))
+	for i, index := range indices {
+		indicesBig[i] = big.NewInt(int64(index))
+	}
+	return indicesBig
+}
@@ -2,21 +2,17 @@ package protocol
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
-	strutils "github.com/rocket-pool/rocketpool-go/utils/strings"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -511,40 +507,7 @@ func GetProposalPayloadString(rp *rocketpool.RocketPool, payload []byte, opts *b
 		return "", err
 	}
 
-	// Get proposal DAO contract ABI
-	daoContractAbi := rocketDAOProtocolProposals.ABI
-
-	// Get proposal payload method
-	method, err := daoContractAbi.MethodById(payload)
-	if err != nil {
-		return "", fmt.Errorf("error getting proposal payload method: %w", err)
-	}
-
-	// Get proposal payload argument values
-	args, err := method.Inputs.UnpackValues(payload[4:])
-	if err != nil {
-		return "", fmt.Errorf("error getting proposal payload arguments: %w", err)
-	}
-
-	// Format argument values as strings
-	argStrs := []string{}
-	for ai, arg := range args {
-		switch method.Inputs[ai].Type.T {
-		case abi.AddressTy:
-			argStrs = append(argStrs, arg.(common.Address).Hex())
-		case abi.HashTy:
-			argStrs = append(argStrs, arg.(common.Hash).Hex())
-		case abi.FixedBytesTy:
-			fallthrough
-		case abi.BytesTy:
-			argStrs = append(argStrs, hex.EncodeToString(arg.([]byte)))
-		default:
-			argStrs = append(argStrs, fmt.Sprintf("%v", arg))
-		}
-	}
-
-	// Build & return payload string
-	return strutils.Sanitize(fmt.Sprintf("%s(%s)", method.RawName, strings.Join(argStrs, ","))), nil
+	return dao.FormatProposalPayload(rocketDAOProtocolProposals.ABI, payload)
 }
 
 // Get the proposal's state
@@ -577,6 +540,22 @@ func GetAddressVoteDirection(rp *rocketpool.RocketPool, proposalId uint64, addre
 // === Transactions ===
 // ====================
 
+// Get a describer for a proposal whose payload calls one of the proposals contract's methods, for use as a transaction's
+// Describe function
+func DescribeProposal(rp *rocketpool.RocketPool, message string, blockNumber uint32, treeNodes []types.VotingTreeNode, method string, params ...interface{}) func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+	return func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+		rocketDAOProtocolProposals, err := getRocketDAOProtocolProposals(rp, nil)
+		if err != nil {
+			return rocketpool.TransactionDescription{}, err
+		}
+		payload, err := rocketDAOProtocolProposals.ABI.Pack(method, params...)
+		if err != nil {
+			return rocketpool.TransactionDescription{}, fmt.Errorf("error encoding %s proposal payload: %w", method, err)
+		}
+		return rocketpool.DescribeContractCall(rp, "rocketDAOProtocolProposal", "propose", message, payload, blockNumber, treeNodes)(opts)
+	}
+}
+
 // Estimate the gas of a proposal submission
 func estimateProposalGas(rp *rocketpool.RocketPool, message string, payload []byte, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
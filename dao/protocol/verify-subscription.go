@@ -0,0 +1,176 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of challenge state transition reported by a subscription
+type ChallengeStateChangeType uint8
+
+const (
+	ChallengeStateChangeType_Challenged ChallengeStateChangeType = iota
+	ChallengeStateChangeType_Responded
+	ChallengeStateChangeType_Defeated
+)
+
+var ChallengeStateChangeTypes = []string{"Challenged", "Responded", "Defeated"}
+
+// A challenge state transition on a proposal, as observed on the verifier contract
+type ChallengeStateChange struct {
+	Type        ChallengeStateChangeType `json:"type"`
+	ProposalID  uint64                   `json:"proposalId"`
+	Index       *big.Int                 `json:"index"` // Not reported for defeats; use GetDefeatIndex
+	Sender      common.Address           `json:"sender"`
+	Timestamp   time.Time                `json:"timestamp"`
+	BlockNumber uint64                   `json:"blockNumber"`
+	TxHash      common.Hash              `json:"txHash"`
+	Removed     bool                     `json:"removed"` // True if the log was removed by a reorg
+}
+
+// Internal struct - returned by the ProposalBondBurned event
+type proposalBondBurned struct {
+	ProposalID *big.Int       `json:"proposalId"`
+	Proposer   common.Address `json:"proposer"`
+	Amount     *big.Int       `json:"amount"`
+	Timestamp  time.Time      `json:"timestamp" event:"timestamp,unix"`
+}
+
+// String conversion
+func (t ChallengeStateChangeType) String() string {
+	if int(t) >= len(ChallengeStateChangeTypes) {
+		return ""
+	}
+	return ChallengeStateChangeTypes[t]
+}
+
+// Subscribe to challenge state transitions (challenged, responded, defeated) for the given proposals.
+// If proposalIDs is empty, transitions for all proposals are reported.
+// Decoded transitions are written to sink until the subscription is unsubscribed or the underlying log subscription fails.
+// Requires a client that supports log subscriptions (e.g. a websocket or IPC connection).
+func SubscribeChallengeStateChanges(rp *rocketpool.RocketPool, proposalIDs []uint64, sink chan<- ChallengeStateChange) (event.Subscription, error) {
+	// Get the contract
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the events
+	challengeSubmittedEvent, exists := rocketDAOProtocolVerifier.ABI.Events["ChallengeSubmitted"]
+	if !exists {
+		return nil, fmt.Errorf("verifier ABI does not contain the ChallengeSubmitted event")
+	}
+	rootSubmittedEvent, exists := rocketDAOProtocolVerifier.ABI.Events["RootSubmitted"]
+	if !exists {
+		return nil, fmt.Errorf("verifier ABI does not contain the RootSubmitted event")
+	}
+	proposalBondBurnedEvent, exists := rocketDAOProtocolVerifier.ABI.Events["ProposalBondBurned"]
+	if !exists {
+		return nil, fmt.Errorf("verifier ABI does not contain the ProposalBondBurned event")
+	}
+
+	// Construct a filter query for relevant logs
+	idBuffers := make([]common.Hash, len(proposalIDs))
+	for i, id := range proposalIDs {
+		proposalIdBig := big.NewInt(0).SetUint64(id)
+		proposalIdBig.FillBytes(idBuffers[i][:])
+	}
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{*rocketDAOProtocolVerifier.Address},
+		Topics:    [][]common.Hash{{challengeSubmittedEvent.ID, rootSubmittedEvent.ID, proposalBondBurnedEvent.ID}, idBuffers},
+	}
+
+	// Subscribe to the logs
+	logs := make(chan types.Log)
+	logSub, err := rp.Client.SubscribeFilterLogs(context.Background(), query, logs)
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to verifier logs: %w", err)
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer logSub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				if len(log.Topics) == 0 {
+					continue
+				}
+
+				// Decode the log
+				var change ChallengeStateChange
+				switch log.Topics[0] {
+				case challengeSubmittedEvent.ID:
+					challenge, err := eth.DecodeEvent[ChallengeSubmitted](rocketDAOProtocolVerifier, "ChallengeSubmitted", log)
+					if err != nil {
+						return err
+					}
+					change = ChallengeStateChange{
+						Type:       ChallengeStateChangeType_Challenged,
+						ProposalID: challenge.ProposalID.Uint64(),
+						Index:      challenge.Index,
+						Sender:     challenge.Challenger,
+						Timestamp:  challenge.Timestamp,
+					}
+
+				case rootSubmittedEvent.ID:
+					root, err := eth.DecodeEvent[RootSubmitted](rocketDAOProtocolVerifier, "RootSubmitted", log)
+					if err != nil {
+						return err
+					}
+					if root.Index.Cmp(common.Big1) == 0 {
+						// The root of the tree is submitted with the proposal itself, so it isn't a challenge response
+						continue
+					}
+					change = ChallengeStateChange{
+						Type:       ChallengeStateChangeType_Responded,
+						ProposalID: root.ProposalID.Uint64(),
+						Index:      root.Index,
+						Sender:     root.Proposer,
+						Timestamp:  root.Timestamp,
+					}
+
+				case proposalBondBurnedEvent.ID:
+					burned, err := eth.DecodeEvent[proposalBondBurned](rocketDAOProtocolVerifier, "ProposalBondBurned", log)
+					if err != nil {
+						return err
+					}
+					change = ChallengeStateChange{
+						Type:       ChallengeStateChangeType_Defeated,
+						ProposalID: burned.ProposalID.Uint64(),
+						Sender:     burned.Proposer,
+						Timestamp:  burned.Timestamp,
+					}
+
+				default:
+					continue
+				}
+				change.BlockNumber = log.BlockNumber
+				change.TxHash = log.TxHash
+				change.Removed = log.Removed
+
+				// Send it to the consumer
+				select {
+				case sink <- change:
+				case err := <-logSub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+
+			case err := <-logSub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/rocketpool-go/utils/multicall"
@@ -28,16 +30,7 @@ type RootSubmitted struct {
 	Index       *big.Int               `json:"index"`
 	Root        types.VotingTreeNode   `json:"root"`
 	TreeNodes   []types.VotingTreeNode `json:"treeNodes"`
-	Timestamp   time.Time              `json:"timestamp"`
-}
-
-// Internal struct - returned by the RootSubmitted event
-type rootSubmittedRaw struct {
-	BlockNumber uint32                 `json:"blockNumber"`
-	Index       *big.Int               `json:"index"`
-	Root        types.VotingTreeNode   `json:"root"`
-	TreeNodes   []types.VotingTreeNode `json:"treeNodes"`
-	Timestamp   *big.Int               `json:"timestamp"`
+	Timestamp   time.Time              `json:"timestamp" event:"timestamp,unix"`
 }
 
 // Structure of the ChallengeSubmitted event
@@ -45,13 +38,14 @@ type ChallengeSubmitted struct {
 	ProposalID *big.Int       `json:"proposalId"`
 	Challenger common.Address `json:"challenger"`
 	Index      *big.Int       `json:"index"`
-	Timestamp  time.Time      `json:"timestamp"`
+	Timestamp  time.Time      `json:"timestamp" event:"timestamp,unix"`
 }
 
-// Internal struct - returned by the ChallengeSubmitted event
-type challengeSubmittedRaw struct {
-	Index     *big.Int `json:"index"`
-	Timestamp *big.Int `json:"timestamp"`
+// A challenge on a proposal's voting tree node
+type Challenge struct {
+	Challenger common.Address       `json:"challenger"`
+	Time       time.Time            `json:"time"`
+	State      types.ChallengeState `json:"state"`
 }
 
 // Get the depth-per-round for voting trees
@@ -73,22 +67,89 @@ func GetNode(rp *rocketpool.RocketPool, proposalId uint64, index uint64, opts *b
 	if err != nil {
 		return types.VotingTreeNode{}, err
 	}
-	// define a struct to unmarshall the VotingTreeNode data from the smart contract call
-	res := new(struct {
-		Sum  *big.Int `json:"sum"`
-		Hash [32]byte `json:"hash"`
-	})
+	res := new(votingTreeNodeResult)
 	err = rocketDAOProtocolVerifier.Call(opts, &res, "getNode", big.NewInt(int64(proposalId)), big.NewInt(int64(index)))
 	if err != nil {
 		return types.VotingTreeNode{}, fmt.Errorf("error getting proposal %d / index %d node: %w", proposalId, index, err)
 	}
-	// convert the [32]byte field into a common.Hash
-	node := types.VotingTreeNode{
-		Sum:  res.Sum,
-		Hash: common.BytesToHash(res.Hash[:]),
+	return res.toNode(), nil
+}
+
+// Get the nodes of a proposal at multiple indices using multicall
+func GetMultiNodesFast(rp *rocketpool.RocketPool, multicallAddress common.Address, proposalId uint64, indices []uint64, opts *bind.CallOpts) ([]types.VotingTreeNode, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		// Get the latest block
+		blockNum, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block number: %w", err)
+		}
+		opts = &bind.CallOpts{
+			BlockNumber: big.NewInt(int64(blockNum)),
+		}
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Run the getters in batches
+	count := uint64(len(indices))
+	results := make([]votingTreeNodeResult, count)
+	propID := big.NewInt(int64(proposalId))
+	for i := uint64(0); i < count; i += challengeStateBatchSize {
+		i := i
+		max := i + challengeStateBatchSize
+		if max > count {
+			max = count
+		}
+
+		// Load details
+		wg.Go(func() error {
+			var err error
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				mc.AddCall(rocketDAOProtocolVerifier, &results[j], "getNode", propID, big.NewInt(int64(indices[j])))
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
 	}
 
-	return node, nil
+	// Convert the results
+	nodes := make([]types.VotingTreeNode, count)
+	for i, result := range results {
+		nodes[i] = result.toNode()
+	}
+	return nodes, nil
+}
+
+// The VotingTreeNode data returned by the getNode contract call
+type votingTreeNodeResult struct {
+	Sum  *big.Int `json:"sum"`
+	Hash [32]byte `json:"hash"`
+}
+
+// Convert the [32]byte hash into a common.Hash
+func (r votingTreeNodeResult) toNode() types.VotingTreeNode {
+	return types.VotingTreeNode{
+		Sum:  r.Sum,
+		Hash: common.BytesToHash(r.Hash[:]),
+	}
 }
 
 // Estimate the gas of CreateChallenge
@@ -149,6 +210,24 @@ func GetChallengeState(rp *rocketpool.RocketPool, proposalId uint64, index uint6
 	return challengeState, nil
 }
 
+// Get a challenge on a proposal and tree node index
+// The verifier packs the challenger into the low 160 bits, the timestamp into the next 64 and the state into the next 8
+func GetChallenge(rp *rocketpool.RocketPool, proposalId uint64, index uint64, opts *bind.CallOpts) (Challenge, error) {
+	data, err := rp.RocketStorage.GetUint(opts, storage.ProposalChallengeKey(proposalId, index))
+	if err != nil {
+		return Challenge{}, fmt.Errorf("error getting proposal %d / index %d challenge: %w", proposalId, index, err)
+	}
+	timestamp := big.NewInt(0).Rsh(data, 160)
+	timestamp.And(timestamp, big.NewInt(0).SetUint64(math.MaxUint64))
+	state := big.NewInt(0).Rsh(data, 224)
+	state.And(state, big.NewInt(math.MaxUint8))
+	return Challenge{
+		Challenger: common.BigToAddress(data),
+		Time:       time.Unix(int64(timestamp.Uint64()), 0),
+		State:      types.ChallengeState(state.Uint64()),
+	}, nil
+}
+
 // Get the defeat index for a proposal
 func GetDefeatIndex(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (uint64, error) {
 	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, opts)
@@ -315,43 +394,7 @@ func GetRootSubmittedEvents(rp *rocketpool.RocketPool, proposalIDs []uint64, int
 		return []RootSubmitted{}, nil
 	}
 
-	events := make([]RootSubmitted, 0, len(logs))
-	for _, log := range logs {
-		// Get the log info values
-		values, err := rootSubmittedEvent.Inputs.Unpack(log.Data)
-		if err != nil {
-			return nil, fmt.Errorf("error unpacking RootSubmitted event data: %w", err)
-		}
-
-		// Get the topic values
-		if len(log.Topics) < 3 {
-			return nil, fmt.Errorf("event had %d topics but at least 3 are required", len(log.Topics))
-		}
-		idHash := log.Topics[1]
-		proposerHash := log.Topics[2]
-		propID := big.NewInt(0).SetBytes(idHash.Bytes())
-		proposer := common.BytesToAddress(proposerHash.Bytes())
-
-		// Convert to a native struct
-		var raw rootSubmittedRaw
-		err = rootSubmittedEvent.Inputs.Copy(&raw, values)
-		if err != nil {
-			return nil, fmt.Errorf("error converting RootSubmitted event data to struct: %w", err)
-		}
-
-		// Get the decoded data
-		events = append(events, RootSubmitted{
-			ProposalID:  propID,
-			Proposer:    proposer,
-			BlockNumber: raw.BlockNumber,
-			Index:       raw.Index,
-			Root:        raw.Root,
-			TreeNodes:   raw.TreeNodes,
-			Timestamp:   time.Unix(raw.Timestamp.Int64(), 0),
-		})
-	}
-
-	return events, nil
+	return eth.DecodeEvents[RootSubmitted](rocketDAOProtocolVerifier, "RootSubmitted", logs)
 }
 
 // Get ChallengeSubmitted event info
@@ -399,40 +442,7 @@ func GetChallengeSubmittedEvents(rp *rocketpool.RocketPool, proposalIDs []uint64
 		return []ChallengeSubmitted{}, nil
 	}
 
-	events := make([]ChallengeSubmitted, 0, len(logs))
-	for _, log := range logs {
-		// Get the log info values
-		values, err := challengeSubmittedEvent.Inputs.Unpack(log.Data)
-		if err != nil {
-			return nil, fmt.Errorf("error unpacking ChallengeSubmitted event data: %w", err)
-		}
-
-		// Get the topic values
-		if len(log.Topics) < 3 {
-			return nil, fmt.Errorf("event had %d topics but at least 3 are required", len(log.Topics))
-		}
-		idHash := log.Topics[1]
-		challengerHash := log.Topics[2]
-		propID := big.NewInt(0).SetBytes(idHash.Bytes())
-		challenger := common.BytesToAddress(challengerHash.Bytes())
-
-		// Convert to a native struct
-		var raw challengeSubmittedRaw
-		err = challengeSubmittedEvent.Inputs.Copy(&raw, values)
-		if err != nil {
-			return nil, fmt.Errorf("error converting ChallengeSubmitted event data to struct: %w", err)
-		}
-
-		// Get the decoded data
-		events = append(events, ChallengeSubmitted{
-			ProposalID: propID,
-			Challenger: challenger,
-			Index:      raw.Index,
-			Timestamp:  time.Unix(raw.Timestamp.Int64(), 0),
-		})
-	}
-
-	return events, nil
+	return eth.DecodeEvents[ChallengeSubmitted](rocketDAOProtocolVerifier, "ChallengeSubmitted", logs)
 }
 
 // Estimate the gas of ClaimBondChallenger
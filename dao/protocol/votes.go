@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const (
+	voteDirectionsFastBatchSize = 500
+)
+
+// Get the direction each address voted in on each proposal, using a multicaller
+// The result is indexed by address, then by proposal, in the order they were provided
+func GetVoteDirectionsFast(rp *rocketpool.RocketPool, multicallAddress common.Address, addresses []common.Address, proposalIds []uint64, opts *bind.CallOpts) ([][]types.VoteDirection, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	proposalCount := len(proposalIds)
+	count := len(addresses) * proposalCount
+	rawDirections := make([]uint8, count)
+
+	// Run the getters in batches
+	for i := 0; i < count; i += voteDirectionsFastBatchSize {
+		i := i
+		max := i + voteDirectionsFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				proposalId := big.NewInt(0).SetUint64(proposalIds[j%proposalCount])
+				mc.AddCall(rocketDAOProtocolProposal, &rawDirections[j], "getReceiptDirection", proposalId, addresses[j/proposalCount])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting vote directions: %w", err)
+	}
+
+	// Build the matrix
+	directions := make([][]types.VoteDirection, len(addresses))
+	for i := range directions {
+		directions[i] = make([]types.VoteDirection, proposalCount)
+		for j := range directions[i] {
+			directions[i][j] = types.VoteDirection(rawDirections[i*proposalCount+j])
+		}
+	}
+
+	// Return
+	return directions, nil
+}
@@ -0,0 +1,105 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+)
+
+// Estimate the gas of BootstrapBool
+func EstimateBootstrapBoolGas(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAONodeTrusted.GetTransactionGasInfo(opts, "bootstrapSettingBool", contractName, settingPath, value)
+}
+
+// Set a bool trusted node DAO setting directly; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapBool(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAONodeTrusted.Transact(opts, "bootstrapSettingBool", contractName, settingPath, value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping %s setting %s: %w", contractName, settingPath, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapUint
+func EstimateBootstrapUintGas(rp *rocketpool.RocketPool, contractName, settingPath string, value *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAONodeTrusted.GetTransactionGasInfo(opts, "bootstrapSettingUint", contractName, settingPath, value)
+}
+
+// Set a uint trusted node DAO setting directly; only callable by the guardian while the DAO is in bootstrap mode
+func BootstrapUint(rp *rocketpool.RocketPool, contractName, settingPath string, value *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAONodeTrusted.Transact(opts, "bootstrapSettingUint", contractName, settingPath, value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping %s setting %s: %w", contractName, settingPath, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of BootstrapMember
+func EstimateBootstrapMemberGas(rp *rocketpool.RocketPool, id, url string, nodeAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAONodeTrusted.GetTransactionGasInfo(opts, "bootstrapMember", id, url, nodeAddress)
+}
+
+// Invite a registered node to the trusted node DAO directly; only callable by the guardian while the DAO is in bootstrap mode
+// The node still has to join with its RPL bond
+func BootstrapMember(rp *rocketpool.RocketPool, id, url string, nodeAddress common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkGuardian(rp, opts); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAONodeTrusted.Transact(opts, "bootstrapMember", id, url, nodeAddress)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error bootstrapping trusted node DAO member %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Check that bootstrap transactions are sent by the guardian, so they fail with a clear error instead of reverting
+func checkGuardian(rp *rocketpool.RocketPool, opts *bind.TransactOpts) error {
+	if opts == nil {
+		return fmt.Errorf("transact options are required to check the guardian")
+	}
+	return storage.CheckGuardian(rp, opts.From, nil)
+}
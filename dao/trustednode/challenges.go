@@ -0,0 +1,213 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Who can resolve a trusted node DAO challenge
+type ChallengeResolver string
+
+const (
+	ChallengeResolver_None   ChallengeResolver = "none"   // The challenge has already been decided
+	ChallengeResolver_Member ChallengeResolver = "member" // Only the challenged member can decide it, which refutes the challenge
+	ChallengeResolver_Anyone ChallengeResolver = "anyone" // The window has passed, so any registered node can decide it and remove the member
+)
+
+// A challenge made against a trusted node DAO member
+type Challenge struct {
+	MemberAddress       common.Address    `json:"memberAddress"`
+	ChallengerAddress   common.Address    `json:"challengerAddress"`
+	ChallengeTime       uint64            `json:"challengeTime"`
+	ChallengeBlock      uint64            `json:"challengeBlock"`
+	WindowEnd           uint64            `json:"windowEnd"`           // After this time, anyone can decide the challenge
+	ChallengerCooldown  uint64            `json:"challengerCooldown"`  // The time the challenger can make another challenge, if they're a member
+	IsChallenged        bool              `json:"isChallenged"`        // Whether the member is still challenged at the queried block
+	Decided             bool              `json:"decided"`             // Whether the challenge was refuted by the member or removed them
+	DeciderAddress      common.Address    `json:"deciderAddress"`      // Empty if the challenge hasn't been decided
+	Success             bool              `json:"success"`             // True if the decision removed the member
+	DecidedTime         uint64            `json:"decidedTime"`         // Zero if the challenge hasn't been decided
+	Resolver            ChallengeResolver `json:"resolver"`            // Who can decide the challenge at the current time
+	TimeUntilResolvable uint64            `json:"timeUntilResolvable"` // Time until anyone can decide the challenge; zero if they already can
+}
+
+// Scans the trusted node DAO's challenge events and reports which challenges are outstanding and who can resolve them
+type ChallengeMonitor struct {
+	IntervalSize *big.Int // The maximum number of blocks per log query; nil for no limit
+
+	rp               *rocketpool.RocketPool
+	multicallAddress common.Address
+}
+
+// Create a new challenge monitor
+func NewChallengeMonitor(rp *rocketpool.RocketPool, multicallAddress common.Address, intervalSize *big.Int) *ChallengeMonitor {
+	return &ChallengeMonitor{
+		IntervalSize:     intervalSize,
+		rp:               rp,
+		multicallAddress: multicallAddress,
+	}
+}
+
+// Get the challenges made since fromBlock (or the deploy block if nil), with their state at the block in opts
+// currentTime is used to determine who can resolve each challenge; challenges are returned in the order they were made
+func (m *ChallengeMonitor) GetChallenges(fromBlock *big.Int, currentTime uint64, opts *bind.CallOpts) ([]Challenge, error) {
+	rocketDAONodeTrustedActions, err := getRocketDAONodeTrustedActions(m.rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rocketDAONodeTrusted, err := getRocketDAONodeTrusted(m.rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	membersSettingsContract, err := m.rp.GetContract(membersSettingsContractName, opts)
+	if err != nil {
+		return nil, err
+	}
+	madeEvent, exists := rocketDAONodeTrustedActions.ABI.Events["ActionChallengeMade"]
+	if !exists {
+		return nil, fmt.Errorf("ActionChallengeMade event not found in the trusted node DAO actions ABI")
+	}
+	decidedEvent, exists := rocketDAONodeTrustedActions.ABI.Events["ActionChallengeDecided"]
+	if !exists {
+		return nil, fmt.Errorf("ActionChallengeDecided event not found in the trusted node DAO actions ABI")
+	}
+
+	// Get the event logs
+	var toBlock *big.Int
+	if opts != nil {
+		toBlock = opts.BlockNumber
+	}
+	addressFilter := []common.Address{*rocketDAONodeTrustedActions.Address}
+	topicFilter := [][]common.Hash{{madeEvent.ID, decidedEvent.ID}}
+	logs, err := eth.GetLogs(m.rp, addressFilter, topicFilter, m.IntervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting challenge logs: %w", err)
+	}
+
+	// Join the decisions to the latest challenge against each member
+	challenges := []*Challenge{}
+	latestChallenges := map[common.Address]*Challenge{}
+	for _, log := range logs {
+		if len(log.Topics) < 3 {
+			continue
+		}
+		memberAddress := common.BytesToAddress(log.Topics[1].Bytes())
+		values := make(map[string]interface{})
+		switch log.Topics[0] {
+		case madeEvent.ID:
+			if err := madeEvent.Inputs.NonIndexed().UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error decoding challenge event in tx %s: %w", log.TxHash.Hex(), err)
+			}
+			challenge := &Challenge{
+				MemberAddress:     memberAddress,
+				ChallengerAddress: common.BytesToAddress(log.Topics[2].Bytes()),
+				ChallengeBlock:    log.BlockNumber,
+			}
+			if challengeTime, ok := values["time"].(*big.Int); ok {
+				challenge.ChallengeTime = challengeTime.Uint64()
+			}
+			challenges = append(challenges, challenge)
+			latestChallenges[memberAddress] = challenge
+
+		case decidedEvent.ID:
+			challenge, exists := latestChallenges[memberAddress]
+			if !exists || challenge.Decided {
+				continue
+			}
+			if err := decidedEvent.Inputs.NonIndexed().UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error decoding challenge decision event in tx %s: %w", log.TxHash.Hex(), err)
+			}
+
+			// Anyone can call decide, but it only closes the challenge if the member refutes it or the window has
+			// passed and the member is removed; a failed decision by someone else leaves it open
+			deciderAddress := common.BytesToAddress(log.Topics[2].Bytes())
+			success, _ := values["success"].(bool)
+			if !success && deciderAddress != memberAddress {
+				continue
+			}
+			challenge.Decided = true
+			challenge.DeciderAddress = deciderAddress
+			challenge.Success = success
+			if decidedTime, ok := values["time"].(*big.Int); ok {
+				challenge.DecidedTime = decidedTime.Uint64()
+			}
+		}
+	}
+
+	// Get the settings and the current challenge state of each challenged member
+	mc, err := multicall.NewMultiCaller(m.rp.Client, m.multicallAddress)
+	if err != nil {
+		return nil, err
+	}
+	var window *big.Int
+	var cooldown *big.Int
+	mc.AddCall(membersSettingsContract, &window, "getChallengeWindow")
+	mc.AddCall(membersSettingsContract, &cooldown, "getChallengeCooldown")
+	isChallenged := make(map[common.Address]*bool, len(latestChallenges))
+	for memberAddress := range latestChallenges {
+		isChallenged[memberAddress] = new(bool)
+		mc.AddCall(rocketDAONodeTrusted, isChallenged[memberAddress], "getMemberIsChallenged", memberAddress)
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error executing multicall: %w", err)
+	}
+
+	// Work out who can resolve each challenge from the member's challenge state and the window
+	// Only the latest challenge against a member can still be open, since a member can't be challenged again until the
+	// previous challenge is closed
+	result := make([]Challenge, len(challenges))
+	for i, challenge := range challenges {
+		challenge.WindowEnd = challenge.ChallengeTime + window.Uint64()
+		challenge.ChallengerCooldown = challenge.ChallengeTime + cooldown.Uint64()
+		challenge.IsChallenged = !challenge.Decided && latestChallenges[challenge.MemberAddress] == challenge && *isChallenged[challenge.MemberAddress]
+		switch {
+		case !challenge.IsChallenged:
+			challenge.Resolver = ChallengeResolver_None
+		case currentTime > challenge.WindowEnd:
+			challenge.Resolver = ChallengeResolver_Anyone
+		default:
+			challenge.Resolver = ChallengeResolver_Member
+			challenge.TimeUntilResolvable = challenge.WindowEnd - currentTime + 1
+		}
+		result[i] = *challenge
+	}
+	return result, nil
+}
+
+// Get the challenges that are still outstanding, ordered by when anyone can decide them
+func (m *ChallengeMonitor) GetOpenChallenges(fromBlock *big.Int, currentTime uint64, opts *bind.CallOpts) ([]Challenge, error) {
+	challenges, err := m.GetChallenges(fromBlock, currentTime, opts)
+	if err != nil {
+		return nil, err
+	}
+	open := []Challenge{}
+	for _, challenge := range challenges {
+		if challenge.Resolver != ChallengeResolver_None {
+			open = append(open, challenge)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].WindowEnd < open[j].WindowEnd
+	})
+	return open, nil
+}
+
+// Check if the given node can decide a challenge at the current time
+func (c Challenge) CanBeDecidedBy(nodeAddress common.Address) bool {
+	switch c.Resolver {
+	case ChallengeResolver_Anyone:
+		return true
+	case ChallengeResolver_Member:
+		return nodeAddress == c.MemberAddress
+	default:
+		return false
+	}
+}
@@ -0,0 +1,180 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The action required before a trusted node DAO proposal deadline lapses
+type ProposalActionType string
+
+const (
+	ProposalAction_Execute ProposalActionType = "execute"
+	ProposalAction_Join    ProposalActionType = "join"
+	ProposalAction_Leave   ProposalActionType = "leave"
+)
+
+// A pending deadline on a trusted node DAO proposal
+type ProposalDeadline struct {
+	ProposalID    uint64             `json:"proposalId"`
+	Action        ProposalActionType `json:"action"`
+	MemberAddress common.Address     `json:"memberAddress"`
+	Deadline      uint64             `json:"deadline"`
+	TimeRemaining uint64             `json:"timeRemaining"`
+}
+
+// Get the trusted node DAO proposals with action deadlines falling within the next window seconds of currentTime
+// This includes succeeded proposals awaiting execution, executed invites that have not been joined, and executed
+// leave proposals that have not been actioned; deadlines are returned in order, soonest first
+func GetProposalDeadlines(rp *rocketpool.RocketPool, currentTime uint64, window uint64, opts *bind.CallOpts) ([]ProposalDeadline, error) {
+
+	// Get contracts and settings
+	rocketDAONodeTrustedProposals, err := getRocketDAONodeTrustedProposals(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	actionTime, err := getProposalActionTime(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get proposals
+	proposals, err := dao.GetDAOProposals(rp, "rocketDAONodeTrustedProposals", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	deadlines := []ProposalDeadline{}
+	memberActions := map[common.Address]map[ProposalActionType]bool{}
+	addDeadline := func(deadline ProposalDeadline) {
+		if deadline.Deadline <= currentTime || deadline.Deadline > currentTime+window {
+			return
+		}
+		deadline.TimeRemaining = deadline.Deadline - currentTime
+		deadlines = append(deadlines, deadline)
+	}
+
+	for i := range proposals {
+		proposal := proposals[len(proposals)-i-1]
+		switch proposal.State {
+		case rptypes.Succeeded:
+			addDeadline(ProposalDeadline{
+				ProposalID: proposal.ID,
+				Action:     ProposalAction_Execute,
+				Deadline:   proposal.ExpiryTime,
+			})
+
+		case rptypes.Executed:
+			// Get the member the proposal applies to
+			if len(proposal.Payload) < 4 {
+				continue
+			}
+			method, err := rocketDAONodeTrustedProposals.ABI.MethodById(proposal.Payload)
+			if err != nil {
+				continue
+			}
+			var action ProposalActionType
+			var proposalType string
+			switch method.Name {
+			case "proposalInvite":
+				action = ProposalAction_Join
+				proposalType = "invited"
+			case "proposalLeave":
+				action = ProposalAction_Leave
+				proposalType = "leave"
+			default:
+				continue
+			}
+			args, err := method.Inputs.Unpack(proposal.Payload[4:])
+			if err != nil {
+				return nil, fmt.Errorf("error decoding trusted node DAO proposal %d payload: %w", proposal.ID, err)
+			}
+			memberAddress, ok := args[len(args)-1].(common.Address)
+			if !ok {
+				return nil, fmt.Errorf("unexpected member address type in trusted node DAO proposal %d payload", proposal.ID)
+			}
+
+			// Skip proposals that have already been actioned or superseded by a newer proposal
+			if memberActions[memberAddress] == nil {
+				memberActions[memberAddress] = map[ProposalActionType]bool{}
+			}
+			if memberActions[memberAddress][action] {
+				continue
+			}
+			memberActions[memberAddress][action] = true
+			isMember, err := GetMemberExists(rp, memberAddress, opts)
+			if err != nil {
+				return nil, err
+			}
+			if (action == ProposalAction_Join && isMember) || (action == ProposalAction_Leave && !isMember) {
+				continue
+			}
+
+			// Get the action deadline
+			executedTime, err := GetMemberProposalExecutedTime(rp, proposalType, memberAddress, opts)
+			if err != nil {
+				return nil, err
+			}
+			addDeadline(ProposalDeadline{
+				ProposalID:    proposal.ID,
+				Action:        action,
+				MemberAddress: memberAddress,
+				Deadline:      executedTime + actionTime,
+			})
+		}
+	}
+
+	sort.Slice(deadlines, func(i, j int) bool {
+		return deadlines[i].Deadline < deadlines[j].Deadline
+	})
+	return deadlines, nil
+}
+
+// Estimate the gas of the action required by a proposal deadline
+// Join and leave actions must be sent by the member the proposal applies to
+func (d ProposalDeadline) EstimateActionGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	switch d.Action {
+	case ProposalAction_Execute:
+		return EstimateExecuteProposalGas(rp, d.ProposalID, opts)
+	case ProposalAction_Join:
+		return EstimateJoinGas(rp, opts)
+	case ProposalAction_Leave:
+		return EstimateLeaveGas(rp, d.MemberAddress, opts)
+	}
+	return rocketpool.GasInfo{}, fmt.Errorf("unknown proposal action '%s'", d.Action)
+}
+
+// Perform the action required by a proposal deadline
+// Leave actions refund the RPL bond to the member address
+func (d ProposalDeadline) SubmitAction(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	switch d.Action {
+	case ProposalAction_Execute:
+		return ExecuteProposal(rp, d.ProposalID, opts)
+	case ProposalAction_Join:
+		return Join(rp, opts)
+	case ProposalAction_Leave:
+		return Leave(rp, d.MemberAddress, opts)
+	}
+	return common.Hash{}, fmt.Errorf("unknown proposal action '%s'", d.Action)
+}
+
+// Get the period during which an action can be performed on an executed proposal in seconds
+func getProposalActionTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	proposalsSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsProposals", opts)
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := proposalsSettingsContract.Call(opts, value, "getActionTime"); err != nil {
+		return 0, fmt.Errorf("error getting proposal action period: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
@@ -0,0 +1,322 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/strings"
+)
+
+// Settings
+const (
+	membersSettingsContractName string = "rocketDAONodeTrustedSettingsMembers"
+)
+
+// Get the transaction info for proposing to invite a new member
+func ProposeInviteMemberTransaction(rp *rocketpool.RocketPool, message string, newMemberAddress common.Address, newMemberId, newMemberUrl string) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose inviting %s (%s) to the trusted node DAO", newMemberId, newMemberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return rocketpool.GasInfo{}, err
+			}
+			return EstimateProposeInviteMemberGas(rp, message, newMemberAddress, newMemberId, newMemberUrl, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return common.Hash{}, err
+			}
+			_, hash, err := ProposeInviteMember(rp, message, newMemberAddress, newMemberId, newMemberUrl, opts)
+			return hash, err
+		},
+		Describe: DescribeProposal(rp, message, "proposalInvite", newMemberId, strings.Sanitize(newMemberUrl), newMemberAddress),
+	}
+}
+
+// Get the transaction info for proposing that a member leaves
+func ProposeMemberLeaveTransaction(rp *rocketpool.RocketPool, message string, memberAddress common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose that %s leaves the trusted node DAO", memberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return rocketpool.GasInfo{}, err
+			}
+			return EstimateProposeMemberLeaveGas(rp, message, memberAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return common.Hash{}, err
+			}
+			_, hash, err := ProposeMemberLeave(rp, message, memberAddress, opts)
+			return hash, err
+		},
+		Describe: DescribeProposal(rp, message, "proposalLeave", memberAddress),
+	}
+}
+
+// Get the transaction info for proposing to replace a member with a new one
+func ProposeReplaceMemberTransaction(rp *rocketpool.RocketPool, message string, memberAddress, newMemberAddress common.Address, newMemberId, newMemberUrl string) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose replacing %s with %s (%s) in the trusted node DAO", memberAddress.Hex(), newMemberId, newMemberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return rocketpool.GasInfo{}, err
+			}
+			return EstimateProposeReplaceMemberGas(rp, message, memberAddress, newMemberAddress, newMemberId, newMemberUrl, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return common.Hash{}, err
+			}
+			_, hash, err := ProposeReplaceMember(rp, message, memberAddress, newMemberAddress, newMemberId, newMemberUrl, opts)
+			return hash, err
+		},
+		Describe: DescribeProposal(rp, message, "proposalReplace", memberAddress, newMemberId, strings.Sanitize(newMemberUrl), newMemberAddress),
+	}
+}
+
+// Get the transaction info for proposing to kick a member, slashing the given amount of their RPL bond
+func ProposeKickMemberTransaction(rp *rocketpool.RocketPool, message string, memberAddress common.Address, rplFineAmount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose kicking %s from the trusted node DAO", memberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return rocketpool.GasInfo{}, err
+			}
+			return EstimateProposeKickMemberGas(rp, message, memberAddress, rplFineAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			if err := checkMemberSigner(rp, opts); err != nil {
+				return common.Hash{}, err
+			}
+			_, hash, err := ProposeKickMember(rp, message, memberAddress, rplFineAmount, opts)
+			return hash, err
+		},
+		Describe: DescribeProposal(rp, message, "proposalKick", memberAddress, rplFineAmount),
+	}
+}
+
+// Get the transaction info for proposing to kick a member, slashing their entire RPL bond
+func ProposeKickMemberWithFullFineTransaction(rp *rocketpool.RocketPool, message string, memberAddress common.Address, opts *bind.CallOpts) (rocketpool.BatchTransaction, error) {
+	bond, err := GetMemberRPLBondAmount(rp, memberAddress, opts)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return ProposeKickMemberTransaction(rp, message, memberAddress, bond), nil
+}
+
+// Get the transactions for joining the trusted node DAO
+// If the node hasn't approved the RPL bond to be spent by the actions contract yet, an approval transaction is added first
+func JoinTransactions(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) ([]rocketpool.BatchTransaction, error) {
+	rocketDAONodeTrustedActions, err := getRocketDAONodeTrustedActions(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the bond and the current allowance
+	bond, err := getMemberRPLBondSetting(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := tokens.GetRPLAllowance(rp, nodeAddress, *rocketDAONodeTrustedActions.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Approve the bond if required
+	txs := []rocketpool.BatchTransaction{}
+	if allowance.Cmp(bond) < 0 {
+		spender := *rocketDAONodeTrustedActions.Address
+		txs = append(txs, rocketpool.BatchTransaction{
+			Name: "approve the trusted node DAO RPL bond",
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return tokens.EstimateApproveRPLGas(rp, spender, bond, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return tokens.ApproveRPL(rp, spender, bond, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketTokenRPL", "approve", spender, bond),
+		})
+	}
+
+	// Join
+	txs = append(txs, rocketpool.BatchTransaction{
+		Name: "join the trusted node DAO",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateJoinGas(rp, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return Join(rp, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedActions", "actionJoin"),
+	})
+	return txs, nil
+}
+
+// Get the transaction info for leaving the trusted node DAO
+// Requires an executed leave proposal
+func LeaveTransaction(rp *rocketpool.RocketPool, rplBondRefundAddress common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("leave the trusted node DAO, refunding the RPL bond to %s", rplBondRefundAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateLeaveGas(rp, rplBondRefundAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return Leave(rp, rplBondRefundAddress, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedActions", "actionLeave", rplBondRefundAddress),
+	}
+}
+
+// Get the transaction info for challenging a member
+// Non-members must pay the challenge cost, which is sent as the transaction value; members can pass nil
+func MakeChallengeTransaction(rp *rocketpool.RocketPool, memberAddress common.Address, challengeCost *big.Int) rocketpool.BatchTransaction {
+	withValue := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		if challengeCost == nil {
+			return opts
+		}
+		txOpts := *opts
+		txOpts.Value = challengeCost
+		return &txOpts
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("challenge trusted node DAO member %s", memberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateMakeChallengeGas(rp, memberAddress, withValue(opts))
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return MakeChallenge(rp, memberAddress, withValue(opts))
+		},
+		Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+			return rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedActions", "actionChallengeMake", memberAddress)(withValue(opts))
+		},
+	}
+}
+
+// Get the transaction info for deciding the challenge against a member
+func DecideChallengeTransaction(rp *rocketpool.RocketPool, memberAddress common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("decide the challenge against trusted node DAO member %s", memberAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDecideChallengeGas(rp, memberAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return DecideChallenge(rp, memberAddress, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedActions", "actionChallengeDecide", memberAddress),
+	}
+}
+
+// Get the transaction info for voting on a proposal
+// If validate is set, the voter must be a member who hasn't voted yet and the proposal must be active
+func VoteOnProposalTransaction(rp *rocketpool.RocketPool, proposalId uint64, support bool, validate bool) rocketpool.BatchTransaction {
+	tx := rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("vote on trusted node DAO proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateVoteOnProposalGas(rp, proposalId, support, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return VoteOnProposal(rp, proposalId, support, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedProposals", "vote", big.NewInt(int64(proposalId)), support),
+	}
+	if !validate {
+		return tx
+	}
+	return tx.WithValidation(func(opts *bind.TransactOpts) error {
+		if err := checkMemberSigner(rp, opts); err != nil {
+			return err
+		}
+		if err := checkProposalState(rp, proposalId, "voted on", rptypes.Active); err != nil {
+			return err
+		}
+		voted, err := dao.GetProposalMemberVoted(rp, proposalId, opts.From, nil)
+		if err != nil {
+			return err
+		}
+		if voted {
+			return fmt.Errorf("member %s has already voted on proposal %d", opts.From.Hex(), proposalId)
+		}
+		return nil
+	})
+}
+
+// Get the transaction info for cancelling a proposal; only the proposer can cancel it
+// If validate is set, the signer must be a member and the proposal must be pending or active
+func CancelProposalTransaction(rp *rocketpool.RocketPool, proposalId uint64, validate bool) rocketpool.BatchTransaction {
+	tx := rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("cancel trusted node DAO proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateCancelProposalGas(rp, proposalId, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return CancelProposal(rp, proposalId, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedProposals", "cancel", big.NewInt(int64(proposalId))),
+	}
+	if !validate {
+		return tx
+	}
+	return tx.WithValidation(func(opts *bind.TransactOpts) error {
+		if err := checkMemberSigner(rp, opts); err != nil {
+			return err
+		}
+		return checkProposalState(rp, proposalId, "cancelled", rptypes.Pending, rptypes.Active)
+	})
+}
+
+// Get the transaction info for executing a proposal
+// If validate is set, the proposal must have succeeded and not expired
+func ExecuteProposalTransaction(rp *rocketpool.RocketPool, proposalId uint64, validate bool) rocketpool.BatchTransaction {
+	tx := rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("execute trusted node DAO proposal %d", proposalId),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateExecuteProposalGas(rp, proposalId, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ExecuteProposal(rp, proposalId, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrustedProposals", "execute", big.NewInt(int64(proposalId))),
+	}
+	if !validate {
+		return tx
+	}
+	return tx.WithValidation(func(opts *bind.TransactOpts) error {
+		return dao.CheckProposalExecutable(rp, proposalId, nil)
+	})
+}
+
+// Check that a proposal is in one of the given states, returning an ErrOutsideWindow error if it isn't
+func checkProposalState(rp *rocketpool.RocketPool, proposalId uint64, action string, states ...rptypes.ProposalState) error {
+	currentState, err := dao.GetProposalState(rp, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range states {
+		if currentState == allowed {
+			return nil
+		}
+	}
+	return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can't be %s while it's %s", proposalId, action, currentState.String())
+}
+
+// Get the RPL bond required to join
+// The settings package depends on this one, so the setting is read directly
+func getMemberRPLBondSetting(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	membersSettingsContract, err := rp.GetContract(membersSettingsContractName, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := membersSettingsContract.Call(opts, value, "getRPLBond"); err != nil {
+		return nil, fmt.Errorf("error getting member RPL bond amount: %w", err)
+	}
+	return *value, nil
+}
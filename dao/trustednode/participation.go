@@ -0,0 +1,241 @@
+package trustednode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// A member's record for one kind of oDAO duty
+type DutyParticipation struct {
+	Duties    uint64   `json:"duties"`    // The number of updates that reached consensus while the node was a member
+	Submitted uint64   `json:"submitted"` // The number of those updates the member submitted for
+	Missed    []uint64 `json:"missed"`    // The block (or rewards interval) of each update the member didn't submit for
+}
+
+// Get the fraction of duties the member submitted for, or 1 if there were none
+func (d DutyParticipation) GetRate() float64 {
+	if d.Duties == 0 {
+		return 1
+	}
+	return float64(d.Submitted) / float64(d.Duties)
+}
+
+// A member's participation in the oDAO duties over a block range
+type MemberParticipation struct {
+	Address    common.Address    `json:"address"`
+	ID         string            `json:"id"`
+	JoinedTime time.Time         `json:"joinedTime"`
+	Balances   DutyParticipation `json:"balances"`
+	Prices     DutyParticipation `json:"prices"`
+	Rewards    DutyParticipation `json:"rewards"`
+}
+
+// The participation of every oDAO member over a block range
+type ParticipationReport struct {
+	StartBlock       uint64                `json:"startBlock"`
+	EndBlock         uint64                `json:"endBlock"`
+	BalancesUpdates  uint64                `json:"balancesUpdates"`
+	PricesUpdates    uint64                `json:"pricesUpdates"`
+	RewardsIntervals uint64                `json:"rewardsIntervals"`
+	Members          []MemberParticipation `json:"members"`
+}
+
+// An update that reached consensus, and the members that submitted for it
+type oDaoDuty struct {
+	key        uint64 // The balances or prices block, or the rewards interval
+	block      uint64 // The block the update was executed on
+	submitters map[common.Address]bool
+}
+
+// Get the balances, prices and rewards participation of the current oDAO members between two blocks
+// Only submissions and updates inside the range are counted, and members who joined during the range only have the
+// updates after they joined counted; members who have since left aren't included
+func GetParticipationReport(rp *rocketpool.RocketPool, startBlock *big.Int, endBlock *big.Int, options eth.LogScanOptions, opts *bind.CallOpts) (ParticipationReport, error) {
+	// Get the members
+	members, err := GetMembers(rp, opts)
+	if err != nil {
+		return ParticipationReport{}, fmt.Errorf("error getting oDAO members: %w", err)
+	}
+
+	// Get the duties; balances and prices submissions hold the block they're for as their first value
+	balances, err := getDuties(rp, "rocketNetworkBalances", "BalancesUpdated", "BalancesSubmitted", getFirstDataWord, startBlock, endBlock, options, opts)
+	if err != nil {
+		return ParticipationReport{}, err
+	}
+	prices, err := getDuties(rp, "rocketNetworkPrices", "PricesUpdated", "PricesSubmitted", getFirstDataWord, startBlock, endBlock, options, opts)
+	if err != nil {
+		return ParticipationReport{}, err
+	}
+	rewards, err := getDuties(rp, "rocketRewardsPool", "RewardSnapshot", "RewardSnapshotSubmitted", getSecondTopic, startBlock, endBlock, options, opts)
+	if err != nil {
+		return ParticipationReport{}, err
+	}
+
+	// Get the time the range started, to find members who joined during it
+	startHeader, err := rp.Client.HeaderByNumber(context.Background(), startBlock)
+	if err != nil {
+		return ParticipationReport{}, fmt.Errorf("error getting header for block %s: %w", startBlock.String(), err)
+	}
+	timestamps := map[uint64]uint64{}
+	getTimestamp := func(block uint64) (uint64, error) {
+		if timestamp, exists := timestamps[block]; exists {
+			return timestamp, nil
+		}
+		header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(block))
+		if err != nil {
+			return 0, fmt.Errorf("error getting header for block %d: %w", block, err)
+		}
+		timestamps[block] = header.Time
+		return header.Time, nil
+	}
+
+	// Score the members
+	participation := make([]MemberParticipation, len(members))
+	for i, member := range members {
+		participation[i] = MemberParticipation{
+			Address:    member.Address,
+			ID:         member.ID,
+			JoinedTime: time.Unix(int64(member.JoinedTime), 0),
+		}
+		for _, set := range []struct {
+			duties []oDaoDuty
+			result *DutyParticipation
+		}{
+			{duties: balances, result: &participation[i].Balances},
+			{duties: prices, result: &participation[i].Prices},
+			{duties: rewards, result: &participation[i].Rewards},
+		} {
+			// Skip the duties from before the member joined
+			duties := set.duties
+			if member.JoinedTime > startHeader.Time {
+				var searchErr error
+				first := sort.Search(len(duties), func(j int) bool {
+					if searchErr != nil {
+						return true
+					}
+					timestamp, err := getTimestamp(duties[j].block)
+					if err != nil {
+						searchErr = err
+						return true
+					}
+					return timestamp >= member.JoinedTime
+				})
+				if searchErr != nil {
+					return ParticipationReport{}, searchErr
+				}
+				duties = duties[first:]
+			}
+			*set.result = scoreDuties(duties, member.Address)
+		}
+	}
+
+	return ParticipationReport{
+		StartBlock:       startBlock.Uint64(),
+		EndBlock:         endBlock.Uint64(),
+		BalancesUpdates:  uint64(len(balances)),
+		PricesUpdates:    uint64(len(prices)),
+		RewardsIntervals: uint64(len(rewards)),
+		Members:          participation,
+	}, nil
+}
+
+// Get the updates of a duty in a block range, in block order, with the members that submitted for each
+// The update events are indexed by their key, and getSubmissionKey reads the key from a submission event
+func getDuties(rp *rocketpool.RocketPool, contractName string, updatedEventName string, submittedEventName string, getSubmissionKey func(types.Log) (uint64, bool), startBlock *big.Int, endBlock *big.Int, options eth.LogScanOptions, opts *bind.CallOpts) ([]oDaoDuty, error) {
+	contract, err := rp.GetContract(contractName, opts)
+	if err != nil {
+		return nil, err
+	}
+	updatedEvent, exists := contract.ABI.Events[updatedEventName]
+	if !exists {
+		return nil, fmt.Errorf("event %s does not exist on %s", updatedEventName, contractName)
+	}
+	submittedEvent, exists := contract.ABI.Events[submittedEventName]
+	if !exists {
+		return nil, fmt.Errorf("event %s does not exist on %s", submittedEventName, contractName)
+	}
+
+	// Get the event logs
+	topicFilter := [][]common.Hash{{updatedEvent.ID, submittedEvent.ID}}
+	logs, err := eth.ScanContractLogs(rp, contractName, topicFilter, startBlock, endBlock, options, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s logs: %w", contractName, err)
+	}
+
+	// Match the submissions to the updates
+	duties := []oDaoDuty{}
+	dutyIndices := map[uint64]int{}
+	submitters := map[uint64]map[common.Address]bool{}
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		switch log.Topics[0] {
+		case updatedEvent.ID:
+			key := log.Topics[1].Big().Uint64()
+			if _, exists := dutyIndices[key]; exists {
+				continue
+			}
+			dutyIndices[key] = len(duties)
+			duties = append(duties, oDaoDuty{
+				key:   key,
+				block: log.BlockNumber,
+			})
+		case submittedEvent.ID:
+			key, ok := getSubmissionKey(log)
+			if !ok {
+				continue
+			}
+			if submitters[key] == nil {
+				submitters[key] = map[common.Address]bool{}
+			}
+			submitters[key][common.BytesToAddress(log.Topics[1].Bytes())] = true
+		}
+	}
+	for i := range duties {
+		duties[i].submitters = submitters[duties[i].key]
+	}
+	return duties, nil
+}
+
+// Count the duties a member submitted for
+func scoreDuties(duties []oDaoDuty, memberAddress common.Address) DutyParticipation {
+	result := DutyParticipation{
+		Duties: uint64(len(duties)),
+		Missed: []uint64{},
+	}
+	for _, duty := range duties {
+		if duty.submitters[memberAddress] {
+			result.Submitted++
+		} else {
+			result.Missed = append(result.Missed, duty.key)
+		}
+	}
+	return result
+}
+
+// Get the key of a submission that holds it as the first value of its data
+func getFirstDataWord(log types.Log) (uint64, bool) {
+	if len(log.Data) < common.HashLength {
+		return 0, false
+	}
+	return new(big.Int).SetBytes(log.Data[:common.HashLength]).Uint64(), true
+}
+
+// Get the key of a submission that holds it as its second indexed value
+func getSecondTopic(log types.Log) (uint64, bool) {
+	if len(log.Topics) < 3 {
+		return 0, false
+	}
+	return log.Topics[2].Big().Uint64(), true
+}
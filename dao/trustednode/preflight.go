@@ -0,0 +1,26 @@
+package trustednode
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Check that a node is a member of the trusted node DAO
+// Returns an error wrapping rocketpool.ErrNotRegistered if it isn't
+func CheckMember(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) error {
+	exists, err := GetMemberExists(rp, memberAddress, opts)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return rocketpool.NewError(rocketpool.ErrNotRegistered, "node %s is not a member of the trusted node DAO", memberAddress.Hex())
+	}
+	return nil
+}
+
+// Check that a transaction that only members can send is being sent by a member
+func checkMemberSigner(rp *rocketpool.RocketPool, opts *bind.TransactOpts) error {
+	return CheckMember(rp, opts.From, nil)
+}
@@ -0,0 +1,165 @@
+package trustednode
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Proposal actions that aren't tied to a deadline
+const (
+	ProposalAction_Vote   ProposalActionType = "vote"
+	ProposalAction_Cancel ProposalActionType = "cancel"
+)
+
+// Who can take an action on a trusted node DAO proposal
+type ProposalActor string
+
+const (
+	ProposalActor_Anyone   ProposalActor = "anyone"
+	ProposalActor_Member   ProposalActor = "member"   // Members that joined before the proposal was created
+	ProposalActor_Proposer ProposalActor = "proposer" // The member that raised the proposal
+)
+
+// The window in which an action can be taken on a proposal
+// A zero OpensAt or ClosesAt means the window has no start or end
+type ProposalActionWindow struct {
+	Action    ProposalActionType `json:"action"`
+	Actor     ProposalActor      `json:"actor"`
+	OpensAt   time.Time          `json:"opensAt"`
+	ClosesAt  time.Time          `json:"closesAt"`
+	Available bool               `json:"available"` // Whether the window is open at the lifecycle's current time
+}
+
+// The current state of a trusted node DAO proposal and the actions that can be taken on it
+// Join and leave actions on executed proposals are covered by GetProposalDeadlines
+type ProposalLifecycle struct {
+	Details        dao.ProposalDetails    `json:"details"`
+	CurrentTime    time.Time              `json:"currentTime"`
+	NextTransition time.Time              `json:"nextTransition"` // Zero if the proposal won't change state on its own
+	Actions        []ProposalActionWindow `json:"actions"`
+
+	rp *rocketpool.RocketPool
+}
+
+// Get the lifecycle of a proposal at the given time
+func GetProposalLifecycle(rp *rocketpool.RocketPool, proposalId uint64, currentTime time.Time, opts *bind.CallOpts) (*ProposalLifecycle, error) {
+	details, err := dao.GetProposalDetails(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewProposalLifecycle(rp, details, currentTime), nil
+}
+
+// Create the lifecycle of a proposal from its details
+func NewProposalLifecycle(rp *rocketpool.RocketPool, details dao.ProposalDetails, currentTime time.Time) *ProposalLifecycle {
+	l := &ProposalLifecycle{
+		Details:     details,
+		CurrentTime: currentTime,
+		Actions:     []ProposalActionWindow{},
+		rp:          rp,
+	}
+
+	createdTime := time.Unix(int64(details.CreatedTime), 0)
+	startTime := time.Unix(int64(details.StartTime), 0)
+	endTime := time.Unix(int64(details.EndTime), 0)
+	expiryTime := time.Unix(int64(details.ExpiryTime), 0)
+
+	switch details.State {
+	case rptypes.Pending:
+		l.NextTransition = startTime
+		l.addAction(ProposalAction_Cancel, ProposalActor_Proposer, createdTime, startTime)
+
+	case rptypes.Active:
+		// Active proposals succeed as soon as they reach the required votes, so the end time is the latest transition
+		l.NextTransition = endTime
+		l.addAction(ProposalAction_Vote, ProposalActor_Member, startTime, endTime)
+		l.addAction(ProposalAction_Cancel, ProposalActor_Proposer, startTime, endTime)
+
+	case rptypes.Succeeded:
+		l.NextTransition = expiryTime
+		l.addAction(ProposalAction_Execute, ProposalActor_Anyone, time.Time{}, expiryTime)
+	}
+	return l
+}
+
+// Get the time until the proposal changes state on its own, or zero if it won't
+func (l *ProposalLifecycle) GetTimeUntilTransition() time.Duration {
+	if l.NextTransition.IsZero() || !l.NextTransition.After(l.CurrentTime) {
+		return 0
+	}
+	return l.NextTransition.Sub(l.CurrentTime)
+}
+
+// Get the window for an action, if it applies to the proposal's current state
+func (l *ProposalLifecycle) GetAction(action ProposalActionType) (ProposalActionWindow, bool) {
+	for _, window := range l.Actions {
+		if window.Action == action {
+			return window, true
+		}
+	}
+	return ProposalActionWindow{}, false
+}
+
+// Get the actions that can be taken now
+func (l *ProposalLifecycle) GetAvailableActions() []ProposalActionWindow {
+	available := []ProposalActionWindow{}
+	for _, window := range l.Actions {
+		if window.Available {
+			available = append(available, window)
+		}
+	}
+	return available
+}
+
+// Get the transaction info for voting on the proposal
+func (l *ProposalLifecycle) VoteTransaction(support bool) (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Vote); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return VoteOnProposalTransaction(l.rp, l.Details.ID, support, false), nil
+}
+
+// Get the transaction info for cancelling the proposal; only the proposer can cancel it
+func (l *ProposalLifecycle) CancelTransaction() (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Cancel); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return CancelProposalTransaction(l.rp, l.Details.ID, false), nil
+}
+
+// Get the transaction info for executing the proposal
+func (l *ProposalLifecycle) ExecuteTransaction() (rocketpool.BatchTransaction, error) {
+	if err := l.checkAction(ProposalAction_Execute); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return ExecuteProposalTransaction(l.rp, l.Details.ID, false), nil
+}
+
+// Add an action window, marking whether it's open at the current time
+func (l *ProposalLifecycle) addAction(action ProposalActionType, actor ProposalActor, opensAt time.Time, closesAt time.Time) {
+	available := (opensAt.IsZero() || !l.CurrentTime.Before(opensAt)) && (closesAt.IsZero() || l.CurrentTime.Before(closesAt))
+	l.Actions = append(l.Actions, ProposalActionWindow{
+		Action:    action,
+		Actor:     actor,
+		OpensAt:   opensAt,
+		ClosesAt:  closesAt,
+		Available: available,
+	})
+}
+
+// Check that an action can be taken at the current time
+func (l *ProposalLifecycle) checkAction(action ProposalActionType) error {
+	window, exists := l.GetAction(action)
+	if !exists {
+		return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can't %s while it's %s", l.Details.ID, action, l.Details.State.String())
+	}
+	if !window.Available {
+		return rocketpool.NewError(rocketpool.ErrOutsideWindow, "proposal %d can only %s between %s and %s", l.Details.ID, action, window.OpensAt.String(), window.ClosesAt.String())
+	}
+	return nil
+}
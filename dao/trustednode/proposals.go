@@ -216,6 +216,22 @@ func EstimateProposalGas(rp *rocketpool.RocketPool, message string, payload []by
 	return rocketDAONodeTrustedProposals.GetTransactionGasInfo(opts, "propose", message, payload)
 }
 
+// Get a describer for a proposal whose payload calls one of the proposals contract's methods, for use as a transaction's
+// Describe function
+func DescribeProposal(rp *rocketpool.RocketPool, message string, method string, params ...interface{}) func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+	return func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+		rocketDAONodeTrustedProposals, err := getRocketDAONodeTrustedProposals(rp, nil)
+		if err != nil {
+			return rocketpool.TransactionDescription{}, err
+		}
+		payload, err := rocketDAONodeTrustedProposals.ABI.Pack(method, params...)
+		if err != nil {
+			return rocketpool.TransactionDescription{}, fmt.Errorf("error encoding %s proposal payload: %w", method, err)
+		}
+		return rocketDAONodeTrustedProposals.DescribeCall("propose", message, payload)(opts)
+	}
+}
+
 // Submit a trusted node DAO proposal
 // Returns the ID of the new proposal
 func SubmitProposal(rp *rocketpool.RocketPool, message string, payload []byte, opts *bind.TransactOpts) (uint64, common.Hash, error) {
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const (
+	memberVotesFastBatchSize = 250
+)
+
+// A member's vote receipt for a proposal
+type MemberVote struct {
+	HasVoted  bool `json:"hasVoted"`
+	Supported bool `json:"supported"`
+}
+
+// Get the vote receipts of each member on each proposal, using a multicaller
+// The result is indexed by member, then by proposal, in the order they were provided
+func GetMemberVotesFast(rp *rocketpool.RocketPool, multicallAddress common.Address, memberAddresses []common.Address, proposalIds []uint64, opts *bind.CallOpts) ([][]MemberVote, error) {
+	rocketDAOProposal, err := getRocketDAOProposal(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	proposalCount := len(proposalIds)
+	count := len(memberAddresses) * proposalCount
+	votes := make([][]MemberVote, len(memberAddresses))
+	for i := range votes {
+		votes[i] = make([]MemberVote, proposalCount)
+	}
+
+	// Run the getters in batches
+	for i := 0; i < count; i += memberVotesFastBatchSize {
+		i := i
+		max := i + memberVotesFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				memberIndex := j / proposalCount
+				proposalIndex := j % proposalCount
+				proposalId := big.NewInt(0).SetUint64(proposalIds[proposalIndex])
+				vote := &votes[memberIndex][proposalIndex]
+				mc.AddCall(rocketDAOProposal, &vote.HasVoted, "getReceiptHasVoted", proposalId, memberAddresses[memberIndex])
+				mc.AddCall(rocketDAOProposal, &vote.Supported, "getReceiptSupported", proposalId, memberAddresses[memberIndex])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting member votes: %w", err)
+	}
+
+	// Return
+	return votes, nil
+}
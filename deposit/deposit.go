@@ -50,6 +50,19 @@ func GetExcessBalance(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int,
 	return *excessBalance, nil
 }
 
+// Get the maximum amount that can currently be deposited into the deposit pool
+func GetMaximumDepositAmount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketDepositPool, err := getRocketDepositPool(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	maximumDepositAmount := new(*big.Int)
+	if err := rocketDepositPool.Call(opts, maximumDepositAmount, "getMaximumDepositAmount"); err != nil {
+		return nil, fmt.Errorf("error getting deposit pool maximum deposit amount: %w", err)
+	}
+	return *maximumDepositAmount, nil
+}
+
 // Estimate the gas of Deposit
 func EstimateDepositGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketDepositPool, err := getRocketDepositPool(rp, nil)
@@ -94,6 +107,34 @@ func AssignDeposits(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.
 	return tx.Hash(), nil
 }
 
+// Get a deposit as a batch transaction; the amount to deposit is the value of the transact options
+func DepositTransaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: "deposit",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDepositGas(rp, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return Deposit(rp, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDepositPool", "deposit"),
+	}
+}
+
+// Get a deposit assignment as a batch transaction
+func AssignDepositsTransaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: "assign deposits",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateAssignDepositsGas(rp, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return AssignDeposits(rp, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDepositPool", "assignDeposits"),
+	}
+}
+
 // Get contracts
 var rocketDepositPoolLock sync.Mutex
 
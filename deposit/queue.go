@@ -0,0 +1,305 @@
+package deposit
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const queuePositionBatchSize = 500
+
+// The deposit pool ETH a queued minipool needs to be assigned, by deposit type
+var queueAssignmentAmounts = map[rptypes.MinipoolDeposit]*big.Int{
+	rptypes.Full:     eth.EthToWei(16),
+	rptypes.Half:     eth.EthToWei(16),
+	rptypes.Empty:    eth.EthToWei(32),
+	rptypes.Variable: eth.EthToWei(31),
+}
+
+// The state of the deposit pool and the minipool queue
+type QueueDetails struct {
+	Balance           *big.Int                           `json:"balance"`
+	UserBalance       *big.Int                           `json:"userBalance"`
+	ExcessBalance     *big.Int                           `json:"excessBalance"`
+	MaximumSize       *big.Int                           `json:"maximumSize"`
+	TotalLength       uint64                             `json:"totalLength"`
+	LengthByType      map[rptypes.MinipoolDeposit]uint64 `json:"lengthByType"`
+	TotalCapacity     *big.Int                           `json:"totalCapacity"` // Total ETH needed to assign every queued minipool
+	EffectiveCapacity *big.Int                           `json:"effectiveCapacity"`
+}
+
+// The position of a minipool in the queue and the ETH that has to be deposited before it is assigned
+type QueuePosition struct {
+	MinipoolAddress  common.Address          `json:"minipoolAddress"`
+	Position         uint64                  `json:"position"` // 1-indexed; 0 means the minipool isn't queued
+	DepositType      rptypes.MinipoolDeposit `json:"depositType"`
+	EthAhead         *big.Int                `json:"ethAhead"`         // ETH needed by the minipools ahead of it
+	EthRequired      *big.Int                `json:"ethRequired"`      // ETH needed by the minipool itself
+	EthShortfall     *big.Int                `json:"ethShortfall"`     // ETH that still has to be deposited before it can be assigned
+	CanBeAssignedNow bool                    `json:"canBeAssignedNow"` // True if the deposit pool already holds enough to reach it
+}
+
+// A minipool waiting in the queue
+type QueuedMinipool struct {
+	MinipoolAddress  common.Address          `json:"minipoolAddress"`
+	Position         uint64                  `json:"position"` // 1-indexed
+	DepositType      rptypes.MinipoolDeposit `json:"depositType"`
+	EthRequired      *big.Int                `json:"ethRequired"`      // ETH needed by the minipool itself
+	EthCumulative    *big.Int                `json:"ethCumulative"`    // ETH needed to assign it and every minipool ahead of it
+	CanBeAssignedNow bool                    `json:"canBeAssignedNow"` // True if the deposit pool already holds enough to reach it
+}
+
+// Get the deposit pool and minipool queue details in a single multicall
+func GetQueueDetails(rp *rocketpool.RocketPool, multicallerAddress common.Address, opts *bind.CallOpts) (QueueDetails, error) {
+	contracts, err := getQueueContracts(rp, opts)
+	if err != nil {
+		return QueueDetails{}, err
+	}
+	depositSettings, err := rp.GetContract("rocketDAOProtocolSettingsDeposit", opts)
+	if err != nil {
+		return QueueDetails{}, err
+	}
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return QueueDetails{}, err
+	}
+
+	// Add the calls
+	details := QueueDetails{
+		LengthByType: map[rptypes.MinipoolDeposit]uint64{},
+	}
+	var totalLength *big.Int
+	mc.AddCall(contracts.depositPool, &details.Balance, "getBalance")
+	mc.AddCall(contracts.depositPool, &details.UserBalance, "getUserBalance")
+	mc.AddCall(contracts.depositPool, &details.ExcessBalance, "getExcessBalance")
+	mc.AddCall(depositSettings, &details.MaximumSize, "getMaximumDepositPoolSize")
+	mc.AddCall(contracts.queue, &totalLength, "getTotalLength")
+	mc.AddCall(contracts.queue, &details.TotalCapacity, "getTotalCapacity")
+	mc.AddCall(contracts.queue, &details.EffectiveCapacity, "getEffectiveCapacity")
+
+	// Legacy queue lengths are only available on contracts that still have them
+	legacyLengths := map[rptypes.MinipoolDeposit]**big.Int{}
+	if _, exists := contracts.queue.ABI.Methods["getLengthLegacy"]; exists {
+		for _, depositType := range []rptypes.MinipoolDeposit{rptypes.Full, rptypes.Half, rptypes.Empty} {
+			length := new(*big.Int)
+			legacyLengths[depositType] = length
+			mc.AddCall(contracts.queue, length, "getLengthLegacy", uint8(depositType))
+		}
+	}
+	var variableLength *big.Int
+	_, hasVariableQueue := contracts.queue.ABI.Methods["getLength"]
+	if hasVariableQueue {
+		mc.AddCall(contracts.queue, &variableLength, "getLength")
+	}
+
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return QueueDetails{}, fmt.Errorf("error getting deposit pool queue details: %w", err)
+	}
+
+	details.TotalLength = totalLength.Uint64()
+	for depositType, length := range legacyLengths {
+		details.LengthByType[depositType] = (*length).Uint64()
+	}
+	if hasVariableQueue {
+		details.LengthByType[rptypes.Variable] = variableLength.Uint64()
+	}
+	return details, nil
+}
+
+// Get the position of a minipool in the queue and how much ETH needs to be deposited before it's assigned
+func GetQueuePosition(rp *rocketpool.RocketPool, multicallerAddress common.Address, minipoolAddress common.Address, opts *bind.CallOpts) (QueuePosition, error) {
+	contracts, err := getQueueContracts(rp, opts)
+	if err != nil {
+		return QueuePosition{}, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Get the position, deposit type and deposit pool balance
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return QueuePosition{}, err
+	}
+	var positionRaw *big.Int
+	var depositTypeRaw uint8
+	var balance *big.Int
+	mc.AddCall(contracts.queue, &positionRaw, "getMinipoolPosition", minipoolAddress)
+	mc.AddCall(contracts.minipoolManager, &depositTypeRaw, "getMinipoolDepositType", minipoolAddress)
+	mc.AddCall(contracts.depositPool, &balance, "getBalance")
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return QueuePosition{}, fmt.Errorf("error getting queue position of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+
+	position := QueuePosition{
+		MinipoolAddress: minipoolAddress,
+		DepositType:     rptypes.MinipoolDeposit(depositTypeRaw),
+		EthAhead:        big.NewInt(0),
+		EthRequired:     big.NewInt(0),
+		EthShortfall:    big.NewInt(0),
+	}
+	if positionRaw.Sign() < 0 {
+		return position, nil
+	}
+	position.Position = positionRaw.Uint64() + 1
+	if amount, exists := queueAssignmentAmounts[position.DepositType]; exists {
+		position.EthRequired.Set(amount)
+	}
+
+	// Add up the ETH needed by every minipool ahead of it
+	_, ahead, err := getQueuedMinipools(rp, contracts, multicallerAddress, positionRaw.Uint64(), opts)
+	if err != nil {
+		return QueuePosition{}, err
+	}
+	for _, depositType := range ahead {
+		if amount, exists := queueAssignmentAmounts[depositType]; exists {
+			position.EthAhead.Add(position.EthAhead, amount)
+		}
+	}
+
+	// Get the shortfall
+	needed := big.NewInt(0).Add(position.EthAhead, position.EthRequired)
+	if balance.Cmp(needed) < 0 {
+		position.EthShortfall.Sub(needed, balance)
+	} else {
+		position.CanBeAssignedNow = true
+	}
+	return position, nil
+}
+
+// Get the next minipools in line for assignment, up to count of them, with the ETH needed to reach each one
+func GetQueuePreview(rp *rocketpool.RocketPool, multicallerAddress common.Address, count uint64, opts *bind.CallOpts) ([]QueuedMinipool, error) {
+	contracts, err := getQueueContracts(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Get the queue length and deposit pool balance
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return nil, err
+	}
+	var totalLength *big.Int
+	var balance *big.Int
+	mc.AddCall(contracts.queue, &totalLength, "getTotalLength")
+	mc.AddCall(contracts.depositPool, &balance, "getBalance")
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error getting minipool queue length: %w", err)
+	}
+	if totalLength.Uint64() < count {
+		count = totalLength.Uint64()
+	}
+
+	// Get the minipools
+	addresses, depositTypes, err := getQueuedMinipools(rp, contracts, multicallerAddress, count, opts)
+	if err != nil {
+		return nil, err
+	}
+	preview := make([]QueuedMinipool, count)
+	cumulative := big.NewInt(0)
+	for i := range preview {
+		required := big.NewInt(0)
+		if amount, exists := queueAssignmentAmounts[depositTypes[i]]; exists {
+			required.Set(amount)
+		}
+		cumulative.Add(cumulative, required)
+		preview[i] = QueuedMinipool{
+			MinipoolAddress:  addresses[i],
+			Position:         uint64(i) + 1,
+			DepositType:      depositTypes[i],
+			EthRequired:      required,
+			EthCumulative:    new(big.Int).Set(cumulative),
+			CanBeAssignedNow: balance.Cmp(cumulative) >= 0,
+		}
+	}
+	return preview, nil
+}
+
+// Get the addresses and deposit types of the first count minipools in the queue
+func getQueuedMinipools(rp *rocketpool.RocketPool, contracts queueContracts, multicallerAddress common.Address, count uint64, opts *bind.CallOpts) ([]common.Address, []rptypes.MinipoolDeposit, error) {
+	// Get the addresses
+	addresses := make([]common.Address, count)
+	for i := uint64(0); i < count; i += queuePositionBatchSize {
+		max := i + queuePositionBatchSize
+		if max > count {
+			max = count
+		}
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		for j := i; j < max; j++ {
+			mc.AddCall(contracts.queue, &addresses[j], "getMinipoolAt", big.NewInt(0).SetUint64(j))
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, nil, fmt.Errorf("error getting queued minipool addresses: %w", err)
+		}
+	}
+
+	// Get the deposit types
+	depositTypes := make([]uint8, count)
+	for i := uint64(0); i < count; i += queuePositionBatchSize {
+		max := i + queuePositionBatchSize
+		if max > count {
+			max = count
+		}
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		for j := i; j < max; j++ {
+			mc.AddCall(contracts.minipoolManager, &depositTypes[j], "getMinipoolDepositType", addresses[j])
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, nil, fmt.Errorf("error getting queued minipool deposit types: %w", err)
+		}
+	}
+
+	types := make([]rptypes.MinipoolDeposit, count)
+	for i, depositType := range depositTypes {
+		types[i] = rptypes.MinipoolDeposit(depositType)
+	}
+	return addresses, types, nil
+}
+
+// Contracts used by the queue getters
+type queueContracts struct {
+	depositPool     *rocketpool.Contract
+	queue           *rocketpool.Contract
+	minipoolManager *rocketpool.Contract
+}
+
+// Get the contracts used by the queue getters
+func getQueueContracts(rp *rocketpool.RocketPool, opts *bind.CallOpts) (queueContracts, error) {
+	depositPool, err := getRocketDepositPool(rp, opts)
+	if err != nil {
+		return queueContracts{}, err
+	}
+	queue, err := rp.VersionManager.GetContract("rocketMinipoolQueue", opts)
+	if err != nil {
+		return queueContracts{}, err
+	}
+	minipoolManager, err := rp.VersionManager.GetContract("rocketMinipoolManager", opts)
+	if err != nil {
+		return queueContracts{}, err
+	}
+	return queueContracts{
+		depositPool:     depositPool,
+		queue:           queue,
+		minipoolManager: minipoolManager,
+	}, nil
+}
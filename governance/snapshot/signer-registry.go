@@ -0,0 +1,220 @@
+package snapshot
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	"golang.org/x/sync/errgroup"
+)
+
+// The registry isn't part of the Rocket Pool network contracts, so its ABI is kept here rather than in RocketStorage
+const signerRegistryAbiString string = `[
+	{"type":"function","name":"nodeToSigner","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"signerToNode","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"setSigner","stateMutability":"nonpayable","inputs":[{"name":"_signer","type":"address"},{"name":"_v","type":"uint8"},{"name":"_r","type":"bytes32"},{"name":"_s","type":"bytes32"}],"outputs":[]},
+	{"type":"function","name":"clearSigner","stateMutability":"nonpayable","inputs":[],"outputs":[]}
+]`
+
+// The message a signalling address signs to prove the node may delegate to it; the node address is lowercase hex
+const signerMessageFormat string = "%s may delegate to me for Rocket Pool governance"
+
+// The number of nodes to query per multicall batch
+const signerBatchSize int = 500
+
+// Global container for the parsed ABI above
+var signerRegistryAbi *abi.ABI
+var signerRegistryAbiLock sync.Mutex
+
+// The registry of the signalling addresses nodes use to vote on Snapshot without their node wallet
+type SignerRegistry struct {
+	Address  common.Address
+	contract *rocketpool.Contract
+}
+
+// Creates a contract wrapper for the signer registry at the given address
+func NewSignerRegistry(address common.Address, client rocketpool.ExecutionClient) (*SignerRegistry, error) {
+	// Parse the ABI
+	signerRegistryAbiLock.Lock()
+	defer signerRegistryAbiLock.Unlock()
+	if signerRegistryAbi == nil {
+		abiParsed, err := abi.JSON(strings.NewReader(signerRegistryAbiString))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing signer registry ABI: %w", err)
+		}
+		signerRegistryAbi = &abiParsed
+	}
+
+	// Create contract
+	contract := &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, *signerRegistryAbi, client, client, client),
+		Address:  &address,
+		ABI:      signerRegistryAbi,
+		Client:   client,
+	}
+
+	return &SignerRegistry{
+		Address:  address,
+		contract: contract,
+	}, nil
+}
+
+// Get the signalling address a node has registered, or the zero address if it hasn't set one
+func (r *SignerRegistry) GetNodeToSigner(nodeAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	signer := new(common.Address)
+	if err := r.contract.Call(opts, signer, "nodeToSigner", nodeAddress); err != nil {
+		return common.Address{}, fmt.Errorf("could not get signalling address for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *signer, nil
+}
+
+// Get the node a signalling address is registered to, or the zero address if it isn't registered
+func (r *SignerRegistry) GetSignerToNode(signerAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	nodeAddress := new(common.Address)
+	if err := r.contract.Call(opts, nodeAddress, "signerToNode", signerAddress); err != nil {
+		return common.Address{}, fmt.Errorf("could not get node for signalling address %s: %w", signerAddress.Hex(), err)
+	}
+	return *nodeAddress, nil
+}
+
+// Get the address a node votes on Snapshot with: its signalling address if it has registered one, otherwise the node address
+func (r *SignerRegistry) GetVotingAddress(nodeAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	signer, err := r.GetNodeToSigner(nodeAddress, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if signer == (common.Address{}) {
+		return nodeAddress, nil
+	}
+	return signer, nil
+}
+
+// Get the signalling addresses of several nodes using multicall; nodes without one have the zero address
+func (r *SignerRegistry) GetNodeToSignerFast(multicallAddress common.Address, nodeAddresses []common.Address, opts *bind.CallOpts) ([]common.Address, error) {
+	// Sync
+	var wg errgroup.Group
+	count := len(nodeAddresses)
+
+	// Run the getters in batches
+	signers := make([]common.Address, count)
+	for i := 0; i < count; i += signerBatchSize {
+		i := i
+		max := i + signerBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(r.contract.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				r.AddNodeToSignerCall(mc, &signers[j], nodeAddresses[j])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return signers, nil
+}
+
+// Add a call that gets a node's signalling address to a multicaller, for bulk getters that batch it with other calls
+func (r *SignerRegistry) AddNodeToSignerCall(mc *multicall.MultiCaller, signer *common.Address, nodeAddress common.Address) {
+	mc.AddCall(r.contract, signer, "nodeToSigner", nodeAddress)
+}
+
+// Get the message a signalling address must sign before a node can register it
+func GetSignerMessage(nodeAddress common.Address) string {
+	return fmt.Sprintf(signerMessageFormat, strings.ToLower(nodeAddress.Hex()))
+}
+
+// Sign the registration message for a node with the signalling address's key
+// The signature is in the 65-byte [R || S || V] format with V as 27 or 28, like eth_sign produces
+func SignSignerMessage(nodeAddress common.Address, signerKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(accounts.TextHash([]byte(GetSignerMessage(nodeAddress))), signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing signalling address message: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// Check that a signature of a node's registration message was made by the signalling address, and split it into the
+// components the registry expects
+// Signatures with V as 0 or 1 (e.g. from some hardware wallets) are accepted too
+func ParseSignerSignature(nodeAddress common.Address, signerAddress common.Address, signature []byte) (uint8, [32]byte, [32]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return 0, [32]byte{}, [32]byte{}, fmt.Errorf("invalid signature length %d, expected %d", len(signature), crypto.SignatureLength)
+	}
+	recoverable := make([]byte, crypto.SignatureLength)
+	copy(recoverable, signature)
+	if recoverable[crypto.RecoveryIDOffset] >= 27 {
+		recoverable[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte(GetSignerMessage(nodeAddress))), recoverable)
+	if err != nil {
+		return 0, [32]byte{}, [32]byte{}, fmt.Errorf("error recovering signer: %w", err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pubkey); recovered != signerAddress {
+		return 0, [32]byte{}, [32]byte{}, fmt.Errorf("signature is from %s, not the signalling address %s", recovered.Hex(), signerAddress.Hex())
+	}
+
+	var r, s [32]byte
+	copy(r[:], recoverable[0:32])
+	copy(s[:], recoverable[32:64])
+	return recoverable[crypto.RecoveryIDOffset] + 27, r, s, nil
+}
+
+// Estimate the gas of SetSigner
+func (r *SignerRegistry) EstimateSetSignerGas(nodeAddress common.Address, signerAddress common.Address, signature []byte, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	v, sigR, sigS, err := ParseSignerSignature(nodeAddress, signerAddress, signature)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return r.contract.GetTransactionGasInfo(opts, "setSigner", signerAddress, v, sigR, sigS)
+}
+
+// Register a signalling address for the node, using the address's signature of the node's registration message
+func (r *SignerRegistry) SetSigner(nodeAddress common.Address, signerAddress common.Address, signature []byte, opts *bind.TransactOpts) (common.Hash, error) {
+	v, sigR, sigS, err := ParseSignerSignature(nodeAddress, signerAddress, signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := r.contract.Transact(opts, "setSigner", signerAddress, v, sigR, sigS)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error setting signalling address: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of ClearSigner
+func (r *SignerRegistry) EstimateClearSignerGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return r.contract.GetTransactionGasInfo(opts, "clearSigner")
+}
+
+// Remove the node's signalling address
+func (r *SignerRegistry) ClearSigner(opts *bind.TransactOpts) (common.Hash, error) {
+	tx, err := r.contract.Transact(opts, "clearSigner")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error clearing signalling address: %w", err)
+	}
+	return tx.Hash(), nil
+}
@@ -0,0 +1,170 @@
+package snapshot
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The Snapshot space for Rocket Pool governance
+const RocketPoolSpace string = "rocketpool-dao.eth"
+
+// The EIP-712 domain Snapshot uses for votes
+const (
+	domainName    string = "snapshot"
+	domainVersion string = "0.1.4"
+)
+
+// A vote on a Snapshot proposal
+// Choices are 1-based indices into the proposal's choices; the choice must be a uint32 for single choice and basic
+// votes, a []uint32 for approval and ranked choice votes, or a map[uint32]uint64 of weights for weighted and
+// quadratic votes
+type Vote struct {
+	From      common.Address `json:"from"`
+	Space     string         `json:"space"`
+	Timestamp uint64         `json:"timestamp"`
+	Proposal  string         `json:"proposal"` // A 32-byte hex ID, or an IPFS hash for legacy proposals
+	Choice    any            `json:"choice"`
+	Reason    string         `json:"reason"`
+	App       string         `json:"app"`
+	Metadata  string         `json:"metadata"`
+}
+
+// A signed vote in the format the Snapshot hub accepts
+type VoteEnvelope struct {
+	Address common.Address `json:"address"`
+	Sig     hexutil.Bytes  `json:"sig"`
+	Data    struct {
+		Domain  map[string]any            `json:"domain"`
+		Types   apitypes.Types            `json:"types"`
+		Message apitypes.TypedDataMessage `json:"message"`
+	} `json:"data"`
+}
+
+// Get the EIP-712 typed data for the vote
+func (v Vote) GetTypedData() (apitypes.TypedData, error) {
+	// Get the proposal type; proposals created before Snapshot moved to hex IDs are referenced by IPFS hash
+	proposalType := "string"
+	if proposalBytes, err := hexutil.Decode(v.Proposal); err == nil && len(proposalBytes) == common.HashLength {
+		proposalType = "bytes32"
+	}
+
+	// Get the choice type
+	// Integers are stored as float64 so the message serializes as plain JSON numbers for the hub; timestamps and
+	// choice indices fit in one without losing precision
+	var choiceType string
+	var choice any
+	switch c := v.Choice.(type) {
+	case uint32:
+		choiceType = "uint32"
+		choice = float64(c)
+	case []uint32:
+		choiceType = "uint32[]"
+		choices := make([]any, len(c))
+		for i, index := range c {
+			choices[i] = float64(index)
+		}
+		choice = choices
+	case map[uint32]uint64:
+		// Snapshot expects weights as a JSON object keyed by the choice index
+		weights := make(map[string]uint64, len(c))
+		for index, weight := range c {
+			weights[strconv.FormatUint(uint64(index), 10)] = weight
+		}
+		bytes, err := json.Marshal(weights)
+		if err != nil {
+			return apitypes.TypedData{}, fmt.Errorf("error serializing vote weights: %w", err)
+		}
+		choiceType = "string"
+		choice = string(bytes)
+	default:
+		return apitypes.TypedData{}, fmt.Errorf("unsupported vote choice type %T", v.Choice)
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"Vote": {
+				{Name: "from", Type: "address"},
+				{Name: "space", Type: "string"},
+				{Name: "timestamp", Type: "uint64"},
+				{Name: "proposal", Type: proposalType},
+				{Name: "choice", Type: choiceType},
+				{Name: "reason", Type: "string"},
+				{Name: "app", Type: "string"},
+				{Name: "metadata", Type: "string"},
+			},
+		},
+		PrimaryType: "Vote",
+		Domain: apitypes.TypedDataDomain{
+			Name:    domainName,
+			Version: domainVersion,
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":      v.From.Hex(),
+			"space":     v.Space,
+			"timestamp": float64(v.Timestamp),
+			"proposal":  v.Proposal,
+			"choice":    choice,
+			"reason":    v.Reason,
+			"app":       v.App,
+			"metadata":  v.Metadata,
+		},
+	}, nil
+}
+
+// Get the EIP-712 hash of the vote that the voter signs
+func (v Vote) GetSigningHash() (common.Hash, error) {
+	typedData, err := v.GetTypedData()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error hashing vote: %w", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Sign the vote and wrap it for submission to the Snapshot hub
+// The key must belong to the vote's From address, which is either the node or its signalling address
+func SignVote(vote Vote, privateKey *ecdsa.PrivateKey) (VoteEnvelope, error) {
+	if address := crypto.PubkeyToAddress(privateKey.PublicKey); address != vote.From {
+		return VoteEnvelope{}, fmt.Errorf("vote is from %s but the key is for %s", vote.From.Hex(), address.Hex())
+	}
+	typedData, err := vote.GetTypedData()
+	if err != nil {
+		return VoteEnvelope{}, err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return VoteEnvelope{}, fmt.Errorf("error hashing vote: %w", err)
+	}
+	sig, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return VoteEnvelope{}, fmt.Errorf("error signing vote: %w", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return NewVoteEnvelope(typedData, vote.From, sig), nil
+}
+
+// Wrap typed vote data that was signed elsewhere (e.g. by a hardware wallet) for submission to the Snapshot hub
+func NewVoteEnvelope(typedData apitypes.TypedData, address common.Address, sig []byte) VoteEnvelope {
+	envelope := VoteEnvelope{
+		Address: address,
+		Sig:     sig,
+	}
+	envelope.Data.Domain = typedData.Domain.Map()
+	envelope.Data.Types = apitypes.Types{"Vote": typedData.Types["Vote"]}
+	envelope.Data.Message = typedData.Message
+	return envelope
+}
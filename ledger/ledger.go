@@ -0,0 +1,363 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The type of a ledger entry
+type EntryType string
+
+const (
+	EntryType_NodeDeposit  EntryType = "node_deposit"
+	EntryType_UserDeposit  EntryType = "user_deposit"
+	EntryType_Refund       EntryType = "refund"
+	EntryType_Distribution EntryType = "distribution"
+	EntryType_RewardsClaim EntryType = "rewards_claim"
+	EntryType_Penalty      EntryType = "penalty"
+)
+
+// The asset an entry is denominated in
+type Asset string
+
+const (
+	Asset_ETH Asset = "ETH"
+	Asset_RPL Asset = "RPL"
+)
+
+// A single cash flow from the perspective of the node operator
+// Amount is positive for flows to the node and negative for flows from it; UserAmount is the rETH pool's share of
+// a distribution (or the user deposit amount), which is reported for reconciliation but not owned by the node
+// Penalty entries record penalty submissions; their cost is realised by the following distribution, so they carry
+// a zero amount
+type Entry struct {
+	Type            EntryType      `json:"type"`
+	Asset           Asset          `json:"asset"`
+	NodeAddress     common.Address `json:"nodeAddress"`
+	MinipoolAddress common.Address `json:"minipoolAddress"`
+	Amount          *big.Int       `json:"amount"`
+	UserAmount      *big.Int       `json:"userAmount"`
+	Time            time.Time      `json:"time"`
+	BlockNumber     uint64         `json:"blockNumber"`
+	TxHash          common.Hash    `json:"txHash"`
+	LogIndex        uint           `json:"logIndex"`
+}
+
+// A node operator's ledger over a block range
+type Ledger struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	StartBlock  uint64         `json:"startBlock"`
+	EndBlock    uint64         `json:"endBlock"`
+	Entries     []Entry        `json:"entries"`
+}
+
+// Get the total of all entries for an asset
+func (l *Ledger) GetTotal(asset Asset) *big.Int {
+	total := big.NewInt(0)
+	for _, entry := range l.Entries {
+		if entry.Asset == asset && entry.Amount != nil {
+			total.Add(total, entry.Amount)
+		}
+	}
+	return total
+}
+
+// Get the entries for a single minipool
+func (l *Ledger) GetMinipoolEntries(minipoolAddress common.Address) []Entry {
+	entries := []Entry{}
+	for _, entry := range l.Entries {
+		if entry.MinipoolAddress == minipoolAddress {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Build the ledger for a node across all of its minipools between two blocks (inclusive)
+func BuildNodeLedger(rp *rocketpool.RocketPool, nodeAddress common.Address, startBlock uint64, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) (*Ledger, error) {
+	ledger := &Ledger{
+		NodeAddress: nodeAddress,
+		StartBlock:  startBlock,
+		EndBlock:    endBlock,
+		Entries:     []Entry{},
+	}
+
+	// Get the minipool entries
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, minipoolAddress := range minipoolAddresses {
+		mp, err := minipool.NewMinipool(rp, minipoolAddress, opts)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := GetMinipoolEntries(rp, mp, nodeAddress, startBlock, endBlock, intervalSize, opts)
+		if err != nil {
+			return nil, err
+		}
+		ledger.Entries = append(ledger.Entries, entries...)
+	}
+
+	// Get the penalty entries
+	entries, err := GetPenaltyEntries(rp, nodeAddress, minipoolAddresses, startBlock, endBlock, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	ledger.Entries = append(ledger.Entries, entries...)
+
+	// Get the rewards claim entries
+	entries, err = GetRewardsClaimEntries(rp, nodeAddress, startBlock, endBlock, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	ledger.Entries = append(ledger.Entries, entries...)
+
+	// Sort in chain order
+	sort.SliceStable(ledger.Entries, func(i, j int) bool {
+		a, b := ledger.Entries[i], ledger.Entries[j]
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		return a.LogIndex < b.LogIndex
+	})
+	return ledger, nil
+}
+
+// Get the deposit, refund and distribution entries for a minipool between two blocks (inclusive)
+// Deposits sent by the deposit pool are treated as user deposits; all others are treated as node deposits
+func GetMinipoolEntries(rp *rocketpool.RocketPool, mp minipool.Minipool, nodeAddress common.Address, startBlock uint64, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) ([]Entry, error) {
+	depositPoolAddress, err := rp.GetAddress("rocketDepositPool", opts)
+	if err != nil {
+		return nil, err
+	}
+	contract := mp.GetContract()
+
+	// Build the topic filter from the events this minipool version supports
+	eventIDs := []common.Hash{}
+	for _, name := range []string{"EtherDeposited", "EtherWithdrawn", "EtherWithdrawalProcessed"} {
+		if event, exists := contract.ABI.Events[name]; exists {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+	if len(eventIDs) == 0 {
+		return []Entry{}, nil
+	}
+
+	// Get the logs
+	logs, err := eth.GetLogs(rp, []common.Address{mp.GetAddress()}, [][]common.Hash{eventIDs}, intervalSize, new(big.Int).SetUint64(startBlock), new(big.Int).SetUint64(endBlock), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting logs for minipool %s: %w", mp.GetAddress().Hex(), err)
+	}
+	return DecodeMinipoolEntries(contract.ABI, *depositPoolAddress, nodeAddress, mp.GetAddress(), logs)
+}
+
+// Decode the deposit, refund and distribution entries from a minipool's logs, which must be in chain order
+// A distribution credits the node's share to the minipool's refund balance, which is only paid out by a later
+// EtherWithdrawn event: in the same transaction when the owner distributes, or in a later refund when anyone else
+// skims the balance. The distribution entry counts the node's share, so withdrawals pay out credited shares first
+// and only the rest (e.g. a refund from a bond reduction) is a refund entry. Shares credited before the first log are
+// unknown, so their withdrawals are counted as refunds.
+func DecodeMinipoolEntries(contractAbi *abi.ABI, depositPoolAddress common.Address, nodeAddress common.Address, minipoolAddress common.Address, logs []types.Log) ([]Entry, error) {
+	depositedEvent, hasDeposited := contractAbi.Events["EtherDeposited"]
+	withdrawnEvent, hasWithdrawn := contractAbi.Events["EtherWithdrawn"]
+	processedEvent, hasProcessed := contractAbi.Events["EtherWithdrawalProcessed"]
+
+	// The node's distributed shares that haven't been withdrawn yet
+	credited := big.NewInt(0)
+
+	entries := []Entry{}
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		entry := newEntry(log, nodeAddress, minipoolAddress)
+		counterparty := common.BytesToAddress(log.Topics[1].Bytes())
+		values := make(map[string]interface{})
+
+		switch {
+		case hasDeposited && log.Topics[0] == depositedEvent.ID:
+			if err := depositedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error decoding deposit event for minipool %s: %w", minipoolAddress.Hex(), err)
+			}
+			amount := values["amount"].(*big.Int)
+			if counterparty == depositPoolAddress {
+				entry.Type = EntryType_UserDeposit
+				entry.Amount = big.NewInt(0)
+				entry.UserAmount = amount
+			} else {
+				entry.Type = EntryType_NodeDeposit
+				entry.Amount = new(big.Int).Neg(amount)
+			}
+
+		case hasWithdrawn && log.Topics[0] == withdrawnEvent.ID:
+			if err := withdrawnEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error decoding withdrawal event for minipool %s: %w", minipoolAddress.Hex(), err)
+			}
+			entry.Type = EntryType_Refund
+			entry.Amount = new(big.Int).Set(values["amount"].(*big.Int))
+			paidOut := new(big.Int).Set(credited)
+			if paidOut.Cmp(entry.Amount) > 0 {
+				paidOut.Set(entry.Amount)
+			}
+			credited.Sub(credited, paidOut)
+			entry.Amount.Sub(entry.Amount, paidOut)
+			if entry.Amount.Sign() == 0 {
+				continue
+			}
+
+		case hasProcessed && log.Topics[0] == processedEvent.ID:
+			if err := processedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("error decoding withdrawal processed event for minipool %s: %w", minipoolAddress.Hex(), err)
+			}
+			entry.Type = EntryType_Distribution
+			entry.Amount = values["nodeAmount"].(*big.Int)
+			entry.UserAmount = values["userAmount"].(*big.Int)
+			credited.Add(credited, entry.Amount)
+
+		default:
+			continue
+		}
+
+		if eventTime, ok := values["time"].(*big.Int); ok {
+			entry.Time = time.Unix(eventTime.Int64(), 0)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Get the penalty submission entries for a node's minipools between two blocks (inclusive)
+func GetPenaltyEntries(rp *rocketpool.RocketPool, nodeAddress common.Address, minipoolAddresses []common.Address, startBlock uint64, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) ([]Entry, error) {
+	rocketNetworkPenalties, err := rp.GetContract("rocketNetworkPenalties", opts)
+	if err != nil {
+		return nil, err
+	}
+	penaltyEvent, exists := rocketNetworkPenalties.ABI.Events["PenaltySubmitted"]
+	if !exists {
+		return []Entry{}, nil
+	}
+
+	// Get the logs
+	logs, err := eth.FilterContractLogs(rp, "rocketNetworkPenalties", eth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlock),
+		ToBlock:   new(big.Int).SetUint64(endBlock),
+		Topics:    [][]common.Hash{{penaltyEvent.ID}},
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting penalty logs: %w", err)
+	}
+
+	isNodeMinipool := map[common.Address]bool{}
+	for _, minipoolAddress := range minipoolAddresses {
+		isNodeMinipool[minipoolAddress] = true
+	}
+
+	entries := []Entry{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := penaltyEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error decoding penalty event: %w", err)
+		}
+		minipoolAddress, ok := values["minipoolAddress"].(common.Address)
+		if !ok || !isNodeMinipool[minipoolAddress] {
+			continue
+		}
+		entry := newEntry(log, nodeAddress, minipoolAddress)
+		entry.Type = EntryType_Penalty
+		entry.Amount = big.NewInt(0)
+		if eventTime, ok := values["time"].(*big.Int); ok {
+			entry.Time = time.Unix(eventTime.Int64(), 0)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Get the rewards claim entries for a node between two blocks (inclusive)
+// Each claim produces an ETH entry and an RPL entry; amounts that were restaked are still reported as claimed
+func GetRewardsClaimEntries(rp *rocketpool.RocketPool, nodeAddress common.Address, startBlock uint64, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) ([]Entry, error) {
+	rocketMerkleDistributorMainnet, err := rp.GetContract("rocketMerkleDistributorMainnet", opts)
+	if err != nil {
+		return nil, err
+	}
+	claimedEvent, exists := rocketMerkleDistributorMainnet.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return []Entry{}, nil
+	}
+
+	// Get the logs
+	logs, err := eth.FilterContractLogs(rp, "rocketMerkleDistributorMainnet", eth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlock),
+		ToBlock:   new(big.Int).SetUint64(endBlock),
+		Topics:    [][]common.Hash{{claimedEvent.ID}, {common.BytesToHash(nodeAddress.Bytes())}},
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim logs for node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	entries := []Entry{}
+	blockTimes := map[uint64]time.Time{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := claimedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error decoding rewards claim event: %w", err)
+		}
+
+		// Get the block time
+		blockTime, exists := blockTimes[log.BlockNumber]
+		if !exists {
+			header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(log.BlockNumber))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", log.BlockNumber, err)
+			}
+			blockTime = time.Unix(int64(header.Time), 0)
+			blockTimes[log.BlockNumber] = blockTime
+		}
+
+		for _, claim := range []struct {
+			asset Asset
+			key   string
+		}{{Asset_ETH, "amountETH"}, {Asset_RPL, "amountRPL"}} {
+			amounts, _ := values[claim.key].([]*big.Int)
+			total := big.NewInt(0)
+			for _, amount := range amounts {
+				total.Add(total, amount)
+			}
+			if total.Sign() == 0 {
+				continue
+			}
+			entry := newEntry(log, nodeAddress, common.Address{})
+			entry.Type = EntryType_RewardsClaim
+			entry.Asset = claim.asset
+			entry.Amount = total
+			entry.Time = blockTime
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Create a ledger entry with the details of a log
+func newEntry(log types.Log, nodeAddress common.Address, minipoolAddress common.Address) Entry {
+	return Entry{
+		Asset:           Asset_ETH,
+		NodeAddress:     nodeAddress,
+		MinipoolAddress: minipoolAddress,
+		BlockNumber:     log.BlockNumber,
+		TxHash:          log.TxHash,
+		LogIndex:        log.Index,
+	}
+}
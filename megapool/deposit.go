@@ -0,0 +1,69 @@
+package megapool
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Estimate the gas of AddValidator
+func EstimateAddValidatorGas(rp *rocketpool.RocketPool, bondAmount *big.Int, useExpressTicket bool, validatorPubkey rptypes.ValidatorPubkey, validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeDeposit.GetTransactionGasInfo(opts, "deposit", bondAmount, useExpressTicket, validatorPubkey[:], validatorSignature[:], depositDataRoot)
+}
+
+// Add a validator to the node's megapool, deploying the megapool first if this is the node's first one
+// The bond is sent as the transaction value
+func AddValidator(rp *rocketpool.RocketPool, bondAmount *big.Int, useExpressTicket bool, validatorPubkey rptypes.ValidatorPubkey, validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeDeposit.Transact(opts, "deposit", bondAmount, useExpressTicket, validatorPubkey[:], validatorSignature[:], depositDataRoot)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error adding megapool validator %s: %w", validatorPubkey.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that adds a validator to the node's megapool, sending the bond as the transaction value
+func AddValidatorTransaction(rp *rocketpool.RocketPool, bondAmount *big.Int, useExpressTicket bool, validatorPubkey rptypes.ValidatorPubkey, validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash) rocketpool.BatchTransaction {
+	withValue := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		txOpts := *opts
+		txOpts.Value = bondAmount
+		return &txOpts
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("add megapool validator %s", validatorPubkey.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateAddValidatorGas(rp, bondAmount, useExpressTicket, validatorPubkey, validatorSignature, depositDataRoot, withValue(opts))
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return AddValidator(rp, bondAmount, useExpressTicket, validatorPubkey, validatorSignature, depositDataRoot, withValue(opts))
+		},
+		Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+			return rocketpool.DescribeContractCall(rp, "rocketNodeDeposit", "deposit", bondAmount, useExpressTicket, validatorPubkey[:], validatorSignature[:], depositDataRoot)(withValue(opts))
+		},
+	}
+}
+
+// Get contracts
+var rocketNodeDepositLock sync.Mutex
+
+func getRocketNodeDeposit(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	if err := checkSaturnDeployed(rp, opts); err != nil {
+		return nil, err
+	}
+	rocketNodeDepositLock.Lock()
+	defer rocketNodeDepositLock.Unlock()
+	return rp.GetContract("rocketNodeDeposit", opts)
+}
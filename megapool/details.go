@@ -0,0 +1,111 @@
+package megapool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const (
+	validatorPubkeysFastBatchSize int = 500
+)
+
+// Details of a megapool, retrieved together
+type MegapoolDetails struct {
+	Address              common.Address `json:"address"`
+	NodeAddress          common.Address `json:"nodeAddress"`
+	ValidatorCount       uint32         `json:"validatorCount"`
+	ActiveValidatorCount uint32         `json:"activeValidatorCount"`
+	Debt                 *big.Int       `json:"debt"`
+	RefundValue          *big.Int       `json:"refundValue"`
+}
+
+// Get a megapool's details in a single multicall
+func GetMegapoolDetails(rp *rocketpool.RocketPool, multicallerAddress common.Address, megapoolAddress common.Address, opts *bind.CallOpts) (MegapoolDetails, error) {
+	if err := checkSaturnDeployed(rp, opts); err != nil {
+		return MegapoolDetails{}, err
+	}
+	contract, err := getMegapoolContract(rp, megapoolAddress, opts)
+	if err != nil {
+		return MegapoolDetails{}, err
+	}
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return MegapoolDetails{}, err
+	}
+
+	details := MegapoolDetails{
+		Address: megapoolAddress,
+	}
+	mc.AddCall(contract, &details.NodeAddress, "getNodeAddress")
+	mc.AddCall(contract, &details.ValidatorCount, "getValidatorCount")
+	mc.AddCall(contract, &details.ActiveValidatorCount, "getActiveValidatorCount")
+	mc.AddCall(contract, &details.Debt, "getDebt")
+	mc.AddCall(contract, &details.RefundValue, "getRefundValue")
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return MegapoolDetails{}, fmt.Errorf("error getting megapool %s details: %w", megapoolAddress.Hex(), err)
+	}
+	return details, nil
+}
+
+// Get the pubkeys of a megapool's validators, using a multicaller
+func GetValidatorPubkeysFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, megapoolAddress common.Address, validatorCount uint32, opts *bind.CallOpts) ([]rptypes.ValidatorPubkey, error) {
+	if err := checkSaturnDeployed(rp, opts); err != nil {
+		return nil, err
+	}
+	contract, err := getMegapoolContract(rp, megapoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	count := int(validatorCount)
+	pubkeys := make([][]byte, count)
+
+	// Run the getters in batches
+	for i := 0; i < count; i += validatorPubkeysFastBatchSize {
+		i := i
+		max := i + validatorPubkeysFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				mc.AddCall(contract, &pubkeys[j], "getValidatorPubkey", uint32(j))
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting megapool %s validator pubkeys: %w", megapoolAddress.Hex(), err)
+	}
+
+	// Return
+	results := make([]rptypes.ValidatorPubkey, count)
+	for i, pubkey := range pubkeys {
+		results[i] = rptypes.BytesToValidatorPubkey(pubkey)
+	}
+	return results, nil
+}
@@ -0,0 +1,63 @@
+package megapool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Get the address a node's megapool is (or will be) deployed at
+func GetMegapoolExpectedAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (common.Address, error) {
+	rocketMegapoolFactory, err := getRocketMegapoolFactory(rp, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	address := new(common.Address)
+	if err := rocketMegapoolFactory.Call(opts, address, "getExpectedAddress", nodeAddress); err != nil {
+		return common.Address{}, fmt.Errorf("error getting megapool address for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *address, nil
+}
+
+// Check if a node's megapool has been deployed
+// Megapools are deployed by the node's first deposit after Saturn
+func GetMegapoolDeployed(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	rocketMegapoolFactory, err := getRocketMegapoolFactory(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	deployed := new(bool)
+	if err := rocketMegapoolFactory.Call(opts, deployed, "getMegapoolDeployed", nodeAddress); err != nil {
+		return false, fmt.Errorf("error checking if the megapool for node %s is deployed: %w", nodeAddress.Hex(), err)
+	}
+	return *deployed, nil
+}
+
+// Get the binding for a node's megapool, or nil if it hasn't been deployed yet
+func GetNodeMegapool(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (Megapool, error) {
+	deployed, err := GetMegapoolDeployed(rp, nodeAddress, opts)
+	if err != nil || !deployed {
+		return nil, err
+	}
+	address, err := GetMegapoolExpectedAddress(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewMegapool(rp, address, opts)
+}
+
+// Get contracts
+var rocketMegapoolFactoryLock sync.Mutex
+
+func getRocketMegapoolFactory(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	if err := checkSaturnDeployed(rp, opts); err != nil {
+		return nil, err
+	}
+	rocketMegapoolFactoryLock.Lock()
+	defer rocketMegapoolFactoryLock.Unlock()
+	return rp.GetContract("rocketMegapoolFactory", opts)
+}
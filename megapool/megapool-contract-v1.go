@@ -0,0 +1,135 @@
+package megapool
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Megapool contract
+type megapool_v1 struct {
+	Address    common.Address
+	Version    uint8
+	Contract   *rocketpool.Contract
+	RocketPool *rocketpool.RocketPool
+}
+
+// Create new megapool contract
+// Megapools are proxies, so they're bound with the ABI of the delegate registered in RocketStorage
+func newMegapool_v1(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (Megapool, error) {
+	contract, err := getMegapoolContract(rp, address, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &megapool_v1{
+		Address:    address,
+		Version:    1,
+		Contract:   contract,
+		RocketPool: rp,
+	}, nil
+}
+
+// Get the contract
+func (mp *megapool_v1) GetContract() *rocketpool.Contract {
+	return mp.Contract
+}
+
+// Get the contract address
+func (mp *megapool_v1) GetAddress() common.Address {
+	return mp.Address
+}
+
+// Get the contract version
+func (mp *megapool_v1) GetVersion() uint8 {
+	return mp.Version
+}
+
+// Get the kind of validator container this is
+func (mp *megapool_v1) GetContainerType() rptypes.ValidatorContainerType {
+	return rptypes.ValidatorContainerType_Megapool
+}
+
+// Get the address of the node that owns the megapool
+func (mp *megapool_v1) GetNodeAddress(opts *bind.CallOpts) (common.Address, error) {
+	nodeAddress := new(common.Address)
+	if err := mp.Contract.Call(opts, nodeAddress, "getNodeAddress"); err != nil {
+		return common.Address{}, fmt.Errorf("error getting megapool %s node address: %w", mp.Address.Hex(), err)
+	}
+	return *nodeAddress, nil
+}
+
+// Get the number of validators that have been added to the megapool, including exited ones
+func (mp *megapool_v1) GetValidatorCount(opts *bind.CallOpts) (uint32, error) {
+	count := new(uint32)
+	if err := mp.Contract.Call(opts, count, "getValidatorCount"); err != nil {
+		return 0, fmt.Errorf("error getting megapool %s validator count: %w", mp.Address.Hex(), err)
+	}
+	return *count, nil
+}
+
+// Get the number of validators in the megapool that haven't exited
+func (mp *megapool_v1) GetActiveValidatorCount(opts *bind.CallOpts) (uint32, error) {
+	count := new(uint32)
+	if err := mp.Contract.Call(opts, count, "getActiveValidatorCount"); err != nil {
+		return 0, fmt.Errorf("error getting megapool %s active validator count: %w", mp.Address.Hex(), err)
+	}
+	return *count, nil
+}
+
+// Get the pubkey of one of the megapool's validators
+func (mp *megapool_v1) GetValidatorPubkey(validatorId uint32, opts *bind.CallOpts) (rptypes.ValidatorPubkey, error) {
+	pubkey := new([]byte)
+	if err := mp.Contract.Call(opts, pubkey, "getValidatorPubkey", validatorId); err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("error getting megapool %s validator %d pubkey: %w", mp.Address.Hex(), validatorId, err)
+	}
+	return rptypes.BytesToValidatorPubkey(*pubkey), nil
+}
+
+// Get the pubkeys of all of the megapool's validators
+func (mp *megapool_v1) GetValidatorPubkeys(opts *bind.CallOpts) ([]rptypes.ValidatorPubkey, error) {
+	count, err := mp.GetValidatorCount(opts)
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := make([]rptypes.ValidatorPubkey, count)
+	for i := uint32(0); i < count; i++ {
+		pubkeys[i], err = mp.GetValidatorPubkey(i, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pubkeys, nil
+}
+
+// Get the ETH the node owes the protocol, which is taken from its rewards and refunds first
+func (mp *megapool_v1) GetDebt(opts *bind.CallOpts) (*big.Int, error) {
+	debt := new(*big.Int)
+	if err := mp.Contract.Call(opts, debt, "getDebt"); err != nil {
+		return nil, fmt.Errorf("error getting megapool %s debt: %w", mp.Address.Hex(), err)
+	}
+	return *debt, nil
+}
+
+// Get the ETH the node can claim as a refund
+func (mp *megapool_v1) GetRefundValue(opts *bind.CallOpts) (*big.Int, error) {
+	refund := new(*big.Int)
+	if err := mp.Contract.Call(opts, refund, "getRefundValue"); err != nil {
+		return nil, fmt.Errorf("error getting megapool %s refund value: %w", mp.Address.Hex(), err)
+	}
+	return *refund, nil
+}
+
+// Get a megapool contract
+var rocketMegapoolLock sync.Mutex
+
+func getMegapoolContract(rp *rocketpool.RocketPool, megapoolAddress common.Address, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketMegapoolLock.Lock()
+	defer rocketMegapoolLock.Unlock()
+	return rp.MakeContract("rocketMegapoolDelegate", megapoolAddress, opts)
+}
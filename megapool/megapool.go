@@ -0,0 +1,78 @@
+package megapool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The protocol version that introduced megapools
+var SaturnVersion = version.Must(version.NewSemver("1.4.0"))
+
+// A node's megapool, which holds all of its validators in a single contract
+type Megapool interface {
+	rptypes.IValidatorContainer
+	GetContract() *rocketpool.Contract
+	GetVersion() uint8
+	GetValidatorCount(opts *bind.CallOpts) (uint32, error)
+	GetActiveValidatorCount(opts *bind.CallOpts) (uint32, error)
+	GetValidatorPubkey(validatorId uint32, opts *bind.CallOpts) (rptypes.ValidatorPubkey, error)
+	GetDebt(opts *bind.CallOpts) (*big.Int, error)
+	GetRefundValue(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// Check if the deployed protocol version has megapools
+// Uses the version found by the version manager's detection if it has been run
+func IsSaturnDeployed(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	protocolVersion := rp.VersionManager.GetDetectedVersion()
+	if protocolVersion == nil {
+		var err error
+		protocolVersion, err = rp.VersionManager.GetProtocolVersion(opts)
+		if err != nil {
+			return false, err
+		}
+	}
+	return protocolVersion.GreaterThanOrEqual(SaturnVersion), nil
+}
+
+// Return an ErrNotDeployed error if megapools aren't deployed yet
+func checkSaturnDeployed(rp *rocketpool.RocketPool, opts *bind.CallOpts) error {
+	deployed, err := IsSaturnDeployed(rp, opts)
+	if err != nil {
+		return err
+	}
+	if !deployed {
+		return rocketpool.NewError(rocketpool.ErrNotDeployed, "megapools require protocol v%s or later", SaturnVersion.String())
+	}
+	return nil
+}
+
+// Create a megapool binding
+func NewMegapool(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (Megapool, error) {
+	if err := checkSaturnDeployed(rp, opts); err != nil {
+		return nil, err
+	}
+
+	// Get the contract version
+	version, err := rocketpool.GetContractVersion(rp, address, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting megapool contract version: %w", err)
+	}
+	return NewMegapoolFromVersion(rp, address, version, opts)
+}
+
+// Create a megapool binding from an explicit version number
+func NewMegapoolFromVersion(rp *rocketpool.RocketPool, address common.Address, version uint8, opts *bind.CallOpts) (Megapool, error) {
+	switch version {
+	case 1:
+		return newMegapool_v1(rp, address, opts)
+	default:
+		return nil, fmt.Errorf("unexpected megapool contract version [%d]", version)
+	}
+}
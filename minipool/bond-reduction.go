@@ -0,0 +1,190 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The stage of a minipool's bond reduction
+type BondReductionState string
+
+const (
+	BondReductionState_None      BondReductionState = "none"      // No reduction has been started
+	BondReductionState_Waiting   BondReductionState = "waiting"   // Started, but the reduction window hasn't opened yet
+	BondReductionState_Reducible BondReductionState = "reducible" // The reduction window is open
+	BondReductionState_Expired   BondReductionState = "expired"   // The reduction window closed before the reduction was completed
+	BondReductionState_Cancelled BondReductionState = "cancelled" // The Oracle DAO cancelled the reduction
+)
+
+// Tracks a minipool's bond reduction through begin, the Oracle DAO scrub period and the final reduction
+// The fields can be filled from a state snapshot (see the state package's NativeMinipoolDetails) or with NewBondReducer
+type BondReducer struct {
+	MinipoolAddress     common.Address         `json:"minipoolAddress"`
+	Status              rptypes.MinipoolStatus `json:"status"`
+	Finalised           bool                   `json:"finalised"`
+	ReduceBondTime      time.Time              `json:"reduceBondTime"` // The Unix epoch if no reduction has been started
+	ReduceBondCancelled bool                   `json:"reduceBondCancelled"`
+	ReduceBondValue     *big.Int               `json:"reduceBondValue"`
+	WindowStart         time.Duration          `json:"windowStart"`
+	WindowLength        time.Duration          `json:"windowLength"`
+
+	rp *rocketpool.RocketPool
+}
+
+// Create a new bond reducer, loading the minipool's bond reduction details
+func NewBondReducer(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (*BondReducer, error) {
+	mp, err := NewMinipool(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	status, err := mp.GetStatus(opts)
+	if err != nil {
+		return nil, err
+	}
+	finalised, err := mp.GetFinalised(opts)
+	if err != nil {
+		return nil, err
+	}
+	reduceBondTime, err := GetReduceBondTime(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	reduceBondCancelled, err := GetReduceBondCancelled(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	reduceBondValue, err := GetReduceBondValue(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	windowStart, err := tnsettings.GetBondReductionWindowStart(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	windowLength, err := tnsettings.GetBondReductionWindowLength(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewBondReducerFromDetails(rp, minipoolAddress, status, finalised, reduceBondTime, reduceBondCancelled, reduceBondValue,
+		time.Duration(windowStart)*time.Second, time.Duration(windowLength)*time.Second), nil
+}
+
+// Create a new bond reducer from details that have already been loaded
+func NewBondReducerFromDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, status rptypes.MinipoolStatus, finalised bool, reduceBondTime time.Time, reduceBondCancelled bool, reduceBondValue *big.Int, windowStart time.Duration, windowLength time.Duration) *BondReducer {
+	return &BondReducer{
+		MinipoolAddress:     minipoolAddress,
+		Status:              status,
+		Finalised:           finalised,
+		ReduceBondTime:      reduceBondTime,
+		ReduceBondCancelled: reduceBondCancelled,
+		ReduceBondValue:     reduceBondValue,
+		WindowStart:         windowStart,
+		WindowLength:        windowLength,
+		rp:                  rp,
+	}
+}
+
+// Get the stage of the bond reduction at the given time
+func (r *BondReducer) GetState(now time.Time) BondReductionState {
+	if r.ReduceBondTime.Unix() == 0 {
+		return BondReductionState_None
+	}
+	if r.ReduceBondCancelled {
+		return BondReductionState_Cancelled
+	}
+	windowOpen := r.ReduceBondTime.Add(r.WindowStart)
+	switch {
+	case now.Before(windowOpen):
+		return BondReductionState_Waiting
+	case now.Before(windowOpen.Add(r.WindowLength)):
+		return BondReductionState_Reducible
+	default:
+		return BondReductionState_Expired
+	}
+}
+
+// Check if a new bond reduction can be started, which requires a staking minipool with no reduction in progress
+// A minipool whose reduction was cancelled by the Oracle DAO can't begin another one
+func (r *BondReducer) CanBeginReduction(now time.Time) bool {
+	if r.Status != rptypes.Staking || r.Finalised {
+		return false
+	}
+	switch r.GetState(now) {
+	case BondReductionState_Waiting, BondReductionState_Reducible, BondReductionState_Cancelled:
+		return false
+	default:
+		return true
+	}
+}
+
+// Check if the bond can be reduced now
+func (r *BondReducer) CanReduce(now time.Time) bool {
+	return r.Status == rptypes.Staking && !r.Finalised && r.GetState(now) == BondReductionState_Reducible
+}
+
+// Get the time remaining until the reduction window opens; 0 if it's already open or no reduction is waiting on it
+func (r *BondReducer) TimeUntilReducible(now time.Time) time.Duration {
+	if r.GetState(now) != BondReductionState_Waiting {
+		return 0
+	}
+	return r.ReduceBondTime.Add(r.WindowStart).Sub(now)
+}
+
+// Check if the reduction window closed before the reduction was completed; a new reduction must be started
+func (r *BondReducer) ReductionExpired(now time.Time) bool {
+	return r.GetState(now) == BondReductionState_Expired
+}
+
+// Get a transaction that begins reducing the minipool's bond to the new amount
+func (r *BondReducer) BeginReductionTransaction(newBondAmount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("begin bond reduction of minipool %s", r.MinipoolAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateBeginReduceBondAmountGas(r.rp, r.MinipoolAddress, newBondAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return BeginReduceBondAmount(r.rp, r.MinipoolAddress, newBondAmount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(r.rp, "rocketMinipoolBondReducer", "beginReduceBondAmount", r.MinipoolAddress, newBondAmount),
+	}
+}
+
+// Get a transaction that completes the bond reduction; only succeeds while the reduction window is open
+func (r *BondReducer) ReduceBondTransaction() (rocketpool.BatchTransaction, error) {
+	mp, err := NewMinipool(r.rp, r.MinipoolAddress, nil)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	mpv3, success := GetMinipoolAsV3(mp)
+	if !success {
+		return rocketpool.BatchTransaction{}, fmt.Errorf("minipool %s cannot reduce its bond because it is version %d", r.MinipoolAddress.Hex(), mp.GetVersion())
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("reduce bond of minipool %s", r.MinipoolAddress.Hex()),
+		Estimate: mpv3.EstimateReduceBondAmountGas,
+		Submit:   mpv3.ReduceBondAmount,
+		Describe: mpv3.GetContract().DescribeCall("reduceBondAmount"),
+	}, nil
+}
+
+// Get a transaction that votes to cancel the bond reduction; only callable by Oracle DAO members
+func (r *BondReducer) VoteCancelReductionTransaction() rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("vote to cancel bond reduction of minipool %s", r.MinipoolAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateVoteCancelReductionGas(r.rp, r.MinipoolAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return VoteCancelReduction(r.rp, r.MinipoolAddress, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(r.rp, "rocketMinipoolBondReducer", "voteCancelReduction", r.MinipoolAddress),
+	}
+}
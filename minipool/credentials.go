@@ -0,0 +1,174 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The type of a validator's withdrawal credentials
+type WithdrawalCredentialType string
+
+const (
+	WithdrawalCredentialType_BLS       WithdrawalCredentialType = "bls"       // 0x00
+	WithdrawalCredentialType_Execution WithdrawalCredentialType = "execution" // 0x01
+	WithdrawalCredentialType_Unknown   WithdrawalCredentialType = "unknown"
+)
+
+// Withdrawal credential readiness of a single minipool's validator
+type CredentialReadiness struct {
+	MinipoolAddress       common.Address           `json:"minipoolAddress"`
+	Pubkey                rptypes.ValidatorPubkey  `json:"pubkey"`
+	Status                rptypes.MinipoolStatus   `json:"status"`
+	IsVacant              bool                     `json:"isVacant"`
+	OnBeacon              bool                     `json:"onBeacon"`
+	BeaconCredentials     common.Hash              `json:"beaconCredentials"`
+	CredentialType        WithdrawalCredentialType `json:"credentialType"`
+	ExpectedCredentials   common.Hash              `json:"expectedCredentials"`
+	MatchesExpected       bool                     `json:"matchesExpected"`
+	NeedsCredentialChange bool                     `json:"needsCredentialChange"`
+	PromotionTime         time.Time                `json:"promotionTime"`
+	CanPromote            bool                     `json:"canPromote"`
+}
+
+// Get the type of a set of withdrawal credentials
+func GetWithdrawalCredentialType(withdrawalCredentials common.Hash) WithdrawalCredentialType {
+	switch withdrawalCredentials[0] {
+	case 0x00:
+		return WithdrawalCredentialType_BLS
+	case Eth1WithdrawalPrefix:
+		return WithdrawalCredentialType_Execution
+	default:
+		return WithdrawalCredentialType_Unknown
+	}
+}
+
+// Get the withdrawal credential readiness of a node's minipools
+// beaconCredentials maps each validator pubkey to its withdrawal credentials on the Beacon Chain; validators missing
+// from it are treated as not yet on the Beacon Chain
+// Validators with 0x00 credentials need a BLS-to-execution change to the minipool address before they can be fully
+// exited automatically; vacant (solo migration) minipools whose credentials point at the minipool can be promoted
+// once the promotion scrub period has passed at the queried block
+func GetNodeCredentialReadiness(rp *rocketpool.RocketPool, nodeAddress common.Address, beaconCredentials map[rptypes.ValidatorPubkey]common.Hash, opts *bind.CallOpts) ([]CredentialReadiness, error) {
+	minipoolAddresses, err := GetNodeMinipoolAddresses(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	promotionScrubPeriod, err := tnsettings.GetPromotionScrubPeriod(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	blockTime, err := getBlockTime(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]CredentialReadiness, 0, len(minipoolAddresses))
+	for _, minipoolAddress := range minipoolAddresses {
+		readiness, err := getCredentialReadiness(rp, minipoolAddress, beaconCredentials, promotionScrubPeriod, blockTime, opts)
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, readiness)
+	}
+	return report, nil
+}
+
+// Get the withdrawal credential readiness of a single minipool
+func getCredentialReadiness(rp *rocketpool.RocketPool, minipoolAddress common.Address, beaconCredentials map[rptypes.ValidatorPubkey]common.Hash, promotionScrubPeriod uint64, blockTime time.Time, opts *bind.CallOpts) (CredentialReadiness, error) {
+	mp, err := NewMinipool(rp, minipoolAddress, opts)
+	if err != nil {
+		return CredentialReadiness{}, err
+	}
+	status, err := mp.GetStatusDetails(opts)
+	if err != nil {
+		return CredentialReadiness{}, fmt.Errorf("error getting status of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	pubkey, err := GetMinipoolPubkey(rp, minipoolAddress, opts)
+	if err != nil {
+		return CredentialReadiness{}, err
+	}
+
+	readiness := CredentialReadiness{
+		MinipoolAddress:     minipoolAddress,
+		Pubkey:              pubkey,
+		Status:              status.Status,
+		IsVacant:            status.IsVacant,
+		ExpectedCredentials: GetExpectedWithdrawalCredentials(minipoolAddress),
+		CredentialType:      WithdrawalCredentialType_Unknown,
+	}
+
+	credentials, onBeacon := beaconCredentials[pubkey]
+	if !onBeacon {
+		return readiness, nil
+	}
+	readiness.OnBeacon = true
+	readiness.BeaconCredentials = credentials
+	readiness.CredentialType = GetWithdrawalCredentialType(credentials)
+	readiness.MatchesExpected = (credentials == readiness.ExpectedCredentials)
+	readiness.NeedsCredentialChange = (readiness.CredentialType == WithdrawalCredentialType_BLS)
+
+	// Check if a solo migration is ready to be promoted
+	if status.IsVacant && status.Status == rptypes.Prelaunch {
+		readiness.PromotionTime = status.StatusTime.Add(time.Duration(promotionScrubPeriod) * time.Second)
+		readiness.CanPromote = readiness.MatchesExpected && !blockTime.Before(readiness.PromotionTime)
+	}
+	return readiness, nil
+}
+
+// Get the timestamp of the block the call options point at, or of the latest block if they don't set one
+func getBlockTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Time, error) {
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error getting block header: %w", err)
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// Get the minipools in a readiness report that need a BLS-to-execution credential change
+func GetMinipoolsNeedingCredentialChange(report []CredentialReadiness) []CredentialReadiness {
+	needsChange := []CredentialReadiness{}
+	for _, readiness := range report {
+		if readiness.NeedsCredentialChange {
+			needsChange = append(needsChange, readiness)
+		}
+	}
+	return needsChange
+}
+
+// Promote every vacant minipool in a readiness report that is ready for promotion
+// Returns the hashes of the promotion transactions, keyed by minipool address
+func PromoteReadyMinipools(rp *rocketpool.RocketPool, report []CredentialReadiness, opts *bind.TransactOpts) (map[common.Address]common.Hash, error) {
+	hashes := map[common.Address]common.Hash{}
+	for _, readiness := range report {
+		if !readiness.CanPromote {
+			continue
+		}
+		mp, err := NewMinipool(rp, readiness.MinipoolAddress, nil)
+		if err != nil {
+			return hashes, err
+		}
+		mpv3, success := GetMinipoolAsV3(mp)
+		if !success {
+			return hashes, fmt.Errorf("minipool %s cannot be promoted because it is version %d", readiness.MinipoolAddress.Hex(), mp.GetVersion())
+		}
+		hash, err := mpv3.Promote(opts)
+		if err != nil {
+			return hashes, err
+		}
+		hashes[readiness.MinipoolAddress] = hash
+	}
+	return hashes, nil
+}
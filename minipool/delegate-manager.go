@@ -0,0 +1,232 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const DelegateDetailsFastBatchSize = 200
+
+// A minipool's delegate details
+type DelegateDetails struct {
+	Address           common.Address `json:"address"`
+	Delegate          common.Address `json:"delegate"`
+	PreviousDelegate  common.Address `json:"previousDelegate"`
+	EffectiveDelegate common.Address `json:"effectiveDelegate"`
+	UseLatestDelegate bool           `json:"useLatestDelegate"`
+	NeedsUpgrade      bool           `json:"needsUpgrade"` // The minipool isn't on the latest delegate and doesn't follow it automatically
+	CanRollback       bool           `json:"canRollback"`  // The minipool has a previous delegate to roll back to
+}
+
+// A dry run of a delegate upgrade across a set of minipools
+type DelegateUpgradeReport struct {
+	LatestDelegate    common.Address   `json:"latestDelegate"`
+	NeedsUpgrade      []common.Address `json:"needsUpgrade"`
+	UpToDate          []common.Address `json:"upToDate"`
+	UseLatestDelegate []common.Address `json:"useLatestDelegate"` // Already on the latest delegate through the setting
+}
+
+// Manages the delegates of a set of minipools, e.g. all of a node's minipools after a delegate upgrade
+// The details are loaded once on creation; create a new manager to refresh them after submitting transactions
+type DelegateManager struct {
+	LatestDelegate common.Address
+	Details        []DelegateDetails
+
+	rp *rocketpool.RocketPool
+}
+
+// Create a new delegate manager, loading the delegate details of the minipools using multicall
+func NewDelegateManager(rp *rocketpool.RocketPool, multicallerAddress common.Address, minipoolAddresses []common.Address, opts *bind.CallOpts) (*DelegateManager, error) {
+	latestDelegate, err := rp.GetAddress("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest minipool delegate address: %w", err)
+	}
+	details, err := getDelegateDetailsFast(rp, multicallerAddress, minipoolAddresses, *latestDelegate, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &DelegateManager{
+		LatestDelegate: *latestDelegate,
+		Details:        details,
+		rp:             rp,
+	}, nil
+}
+
+// Get a dry-run report of which minipools need upgrading to the latest delegate
+func (m *DelegateManager) GetUpgradeReport() DelegateUpgradeReport {
+	report := DelegateUpgradeReport{
+		LatestDelegate:    m.LatestDelegate,
+		NeedsUpgrade:      []common.Address{},
+		UpToDate:          []common.Address{},
+		UseLatestDelegate: []common.Address{},
+	}
+	for _, details := range m.Details {
+		switch {
+		case details.NeedsUpgrade:
+			report.NeedsUpgrade = append(report.NeedsUpgrade, details.Address)
+		case details.UseLatestDelegate:
+			report.UseLatestDelegate = append(report.UseLatestDelegate, details.Address)
+		default:
+			report.UpToDate = append(report.UpToDate, details.Address)
+		}
+	}
+	return report
+}
+
+// Get delegate upgrade transactions for the minipools that need upgrading
+func (m *DelegateManager) GetDelegateUpgradeTransactions() ([]rocketpool.BatchTransaction, error) {
+	txs := []rocketpool.BatchTransaction{}
+	for _, details := range m.Details {
+		if !details.NeedsUpgrade {
+			continue
+		}
+		tx, err := DelegateUpgradeTransaction(m.rp, details.Address)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// Get delegate rollback transactions for the minipools that have a previous delegate
+func (m *DelegateManager) GetDelegateRollbackTransactions() ([]rocketpool.BatchTransaction, error) {
+	txs := []rocketpool.BatchTransaction{}
+	for _, details := range m.Details {
+		if !details.CanRollback {
+			continue
+		}
+		tx, err := DelegateRollbackTransaction(m.rp, details.Address)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// Get transactions that change the use latest delegate setting for the minipools that don't already have it
+func (m *DelegateManager) GetSetUseLatestDelegateTransactions(setting bool) ([]rocketpool.BatchTransaction, error) {
+	txs := []rocketpool.BatchTransaction{}
+	for _, details := range m.Details {
+		if details.UseLatestDelegate == setting {
+			continue
+		}
+		tx, err := SetUseLatestDelegateTransaction(m.rp, details.Address, setting)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// Get a transaction that upgrades a minipool to the latest delegate
+func DelegateUpgradeTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address) (rocketpool.BatchTransaction, error) {
+	mp, err := newDelegateMinipool(rp, minipoolAddress)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("upgrade delegate of minipool %s", minipoolAddress.Hex()),
+		Estimate: mp.EstimateDelegateUpgradeGas,
+		Submit:   mp.DelegateUpgrade,
+		Describe: mp.GetContract().DescribeCall("delegateUpgrade"),
+	}, nil
+}
+
+// Get a transaction that rolls a minipool back to its previous delegate
+func DelegateRollbackTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address) (rocketpool.BatchTransaction, error) {
+	mp, err := newDelegateMinipool(rp, minipoolAddress)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("roll back delegate of minipool %s", minipoolAddress.Hex()),
+		Estimate: mp.EstimateDelegateRollbackGas,
+		Submit:   mp.DelegateRollback,
+		Describe: mp.GetContract().DescribeCall("delegateRollback"),
+	}, nil
+}
+
+// Get a transaction that sets whether a minipool always uses the latest delegate
+func SetUseLatestDelegateTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address, setting bool) (rocketpool.BatchTransaction, error) {
+	mp, err := newDelegateMinipool(rp, minipoolAddress)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("set use latest delegate of minipool %s to %t", minipoolAddress.Hex(), setting),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return mp.EstimateSetUseLatestDelegateGas(setting, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return mp.SetUseLatestDelegate(setting, opts)
+		},
+		Describe: mp.GetContract().DescribeCall("setUseLatestDelegate", setting),
+	}, nil
+}
+
+// Create a minipool binding for the delegate methods
+// The delegate methods are the same in every minipool version, so the v3 ABI is used without looking up the version
+func newDelegateMinipool(rp *rocketpool.RocketPool, minipoolAddress common.Address) (Minipool, error) {
+	return NewMinipoolFromVersion(rp, minipoolAddress, 3, nil)
+}
+
+// Get the delegate details of minipools using multicall
+func getDelegateDetailsFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, minipoolAddresses []common.Address, latestDelegate common.Address, opts *bind.CallOpts) ([]DelegateDetails, error) {
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Load the details in batches
+	count := len(minipoolAddresses)
+	details := make([]DelegateDetails, count)
+	for bsi := 0; bsi < count; bsi += DelegateDetailsFastBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + DelegateDetailsFastBatchSize
+		if mei > count {
+			mei = count
+		}
+
+		// Add the calls
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, err
+		}
+		for mi := msi; mi < mei; mi++ {
+			details[mi].Address = minipoolAddresses[mi]
+			mp, err := newDelegateMinipool(rp, minipoolAddresses[mi])
+			if err != nil {
+				return nil, err
+			}
+			contract := mp.GetContract()
+			mc.AddCall(contract, &details[mi].Delegate, "getDelegate")
+			mc.AddCall(contract, &details[mi].PreviousDelegate, "getPreviousDelegate")
+			mc.AddCall(contract, &details[mi].EffectiveDelegate, "getEffectiveDelegate")
+			mc.AddCall(contract, &details[mi].UseLatestDelegate, "getUseLatestDelegate")
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, fmt.Errorf("error getting minipool delegate details: %w", err)
+		}
+
+	}
+
+	// Check which minipools need upgrading
+	for i := range details {
+		details[i].NeedsUpgrade = !details[i].UseLatestDelegate && details[i].Delegate != latestDelegate
+		details[i].CanRollback = details[i].PreviousDelegate != (common.Address{}) && details[i].PreviousDelegate != details[i].Delegate
+	}
+
+	// Return
+	return details, nil
+
+}
@@ -0,0 +1,83 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-ssz"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/bls"
+)
+
+var DomainDeposit = bls.DomainDeposit
+
+// A signed Beacon deposit for a minipool's validator
+type SignedDepositData struct {
+	Pubkey                types.ValidatorPubkey    `json:"pubkey"`
+	WithdrawalCredentials common.Hash              `json:"withdrawalCredentials"`
+	Amount                uint64                   `json:"amount"` // Gwei
+	Signature             types.ValidatorSignature `json:"signature"`
+	DepositDataRoot       common.Hash              `json:"depositDataRoot"`
+}
+
+// The deposit data submitted to the deposit contract
+type depositData struct {
+	Pubkey                []byte `ssz-size:"48"`
+	WithdrawalCredentials []byte `ssz-size:"32"`
+	Amount                uint64
+	Signature             []byte `ssz-size:"96"`
+}
+
+// Compute the signature domain for deposits
+// Deposits are valid across forks, so they're always signed with the genesis fork version and no validators root
+func GetDepositDomain(genesisForkVersion [4]byte) ([]byte, error) {
+	return bls.ComputeDomain(DomainDeposit, genesisForkVersion, common.Hash{})
+}
+
+// Create the signed deposit data for a minipool's validator, with withdrawal credentials pointing at the minipool
+// amount is in gwei
+func SignDepositData(validatorKey *bls.SecretKey, minipoolAddress common.Address, amount uint64, genesisForkVersion [4]byte) (SignedDepositData, error) {
+	pubkey := validatorKey.PublicKey()
+	withdrawalCredentials := GetExpectedWithdrawalCredentials(minipoolAddress)
+
+	// Get the signing root
+	signingRoot, err := GetDepositSigningRoot(pubkey, withdrawalCredentials, amount, genesisForkVersion)
+	if err != nil {
+		return SignedDepositData{}, err
+	}
+
+	// Sign the deposit
+	signature, err := validatorKey.Sign(signingRoot[:])
+	if err != nil {
+		return SignedDepositData{}, fmt.Errorf("error signing deposit: %w", err)
+	}
+	depositDataRoot, err := ssz.HashTreeRoot(depositData{
+		Pubkey:                pubkey.Bytes(),
+		WithdrawalCredentials: withdrawalCredentials.Bytes(),
+		Amount:                amount,
+		Signature:             signature.Bytes(),
+	})
+	if err != nil {
+		return SignedDepositData{}, fmt.Errorf("error computing deposit data root: %w", err)
+	}
+
+	return SignedDepositData{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+		Signature:             signature,
+		DepositDataRoot:       depositDataRoot,
+	}, nil
+}
+
+// Get the root a validator key signs for a deposit; amount is in gwei
+func GetDepositSigningRoot(pubkey types.ValidatorPubkey, withdrawalCredentials common.Hash, amount uint64, genesisForkVersion [4]byte) (common.Hash, error) {
+	return bls.GetDepositSigningRoot(pubkey, withdrawalCredentials, amount, genesisForkVersion)
+}
+
+// Check that a deposit's signature was made by its pubkey over its withdrawal credentials and amount
+// The Beacon Chain ignores the first deposit for a pubkey if its signature is invalid, so this should be checked before the deposit is relied on
+func VerifyDepositSignature(pubkey types.ValidatorPubkey, withdrawalCredentials common.Hash, amount uint64, signature types.ValidatorSignature, genesisForkVersion [4]byte) (bool, error) {
+	return bls.VerifyDepositSignature(pubkey, withdrawalCredentials, amount, signature, genesisForkVersion)
+}
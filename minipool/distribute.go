@@ -0,0 +1,69 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Get the transaction info for distributing a minipool's balance
+// Version 2 minipools always distribute their full balance, so rewardsOnly must be false for them. If validate is set,
+// the minipool must be staking, not finalised, and have a balance to distribute.
+func DistributeBalanceTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address, rewardsOnly bool, validate bool) (rocketpool.BatchTransaction, error) {
+	mp, err := NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+
+	tx := rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("distribute balance of minipool %s", minipoolAddress.Hex()),
+	}
+	if mpv3, success := GetMinipoolAsV3(mp); success {
+		tx.Estimate = func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return mpv3.EstimateDistributeBalanceGas(rewardsOnly, opts)
+		}
+		tx.Submit = func(opts *bind.TransactOpts) (common.Hash, error) {
+			return mpv3.DistributeBalance(rewardsOnly, opts)
+		}
+		tx.Describe = mpv3.GetContract().DescribeCall("distributeBalance", rewardsOnly)
+	} else if mpv2, success := GetMinipoolAsV2(mp); success && !rewardsOnly {
+		tx.Estimate = mpv2.EstimateDistributeBalanceGas
+		tx.Submit = mpv2.DistributeBalance
+		tx.Describe = mpv2.GetContract().DescribeCall("distributeBalance")
+	} else {
+		return rocketpool.BatchTransaction{}, fmt.Errorf("minipool %s cannot distribute its balance with rewardsOnly = %t because it is version %d", minipoolAddress.Hex(), rewardsOnly, mp.GetVersion())
+	}
+	if !validate {
+		return tx, nil
+	}
+
+	return tx.WithValidation(func(opts *bind.TransactOpts) error {
+		status, err := mp.GetStatus(nil)
+		if err != nil {
+			return err
+		}
+		if status != rptypes.Staking && status != rptypes.Dissolved {
+			return fmt.Errorf("minipool %s can't distribute its balance while it's %s", minipoolAddress.Hex(), status.String())
+		}
+		finalised, err := mp.GetFinalised(nil)
+		if err != nil {
+			return err
+		}
+		if finalised {
+			return fmt.Errorf("minipool %s has already been finalised", minipoolAddress.Hex())
+		}
+		balance, err := rp.Client.BalanceAt(context.Background(), minipoolAddress, nil)
+		if err != nil {
+			return fmt.Errorf("error getting minipool %s balance: %w", minipoolAddress.Hex(), err)
+		}
+		if balance.Sign() == 0 {
+			return rocketpool.NewError(rocketpool.ErrInsufficientBalance, "minipool %s has no balance to distribute", minipoolAddress.Hex())
+		}
+		return nil
+	}), nil
+}
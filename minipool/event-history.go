@@ -0,0 +1,211 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of a minipool lifecycle event
+type MinipoolEventType string
+
+const (
+	MinipoolEventType_Status        MinipoolEventType = "status"
+	MinipoolEventType_Deposit       MinipoolEventType = "deposit"
+	MinipoolEventType_Queue         MinipoolEventType = "queue"
+	MinipoolEventType_Scrub         MinipoolEventType = "scrub"
+	MinipoolEventType_BondReduction MinipoolEventType = "bondReduction"
+	MinipoolEventType_Distribution  MinipoolEventType = "distribution"
+	MinipoolEventType_Penalty       MinipoolEventType = "penalty"
+	MinipoolEventType_Other         MinipoolEventType = "other"
+)
+
+// The kinds of the known lifecycle events, by event name
+var minipoolEventTypes = map[string]MinipoolEventType{
+	"MinipoolCreated":          MinipoolEventType_Status,
+	"MinipoolDestroyed":        MinipoolEventType_Status,
+	"StatusUpdated":            MinipoolEventType_Status,
+	"MinipoolPromoted":         MinipoolEventType_Status,
+	"MinipoolVacancyPrepared":  MinipoolEventType_Status,
+	"EtherDeposited":           MinipoolEventType_Deposit,
+	"MinipoolPrestaked":        MinipoolEventType_Deposit,
+	"MinipoolEnqueued":         MinipoolEventType_Queue,
+	"MinipoolDequeued":         MinipoolEventType_Queue,
+	"MinipoolRemoved":          MinipoolEventType_Queue,
+	"ScrubVoted":               MinipoolEventType_Scrub,
+	"MinipoolScrubbed":         MinipoolEventType_Scrub,
+	"BeginBondReduction":       MinipoolEventType_BondReduction,
+	"CancelReductionVoted":     MinipoolEventType_BondReduction,
+	"ReductionCancelled":       MinipoolEventType_BondReduction,
+	"BondReduced":              MinipoolEventType_BondReduction,
+	"EtherReceived":            MinipoolEventType_Distribution,
+	"EtherWithdrawn":           MinipoolEventType_Distribution,
+	"EtherWithdrawalProcessed": MinipoolEventType_Distribution,
+	"EtherRefunded":            MinipoolEventType_Distribution,
+	"PenaltySubmitted":         MinipoolEventType_Penalty,
+	"PenaltyUpdated":           MinipoolEventType_Penalty,
+}
+
+// The network contracts that emit events with the minipool address as their first indexed parameter
+var minipoolEventContracts = []string{
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketMinipoolBondReducer",
+}
+
+// The penalty events, which don't index the minipool address so they're filtered after decoding
+var minipoolPenaltyEvents = []string{
+	"PenaltySubmitted",
+	"PenaltyUpdated",
+}
+
+// A decoded event from a minipool's history
+type MinipoolEvent struct {
+	Type        MinipoolEventType `json:"type"`
+	Name        string            `json:"name"`
+	Contract    common.Address    `json:"contract"` // The minipool itself, or the network contract that emitted the event
+	BlockNumber uint64            `json:"blockNumber"`
+	TxHash      common.Hash       `json:"txHash"`
+	LogIndex    uint              `json:"logIndex"`
+	Values      map[string]any    `json:"values"` // The event's parameters by name, including the indexed ones
+}
+
+// Get the lifecycle events of a minipool between two blocks, in the order they happened
+// This covers the events the minipool emits and the events the network contracts emit about it; events are decoded
+// with the current ABIs, so events that only exist in older contract versions are skipped
+func GetEventHistory(rp *rocketpool.RocketPool, address common.Address, startBlock *big.Int, endBlock *big.Int, options eth.LogScanOptions, opts *bind.CallOpts) ([]MinipoolEvent, error) {
+	mp, err := NewMinipool(rp, address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the minipool's own events
+	logs, err := eth.ScanLogs(rp, []common.Address{address}, nil, startBlock, endBlock, options)
+	if err != nil {
+		return nil, fmt.Errorf("error getting logs for minipool %s: %w", address.Hex(), err)
+	}
+	events, err := decodeMinipoolEvents(mp.GetContract().ABI, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the network contracts' events about the minipool
+	topicFilter := [][]common.Hash{{}, {common.BytesToHash(address.Bytes())}}
+	for _, contractName := range minipoolEventContracts {
+		contract, err := rp.GetContract(contractName, opts)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := eth.ScanContractLogs(rp, contractName, topicFilter, startBlock, endBlock, options, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s logs for minipool %s: %w", contractName, address.Hex(), err)
+		}
+		contractEvents, err := decodeMinipoolEvents(contract.ABI, logs)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, contractEvents...)
+	}
+
+	// Get the penalty events about the minipool
+	penaltyEvents, err := getMinipoolPenaltyEvents(rp, address, startBlock, endBlock, options, opts)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, penaltyEvents...)
+
+	// Return
+	sort.SliceStable(events, func(i int, j int) bool {
+		if events[i].BlockNumber == events[j].BlockNumber {
+			return events[i].LogIndex < events[j].LogIndex
+		}
+		return events[i].BlockNumber < events[j].BlockNumber
+	})
+	return events, nil
+}
+
+// Get the penalty events for a minipool; the minipool address isn't indexed, so every penalty log in the range is
+// scanned and the ones for other minipools are dropped
+func getMinipoolPenaltyEvents(rp *rocketpool.RocketPool, address common.Address, startBlock *big.Int, endBlock *big.Int, options eth.LogScanOptions, opts *bind.CallOpts) ([]MinipoolEvent, error) {
+	rocketNetworkPenalties, err := rp.GetContract("rocketNetworkPenalties", opts)
+	if err != nil {
+		return nil, err
+	}
+	eventIds := []common.Hash{}
+	for _, eventName := range minipoolPenaltyEvents {
+		if event, exists := rocketNetworkPenalties.ABI.Events[eventName]; exists {
+			eventIds = append(eventIds, event.ID)
+		}
+	}
+	if len(eventIds) == 0 {
+		return []MinipoolEvent{}, nil
+	}
+
+	logs, err := eth.ScanContractLogs(rp, "rocketNetworkPenalties", [][]common.Hash{eventIds}, startBlock, endBlock, options, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rocketNetworkPenalties logs for minipool %s: %w", address.Hex(), err)
+	}
+	decoded, err := decodeMinipoolEvents(rocketNetworkPenalties.ABI, logs)
+	if err != nil {
+		return nil, err
+	}
+	events := []MinipoolEvent{}
+	for _, event := range decoded {
+		if minipoolAddress, ok := event.Values["minipoolAddress"].(common.Address); ok && minipoolAddress == address {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// Decode the logs of the events in an ABI, skipping the logs of any other events
+func decodeMinipoolEvents(contractAbi *abi.ABI, logs []types.Log) ([]MinipoolEvent, error) {
+	events := make([]MinipoolEvent, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		event, err := contractAbi.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+
+		// Get the event values
+		values := map[string]any{}
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error unpacking %s event data in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+		}
+		indexed := abi.Arguments{}
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("error unpacking %s event topics in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+		}
+
+		eventType, exists := minipoolEventTypes[event.Name]
+		if !exists {
+			eventType = MinipoolEventType_Other
+		}
+		events = append(events, MinipoolEvent{
+			Type:        eventType,
+			Name:        event.Name,
+			Contract:    log.Address,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			LogIndex:    log.Index,
+			Values:      values,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,88 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-ssz"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/bls"
+)
+
+// Withdrawal credential and signature domain settings
+const (
+	Eth1WithdrawalPrefix byte = 0x01
+)
+
+var DomainVoluntaryExit = bls.DomainVoluntaryExit
+
+// A voluntary exit message for a validator
+type VoluntaryExit struct {
+	Epoch          uint64 `json:"epoch,string"`
+	ValidatorIndex uint64 `json:"validator_index,string"`
+}
+
+// A voluntary exit message with its signature
+type SignedVoluntaryExit struct {
+	Message   VoluntaryExit            `json:"message"`
+	Signature types.ValidatorSignature `json:"signature"`
+}
+
+// Get the expected 0x01 withdrawal credentials for a minipool
+func GetExpectedWithdrawalCredentials(minipoolAddress common.Address) common.Hash {
+	var withdrawalCredentials common.Hash
+	withdrawalCredentials[0] = Eth1WithdrawalPrefix
+	copy(withdrawalCredentials[12:], minipoolAddress.Bytes())
+	return withdrawalCredentials
+}
+
+// Check whether the on-chain withdrawal credentials for a minipool match the expected 0x01 credentials
+// Returns the on-chain credentials along with the result
+func VerifyWithdrawalCredentials(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (bool, common.Hash, error) {
+	withdrawalCredentials, err := GetMinipoolWithdrawalCredentials(rp, minipoolAddress, opts)
+	if err != nil {
+		return false, common.Hash{}, err
+	}
+	return withdrawalCredentials == GetExpectedWithdrawalCredentials(minipoolAddress), withdrawalCredentials, nil
+}
+
+// Compute the signature domain for voluntary exits
+func GetVoluntaryExitDomain(forkVersion [4]byte, genesisValidatorsRoot common.Hash) ([]byte, error) {
+	return bls.ComputeDomain(DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
+}
+
+// Create a signed voluntary exit message for a validator
+// forkVersion should be the Capella fork version for the network, as required by EIP-7044
+func SignVoluntaryExit(validatorKey *bls.SecretKey, validatorIndex uint64, epoch uint64, forkVersion [4]byte, genesisValidatorsRoot common.Hash) (SignedVoluntaryExit, error) {
+	exit := VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorIndex,
+	}
+
+	// Get the signing root
+	domain, err := GetVoluntaryExitDomain(forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return SignedVoluntaryExit{}, err
+	}
+	objectRoot, err := ssz.HashTreeRoot(exit)
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("error computing voluntary exit root: %w", err)
+	}
+	signingRoot, err := bls.ComputeSigningRoot(objectRoot, domain)
+	if err != nil {
+		return SignedVoluntaryExit{}, err
+	}
+
+	// Sign the exit
+	signature, err := validatorKey.Sign(signingRoot[:])
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("error signing voluntary exit: %w", err)
+	}
+	return SignedVoluntaryExit{
+		Message:   exit,
+		Signature: signature,
+	}, nil
+}
@@ -7,8 +7,20 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const ExpectedAddressFastBatchSize = 500
+
+// The EIP-1167 minimal proxy init code that the minipool factory deploys minipools with since Atlas (v1.2), split
+// around the address of the minipool base contract
+var (
+	minipoolCloneCodePrefix = common.FromHex("0x3d602d80600a3d3981f3363d3d373d3d3d363d73")
+	minipoolCloneCodeSuffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
 )
 
 // Get the address of a minipool based on the node address and a salt
@@ -24,11 +36,104 @@ func GetExpectedAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, s
 	return *address, nil
 }
 
+// Predict the address of a minipool based on the node address and a salt without calling the factory
+// Replicates the factory's CREATE2 computation for the current deployment; minipools created before Atlas (v1.2) used
+// a different scheme that depended on the deposit type, so this returns an error if the minipool base contract
+// isn't deployed
+func PredictMinipoolAddress(rp *rocketpool.RocketPool, nodeAddress common.Address, salt *big.Int, opts *bind.CallOpts) (common.Address, error) {
+	addresses, err := PredictMinipoolAddresses(rp, nodeAddress, []*big.Int{salt}, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return addresses[0], nil
+}
+
+// Predict the addresses of minipools for a node and a list of salts without calling the factory
+func PredictMinipoolAddresses(rp *rocketpool.RocketPool, nodeAddress common.Address, salts []*big.Int, opts *bind.CallOpts) ([]common.Address, error) {
+	rocketMinipoolFactory, err := getRocketMinipoolFactory(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	initHash, err := GetMinipoolInitHash(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]common.Address, len(salts))
+	for i, salt := range salts {
+		addresses[i] = crypto.CreateAddress2(*rocketMinipoolFactory.Address, getNodeSalt(nodeAddress, salt), initHash.Bytes())
+	}
+	return addresses, nil
+}
+
+// Get the hash of the init code the minipool factory deploys minipools with
+func GetMinipoolInitHash(rp *rocketpool.RocketPool, opts *bind.CallOpts) (common.Hash, error) {
+	minipoolBase, err := rp.GetAddress("rocketMinipoolBase", opts)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting minipool base address: %w", err)
+	}
+	if *minipoolBase == (common.Address{}) {
+		return common.Hash{}, fmt.Errorf("minipool base contract is not deployed; minipool addresses can only be predicted on v1.2 or later")
+	}
+	initCode := make([]byte, 0, len(minipoolCloneCodePrefix)+common.AddressLength+len(minipoolCloneCodeSuffix))
+	initCode = append(initCode, minipoolCloneCodePrefix...)
+	initCode = append(initCode, minipoolBase.Bytes()...)
+	initCode = append(initCode, minipoolCloneCodeSuffix...)
+	return crypto.Keccak256Hash(initCode), nil
+}
+
+// Get the addresses of minipools for a node and a list of salts from the factory using multicall
+func GetExpectedAddressesFast(rp *rocketpool.RocketPool, multicallerAddress common.Address, nodeAddress common.Address, salts []*big.Int, opts *bind.CallOpts) ([]common.Address, error) {
+	rocketMinipoolFactory, err := getRocketMinipoolFactory(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Load the addresses in batches
+	count := len(salts)
+	addresses := make([]common.Address, count)
+	for bsi := 0; bsi < count; bsi += ExpectedAddressFastBatchSize {
+
+		// Get batch start & end index
+		asi := bsi
+		aei := bsi + ExpectedAddressFastBatchSize
+		if aei > count {
+			aei = count
+		}
+
+		// Add the calls
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, err
+		}
+		for ai := asi; ai < aei; ai++ {
+			mc.AddCall(rocketMinipoolFactory, &addresses[ai], "getExpectedAddress", nodeAddress, salts[ai])
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, fmt.Errorf("error getting minipool expected addresses: %w", err)
+		}
+
+	}
+
+	// Return
+	return addresses, nil
+
+}
+
+// Combine a node's address and a salt into the salt the factory uses with CREATE2
+func getNodeSalt(nodeAddress common.Address, salt *big.Int) common.Hash {
+	saltBytes := [32]byte{}
+	salt.FillBytes(saltBytes[:])
+	return crypto.Keccak256Hash(nodeAddress.Bytes(), saltBytes[:])
+}
+
 // Get contracts
 var rocketMinipoolFactoryLock sync.Mutex
 
 func getRocketMinipoolFactory(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketMinipoolFactoryLock.Lock()
 	defer rocketMinipoolFactoryLock.Unlock()
-	return rp.GetContract("rocketMinipoolFactory", opts)
+	return rp.VersionManager.GetContract("rocketMinipoolFactory", opts)
 }
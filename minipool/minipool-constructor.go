@@ -12,6 +12,7 @@ import (
 )
 
 // Create a minipool binding
+// Every binding implements ValidatorOwner; use GetMinipoolAsValidatorOwner or NewMinipoolFromVersion to access it
 func NewMinipool(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (Minipool, error) {
 
 	// Get the contract version
@@ -39,7 +40,8 @@ func NewMinipool(rp *rocketpool.RocketPool, address common.Address, opts *bind.C
 }
 
 // Create a minipool binding from an explicit version number
-func NewMinipoolFromVersion(rp *rocketpool.RocketPool, address common.Address, version uint8, opts *bind.CallOpts) (Minipool, error) {
+// The result covers every version; use GetMinipoolAsV2 or GetMinipoolAsV3 for version-specific features
+func NewMinipoolFromVersion(rp *rocketpool.RocketPool, address common.Address, version uint8, opts *bind.CallOpts) (ValidatorOwner, error) {
 	switch version {
 	case 1, 2:
 		return newMinipool_v2(rp, address)
@@ -64,6 +66,7 @@ func createMinipoolContractFromEncodedAbi(rp *rocketpool.RocketPool, address com
 		Address:  &address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     "rocketMinipool",
 	}, nil
 }
 
@@ -75,6 +78,7 @@ func createMinipoolContractFromAbi(rp *rocketpool.RocketPool, address common.Add
 		Address:  &address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     "rocketMinipool",
 	}, nil
 }
 
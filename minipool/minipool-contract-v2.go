@@ -43,7 +43,7 @@ type minipool_v2 struct {
 var minipoolV2Abi *abi.ABI
 
 // Create new minipool contract
-func newMinipool_v2(rp *rocketpool.RocketPool, address common.Address) (Minipool, error) {
+func newMinipool_v2(rp *rocketpool.RocketPool, address common.Address) (ValidatorOwner, error) {
 
 	var contract *rocketpool.Contract
 	var err error
@@ -92,6 +92,23 @@ func (mp *minipool_v2) GetVersion() uint8 {
 	return mp.Version
 }
 
+// Get the kind of validator container this is
+func (mp *minipool_v2) GetContainerType() rptypes.ValidatorContainerType {
+	return rptypes.ValidatorContainerType_Minipool
+}
+
+// Get the pubkey of the minipool's validator, or none if it doesn't have one yet
+func (mp *minipool_v2) GetValidatorPubkeys(opts *bind.CallOpts) ([]rptypes.ValidatorPubkey, error) {
+	pubkey, err := GetMinipoolPubkey(mp.RocketPool, mp.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	if pubkey == (rptypes.ValidatorPubkey{}) {
+		return []rptypes.ValidatorPubkey{}, nil
+	}
+	return []rptypes.ValidatorPubkey{pubkey}, nil
+}
+
 // Get status details
 func (mp *minipool_v2) GetStatusDetails(opts *bind.CallOpts) (StatusDetails, error) {
 
@@ -340,6 +357,19 @@ func (mp *minipool_v2) Refund(opts *bind.TransactOpts) (common.Hash, error) {
 	return tx.Hash(), nil
 }
 
+// Get the minipool's ETH balance
+func (mp *minipool_v2) GetBalance(opts *bind.CallOpts) (*big.Int, error) {
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	balance, err := mp.RocketPool.Client.BalanceAt(context.Background(), mp.Address, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool %s balance: %w", mp.Address.Hex(), err)
+	}
+	return balance, nil
+}
+
 // Estimate the gas of DistributeBalance
 func (mp *minipool_v2) EstimateDistributeBalanceGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return mp.Contract.GetTransactionGasInfo(opts, "distributeBalance")
@@ -357,6 +387,16 @@ func (mp *minipool_v2) DistributeBalance(opts *bind.TransactOpts) (common.Hash,
 	return tx.Hash(), nil
 }
 
+// Estimate the gas of Distribute
+func (mp *minipool_v2) EstimateDistributeGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return mp.EstimateDistributeBalanceGas(opts)
+}
+
+// Distribute the minipool's full ETH balance; the same as DistributeBalance
+func (mp *minipool_v2) Distribute(opts *bind.TransactOpts) (common.Hash, error) {
+	return mp.DistributeBalance(opts)
+}
+
 // Estimate the gas of DistributeBalanceAndFinalise
 func (mp *minipool_v2) EstimateDistributeBalanceAndFinaliseGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return mp.Contract.GetTransactionGasInfo(opts, "distributeBalanceAndFinalise")
@@ -33,6 +33,8 @@ type MinipoolV3 interface {
 	GetUserDistributed(opts *bind.CallOpts) (bool, error)
 	EstimateDistributeBalanceGas(rewardsOnly bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error)
 	DistributeBalance(rewardsOnly bool, opts *bind.TransactOpts) (common.Hash, error)
+	GetVacant(opts *bind.CallOpts) (bool, error)
+	GetBondReductionDetails(opts *bind.CallOpts) (BondReductionDetails, error)
 }
 
 // Minipool contract
@@ -47,7 +49,7 @@ type minipool_v3 struct {
 var minipoolV3Abi *abi.ABI
 
 // Create new minipool contract
-func newMinipool_v3(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (Minipool, error) {
+func newMinipool_v3(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (ValidatorOwner, error) {
 
 	var contract *rocketpool.Contract
 	var err error
@@ -96,6 +98,71 @@ func (mp *minipool_v3) GetVersion() uint8 {
 	return mp.Version
 }
 
+// Get the minipool's pending and last bond reduction details
+func (mp *minipool_v3) GetBondReductionDetails(opts *bind.CallOpts) (BondReductionDetails, error) {
+
+	// Data
+	var wg errgroup.Group
+	details := BondReductionDetails{}
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		details.ReduceBondTime, err = GetReduceBondTime(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.ReduceBondValue, err = GetReduceBondValue(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.ReduceBondCancelled, err = GetReduceBondCancelled(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.LastReductionTime, err = GetLastBondReductionTime(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.LastReductionPrevValue, err = GetLastBondReductionPrevValue(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.LastReductionPrevNodeFee, err = GetLastBondReductionPrevNodeFee(mp.RocketPool, mp.Address, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return BondReductionDetails{}, err
+	}
+
+	// Return
+	return details, nil
+}
+
+// Get the kind of validator container this is
+func (mp *minipool_v3) GetContainerType() rptypes.ValidatorContainerType {
+	return rptypes.ValidatorContainerType_Minipool
+}
+
+// Get the pubkey of the minipool's validator, or none if it doesn't have one yet
+func (mp *minipool_v3) GetValidatorPubkeys(opts *bind.CallOpts) ([]rptypes.ValidatorPubkey, error) {
+	pubkey, err := GetMinipoolPubkey(mp.RocketPool, mp.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	if pubkey == (rptypes.ValidatorPubkey{}) {
+		return []rptypes.ValidatorPubkey{}, nil
+	}
+	return []rptypes.ValidatorPubkey{pubkey}, nil
+}
+
 // Get status details
 func (mp *minipool_v3) GetStatusDetails(opts *bind.CallOpts) (StatusDetails, error) {
 
@@ -374,6 +441,19 @@ func (mp *minipool_v3) GetUserDistributed(opts *bind.CallOpts) (bool, error) {
 	return *distributed, nil
 }
 
+// Get the minipool's ETH balance
+func (mp *minipool_v3) GetBalance(opts *bind.CallOpts) (*big.Int, error) {
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	balance, err := mp.RocketPool.Client.BalanceAt(context.Background(), mp.Address, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool %s balance: %w", mp.Address.Hex(), err)
+	}
+	return balance, nil
+}
+
 // Estimate the gas of DistributeBalance
 func (mp *minipool_v3) EstimateDistributeBalanceGas(rewardsOnly bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return mp.Contract.GetTransactionGasInfo(opts, "distributeBalance", rewardsOnly)
@@ -388,6 +468,16 @@ func (mp *minipool_v3) DistributeBalance(rewardsOnly bool, opts *bind.TransactOp
 	return tx.Hash(), nil
 }
 
+// Estimate the gas of Distribute
+func (mp *minipool_v3) EstimateDistributeGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return mp.EstimateDistributeBalanceGas(false, opts)
+}
+
+// Distribute the minipool's full ETH balance; the same as DistributeBalance with rewardsOnly unset
+func (mp *minipool_v3) Distribute(opts *bind.TransactOpts) (common.Hash, error) {
+	return mp.DistributeBalance(false, opts)
+}
+
 // Estimate the gas of Stake
 func (mp *minipool_v3) EstimateStakeGas(validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return mp.Contract.GetTransactionGasInfo(opts, "stake", validatorSignature[:], depositDataRoot)
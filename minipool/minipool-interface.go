@@ -27,6 +27,14 @@ type NodeDetails struct {
 	RefundBalance   *big.Int       `json:"refundBalance"`
 	DepositAssigned bool           `json:"depositAssigned"`
 }
+type BondReductionDetails struct {
+	ReduceBondTime           time.Time `json:"reduceBondTime"`
+	ReduceBondValue          *big.Int  `json:"reduceBondValue"`
+	ReduceBondCancelled      bool      `json:"reduceBondCancelled"`
+	LastReductionTime        time.Time `json:"lastReductionTime"`
+	LastReductionPrevValue   *big.Int  `json:"lastReductionPrevValue"`
+	LastReductionPrevNodeFee *big.Int  `json:"lastReductionPrevNodeFee"`
+}
 type UserDetails struct {
 	DepositBalance      *big.Int  `json:"depositBalance"`
 	DepositAssigned     bool      `json:"depositAssigned"`
@@ -100,3 +108,33 @@ type Minipool interface {
 	VoteScrub(opts *bind.TransactOpts) (common.Hash, error)
 	GetPrestakeEvent(intervalSize *big.Int, opts *bind.CallOpts) (PrestakeData, error)
 }
+
+// The surface shared by every minipool contract version, so callers can work with minipools without checking the version
+// It extends Minipool rather than adding to it so existing implementations of Minipool keep compiling
+// Use GetMinipoolAsV2 or GetMinipoolAsV3 to access the features of a specific version
+type ValidatorOwner interface {
+	Minipool
+	rptypes.IValidatorContainer
+	GetBalance(opts *bind.CallOpts) (*big.Int, error)
+	EstimateDistributeGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error)
+	Distribute(opts *bind.TransactOpts) (common.Hash, error)
+}
+
+// Get the minipool as a validator owner if it implements the required methods
+func GetMinipoolAsValidatorOwner(mp Minipool) (ValidatorOwner, bool) {
+	castedMp, ok := mp.(ValidatorOwner)
+	if ok {
+		return castedMp, true
+	}
+	return nil, false
+}
+
+// Get the minipool as a validator container if it implements the required methods
+// The container methods aren't part of the Minipool interface so existing implementations of it keep compiling
+func GetMinipoolAsValidatorContainer(mp Minipool) (rptypes.IValidatorContainer, bool) {
+	castedMp, ok := mp.(rptypes.IValidatorContainer)
+	if ok {
+		return castedMp, true
+	}
+	return nil, false
+}
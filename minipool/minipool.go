@@ -7,12 +7,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/rocket-pool/rocketpool-go/types"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 )
 
 // Settings
@@ -21,6 +22,7 @@ const (
 	MinipoolAddressBatchSize       = 50
 	MinipoolDetailsBatchSize       = 20
 	NativeMinipoolDetailsBatchSize = 1000
+	MinipoolPubkeyBatchSize        = 500
 )
 
 // Minipool details
@@ -515,6 +517,53 @@ func GetMinipoolByPubkey(rp *rocketpool.RocketPool, pubkey rptypes.ValidatorPubk
 	return *minipoolAddress, nil
 }
 
+// Get the minipool addresses of a list of validator pubkeys using multicall, in the same order as the pubkeys
+// Validators that don't belong to a minipool get the zero address
+func GetMinipoolsByPubkeys(rp *rocketpool.RocketPool, multicallerAddress common.Address, pubkeys [][]byte, opts *bind.CallOpts) ([]common.Address, error) {
+	rocketMinipoolManager, err := getRocketMinipoolManager(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, pubkey := range pubkeys {
+		if len(pubkey) != rptypes.ValidatorPubkeyLength {
+			return nil, fmt.Errorf("invalid validator pubkey 0x%x: expected %d bytes but got %d", pubkey, rptypes.ValidatorPubkeyLength, len(pubkey))
+		}
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Load the addresses in batches
+	count := len(pubkeys)
+	addresses := make([]common.Address, count)
+	for bsi := 0; bsi < count; bsi += MinipoolPubkeyBatchSize {
+
+		// Get batch start & end index
+		asi := bsi
+		aei := bsi + MinipoolPubkeyBatchSize
+		if aei > count {
+			aei = count
+		}
+
+		// Add the calls
+		mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+		if err != nil {
+			return nil, err
+		}
+		for ai := asi; ai < aei; ai++ {
+			mc.AddCall(rocketMinipoolManager, &addresses[ai], "getMinipoolByPubkey", pubkeys[ai])
+		}
+		if _, err := mc.FlexibleCall(true, opts); err != nil {
+			return nil, fmt.Errorf("error getting validator minipool addresses: %w", err)
+		}
+
+	}
+
+	// Return
+	return addresses, nil
+
+}
+
 // Check whether a minipool exists
 func GetMinipoolExists(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (bool, error) {
 	rocketMinipoolManager, err := getRocketMinipoolManager(rp, opts)
@@ -556,8 +605,7 @@ func GetMinipoolWithdrawalCredentials(rp *rocketpool.RocketPool, minipoolAddress
 
 // Get the number of penalties applied to a minipool
 func GetMinipoolPenaltyCount(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (uint64, error) {
-	key := crypto.Keccak256Hash([]byte("network.penalties.penalty"), minipoolAddress.Bytes())
-	penalties, err := rp.RocketStorage.GetUint(opts, key)
+	penalties, err := rp.RocketStorage.GetUint(opts, storage.MinipoolPenaltyCountKey(minipoolAddress))
 	if err != nil {
 		return 0, err
 	}
@@ -622,5 +670,5 @@ var rocketMinipoolManagerLock sync.Mutex
 func getRocketMinipoolManager(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketMinipoolManagerLock.Lock()
 	defer rocketMinipoolManagerLock.Unlock()
-	return rp.GetContract("rocketMinipoolManager", opts)
+	return rp.VersionManager.GetContract("rocketMinipoolManager", opts)
 }
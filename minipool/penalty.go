@@ -0,0 +1,79 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+)
+
+// Get a minipool's penalty rate as stored, which may exceed the maximum penalty rate
+func GetMinipoolPenaltyRateRaw(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rate, err := rp.RocketStorage.GetUint(opts, storage.MinipoolPenaltyRateKey(minipoolAddress))
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool %s penalty rate: %w", minipoolAddress.Hex(), err)
+	}
+	return rate, nil
+}
+
+// Get a minipool's effective penalty rate, capped at the maximum penalty rate
+func GetMinipoolPenaltyRate(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketMinipoolPenalty, err := getRocketMinipoolPenalty(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rate := new(*big.Int)
+	if err := rocketMinipoolPenalty.Call(opts, rate, "getPenaltyRate", minipoolAddress); err != nil {
+		return nil, fmt.Errorf("error getting minipool %s penalty rate: %w", minipoolAddress.Hex(), err)
+	}
+	return *rate, nil
+}
+
+// Get the maximum penalty rate that can be applied to a minipool
+func GetMaxPenaltyRate(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketMinipoolPenalty, err := getRocketMinipoolPenalty(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rate := new(*big.Int)
+	if err := rocketMinipoolPenalty.Call(opts, rate, "getMaxPenaltyRate"); err != nil {
+		return nil, fmt.Errorf("error getting max penalty rate: %w", err)
+	}
+	return *rate, nil
+}
+
+// Estimate the gas of SetMaxPenaltyRate
+func EstimateSetMaxPenaltyRateGas(rp *rocketpool.RocketPool, rate *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketMinipoolPenalty, err := getRocketMinipoolPenalty(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketMinipoolPenalty.GetTransactionGasInfo(opts, "setMaxPenaltyRate", rate)
+}
+
+// Set the maximum penalty rate; only callable by the guardian
+func SetMaxPenaltyRate(rp *rocketpool.RocketPool, rate *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketMinipoolPenalty, err := getRocketMinipoolPenalty(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketMinipoolPenalty.Transact(opts, "setMaxPenaltyRate", rate)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error setting max penalty rate: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get contracts
+var rocketMinipoolPenaltyLock sync.Mutex
+
+func getRocketMinipoolPenalty(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketMinipoolPenaltyLock.Lock()
+	defer rocketMinipoolPenaltyLock.Unlock()
+	return rp.GetContract("rocketMinipoolPenalty", opts)
+}
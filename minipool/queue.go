@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 )
 
 // Minipool queue capacity
@@ -69,6 +70,32 @@ func GetQueueTotalLength(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64
 	return (*length).Uint64(), nil
 }
 
+// Get the length of the variable (Atlas) minipool queue
+func GetQueueLength(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketMinipoolQueue, err := getRocketMinipoolQueue(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	length := new(*big.Int)
+	if err := rocketMinipoolQueue.Call(opts, length, "getLength"); err != nil {
+		return 0, fmt.Errorf("error getting minipool queue length: %w", err)
+	}
+	return (*length).Uint64(), nil
+}
+
+// Get the length of one of the legacy (pre-Atlas) minipool queues
+func GetQueueLengthLegacy(rp *rocketpool.RocketPool, depositType rptypes.MinipoolDeposit, opts *bind.CallOpts) (uint64, error) {
+	rocketMinipoolQueue, err := getRocketMinipoolQueue(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	length := new(*big.Int)
+	if err := rocketMinipoolQueue.Call(opts, length, "getLengthLegacy", uint8(depositType)); err != nil {
+		return 0, fmt.Errorf("error getting %s minipool queue length: %w", depositType.String(), err)
+	}
+	return (*length).Uint64(), nil
+}
+
 // Get the total capacity of the minipool queue
 func GetQueueTotalCapacity(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
 	rocketMinipoolQueue, err := getRocketMinipoolQueue(rp, opts)
@@ -140,5 +167,5 @@ var rocketMinipoolQueueLock sync.Mutex
 func getRocketMinipoolQueue(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketMinipoolQueueLock.Lock()
 	defer rocketMinipoolQueueLock.Unlock()
-	return rp.GetContract("rocketMinipoolQueue", opts)
+	return rp.VersionManager.GetContract("rocketMinipoolQueue", opts)
 }
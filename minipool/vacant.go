@@ -0,0 +1,92 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Promotion readiness of a vacant (solo staker migration) minipool
+type PromotionReadiness struct {
+	MinipoolAddress   common.Address         `json:"minipoolAddress"`
+	Status            rptypes.MinipoolStatus `json:"status"`
+	IsVacant          bool                   `json:"isVacant"`
+	PromotionTime     time.Time              `json:"promotionTime"` // The end of the promotion scrub period
+	ScrubPeriodPassed bool                   `json:"scrubPeriodPassed"`
+	CredentialsMatch  bool                   `json:"credentialsMatch"`
+	CanPromote        bool                   `json:"canPromote"`
+}
+
+// Get the promotion readiness of a vacant minipool at the queried block
+// beaconCredentials are the withdrawal credentials of the solo validator on the Beacon Chain
+func GetPromotionReadiness(rp *rocketpool.RocketPool, minipoolAddress common.Address, beaconCredentials common.Hash, opts *bind.CallOpts) (PromotionReadiness, error) {
+	mp, err := NewMinipool(rp, minipoolAddress, opts)
+	if err != nil {
+		return PromotionReadiness{}, err
+	}
+	status, err := mp.GetStatusDetails(opts)
+	if err != nil {
+		return PromotionReadiness{}, fmt.Errorf("error getting status of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	promotionScrubPeriod, err := tnsettings.GetPromotionScrubPeriod(rp, opts)
+	if err != nil {
+		return PromotionReadiness{}, err
+	}
+	blockTime, err := getBlockTime(rp, opts)
+	if err != nil {
+		return PromotionReadiness{}, err
+	}
+
+	readiness := PromotionReadiness{
+		MinipoolAddress:  minipoolAddress,
+		Status:           status.Status,
+		IsVacant:         status.IsVacant,
+		PromotionTime:    status.StatusTime.Add(time.Duration(promotionScrubPeriod) * time.Second),
+		CredentialsMatch: ValidateVacantMinipoolCredentials(minipoolAddress, beaconCredentials) == nil,
+	}
+	readiness.ScrubPeriodPassed = !blockTime.Before(readiness.PromotionTime)
+	readiness.CanPromote = readiness.IsVacant && readiness.Status == rptypes.Prelaunch && readiness.ScrubPeriodPassed && readiness.CredentialsMatch
+	return readiness, nil
+}
+
+// Check that a solo validator's withdrawal credentials point at its vacant minipool
+// Returns an error wrapping rocketpool.ErrCredentialsMismatch if they don't; promoting such a minipool would get it
+// scrubbed by the Oracle DAO
+func ValidateVacantMinipoolCredentials(minipoolAddress common.Address, beaconCredentials common.Hash) error {
+	expectedCredentials := GetExpectedWithdrawalCredentials(minipoolAddress)
+	if beaconCredentials == expectedCredentials {
+		return nil
+	}
+	if GetWithdrawalCredentialType(beaconCredentials) == WithdrawalCredentialType_BLS {
+		return rocketpool.NewError(rocketpool.ErrCredentialsMismatch, "validator for minipool %s still has BLS withdrawal credentials %s; change them to %s first", minipoolAddress.Hex(), beaconCredentials.Hex(), expectedCredentials.Hex())
+	}
+	return rocketpool.NewError(rocketpool.ErrCredentialsMismatch, "validator for minipool %s has withdrawal credentials %s but expected %s", minipoolAddress.Hex(), beaconCredentials.Hex(), expectedCredentials.Hex())
+}
+
+// Get a transaction that promotes a vacant minipool
+// The solo validator's withdrawal credentials are validated first, since they can't be checked on-chain
+func PromoteTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address, beaconCredentials common.Hash) (rocketpool.BatchTransaction, error) {
+	if err := ValidateVacantMinipoolCredentials(minipoolAddress, beaconCredentials); err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	mp, err := NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	mpv3, success := GetMinipoolAsV3(mp)
+	if !success {
+		return rocketpool.BatchTransaction{}, fmt.Errorf("minipool %s cannot be promoted because it is version %d", minipoolAddress.Hex(), mp.GetVersion())
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("promote minipool %s", minipoolAddress.Hex()),
+		Estimate: mpv3.EstimatePromoteGas,
+		Submit:   mpv3.Promote,
+		Describe: mpv3.GetContract().DescribeCall("promote"),
+	}, nil
+}
@@ -0,0 +1,196 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A tree node a proposer submitted that doesn't match the locally generated voting tree, so it can be challenged
+type ChallengeCandidate struct {
+	ProposalID   uint64                 `json:"proposalId"`
+	Index        uint64                 `json:"index"`        // The node's index in the tree, where the root is 1 and the children of i are 2i and 2i+1
+	Node         types.VotingTreeNode   `json:"node"`         // The node the proposer submitted
+	ExpectedNode types.VotingTreeNode   `json:"expectedNode"` // The node in the locally generated tree
+	Witness      []types.VotingTreeNode `json:"witness"`      // The proof of the submitted node against the root it was submitted under, from the bottom up
+}
+
+// Get the transaction info for challenging the node
+func (c ChallengeCandidate) CreateChallengeTransaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("challenge index %d of proposal %d", c.Index, c.ProposalID),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return protocol.EstimateCreateChallengeGas(rp, c.ProposalID, c.Index, c.Node, c.Witness, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return protocol.CreateChallenge(rp, c.ProposalID, c.Index, c.Node, c.Witness, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAOProtocolVerifier", "createChallenge", big.NewInt(int64(c.ProposalID)), big.NewInt(int64(c.Index)), c.Node, c.Witness),
+	}
+}
+
+// Finds tree nodes submitted by Protocol DAO proposers that don't match the voting power snapshot, for challenging
+// Only the network tree is checked; nodes below its leaves (the per-node delegation trees) are skipped
+type ChallengeScanner struct {
+	StartBlock        *big.Int         // The block to scan verifier events from, e.g. the Houston deployment block
+	IntervalSize      *big.Int         // The number of blocks to scan per event query
+	VerifierAddresses []common.Address // Previous verifier contract addresses to scan events from, in addition to the current one
+
+	rp               *rocketpool.RocketPool
+	multicallAddress common.Address
+	trees            map[uint32]*VotingTree
+}
+
+// Create a new challenge scanner
+func NewChallengeScanner(rp *rocketpool.RocketPool, multicallAddress common.Address, startBlock *big.Int, intervalSize *big.Int) *ChallengeScanner {
+	return &ChallengeScanner{
+		StartBlock:       startBlock,
+		IntervalSize:     intervalSize,
+		rp:               rp,
+		multicallAddress: multicallAddress,
+		trees:            map[uint32]*VotingTree{},
+	}
+}
+
+// Scan the proposals that are still in their challenge window for submitted nodes that can be challenged
+// Nodes that have already been challenged are skipped
+func (s *ChallengeScanner) Scan(opts *bind.CallOpts) ([]ChallengeCandidate, error) {
+	// Get the pending proposals
+	proposals, err := protocol.GetProposals(s.rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposals: %w", err)
+	}
+	targetBlocks := map[uint64]uint32{}
+	proposalIds := []uint64{}
+	for _, proposal := range proposals {
+		if proposal.State == types.ProtocolDaoProposalState_Pending {
+			targetBlocks[proposal.ID] = proposal.TargetBlock
+			proposalIds = append(proposalIds, proposal.ID)
+		}
+	}
+	if len(proposalIds) == 0 {
+		return []ChallengeCandidate{}, nil
+	}
+
+	// Get the submitted roots and existing challenges
+	roots, err := protocol.GetRootSubmittedEvents(s.rp, proposalIds, s.IntervalSize, s.StartBlock, nil, s.VerifierAddresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submitted roots: %w", err)
+	}
+	challenges, err := protocol.GetChallengeSubmittedEvents(s.rp, proposalIds, s.IntervalSize, s.StartBlock, nil, s.VerifierAddresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submitted challenges: %w", err)
+	}
+	challenged := map[uint64]map[uint64]bool{}
+	for _, challenge := range challenges {
+		proposalId := challenge.ProposalID.Uint64()
+		if challenged[proposalId] == nil {
+			challenged[proposalId] = map[uint64]bool{}
+		}
+		challenged[proposalId][challenge.Index.Uint64()] = true
+	}
+
+	// Check the submitted nodes against the local trees
+	candidates := []ChallengeCandidate{}
+	for _, root := range roots {
+		proposalId := root.ProposalID.Uint64()
+		targetBlock, exists := targetBlocks[proposalId]
+		if !exists {
+			continue
+		}
+		tree, err := s.getTree(targetBlock, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range getChallengeCandidates(tree, proposalId, root.Index.Uint64(), root.TreeNodes) {
+			if !challenged[proposalId][candidate.Index] {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	// Return
+	sort.Slice(candidates, func(i int, j int) bool {
+		if candidates[i].ProposalID == candidates[j].ProposalID {
+			return candidates[i].Index < candidates[j].Index
+		}
+		return candidates[i].ProposalID < candidates[j].ProposalID
+	})
+	return candidates, nil
+}
+
+// Get the voting tree for a block, reusing it across proposals with the same target block
+func (s *ChallengeScanner) getTree(blockNumber uint32, opts *bind.CallOpts) (*VotingTree, error) {
+	if tree, exists := s.trees[blockNumber]; exists {
+		return tree, nil
+	}
+	tree, err := GetVotingTree(s.rp, blockNumber, s.multicallAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.trees[blockNumber] = tree
+	return tree, nil
+}
+
+// Get the nodes submitted under a root that don't match the local tree, with their witnesses
+func getChallengeCandidates(tree *VotingTree, proposalId uint64, rootIndex uint64, treeNodes []types.VotingTreeNode) []ChallengeCandidate {
+	count := uint64(len(treeNodes))
+	if count == 0 || count&(count-1) != 0 {
+		return nil
+	}
+
+	// Build the submitted subtree so the witnesses can be taken from it
+	levels := [][]types.VotingTreeNode{treeNodes}
+	for level := treeNodes; len(level) > 1; {
+		parents := make([]types.VotingTreeNode, len(level)/2)
+		for i := range parents {
+			parents[i] = getParentNode(level[2*i], level[2*i+1])
+		}
+		levels = append(levels, parents)
+		level = parents
+	}
+
+	candidates := []ChallengeCandidate{}
+	for k, node := range treeNodes {
+		index := rootIndex*count + uint64(k)
+		expected, exists := tree.GetNode(index)
+		if !exists {
+			continue
+		}
+		if node.Hash == expected.Hash && node.Sum.Cmp(expected.Sum) == 0 {
+			continue
+		}
+		witness := make([]types.VotingTreeNode, len(levels)-1)
+		for l := range witness {
+			witness[l] = levels[l][(k>>l)^1]
+		}
+		candidates = append(candidates, ChallengeCandidate{
+			ProposalID:   proposalId,
+			Index:        index,
+			Node:         node,
+			ExpectedNode: expected,
+			Witness:      witness,
+		})
+	}
+	return candidates
+}
+
+// Get the node at an index of the tree, where the root is 1 and the children of i are 2i and 2i+1
+// Returns false if the index is below the tree's leaves
+func (t *VotingTree) GetNode(index uint64) (types.VotingTreeNode, bool) {
+	if index == 0 {
+		return types.VotingTreeNode{}, false
+	}
+	depth := bits.Len64(index) - 1
+	if depth >= len(t.Levels) {
+		return types.VotingTreeNode{}, false
+	}
+	return t.Levels[depth][index-(uint64(1)<<depth)], true
+}
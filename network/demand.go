@@ -0,0 +1,131 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+)
+
+var calcBase = big.NewInt(1e18)
+
+// The network's demand for node operator ETH and the resulting node commission rate
+type NodeDemandDetails struct {
+	NodeDemand        *big.Int `json:"nodeDemand"`        // Deposit pool balance minus the minipool queue's capacity; negative when the queue is longer
+	DepositPoolExcess *big.Int `json:"depositPoolExcess"` // Deposit pool ETH not needed by the minipool queue
+	NodeFee           *big.Int `json:"nodeFee"`           // The commission rate new minipools receive, scaled by 1e18
+}
+
+// The settings of the legacy node fee curve, which set the commission rate of new minipools from the node demand
+// Recent protocol versions set the minimum, target and maximum fees to the same value, which flattens the curve
+type LegacyNodeFeeSettings struct {
+	MinimumNodeFee *big.Int `json:"minimumNodeFee"`
+	TargetNodeFee  *big.Int `json:"targetNodeFee"`
+	MaximumNodeFee *big.Int `json:"maximumNodeFee"`
+	DemandRange    *big.Int `json:"demandRange"`
+}
+
+// Get the current node demand, deposit pool excess and node commission rate
+func GetNodeDemandDetails(rp *rocketpool.RocketPool, opts *bind.CallOpts) (NodeDemandDetails, error) {
+	nodeDemand, err := GetNodeDemand(rp, opts)
+	if err != nil {
+		return NodeDemandDetails{}, err
+	}
+	excess, err := deposit.GetExcessBalance(rp, opts)
+	if err != nil {
+		return NodeDemandDetails{}, err
+	}
+	rocketNetworkFees, err := getRocketNetworkFees(rp, opts)
+	if err != nil {
+		return NodeDemandDetails{}, err
+	}
+	nodeFee := new(*big.Int)
+	if err := rocketNetworkFees.Call(opts, nodeFee, "getNodeFee"); err != nil {
+		return NodeDemandDetails{}, fmt.Errorf("error getting network node fee: %w", err)
+	}
+	return NodeDemandDetails{
+		NodeDemand:        nodeDemand,
+		DepositPoolExcess: excess,
+		NodeFee:           *nodeFee,
+	}, nil
+}
+
+// Get the settings of the legacy node fee curve
+func GetLegacyNodeFeeSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (LegacyNodeFeeSettings, error) {
+	minimumNodeFee, err := protocol.GetMinimumNodeFeeRaw(rp, opts)
+	if err != nil {
+		return LegacyNodeFeeSettings{}, err
+	}
+	targetNodeFee, err := protocol.GetTargetNodeFeeRaw(rp, opts)
+	if err != nil {
+		return LegacyNodeFeeSettings{}, err
+	}
+	maximumNodeFee, err := protocol.GetMaximumNodeFeeRaw(rp, opts)
+	if err != nil {
+		return LegacyNodeFeeSettings{}, err
+	}
+	demandRange, err := protocol.GetNodeFeeDemandRange(rp, opts)
+	if err != nil {
+		return LegacyNodeFeeSettings{}, err
+	}
+	return LegacyNodeFeeSettings{
+		MinimumNodeFee: minimumNodeFee,
+		TargetNodeFee:  targetNodeFee,
+		MaximumNodeFee: maximumNodeFee,
+		DemandRange:    demandRange,
+	}, nil
+}
+
+// Calculate the node commission rate for a node demand on the legacy fee curve, scaled by 1e18
+// This matches RocketNetworkFees.getNodeFeeByDemand, so it can be used to chart the curve without querying each point
+func CalculateLegacyNodeFee(settings LegacyNodeFeeSettings, nodeDemand *big.Int) *big.Int {
+	if settings.DemandRange.Sign() == 0 {
+		return new(big.Int).Set(settings.TargetNodeFee)
+	}
+
+	// Normalize the node demand to the demand range
+	normalizedDemand := new(big.Int).Abs(nodeDemand)
+	normalizedDemand.Mul(normalizedDemand, calcBase)
+	normalizedDemand.Div(normalizedDemand, settings.DemandRange)
+	if normalizedDemand.Cmp(calcBase) > 0 {
+		if nodeDemand.Sign() < 0 {
+			return new(big.Int).Set(settings.MinimumNodeFee)
+		}
+		return new(big.Int).Set(settings.MaximumNodeFee)
+	}
+
+	// Interpolate with the cube of the normalized demand, dividing once like the contract so the results truncate the same way
+	t := new(big.Int).Mul(normalizedDemand, normalizedDemand)
+	t.Mul(t, normalizedDemand)
+	t.Div(t, new(big.Int).Mul(calcBase, calcBase))
+	if nodeDemand.Sign() < 0 {
+		delta := new(big.Int).Sub(settings.TargetNodeFee, settings.MinimumNodeFee)
+		delta.Mul(delta, t)
+		delta.Div(delta, calcBase)
+		return delta.Sub(settings.TargetNodeFee, delta)
+	}
+	delta := new(big.Int).Sub(settings.MaximumNodeFee, settings.TargetNodeFee)
+	delta.Mul(delta, t)
+	delta.Div(delta, calcBase)
+	return delta.Add(settings.TargetNodeFee, delta)
+}
+
+// Calculate the fee taken from a deposit into the deposit pool, given the deposit fee setting scaled by 1e18
+// The rest of the deposit is minted as rETH at the current exchange rate
+func CalculateDepositFee(amount *big.Int, depositFee *big.Int) *big.Int {
+	fee := new(big.Int).Mul(amount, depositFee)
+	return fee.Div(fee, calcBase)
+}
+
+// Get the fee that would be taken from a deposit into the deposit pool under the current deposit fee setting
+func GetDepositFeeForAmount(rp *rocketpool.RocketPool, amount *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+	depositFee, err := protocol.GetDepositFee(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateDepositFee(amount, depositFee), nil
+}
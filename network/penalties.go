@@ -9,8 +9,36 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
 )
 
+// Check whether a trusted node has submitted a penalty for a minipool at a block
+func GetPenaltySubmitted(rp *rocketpool.RocketPool, nodeAddress common.Address, minipoolAddress common.Address, block *big.Int, opts *bind.CallOpts) (bool, error) {
+	submitted, err := rp.RocketStorage.GetBool(opts, storage.PenaltySubmittedKey(nodeAddress, minipoolAddress, block))
+	if err != nil {
+		return false, fmt.Errorf("error getting penalty submission for minipool %s at block %s: %w", minipoolAddress.Hex(), block.String(), err)
+	}
+	return submitted, nil
+}
+
+// Get the number of trusted nodes that have submitted a penalty for a minipool at a block
+func GetPenaltySubmissionCount(rp *rocketpool.RocketPool, minipoolAddress common.Address, block *big.Int, opts *bind.CallOpts) (uint64, error) {
+	count, err := rp.RocketStorage.GetUint(opts, storage.PenaltySubmissionCountKey(minipoolAddress, block))
+	if err != nil {
+		return 0, fmt.Errorf("error getting penalty submission count for minipool %s at block %s: %w", minipoolAddress.Hex(), block.String(), err)
+	}
+	return count.Uint64(), nil
+}
+
+// Check whether the penalty for a minipool at a block has been applied
+func GetPenaltyExecuted(rp *rocketpool.RocketPool, minipoolAddress common.Address, block *big.Int, opts *bind.CallOpts) (bool, error) {
+	executed, err := rp.RocketStorage.GetBool(opts, storage.PenaltyExecutedKey(minipoolAddress, block))
+	if err != nil {
+		return false, fmt.Errorf("error getting penalty execution for minipool %s at block %s: %w", minipoolAddress.Hex(), block.String(), err)
+	}
+	return executed, nil
+}
+
 // Estimate the gas of SubmitPenalty
 func EstimateSubmitPenaltyGas(rp *rocketpool.RocketPool, minipoolAddress common.Address, block *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNetworkPenalties, err := getRocketNetworkPenalties(rp, nil)
@@ -22,17 +50,31 @@ func EstimateSubmitPenaltyGas(rp *rocketpool.RocketPool, minipoolAddress common.
 
 // Submit penalty for given minipool
 func SubmitPenalty(rp *rocketpool.RocketPool, minipoolAddress common.Address, block *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
-	rocketNetworkPrices, err := getRocketNetworkPenalties(rp, nil)
+	rocketNetworkPenalties, err := getRocketNetworkPenalties(rp, nil)
 	if err != nil {
 		return common.Hash{}, err
 	}
-	tx, err := rocketNetworkPrices.Transact(opts, "submitPenalty", minipoolAddress, block)
+	tx, err := rocketNetworkPenalties.Transact(opts, "submitPenalty", minipoolAddress, block)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error submitting penalty: %w", err)
 	}
 	return tx.Hash(), nil
 }
 
+// Get a penalty submission as a batch transaction
+func SubmitPenaltyTransaction(rp *rocketpool.RocketPool, minipoolAddress common.Address, block *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("submit penalty for minipool %s at block %s", minipoolAddress.Hex(), block.String()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSubmitPenaltyGas(rp, minipoolAddress, block, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SubmitPenalty(rp, minipoolAddress, block, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNetworkPenalties", "submitPenalty", minipoolAddress, block),
+	}
+}
+
 // Get contracts
 var rocketNetworkPenaltiesLock sync.Mutex
 
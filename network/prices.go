@@ -7,11 +7,17 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-version"
 
+	v120network "github.com/rocket-pool/rocketpool-go/legacy/v1.2.0/network"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 )
 
+// The protocol version that added the slot timestamp to price submissions
+var houstonVersion = version.Must(version.NewSemver("1.3.0"))
+
 // Info for a price updated event
 type PriceUpdatedEvent struct {
 	BlockNumber   *big.Int `json:"blockNumber"`
@@ -68,6 +74,82 @@ func SubmitPrices(rp *rocketpool.RocketPool, block uint64, slotTimestamp uint64,
 	return tx.Hash(), nil
 }
 
+// Estimate the gas of ExecuteUpdatePrices
+func EstimateExecuteUpdatePricesGas(rp *rocketpool.RocketPool, block uint64, slotTimestamp uint64, rplPrice *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNetworkPrices, err := getRocketNetworkPrices(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNetworkPrices.GetTransactionGasInfo(opts, "executeUpdatePrices", big.NewInt(int64(block)), big.NewInt(int64(slotTimestamp)), rplPrice)
+}
+
+// Apply a price submission that reached consensus without being applied, e.g. because the consensus threshold was lowered
+func ExecuteUpdatePrices(rp *rocketpool.RocketPool, block uint64, slotTimestamp uint64, rplPrice *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNetworkPrices, err := getRocketNetworkPrices(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNetworkPrices.Transact(opts, "executeUpdatePrices", big.NewInt(int64(block)), big.NewInt(int64(slotTimestamp)), rplPrice)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error executing network prices update: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that submits network prices, using the submission format of the detected protocol version
+// Before Houston, submissions don't include the slot timestamp, so it's ignored; run version detection on the
+// RocketPool instance first, otherwise the latest format is used
+func SubmitPricesTransaction(rp *rocketpool.RocketPool, block uint64, slotTimestamp uint64, rplPrice *big.Int) rocketpool.BatchTransaction {
+	if protocolVersion := rp.VersionManager.GetDetectedVersion(); protocolVersion != nil && protocolVersion.LessThan(houstonVersion) {
+		return rocketpool.BatchTransaction{
+			Name: fmt.Sprintf("submit prices for block %d", block),
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return v120network.EstimateSubmitPricesGas(rp, block, rplPrice, opts, nil)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return v120network.SubmitPrices(rp, block, rplPrice, opts, nil)
+			},
+			Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+				rocketNetworkPrices, err := getRocketNetworkPrices(rp, nil)
+				if err != nil {
+					return rocketpool.TransactionDescription{}, err
+				}
+				return rocketNetworkPrices.DescribeCall("submitPrices", big.NewInt(int64(block)), rplPrice)(opts)
+			},
+		}
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("submit prices for block %d", block),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSubmitPricesGas(rp, block, slotTimestamp, rplPrice, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SubmitPrices(rp, block, slotTimestamp, rplPrice, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNetworkPrices", "submitPrices", big.NewInt(int64(block)), big.NewInt(int64(slotTimestamp)), rplPrice),
+	}
+}
+
+// Check whether a trusted node has submitted the given prices
+func GetPricesSubmitted(rp *rocketpool.RocketPool, nodeAddress common.Address, block uint64, slotTimestamp uint64, rplPrice *big.Int, opts *bind.CallOpts) (bool, error) {
+	key := storage.PricesSubmittedKey(nodeAddress, new(big.Int).SetUint64(block), new(big.Int).SetUint64(slotTimestamp), rplPrice)
+	submitted, err := rp.RocketStorage.GetBool(opts, key)
+	if err != nil {
+		return false, fmt.Errorf("error getting prices submission for block %d: %w", block, err)
+	}
+	return submitted, nil
+}
+
+// Get the number of trusted nodes that have submitted the given prices
+func GetPricesSubmissionCount(rp *rocketpool.RocketPool, block uint64, slotTimestamp uint64, rplPrice *big.Int, opts *bind.CallOpts) (uint64, error) {
+	key := storage.PricesSubmissionCountKey(new(big.Int).SetUint64(block), new(big.Int).SetUint64(slotTimestamp), rplPrice)
+	count, err := rp.RocketStorage.GetUint(opts, key)
+	if err != nil {
+		return 0, fmt.Errorf("error getting prices submission count for block %d: %w", block, err)
+	}
+	return count.Uint64(), nil
+}
+
 // Returns an array of block numbers for prices submissions the given trusted node has submitted since fromBlock
 func GetPricesSubmissions(rp *rocketpool.RocketPool, nodeAddress common.Address, fromBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) (*[]uint64, error) {
 	// Get contracts
@@ -174,5 +256,5 @@ var rocketNetworkPricesLock sync.Mutex
 func getRocketNetworkPrices(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketNetworkPricesLock.Lock()
 	defer rocketNetworkPricesLock.Unlock()
-	return rp.GetContract("rocketNetworkPrices", opts)
+	return rp.VersionManager.GetContract("rocketNetworkPrices", opts)
 }
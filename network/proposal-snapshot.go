@@ -0,0 +1,42 @@
+package network
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The voting power snapshot a Protocol DAO proposal is submitted with
+type ProposalSnapshot struct {
+	BlockNumber uint32                 `json:"blockNumber"`
+	Pollard     []types.VotingTreeNode `json:"pollard"`
+}
+
+// Get a voting power snapshot for a new proposal from the latest votable block
+// The snapshot can be passed to any of the dao/protocol proposal bindings
+func GetProposalSnapshot(rp *rocketpool.RocketPool, multicallAddress common.Address, opts *bind.CallOpts) (ProposalSnapshot, error) {
+	blockNumber, err := GetLatestVotableBlock(rp)
+	if err != nil {
+		return ProposalSnapshot{}, err
+	}
+	pollard, err := GetProposalPollard(rp, blockNumber, multicallAddress, opts)
+	if err != nil {
+		return ProposalSnapshot{}, err
+	}
+	return ProposalSnapshot{
+		BlockNumber: blockNumber,
+		Pollard:     pollard,
+	}, nil
+}
+
+// Get the transaction info for proposing a new value for a Protocol DAO setting, with a voting power snapshot from the latest votable block
+// The value must be a *big.Int, bool or common.Address matching the setting's type
+func ProposeSettingTransaction(rp *rocketpool.RocketPool, multicallAddress common.Address, setting protocol.ProtocolDaoSetting, message string, value any, opts *bind.CallOpts) (rocketpool.BatchTransaction, error) {
+	snapshot, err := GetProposalSnapshot(rp, multicallAddress, opts)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return setting.ProposeTransaction(rp, message, value, snapshot.BlockNumber, snapshot.Pollard)
+}
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	"golang.org/x/sync/errgroup"
+)
+
+// A node's voting power and delegate on a block
+type NodeVotingCheckpoint struct {
+	BlockNumber uint32         `json:"blockNumber"`
+	VotingPower *big.Int       `json:"votingPower"`
+	Delegate    common.Address `json:"delegate"`
+}
+
+// Gets a node's voting power and delegate on each of the provided blocks using multicall
+// The voting contract keeps its own checkpoints, so this doesn't need an archive node
+func GetNodeVotingHistoryFast(rp *rocketpool.RocketPool, address common.Address, blockNumbers []uint32, multicallAddress common.Address, opts *bind.CallOpts) ([]NodeVotingCheckpoint, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	count := uint64(len(blockNumbers))
+
+	// Run the getters in batches
+	checkpoints := make([]NodeVotingCheckpoint, count)
+	for i := uint64(0); i < count; i += nodeVotingDetailsBatchSize {
+		i := i
+		max := i + nodeVotingDetailsBatchSize
+		if max > count {
+			max = count
+		}
+
+		// Load details
+		wg.Go(func() error {
+			var err error
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				blockNumber := blockNumbers[j]
+				checkpoints[j].BlockNumber = blockNumber
+				mc.AddCall(rocketNetworkVoting, &checkpoints[j].VotingPower, "getVotingPower", address, blockNumber)
+				mc.AddCall(rocketNetworkVoting, &checkpoints[j].Delegate, "getDelegate", address, blockNumber)
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+// Get the checkpoints in a node's voting history where its delegate changed, including the first one
+// The checkpoints are expected to be in block order; changes between two sampled blocks are only seen once
+func GetVotingDelegateChanges(checkpoints []NodeVotingCheckpoint) []NodeVotingCheckpoint {
+	changes := []NodeVotingCheckpoint{}
+	for i, checkpoint := range checkpoints {
+		if i == 0 || checkpoint.Delegate != checkpoints[i-1].Delegate {
+			changes = append(changes, checkpoint)
+		}
+	}
+	return changes
+}
+
+// Gets the voting power and delegate of every node at the specified block, keyed by node address
+func GetNodeInfoMapFast(rp *rocketpool.RocketPool, blockNumber uint32, multicallAddress common.Address, opts *bind.CallOpts) (map[common.Address]types.NodeVotingInfo, error) {
+	votingInfos, err := GetNodeInfoSnapshotFast(rp, blockNumber, multicallAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	infoMap := make(map[common.Address]types.NodeVotingInfo, len(votingInfos))
+	for _, info := range votingInfos {
+		infoMap[info.NodeAddress] = info
+	}
+	return infoMap, nil
+}
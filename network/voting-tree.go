@@ -0,0 +1,143 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The network voting tree for a block, used to create and defend Protocol DAO proposals
+// Each leaf holds the voting power delegated to a node, in voting node index order; the leaves are padded with
+// empty nodes to a power of two
+type VotingTree struct {
+	BlockNumber uint32                   `json:"blockNumber"`
+	Levels      [][]types.VotingTreeNode `json:"levels"` // The first level is the root, the last is the leaves
+}
+
+// Create the network voting tree from a snapshot of the nodes' voting power and delegates
+func NewVotingTree(blockNumber uint32, votingInfos []types.NodeVotingInfo) *VotingTree {
+	// Get the voting power delegated to each node
+	delegatedPower := make(map[common.Address]*big.Int, len(votingInfos))
+	for _, info := range votingInfos {
+		if info.VotingPower == nil {
+			continue
+		}
+		power, exists := delegatedPower[info.Delegate]
+		if !exists {
+			power = big.NewInt(0)
+			delegatedPower[info.Delegate] = power
+		}
+		power.Add(power, info.VotingPower)
+	}
+
+	// Create the leaves
+	leafCount := 1
+	for leafCount < len(votingInfos) {
+		leafCount *= 2
+	}
+	leaves := make([]types.VotingTreeNode, leafCount)
+	for i := range leaves {
+		sum := big.NewInt(0)
+		if i < len(votingInfos) {
+			if power, exists := delegatedPower[votingInfos[i].NodeAddress]; exists {
+				sum.Set(power)
+			}
+		}
+		leaves[i] = getLeafNode(sum)
+	}
+
+	// Hash up to the root
+	levels := [][]types.VotingTreeNode{leaves}
+	for level := leaves; len(level) > 1; {
+		parents := make([]types.VotingTreeNode, len(level)/2)
+		for i := range parents {
+			parents[i] = getParentNode(level[2*i], level[2*i+1])
+		}
+		levels = append([][]types.VotingTreeNode{parents}, levels...)
+		level = parents
+	}
+	return &VotingTree{
+		BlockNumber: blockNumber,
+		Levels:      levels,
+	}
+}
+
+// Get the network voting tree for a block
+func GetVotingTree(rp *rocketpool.RocketPool, blockNumber uint32, multicallAddress common.Address, opts *bind.CallOpts) (*VotingTree, error) {
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	votingInfos, err := GetNodeInfoSnapshotFast(rp, blockNumber, multicallAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting voting snapshot for block %d: %w", blockNumber, err)
+	}
+	return NewVotingTree(blockNumber, votingInfos), nil
+}
+
+// Get the root of the tree, which holds the total voting power
+func (t *VotingTree) GetRoot() types.VotingTreeNode {
+	return t.Levels[0][0]
+}
+
+// Get the depth of the tree's leaves below the root
+func (t *VotingTree) GetDepth() uint64 {
+	return uint64(len(t.Levels) - 1)
+}
+
+// Get the pollard submitted with a proposal, which is every tree node one round below the root
+// If the tree is shallower than a round, the pollard is the leaves
+func (t *VotingTree) GetPollard(depthPerRound uint64) []types.VotingTreeNode {
+	depth := depthPerRound
+	if depth > t.GetDepth() {
+		depth = t.GetDepth()
+	}
+	pollard := make([]types.VotingTreeNode, len(t.Levels[depth]))
+	copy(pollard, t.Levels[depth])
+	return pollard
+}
+
+// Get the latest block a proposal can take its voting power snapshot from
+// The snapshot must be from a block before the proposal is submitted, so this is the latest block the client has
+func GetLatestVotableBlock(rp *rocketpool.RocketPool) (uint32, error) {
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	return uint32(blockNumber), nil
+}
+
+// Get the pollard for a proposal that takes its voting power snapshot from the given block
+func GetProposalPollard(rp *rocketpool.RocketPool, blockNumber uint32, multicallAddress common.Address, opts *bind.CallOpts) ([]types.VotingTreeNode, error) {
+	depthPerRound, err := protocol.GetDepthPerRound(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := GetVotingTree(rp, blockNumber, multicallAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tree.GetPollard(depthPerRound), nil
+}
+
+// Get a leaf node of the voting tree
+func getLeafNode(sum *big.Int) types.VotingTreeNode {
+	return types.VotingTreeNode{
+		Sum:  sum,
+		Hash: crypto.Keccak256Hash(common.BigToHash(sum).Bytes()),
+	}
+}
+
+// Get the parent of two nodes of the voting tree
+func getParentNode(left types.VotingTreeNode, right types.VotingTreeNode) types.VotingTreeNode {
+	return types.VotingTreeNode{
+		Sum:  new(big.Int).Add(left.Sum, right.Sum),
+		Hash: crypto.Keccak256Hash(left.Hash.Bytes(), common.BigToHash(left.Sum).Bytes(), right.Hash.Bytes(), common.BigToHash(right.Sum).Bytes()),
+	}
+}
@@ -0,0 +1,175 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/bls"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Assembles node deposits (minipool creation): the validator deposit data, the expected minipool address, the
+// pre-deposit checks and the final transaction
+type DepositBuilder struct {
+	NodeAddress        common.Address
+	BondAmount         *big.Int
+	MinimumNodeFee     float64
+	GenesisForkVersion [4]byte // The Beacon Chain's genesis fork version, used for the deposit signature domain
+	UseCredit          bool    // Use the node's deposit credit and ETH balance before the transaction value
+
+	rp *rocketpool.RocketPool
+}
+
+// A node deposit that's ready to be submitted
+type PreparedDeposit struct {
+	MinipoolAddress common.Address              `json:"minipoolAddress"`
+	Salt            *big.Int                    `json:"salt"`
+	BondAmount      *big.Int                    `json:"bondAmount"`
+	Value           *big.Int                    `json:"value"` // The ETH to send with the transaction
+	DepositData     minipool.SignedDepositData  `json:"depositData"`
+	Transaction     rocketpool.BatchTransaction `json:"-"`
+}
+
+// Create a new deposit builder
+func NewDepositBuilder(rp *rocketpool.RocketPool, nodeAddress common.Address, bondAmount *big.Int, minimumNodeFee float64, genesisForkVersion [4]byte) *DepositBuilder {
+	return &DepositBuilder{
+		NodeAddress:        nodeAddress,
+		BondAmount:         bondAmount,
+		MinimumNodeFee:     minimumNodeFee,
+		GenesisForkVersion: genesisForkVersion,
+		rp:                 rp,
+	}
+}
+
+// Check the deposit and prepare it for the validator key and minipool salt
+// The Value of the transact options is set by the transaction, so it doesn't need to be set by the caller
+func (b *DepositBuilder) Build(validatorKey *bls.SecretKey, salt *big.Int, opts *bind.CallOpts) (*PreparedDeposit, error) {
+	// Check the node and bond
+	if err := CheckNodeRegistered(b.rp, b.NodeAddress, opts); err != nil {
+		return nil, err
+	}
+	depositEnabled, err := protocol.GetNodeDepositEnabled(b.rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !depositEnabled {
+		return nil, rocketpool.NewError(rocketpool.ErrSettingDisabled, "node deposits are currently disabled")
+	}
+	if err := b.checkBondAmount(opts); err != nil {
+		return nil, err
+	}
+	if err := CheckRplBond(b.rp, b.NodeAddress, b.BondAmount, opts); err != nil {
+		return nil, err
+	}
+	value, err := b.getValue(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the minipool address and deposit data
+	minipoolAddress, err := minipool.GetExpectedAddress(b.rp, b.NodeAddress, salt, opts)
+	if err != nil {
+		return nil, err
+	}
+	preLaunchValue, err := protocol.GetPreLaunchValue(b.rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	depositAmount := new(big.Int).Div(preLaunchValue, big.NewInt(1e9)).Uint64()
+	depositData, err := minipool.SignDepositData(validatorKey, minipoolAddress, depositAmount, b.GenesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared := &PreparedDeposit{
+		MinipoolAddress: minipoolAddress,
+		Salt:            salt,
+		BondAmount:      b.BondAmount,
+		Value:           value,
+		DepositData:     depositData,
+	}
+	prepared.Transaction = b.getTransaction(prepared)
+	return prepared, nil
+}
+
+// Check that the bond amount is one of the amounts the protocol accepts
+func (b *DepositBuilder) checkBondAmount(opts *bind.CallOpts) error {
+	amounts, err := GetDepositAmounts(b.rp, opts)
+	if err != nil {
+		return err
+	}
+	for _, amount := range amounts {
+		if amount.Cmp(b.BondAmount) == 0 {
+			return nil
+		}
+	}
+	validAmounts := make([]float64, len(amounts))
+	for i, amount := range amounts {
+		validAmounts[i] = eth.WeiToEth(amount)
+	}
+	return fmt.Errorf("bond amount of %.6f ETH is not valid; valid amounts are %v", eth.WeiToEth(b.BondAmount), validAmounts)
+}
+
+// Get the ETH to send with the deposit, and check the node can cover it
+func (b *DepositBuilder) getValue(opts *bind.CallOpts) (*big.Int, error) {
+	value := new(big.Int).Set(b.BondAmount)
+	if b.UseCredit {
+		var err error
+		value, err = GetDepositValueWithCredit(b.rp, b.NodeAddress, b.BondAmount, opts)
+		if err != nil {
+			return nil, err
+		}
+		if value.Sign() == 0 {
+			return value, nil
+		}
+	}
+
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	balance, err := b.rp.Client.BalanceAt(context.Background(), b.NodeAddress, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ETH balance of node %s: %w", b.NodeAddress.Hex(), err)
+	}
+	if balance.Cmp(value) < 0 {
+		return nil, rocketpool.NewError(rocketpool.ErrInsufficientBalance, "node %s has %.6f ETH but the deposit requires %.6f ETH", b.NodeAddress.Hex(), eth.WeiToEth(balance), eth.WeiToEth(value))
+	}
+	return value, nil
+}
+
+// Get the transaction for a prepared deposit
+func (b *DepositBuilder) getTransaction(prepared *PreparedDeposit) rocketpool.BatchTransaction {
+	data := prepared.DepositData
+	if b.UseCredit {
+		return DepositWithCreditTransaction(b.rp, prepared.BondAmount, b.MinimumNodeFee, data.Pubkey, data.Signature, data.DepositDataRoot, prepared.Salt, prepared.MinipoolAddress, prepared.Value)
+	}
+	withValue := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		txOpts := *opts
+		txOpts.Value = prepared.Value
+		return &txOpts
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("deposit for minipool %s", prepared.MinipoolAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDepositGas(b.rp, prepared.BondAmount, b.MinimumNodeFee, data.Pubkey, data.Signature, data.DepositDataRoot, prepared.Salt, prepared.MinipoolAddress, withValue(opts))
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := Deposit(b.rp, prepared.BondAmount, b.MinimumNodeFee, data.Pubkey, data.Signature, data.DepositDataRoot, prepared.Salt, prepared.MinipoolAddress, withValue(opts))
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+		Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+			return rocketpool.DescribeContractCall(b.rp, "rocketNodeDeposit", "deposit", prepared.BondAmount, eth.EthToWei(b.MinimumNodeFee), data.Pubkey[:], data.Signature[:], data.DepositDataRoot, prepared.Salt, prepared.MinipoolAddress)(withValue(opts))
+		},
+	}
+}
@@ -36,6 +36,53 @@ func Deposit(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNodeFee floa
 	return tx, nil
 }
 
+// Estimate the gas of DepositEthFor
+func EstimateDepositEthForGas(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeDeposit.GetTransactionGasInfo(opts, "depositEthFor", nodeAddress)
+}
+
+// Stake the transaction value on behalf of a node, adding it to the node's ETH balance for use in deposits
+func DepositEthFor(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (*types.Transaction, error) {
+	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := rocketNodeDeposit.Transact(opts, "depositEthFor", nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error depositing ETH for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx, nil
+}
+
+// Get a transaction that stakes ETH on behalf of a node; the amount is sent as the transaction value
+func DepositEthForTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, ethAmount *big.Int) rocketpool.BatchTransaction {
+	withValue := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		txOpts := *opts
+		txOpts.Value = ethAmount
+		return &txOpts
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("deposit %.6f ETH for node %s", eth.WeiToEth(ethAmount), nodeAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDepositEthForGas(rp, nodeAddress, withValue(opts))
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := DepositEthFor(rp, nodeAddress, withValue(opts))
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+		Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+			return rocketpool.DescribeContractCall(rp, "rocketNodeDeposit", "depositEthFor", nodeAddress)(withValue(opts))
+		},
+	}
+}
+
 // Estimate the gas to WithdrawETH
 func EstimateWithdrawEthGas(rp *rocketpool.RocketPool, nodeAccount common.Address, ethAmount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
@@ -58,6 +105,24 @@ func WithdrawEth(rp *rocketpool.RocketPool, nodeAccount common.Address, ethAmoun
 	return tx, nil
 }
 
+// Get a transaction that withdraws unused ETH that was staked on behalf of the node
+func WithdrawEthTransaction(rp *rocketpool.RocketPool, nodeAccount common.Address, ethAmount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("withdraw %.6f ETH for node %s", eth.WeiToEth(ethAmount), nodeAccount.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateWithdrawEthGas(rp, nodeAccount, ethAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := WithdrawEth(rp, nodeAccount, ethAmount, opts)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeDeposit", "withdrawEth", nodeAccount, ethAmount),
+	}
+}
+
 // Estimate the gas of DepositWithCredit
 func EstimateDepositWithCreditGas(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNodeFee float64, validatorPubkey rptypes.ValidatorPubkey, validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash, salt *big.Int, expectedMinipoolAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
@@ -80,6 +145,32 @@ func DepositWithCredit(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNo
 	return tx, nil
 }
 
+// Get a transaction that makes a node deposit using the credit balance and ETH balance first, sending value to cover the
+// rest of the bond; use GetDepositValueWithCredit to get the value
+func DepositWithCreditTransaction(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNodeFee float64, validatorPubkey rptypes.ValidatorPubkey, validatorSignature rptypes.ValidatorSignature, depositDataRoot common.Hash, salt *big.Int, expectedMinipoolAddress common.Address, value *big.Int) rocketpool.BatchTransaction {
+	withValue := func(opts *bind.TransactOpts) *bind.TransactOpts {
+		txOpts := *opts
+		txOpts.Value = value
+		return &txOpts
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("deposit with credit for minipool %s", expectedMinipoolAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateDepositWithCreditGas(rp, bondAmount, minimumNodeFee, validatorPubkey, validatorSignature, depositDataRoot, salt, expectedMinipoolAddress, withValue(opts))
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := DepositWithCredit(rp, bondAmount, minimumNodeFee, validatorPubkey, validatorSignature, depositDataRoot, salt, expectedMinipoolAddress, withValue(opts))
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+		Describe: func(opts *bind.TransactOpts) (rocketpool.TransactionDescription, error) {
+			return rocketpool.DescribeContractCall(rp, "rocketNodeDeposit", "depositWithCredit", bondAmount, eth.EthToWei(minimumNodeFee), validatorPubkey[:], validatorSignature[:], depositDataRoot, salt, expectedMinipoolAddress)(withValue(opts))
+		},
+	}
+}
+
 // Estimate the gas of CreateVacantMinipool
 func EstimateCreateVacantMinipoolGas(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNodeFee float64, validatorPubkey rptypes.ValidatorPubkey, salt *big.Int, expectedMinipoolAddress common.Address, currentBalance *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeDeposit, err := getRocketNodeDeposit(rp, nil)
@@ -102,6 +193,37 @@ func CreateVacantMinipool(rp *rocketpool.RocketPool, bondAmount *big.Int, minimu
 	return tx, nil
 }
 
+// Get a transaction that makes a vacant minipool for solo staker migration
+func CreateVacantMinipoolTransaction(rp *rocketpool.RocketPool, bondAmount *big.Int, minimumNodeFee float64, validatorPubkey rptypes.ValidatorPubkey, salt *big.Int, expectedMinipoolAddress common.Address, currentBalance *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("create vacant minipool %s", expectedMinipoolAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateCreateVacantMinipoolGas(rp, bondAmount, minimumNodeFee, validatorPubkey, salt, expectedMinipoolAddress, currentBalance, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := CreateVacantMinipool(rp, bondAmount, minimumNodeFee, validatorPubkey, salt, expectedMinipoolAddress, currentBalance, opts)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeDeposit", "createVacantMinipool", bondAmount, eth.EthToWei(minimumNodeFee), validatorPubkey[:], salt, expectedMinipoolAddress, currentBalance),
+	}
+}
+
+// Get the bond amounts a node can deposit
+func GetDepositAmounts(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]*big.Int, error) {
+	rocketNodeDeposit, err := getRocketNodeDeposit(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	amounts := new([]*big.Int)
+	if err := rocketNodeDeposit.Call(opts, amounts, "getDepositAmounts"); err != nil {
+		return nil, fmt.Errorf("error getting node deposit amounts: %w", err)
+	}
+	return *amounts, nil
+}
+
 // Get the amount of ETH in the node's deposit credit bank
 func GetNodeDepositCredit(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
 	rocketNodeDeposit, err := getRocketNodeDeposit(rp, opts)
@@ -172,11 +294,24 @@ func GetNodeUsableCredit(rp *rocketpool.RocketPool, nodeAddress common.Address,
 	return *usableCredit, nil
 }
 
+// Get the ETH that must be sent with a credit deposit for the given bond, after the node's usable credit and ETH balance
+// are applied
+func GetDepositValueWithCredit(rp *rocketpool.RocketPool, nodeAddress common.Address, bondAmount *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+	usableCreditAndBalance, err := GetNodeUsableCreditAndBalance(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	if usableCreditAndBalance.Cmp(bondAmount) >= 0 {
+		return big.NewInt(0), nil
+	}
+	return new(big.Int).Sub(bondAmount, usableCreditAndBalance), nil
+}
+
 // Get contracts
 var rocketNodeDepositLock sync.Mutex
 
 func getRocketNodeDeposit(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketNodeDepositLock.Lock()
 	defer rocketNodeDepositLock.Unlock()
-	return rp.GetContract("rocketNodeDeposit", opts)
+	return rp.VersionManager.GetContract("rocketNodeDeposit", opts)
 }
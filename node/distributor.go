@@ -7,10 +7,27 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 )
 
+// Settings
+const (
+	distributorDetailsFastBatchSize int = 500
+)
+
+// The balance details of a node's fee distributor
+type DistributorBalanceDetails struct {
+	NodeAddress        common.Address `json:"nodeAddress"`
+	DistributorAddress common.Address `json:"distributorAddress"`
+	Initialised        bool           `json:"initialised"`
+	Balance            *big.Int       `json:"balance"`
+	NodeShare          *big.Int       `json:"nodeShare"` // Zero if the distributor isn't initialised or is empty
+	UserShare          *big.Int       `json:"userShare"` // Zero if the distributor isn't initialised or is empty
+}
+
 // Distributor contract
 type Distributor struct {
 	Address    common.Address
@@ -48,6 +65,189 @@ func GetDistributorAddress(rp *rocketpool.RocketPool, nodeAddress common.Address
 	return address, nil
 }
 
+// Gets the distributor addresses for a list of nodes using a multicaller
+func GetDistributorAddressesFast(rp *rocketpool.RocketPool, multicallAddress common.Address, nodeAddresses []common.Address, opts *bind.CallOpts) ([]common.Address, error) {
+	rocketNodeDistributorFactory, err := getRocketNodeDistributorFactory(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	count := len(nodeAddresses)
+	addresses := make([]common.Address, count)
+
+	// Run the getters in batches
+	for i := 0; i < count; i += distributorDetailsFastBatchSize {
+		i := i
+		max := i + distributorDetailsFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				mc.AddCall(rocketNodeDistributorFactory, &addresses[j], "getProxyAddress", nodeAddresses[j])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting distributor addresses: %w", err)
+	}
+
+	// Return
+	return addresses, nil
+}
+
+// Gets the balances and node / user shares of the distributors for a list of nodes, using a multicaller and a balance batcher
+func GetDistributorBalanceDetailsFast(rp *rocketpool.RocketPool, multicallAddress common.Address, balanceBatcherAddress common.Address, nodeAddresses []common.Address, opts *bind.CallOpts) ([]DistributorBalanceDetails, error) {
+	rocketNodeManager, err := getRocketNodeManager(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rocketNodeDistributorFactory, err := getRocketNodeDistributorFactory(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Sync
+	var wg errgroup.Group
+	count := len(nodeAddresses)
+	details := make([]DistributorBalanceDetails, count)
+
+	// Get the distributor addresses and initialisation status
+	for i := 0; i < count; i += distributorDetailsFastBatchSize {
+		i := i
+		max := i + distributorDetailsFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				details[j].NodeAddress = nodeAddresses[j]
+				details[j].NodeShare = big.NewInt(0)
+				details[j].UserShare = big.NewInt(0)
+				mc.AddCall(rocketNodeDistributorFactory, &details[j].DistributorAddress, "getProxyAddress", nodeAddresses[j])
+				mc.AddCall(rocketNodeManager, &details[j].Initialised, "getFeeDistributorInitialised", nodeAddresses[j])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting distributor addresses: %w", err)
+	}
+
+	// Get the distributor balances
+	balanceBatcher, err := multicall.NewBalanceBatcher(rp.Client, balanceBatcherAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error creating balance batcher: %w", err)
+	}
+	distributorAddresses := make([]common.Address, count)
+	for i := range details {
+		distributorAddresses[i] = details[i].DistributorAddress
+	}
+	balances, err := balanceBatcher.GetEthBalances(distributorAddresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting distributor balances: %w", err)
+	}
+	for i := range details {
+		details[i].Balance = balances[i]
+	}
+
+	// Get the shares of the initialised distributors that have a balance
+	for i := 0; i < count; i += distributorDetailsFastBatchSize {
+		i := i
+		max := i + distributorDetailsFastBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				if !details[j].Initialised || details[j].Balance.Sign() == 0 {
+					continue
+				}
+				distributor, err := getDistributorContract(rp, details[j].DistributorAddress, opts)
+				if err != nil {
+					return err
+				}
+				mc.AddCall(distributor, &details[j].NodeShare, "getNodeShare")
+				mc.AddCall(distributor, &details[j].UserShare, "getUserShare")
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting distributor shares: %w", err)
+	}
+
+	// Return
+	return details, nil
+}
+
+// Get the distribute transactions for every initialised distributor with a balance of at least the threshold (in wei)
+func GetDistributeTransactions(rp *rocketpool.RocketPool, details []DistributorBalanceDetails, threshold *big.Int) ([]rocketpool.BatchTransaction, error) {
+	txs := []rocketpool.BatchTransaction{}
+	for _, distributor := range details {
+		if !distributor.Initialised || distributor.Balance == nil || distributor.Balance.Sign() == 0 {
+			continue
+		}
+		if threshold != nil && distributor.Balance.Cmp(threshold) < 0 {
+			continue
+		}
+		tx, err := DistributeTransaction(rp, distributor.DistributorAddress)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// Get a transaction that distributes a fee distributor's balance
+func DistributeTransaction(rp *rocketpool.RocketPool, distributorAddress common.Address) (rocketpool.BatchTransaction, error) {
+	distributor, err := NewDistributor(rp, distributorAddress, nil)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("distribute fee distributor %s", distributorAddress.Hex()),
+		Estimate: distributor.EstimateDistributeGas,
+		Submit:   distributor.Distribute,
+		Describe: distributor.Contract.DescribeCall("distribute"),
+	}, nil
+}
+
 // Estimate the gas of a distribute
 func (d *Distributor) EstimateDistributeGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return d.Contract.GetTransactionGasInfo(opts, "distribute")
@@ -685,6 +685,20 @@ func SetRPLWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Addre
 	return tx.Hash(), nil
 }
 
+// Get the transaction info for setting the RPL-specific withdrawal address
+func SetRPLWithdrawalAddressTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, withdrawalAddress common.Address, confirm bool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("set node %s RPL withdrawal address to %s", nodeAddress.Hex(), withdrawalAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSetRPLWithdrawalAddressGas(rp, nodeAddress, withdrawalAddress, confirm, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SetRPLWithdrawalAddress(rp, nodeAddress, withdrawalAddress, confirm, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeManager", "setRPLWithdrawalAddress", nodeAddress, withdrawalAddress, confirm),
+	}
+}
+
 // Estimate the gas for confirming the RPL-specific withdrawal address
 func EstimateConfirmRPLWithdrawalAddressGas(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeManager, err := getRocketNodeManager(rp, nil)
@@ -707,6 +721,20 @@ func ConfirmRPLWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.A
 	return tx.Hash(), nil
 }
 
+// Get the transaction info for confirming the pending RPL-specific withdrawal address; must be sent from the pending address
+func ConfirmRPLWithdrawalAddressTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("confirm node %s RPL withdrawal address", nodeAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateConfirmRPLWithdrawalAddressGas(rp, nodeAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ConfirmRPLWithdrawalAddress(rp, nodeAddress, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeManager", "confirmRPLWithdrawalAddress", nodeAddress),
+	}
+}
+
 // Get contracts
 var rocketNodeManagerLock sync.Mutex
 
@@ -721,7 +749,7 @@ var rocketNetworkPricesLock sync.Mutex
 func getRocketNetworkPrices(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
 	rocketNetworkPricesLock.Lock()
 	defer rocketNetworkPricesLock.Unlock()
-	return rp.GetContract("rocketNetworkPrices", opts)
+	return rp.VersionManager.GetContract("rocketNetworkPrices", opts)
 }
 
 var rocketNetworkBalancesLock sync.Mutex
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Check that a node is registered with the network
+// Returns an error wrapping rocketpool.ErrNotRegistered if it isn't
+func CheckNodeRegistered(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) error {
+	exists, err := GetNodeExists(rp, nodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return rocketpool.NewError(rocketpool.ErrNotRegistered, "node %s is not registered with Rocket Pool", nodeAddress.Hex())
+	}
+	return nil
+}
+
+// Check that a node can make a deposit with the given amount of ETH
+func CheckDeposit(rp *rocketpool.RocketPool, nodeAddress common.Address, depositAmount *big.Int, opts *bind.CallOpts) error {
+	if err := CheckNodeRegistered(rp, nodeAddress, opts); err != nil {
+		return err
+	}
+
+	// Check the setting
+	depositEnabled, err := protocol.GetNodeDepositEnabled(rp, opts)
+	if err != nil {
+		return err
+	}
+	if !depositEnabled {
+		return rocketpool.NewError(rocketpool.ErrSettingDisabled, "node deposits are currently disabled")
+	}
+
+	// Check the balance
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	balance, err := rp.Client.BalanceAt(context.Background(), nodeAddress, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting ETH balance of node %s: %w", nodeAddress.Hex(), err)
+	}
+	if balance.Cmp(depositAmount) < 0 {
+		return rocketpool.NewError(rocketpool.ErrInsufficientBalance, "node %s has %.6f ETH but the deposit requires %.6f ETH", nodeAddress.Hex(), eth.WeiToEth(balance), eth.WeiToEth(depositAmount))
+	}
+	return nil
+}
+
+// Check that a node can stake the given amount of RPL
+func CheckStakeRPL(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int, opts *bind.CallOpts) error {
+	if err := CheckNodeRegistered(rp, nodeAddress, opts); err != nil {
+		return err
+	}
+	balance, err := tokens.GetRPLBalance(rp, nodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(rplAmount) < 0 {
+		return rocketpool.NewError(rocketpool.ErrInsufficientBalance, "node %s has %.6f RPL but the stake requires %.6f RPL", nodeAddress.Hex(), eth.WeiToEth(balance), eth.WeiToEth(rplAmount))
+	}
+	return nil
+}
+
+// Check that a node has enough RPL staked to create a minipool with the given bond
+// Returns an error wrapping rocketpool.ErrInsufficientBalance if the ETH it would borrow would exceed the limit its RPL
+// stake allows
+func CheckRplBond(rp *rocketpool.RocketPool, nodeAddress common.Address, bondAmount *big.Int, opts *bind.CallOpts) error {
+	launchBalance, err := protocol.GetLaunchBalance(rp, opts)
+	if err != nil {
+		return err
+	}
+	ethMatched, err := GetNodeEthMatched(rp, nodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	ethMatchedLimit, err := GetNodeEthMatchedLimit(rp, nodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	newEthMatched := new(big.Int).Sub(launchBalance, bondAmount)
+	newEthMatched.Add(newEthMatched, ethMatched)
+	if newEthMatched.Cmp(ethMatchedLimit) > 0 {
+		return rocketpool.NewError(rocketpool.ErrInsufficientBalance, "node %s would borrow %.6f ETH but its RPL stake only allows %.6f ETH", nodeAddress.Hex(), eth.WeiToEth(newEthMatched), eth.WeiToEth(ethMatchedLimit))
+	}
+	return nil
+}
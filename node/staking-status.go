@@ -0,0 +1,123 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// An overview of a node's RPL and ETH staking, with its collateralization
+// The raw fields are in wei; ratios are fractions of 1e18 (e.g. 1e17 is 10%)
+type StakingStatus struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	RplPrice    *big.Int       `json:"rplPrice"` // ETH per RPL
+
+	// Raw values
+	RplStake          *big.Int `json:"rplStake"`
+	EffectiveRplStake *big.Int `json:"effectiveRplStake"`
+	MinimumRplStake   *big.Int `json:"minimumRplStake"`
+	MaximumRplStake   *big.Int `json:"maximumRplStake"`
+	RplLocked         *big.Int `json:"rplLocked"` // Locked in proposal bonds; nil before Houston
+	EthMatched        *big.Int `json:"ethMatched"`
+	EthMatchedLimit   *big.Int `json:"ethMatchedLimit"`
+	BorrowedEth       *big.Int `json:"borrowedEth"` // ETH provided by the deposit pool
+	BondedEth         *big.Int `json:"bondedEth"`   // ETH provided by the node; nil before Houston
+	RplStakeValue     *big.Int `json:"rplStakeValue"`
+	BorrowedRatio     *big.Int `json:"borrowedRatio"` // RPL stake value / borrowed ETH; nil if nothing is borrowed
+	BondedRatio       *big.Int `json:"bondedRatio"`   // RPL stake value / bonded ETH; nil if nothing is bonded
+
+	// Formatted values
+	RplStakeFloat          float64 `json:"rplStakeFloat"`
+	EffectiveRplStakeFloat float64 `json:"effectiveRplStakeFloat"`
+	MinimumRplStakeFloat   float64 `json:"minimumRplStakeFloat"`
+	MaximumRplStakeFloat   float64 `json:"maximumRplStakeFloat"`
+	BorrowedEthFloat       float64 `json:"borrowedEthFloat"`
+	BondedEthFloat         float64 `json:"bondedEthFloat"`
+	BorrowedRatioFloat     float64 `json:"borrowedRatioFloat"`
+	BondedRatioFloat       float64 `json:"bondedRatioFloat"`
+}
+
+// Get a node's staking overview in a single multicall
+func GetStakingStatus(rp *rocketpool.RocketPool, multicallerAddress common.Address, nodeAddress common.Address, opts *bind.CallOpts) (*StakingStatus, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rocketNetworkPrices, err := rp.VersionManager.GetContract("rocketNetworkPrices", opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+
+	// Add the calls
+	status := &StakingStatus{
+		NodeAddress: nodeAddress,
+	}
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return nil, err
+	}
+	mc.AddCall(rocketNetworkPrices, &status.RplPrice, "getRPLPrice")
+	mc.AddCall(rocketNodeStaking, &status.RplStake, "getNodeRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &status.EffectiveRplStake, "getNodeEffectiveRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &status.MinimumRplStake, "getNodeMinimumRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &status.MaximumRplStake, "getNodeMaximumRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &status.EthMatched, "getNodeETHMatched", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &status.EthMatchedLimit, "getNodeETHMatchedLimit", nodeAddress)
+	if _, exists := rocketNodeStaking.ABI.Methods["getNodeETHProvided"]; exists {
+		mc.AddCall(rocketNodeStaking, &status.BondedEth, "getNodeETHProvided", nodeAddress)
+	}
+	if _, exists := rocketNodeStaking.ABI.Methods["getNodeRPLLocked"]; exists {
+		mc.AddCall(rocketNodeStaking, &status.RplLocked, "getNodeRPLLocked", nodeAddress)
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error getting staking status of node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	// Effective stake should be zero if it's less than the minimum RPL stake
+	if status.EffectiveRplStake.Cmp(status.MinimumRplStake) < 0 {
+		status.EffectiveRplStake = big.NewInt(0)
+	}
+
+	// Calculate the collateralization
+	oneEth := eth.EthToWei(1)
+	status.BorrowedEth = status.EthMatched
+	status.RplStakeValue = new(big.Int).Mul(status.RplStake, status.RplPrice)
+	status.RplStakeValue.Div(status.RplStakeValue, oneEth)
+	status.BorrowedRatio = getCollateralRatio(status.RplStakeValue, status.BorrowedEth)
+	status.BondedRatio = getCollateralRatio(status.RplStakeValue, status.BondedEth)
+
+	// Format the values
+	status.RplStakeFloat = eth.WeiToEth(status.RplStake)
+	status.EffectiveRplStakeFloat = eth.WeiToEth(status.EffectiveRplStake)
+	status.MinimumRplStakeFloat = eth.WeiToEth(status.MinimumRplStake)
+	status.MaximumRplStakeFloat = eth.WeiToEth(status.MaximumRplStake)
+	status.BorrowedEthFloat = eth.WeiToEth(status.BorrowedEth)
+	if status.BondedEth != nil {
+		status.BondedEthFloat = eth.WeiToEth(status.BondedEth)
+	}
+	if status.BorrowedRatio != nil {
+		status.BorrowedRatioFloat = eth.WeiToEth(status.BorrowedRatio)
+	}
+	if status.BondedRatio != nil {
+		status.BondedRatioFloat = eth.WeiToEth(status.BondedRatio)
+	}
+	return status, nil
+}
+
+// Get the ratio of a value to an amount of ETH as a fraction of 1e18, or nil if the amount is empty
+func getCollateralRatio(value *big.Int, ethAmount *big.Int) *big.Int {
+	if ethAmount == nil || ethAmount.Sign() == 0 {
+		return nil
+	}
+	ratio := new(big.Int).Mul(value, eth.EthToWei(1))
+	return ratio.Div(ratio, ethAmount)
+}
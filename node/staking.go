@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
 )
 
 // Get the version of the Node Staking contract
@@ -158,6 +159,45 @@ func StakeRPL(rp *rocketpool.RocketPool, rplAmount *big.Int, opts *bind.Transact
 	return tx.Hash(), nil
 }
 
+// Check if the Node Staking contract can stake RPL with an EIP-2612 permit in a single transaction
+func GetStakeRPLWithPermitSupported(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	_, exists := rocketNodeStaking.ABI.Methods["stakeRPLWithPermit"]
+	return exists, nil
+}
+
+// Estimate the gas of StakeRPLWithPermit
+func EstimateStakeRPLWithPermitGas(rp *rocketpool.RocketPool, permit tokens.SignedPermit, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeStaking.GetTransactionGasInfo(opts, "stakeRPLWithPermit", permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+}
+
+// Stake RPL using a signed permit for the Node Staking contract instead of a separate approval transaction
+// Requires a Node Staking contract that supports it; see GetStakeRPLWithPermitSupported
+func StakeRPLWithPermit(rp *rocketpool.RocketPool, permit tokens.SignedPermit, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, exists := rocketNodeStaking.ABI.Methods["stakeRPLWithPermit"]; !exists {
+		return common.Hash{}, fmt.Errorf("the Node Staking contract does not support staking RPL with a permit")
+	}
+	if permit.Spender != *rocketNodeStaking.Address {
+		return common.Hash{}, fmt.Errorf("permit spender %s is not the Node Staking contract %s", permit.Spender.Hex(), rocketNodeStaking.Address.Hex())
+	}
+	tx, err := rocketNodeStaking.Transact(opts, "stakeRPLWithPermit", permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error staking RPL with permit: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
 // Estimate the gas of set RPL locking allowed
 func EstimateSetRPLLockingAllowedGas(rp *rocketpool.RocketPool, caller common.Address, allowed bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
@@ -215,6 +255,85 @@ func SetStakeRPLForAllowed(rp *rocketpool.RocketPool, caller common.Address, all
 	return tx.Hash(), nil
 }
 
+// Check whether a caller is allowed to stake RPL on behalf of a node
+func GetStakeRPLForAllowed(rp *rocketpool.RocketPool, nodeAddress common.Address, caller common.Address, opts *bind.CallOpts) (bool, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	value := new(bool)
+	if err := rocketNodeStaking.Call(opts, value, "getStakeRPLForAllowed", nodeAddress, caller); err != nil {
+		return false, fmt.Errorf("error getting stake RPL for allowed: %w", err)
+	}
+	return *value, nil
+}
+
+// Estimate the gas of SetStakeRPLForAllowedForNode
+func EstimateSetStakeRPLForAllowedForNodeGas(rp *rocketpool.RocketPool, nodeAddress common.Address, caller common.Address, allowed bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	method, err := getOverloadedMethodName(rocketNodeStaking, "setStakeRPLForAllowed", 3)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeStaking.GetTransactionGasInfo(opts, method, nodeAddress, caller, allowed)
+}
+
+// Set whether a caller is allowed to stake RPL on behalf of a node; callable by the node or its RPL withdrawal address
+func SetStakeRPLForAllowedForNode(rp *rocketpool.RocketPool, nodeAddress common.Address, caller common.Address, allowed bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	method, err := getOverloadedMethodName(rocketNodeStaking, "setStakeRPLForAllowed", 3)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeStaking.Transact(opts, method, nodeAddress, caller, allowed)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error setting stake RPL for allowed for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of StakeRPLFor
+func EstimateStakeRPLForGas(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeStaking.GetTransactionGasInfo(opts, "stakeRPLFor", nodeAddress, rplAmount)
+}
+
+// Stake RPL on behalf of a node; the caller must be allowed by the node and have approved the RPL transfer
+func StakeRPLFor(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeStaking.Transact(opts, "stakeRPLFor", nodeAddress, rplAmount)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error staking RPL for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a transaction that stakes RPL on behalf of a node
+func StakeRPLForTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("stake RPL for node %s", nodeAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateStakeRPLForGas(rp, nodeAddress, rplAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return StakeRPLFor(rp, nodeAddress, rplAmount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeStaking", "stakeRPLFor", nodeAddress, rplAmount),
+	}
+}
+
 // Estimate the gas of WithdrawRPL
 func EstimateWithdrawRPLGas(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketNodeStaking, err := getRocketNodeStaking(rp, nil)
@@ -237,6 +356,20 @@ func WithdrawRPL(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmoun
 	return tx.Hash(), nil
 }
 
+// Get a transaction that withdraws staked RPL from a node; callable by the node or its RPL withdrawal address
+func WithdrawRPLTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, rplAmount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("withdraw RPL from node %s", nodeAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateWithdrawRPLGas(rp, nodeAddress, rplAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return WithdrawRPL(rp, nodeAddress, rplAmount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketNodeStaking", "withdrawRPL", nodeAddress, rplAmount),
+	}
+}
+
 // Calculate total effective RPL stake
 func CalculateTotalEffectiveRPLStake(rp *rocketpool.RocketPool, offset, limit, rplPrice *big.Int, opts *bind.CallOpts) (*big.Int, error) {
 	rocketNodeStaking, err := getRocketNodeStaking(rp, opts)
@@ -263,6 +396,33 @@ func GetNodeRPLLocked(rp *rocketpool.RocketPool, nodeAddress common.Address, opt
 	return *value, nil
 }
 
+// Get the amount of a node's staked RPL that isn't locked, which bounds how much can be withdrawn
+func GetNodeUnlockedRPLStake(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	stake, err := GetNodeRPLStake(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := GetNodeRPLLocked(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	unlocked := big.NewInt(0).Sub(stake, locked)
+	if unlocked.Sign() < 0 {
+		return big.NewInt(0), nil
+	}
+	return unlocked, nil
+}
+
+// Get the ABI name of an overloaded contract method by its number of inputs
+func getOverloadedMethodName(contract *rocketpool.Contract, name string, inputCount int) (string, error) {
+	for methodName, method := range contract.ABI.Methods {
+		if method.RawName == name && len(method.Inputs) == inputCount {
+			return methodName, nil
+		}
+	}
+	return "", fmt.Errorf("contract does not have a %s method with %d inputs", name, inputCount)
+}
+
 // Get contracts
 var rocketNodeStakingLock sync.Mutex
 
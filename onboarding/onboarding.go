@@ -0,0 +1,251 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The total ETH balance of a minipool's validator at launch
+var launchBalance = eth.EthToWei(32)
+
+// A step in the onboarding flow
+type Step string
+
+const (
+	Step_Register   Step = "register"
+	Step_ApproveRPL Step = "approve_rpl"
+	Step_StakeRPL   Step = "stake_rpl"
+	Step_Deposit    Step = "deposit"
+)
+
+// The onboarding status of a node for a minipool with a given bond
+type Status struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	BondAmount  *big.Int       `json:"bondAmount"`
+
+	// Registration
+	Registered          bool `json:"registered"`
+	RegistrationEnabled bool `json:"registrationEnabled"`
+
+	// ETH
+	DepositsEnabled bool     `json:"depositsEnabled"`
+	EthBalance      *big.Int `json:"ethBalance"`
+	DepositCredit   *big.Int `json:"depositCredit"`
+	EthRequired     *big.Int `json:"ethRequired"` // Bond amount not covered by deposit credit
+
+	// RPL
+	RplPrice          *big.Int       `json:"rplPrice"`
+	RplBalance        *big.Int       `json:"rplBalance"`
+	RplStake          *big.Int       `json:"rplStake"`
+	RplStakeRequired  *big.Int       `json:"rplStakeRequired"` // Minimum total stake once the new minipool has been made
+	RplToStake        *big.Int       `json:"rplToStake"`       // Additional RPL needed to reach the minimum
+	RplAllowance      *big.Int       `json:"rplAllowance"`
+	RplStakingAddress common.Address `json:"rplStakingAddress"`
+
+	// Deposit pool
+	DepositPoolBalance *big.Int `json:"depositPoolBalance"`
+
+	// Next action
+	NextStep        Step                         `json:"nextStep"`
+	NextTransaction *rocketpool.BatchTransaction `json:"-"`        // Nil if the next step can't be taken or needs inputs the flow doesn't have (e.g. deposit data)
+	Blockers        []string                     `json:"blockers"` // Reasons the next step can't be taken yet
+	Warnings        []string                     `json:"warnings"` // Things that won't stop the flow but the operator should know about
+}
+
+// Check every onboarding prerequisite in order for a node that wants to create a minipool with the given bond, and get
+// the next transaction it needs to submit along with anything blocking it
+// The deposit step itself isn't returned as a transaction since it needs validator deposit data; once NextStep is
+// Step_Deposit with no blockers, the node can call node.Deposit
+func GetStatus(rp *rocketpool.RocketPool, nodeAddress common.Address, bondAmount *big.Int, timezoneLocation string, opts *bind.CallOpts) (*Status, error) {
+	status := &Status{
+		NodeAddress:      nodeAddress,
+		BondAmount:       big.NewInt(0).Set(bondAmount),
+		DepositCredit:    big.NewInt(0),
+		RplStake:         big.NewInt(0),
+		RplStakeRequired: big.NewInt(0),
+		RplToStake:       big.NewInt(0),
+		Blockers:         []string{},
+		Warnings:         []string{},
+	}
+	if err := status.load(rp, opts); err != nil {
+		return nil, err
+	}
+
+	// Registration
+	if !status.Registered {
+		status.NextStep = Step_Register
+		if !status.RegistrationEnabled {
+			status.Blockers = append(status.Blockers, "node registrations are currently disabled")
+			return status, nil
+		}
+		status.NextTransaction = &rocketpool.BatchTransaction{
+			Name: "register node",
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateRegisterNodeGas(rp, timezoneLocation, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.RegisterNode(rp, timezoneLocation, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketNodeManager", "registerNode", timezoneLocation),
+		}
+		return status, nil
+	}
+
+	// RPL stake
+	if status.RplToStake.Sign() > 0 {
+		if status.RplBalance.Cmp(status.RplToStake) < 0 {
+			status.NextStep = Step_StakeRPL
+			status.Blockers = append(status.Blockers, fmt.Sprintf("node needs to stake %.6f more RPL but only holds %.6f RPL", eth.WeiToEth(status.RplToStake), eth.WeiToEth(status.RplBalance)))
+			return status, nil
+		}
+		amount := big.NewInt(0).Set(status.RplToStake)
+		if status.RplAllowance.Cmp(amount) < 0 {
+			status.NextStep = Step_ApproveRPL
+			status.NextTransaction = &rocketpool.BatchTransaction{
+				Name: "approve RPL for staking",
+				Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+					return tokens.EstimateApproveRPLGas(rp, status.RplStakingAddress, amount, opts)
+				},
+				Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+					return tokens.ApproveRPL(rp, status.RplStakingAddress, amount, opts)
+				},
+				Describe: rocketpool.DescribeContractCall(rp, "rocketTokenRPL", "approve", status.RplStakingAddress, amount),
+			}
+			return status, nil
+		}
+		status.NextStep = Step_StakeRPL
+		status.NextTransaction = &rocketpool.BatchTransaction{
+			Name: "stake RPL",
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateStakeGas(rp, amount, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.StakeRPL(rp, amount, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketNodeStaking", "stakeRPL", amount),
+		}
+		return status, nil
+	}
+
+	// Deposit
+	status.NextStep = Step_Deposit
+	if !status.DepositsEnabled {
+		status.Blockers = append(status.Blockers, "node deposits are currently disabled")
+	}
+	if status.EthBalance.Cmp(status.EthRequired) < 0 {
+		status.Blockers = append(status.Blockers, fmt.Sprintf("node needs %.6f ETH for the deposit but only holds %.6f ETH", eth.WeiToEth(status.EthRequired), eth.WeiToEth(status.EthBalance)))
+	}
+	borrowed := big.NewInt(0).Sub(launchBalance, bondAmount)
+	if status.DepositPoolBalance.Cmp(borrowed) < 0 {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("the deposit pool only has %.6f ETH, so the new minipool will wait in the queue until %.6f ETH is available for it", eth.WeiToEth(status.DepositPoolBalance), eth.WeiToEth(borrowed)))
+	}
+	return status, nil
+}
+
+// Get the next transaction of an onboarding flow, refreshing the status first so it reflects any transactions that
+// have been submitted since
+func GetNextTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, bondAmount *big.Int, timezoneLocation string, opts *bind.CallOpts) (*rocketpool.BatchTransaction, []string, error) {
+	status, err := GetStatus(rp, nodeAddress, bondAmount, timezoneLocation, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return status.NextTransaction, status.Blockers, nil
+}
+
+// Load the node and network state the flow depends on
+func (s *Status) load(rp *rocketpool.RocketPool, opts *bind.CallOpts) error {
+	var err error
+	s.Registered, err = node.GetNodeExists(rp, s.NodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	s.RegistrationEnabled, err = protocol.GetNodeRegistrationEnabled(rp, opts)
+	if err != nil {
+		return err
+	}
+	s.DepositsEnabled, err = protocol.GetNodeDepositEnabled(rp, opts)
+	if err != nil {
+		return err
+	}
+
+	// ETH
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+	s.EthBalance, err = rp.Client.BalanceAt(context.Background(), s.NodeAddress, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting ETH balance of node %s: %w", s.NodeAddress.Hex(), err)
+	}
+	if s.Registered {
+		s.DepositCredit, err = node.GetNodeUsableCredit(rp, s.NodeAddress, opts)
+		if err != nil {
+			return err
+		}
+	}
+	s.EthRequired = big.NewInt(0).Sub(s.BondAmount, s.DepositCredit)
+	if s.EthRequired.Sign() < 0 {
+		s.EthRequired.SetUint64(0)
+	}
+	s.DepositPoolBalance, err = deposit.GetBalance(rp, opts)
+	if err != nil {
+		return err
+	}
+
+	// RPL
+	s.RplPrice, err = network.GetRPLPrice(rp, opts)
+	if err != nil {
+		return err
+	}
+	s.RplBalance, err = tokens.GetRPLBalance(rp, s.NodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	stakingAddress, err := rp.GetAddress("rocketNodeStaking", opts)
+	if err != nil {
+		return err
+	}
+	s.RplStakingAddress = *stakingAddress
+	s.RplAllowance, err = tokens.GetRPLAllowance(rp, s.NodeAddress, s.RplStakingAddress, opts)
+	if err != nil {
+		return err
+	}
+	minimumFraction, err := protocol.GetMinimumPerMinipoolStakeRaw(rp, opts)
+	if err != nil {
+		return err
+	}
+
+	// Get the minimum stake with the new minipool's borrowed ETH included
+	ethMatched := big.NewInt(0).Sub(launchBalance, s.BondAmount)
+	if s.Registered {
+		s.RplStake, err = node.GetNodeRPLStake(rp, s.NodeAddress, opts)
+		if err != nil {
+			return err
+		}
+		currentMatched, err := node.GetNodeEthMatched(rp, s.NodeAddress, opts)
+		if err != nil {
+			return err
+		}
+		ethMatched.Add(ethMatched, currentMatched)
+	}
+	if s.RplPrice.Sign() > 0 {
+		s.RplStakeRequired.Mul(ethMatched, minimumFraction)
+		s.RplStakeRequired.Div(s.RplStakeRequired, s.RplPrice)
+	}
+	if s.RplStake.Cmp(s.RplStakeRequired) < 0 {
+		s.RplToStake.Sub(s.RplStakeRequired, s.RplStake)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// A node's rewards for one interval, as listed in the interval's rewards file
+type IntervalClaim struct {
+	Index            uint64        `json:"index"`
+	MerkleRoot       common.Hash   `json:"merkleRoot"` // The root the rewards file lists for the interval
+	RewardNetwork    uint64        `json:"rewardNetwork"`
+	CollateralRPL    *big.Int      `json:"collateralRpl"`
+	OracleDaoRPL     *big.Int      `json:"oracleDaoRpl"`
+	SmoothingPoolETH *big.Int      `json:"smoothingPoolEth"`
+	MerkleProof      []common.Hash `json:"merkleProof"`
+}
+
+// Get the total RPL the node can claim for the interval
+func (c IntervalClaim) GetAmountRPL() *big.Int {
+	return new(big.Int).Add(c.CollateralRPL, c.OracleDaoRPL)
+}
+
+// The parts of a rewards interval file needed to claim a node's rewards
+type intervalFile struct {
+	Index       uint64                       `json:"index"`
+	MerkleRoot  string                       `json:"merkleRoot"`
+	NodeRewards map[string]intervalFileEntry `json:"nodeRewards"`
+}
+type intervalFileEntry struct {
+	RewardNetwork    uint64   `json:"rewardNetwork"`
+	CollateralRPL    string   `json:"collateralRpl"`
+	OracleDaoRPL     string   `json:"oracleDaoRpl"`
+	SmoothingPoolETH string   `json:"smoothingPoolEth"`
+	MerkleProof      []string `json:"merkleProof"`
+}
+
+// Get a node's claim from the JSON contents of a rewards interval file
+// Returns false if the node has no rewards in the interval
+func GetIntervalClaimFromFile(data []byte, nodeAddress common.Address) (IntervalClaim, bool, error) {
+	var file intervalFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return IntervalClaim{}, false, fmt.Errorf("error decoding rewards file: %w", err)
+	}
+
+	// Find the node; addresses may or may not be checksummed
+	var entry intervalFileEntry
+	found := false
+	for address, nodeEntry := range file.NodeRewards {
+		if common.IsHexAddress(address) && common.HexToAddress(address) == nodeAddress {
+			entry = nodeEntry
+			found = true
+			break
+		}
+	}
+	if !found {
+		return IntervalClaim{}, false, nil
+	}
+
+	// Decode the amounts and proof
+	claim := IntervalClaim{
+		Index:         file.Index,
+		MerkleRoot:    common.HexToHash(file.MerkleRoot),
+		RewardNetwork: entry.RewardNetwork,
+		MerkleProof:   make([]common.Hash, len(entry.MerkleProof)),
+	}
+	for _, amount := range []struct {
+		name  string
+		value string
+		out   **big.Int
+	}{
+		{name: "collateral RPL", value: entry.CollateralRPL, out: &claim.CollateralRPL},
+		{name: "Oracle DAO RPL", value: entry.OracleDaoRPL, out: &claim.OracleDaoRPL},
+		{name: "Smoothing Pool ETH", value: entry.SmoothingPoolETH, out: &claim.SmoothingPoolETH},
+	} {
+		if amount.value == "" {
+			*amount.out = big.NewInt(0)
+			continue
+		}
+		value, ok := math.ParseBig256(amount.value)
+		if !ok {
+			return IntervalClaim{}, false, fmt.Errorf("invalid %s amount '%s' for node %s in interval %d", amount.name, amount.value, nodeAddress.Hex(), file.Index)
+		}
+		*amount.out = value
+	}
+	for i, proof := range entry.MerkleProof {
+		claim.MerkleProof[i] = common.HexToHash(proof)
+	}
+	return claim, true, nil
+}
+
+// Check that a claim's Merkle proof proves the node's rewards against the interval's root
+// The leaf is the node address, reward network, RPL and ETH packed together, and pairs are sorted before hashing
+func VerifyIntervalClaim(nodeAddress common.Address, claim IntervalClaim) bool {
+	hash := crypto.Keccak256Hash(
+		nodeAddress.Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(claim.RewardNetwork)).Bytes(),
+		common.BigToHash(claim.GetAmountRPL()).Bytes(),
+		common.BigToHash(claim.SmoothingPoolETH).Bytes(),
+	)
+	for _, sibling := range claim.MerkleProof {
+		if bytes.Compare(hash.Bytes(), sibling.Bytes()) <= 0 {
+			hash = crypto.Keccak256Hash(hash.Bytes(), sibling.Bytes())
+		} else {
+			hash = crypto.Keccak256Hash(sibling.Bytes(), hash.Bytes())
+		}
+	}
+	return hash == claim.MerkleRoot
+}
+
+// A validated claim of several intervals that restakes part of the claimed RPL, in the form claimAndStake takes
+type ClaimAndStakePlan struct {
+	NodeAddress  common.Address  `json:"nodeAddress"`
+	Indices      []*big.Int      `json:"indices"`
+	AmountsRPL   []*big.Int      `json:"amountsRpl"`
+	AmountsETH   []*big.Int      `json:"amountsEth"`
+	MerkleProofs [][]common.Hash `json:"merkleProofs"`
+	TotalRPL     *big.Int        `json:"totalRpl"`
+	TotalETH     *big.Int        `json:"totalEth"`
+	StakeAmount  *big.Int        `json:"stakeAmount"` // The RPL restaked, which is kept out of the node's withdrawal address
+}
+
+// Get the transaction info for the claim; if nothing is restaked, it's a plain claim
+func (p ClaimAndStakePlan) Transaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	if p.StakeAmount.Sign() == 0 {
+		return rocketpool.BatchTransaction{
+			Name: fmt.Sprintf("claim rewards for %d intervals", len(p.Indices)),
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return EstimateClaimGas(rp, p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return Claim(rp, p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketMerkleDistributorMainnet", "claim", p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs),
+		}
+	}
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("claim rewards for %d intervals and restake %s RPL wei", len(p.Indices), p.StakeAmount.String()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateClaimAndStakeGas(rp, p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs, p.StakeAmount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ClaimAndStake(rp, p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs, p.StakeAmount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketMerkleDistributorMainnet", "claimAndStake", p.NodeAddress, p.Indices, p.AmountsRPL, p.AmountsETH, p.MerkleProofs, p.StakeAmount),
+	}
+}
+
+// Validate a node's claims for one or more intervals and combine them into a single claim that restakes stakePercent
+// (0 to 100) of the claimed RPL
+// Each claim must be for the mainnet reward network, prove against its interval's root, match the root the distributor
+// holds for the interval, and not have been claimed yet
+func GetClaimAndStakePlan(rp *rocketpool.RocketPool, nodeAddress common.Address, claims []IntervalClaim, stakePercent uint64, opts *bind.CallOpts) (ClaimAndStakePlan, error) {
+	if len(claims) == 0 {
+		return ClaimAndStakePlan{}, fmt.Errorf("no intervals to claim")
+	}
+	if stakePercent > 100 {
+		return ClaimAndStakePlan{}, fmt.Errorf("stake percent %d is more than 100", stakePercent)
+	}
+
+	// Claim the intervals in order
+	claims = append([]IntervalClaim{}, claims...)
+	sort.Slice(claims, func(i int, j int) bool { return claims[i].Index < claims[j].Index })
+
+	plan := ClaimAndStakePlan{
+		NodeAddress:  nodeAddress,
+		Indices:      make([]*big.Int, len(claims)),
+		AmountsRPL:   make([]*big.Int, len(claims)),
+		AmountsETH:   make([]*big.Int, len(claims)),
+		MerkleProofs: make([][]common.Hash, len(claims)),
+		TotalRPL:     big.NewInt(0),
+		TotalETH:     big.NewInt(0),
+	}
+	for i, claim := range claims {
+		if i > 0 && claim.Index == claims[i-1].Index {
+			return ClaimAndStakePlan{}, fmt.Errorf("interval %d is included more than once", claim.Index)
+		}
+		if claim.CollateralRPL == nil || claim.OracleDaoRPL == nil || claim.SmoothingPoolETH == nil {
+			return ClaimAndStakePlan{}, fmt.Errorf("interval %d is missing reward amounts", claim.Index)
+		}
+		if claim.RewardNetwork != 0 {
+			return ClaimAndStakePlan{}, fmt.Errorf("interval %d rewards are on network %d, which can't be claimed from the mainnet distributor", claim.Index, claim.RewardNetwork)
+		}
+		if !VerifyIntervalClaim(nodeAddress, claim) {
+			return ClaimAndStakePlan{}, fmt.Errorf("Merkle proof for node %s in interval %d does not match the interval's root", nodeAddress.Hex(), claim.Index)
+		}
+
+		// Check the claim against the distributor
+		index := new(big.Int).SetUint64(claim.Index)
+		root, err := MerkleRoots(rp, index, opts)
+		if err != nil {
+			return ClaimAndStakePlan{}, err
+		}
+		if common.BytesToHash(root) != claim.MerkleRoot {
+			return ClaimAndStakePlan{}, fmt.Errorf("Merkle root %s for interval %d does not match the distributor's root %s", claim.MerkleRoot.Hex(), claim.Index, common.BytesToHash(root).Hex())
+		}
+		claimed, err := IsClaimed(rp, index, nodeAddress, opts)
+		if err != nil {
+			return ClaimAndStakePlan{}, err
+		}
+		if claimed {
+			return ClaimAndStakePlan{}, fmt.Errorf("node %s has already claimed its rewards for interval %d", nodeAddress.Hex(), claim.Index)
+		}
+
+		amountRPL := claim.GetAmountRPL()
+		plan.Indices[i] = index
+		plan.AmountsRPL[i] = amountRPL
+		plan.AmountsETH[i] = new(big.Int).Set(claim.SmoothingPoolETH)
+		plan.MerkleProofs[i] = claim.MerkleProof
+		plan.TotalRPL.Add(plan.TotalRPL, amountRPL)
+		plan.TotalETH.Add(plan.TotalETH, claim.SmoothingPoolETH)
+	}
+
+	// Get the restake amount, rounding down so it can't exceed the claimed RPL
+	plan.StakeAmount = new(big.Int).Mul(plan.TotalRPL, new(big.Int).SetUint64(stakePercent))
+	plan.StakeAmount.Div(plan.StakeAmount, big.NewInt(100))
+	return plan, nil
+}
@@ -0,0 +1,83 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Settings
+const (
+	claimStatusBatchSize int = 1000
+)
+
+// Check if a node has claimed its rewards for each of the given intervals, in the same order as the intervals
+func GetClaimStatus(rp *rocketpool.RocketPool, multicallerAddress common.Address, nodeAddress common.Address, intervals []uint64, opts *bind.CallOpts) ([]bool, error) {
+	statuses, err := GetClaimStatusForNodes(rp, multicallerAddress, []common.Address{nodeAddress}, intervals, opts)
+	if err != nil {
+		return nil, err
+	}
+	return statuses[0], nil
+}
+
+// Check if each node has claimed its rewards for each of the given intervals, indexed by node then interval
+func GetClaimStatusForNodes(rp *rocketpool.RocketPool, multicallerAddress common.Address, nodeAddresses []common.Address, intervals []uint64, opts *bind.CallOpts) ([][]bool, error) {
+	rocketDistributorMainnet, err := getRocketDistributorMainnet(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	statuses := make([][]bool, len(nodeAddresses))
+	for i := range statuses {
+		statuses[i] = make([]bool, len(intervals))
+	}
+	intervalsBig := make([]*big.Int, len(intervals))
+	for i, interval := range intervals {
+		intervalsBig[i] = new(big.Int).SetUint64(interval)
+	}
+
+	// Sync
+	var wg errgroup.Group
+	count := len(nodeAddresses) * len(intervals)
+
+	// Run the getters in batches, with a call per node and interval pair
+	for i := 0; i < count; i += claimStatusBatchSize {
+		i := i
+		max := i + claimStatusBatchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				node := j / len(intervals)
+				interval := j % len(intervals)
+				mc.AddCall(rocketDistributorMainnet, &statuses[node][interval], "isClaimed", intervalsBig[interval], nodeAddresses[node])
+			}
+			_, err = mc.FlexibleCall(true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting rewards claim statuses: %w", err)
+	}
+
+	// Return
+	return statuses, nil
+}
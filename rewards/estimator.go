@@ -0,0 +1,119 @@
+package rewards
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/tokens"
+)
+
+var (
+	estimatorCalcBase     = big.NewInt(1e18)
+	estimatorValidatorEth = new(big.Int).Mul(big.NewInt(32), big.NewInt(1e18))
+	secondsPerYear        = (365 * 24 * time.Hour).Seconds()
+)
+
+// Projects a node's rewards for the current interval from the network's current state
+// The projections assume the rest of the interval looks like the part that has passed: the smoothing pool keeps filling
+// at the same rate, RPL inflation is minted on schedule, the effective stakes don't change and every smoothing pool
+// minipool performs equally well
+type Estimator struct {
+	IntervalStart              time.Time             `json:"intervalStart"`
+	IntervalDuration           time.Duration         `json:"intervalDuration"`
+	Inflation                  *tokens.InflationCalc `json:"inflation"`                  // If nil, RPL that hasn't been minted yet isn't included
+	PendingRPLRewards          *big.Int              `json:"pendingRplRewards"`          // RPL already minted for the interval
+	NodeOperatorRewardsPercent *big.Int              `json:"nodeOperatorRewardsPercent"` // Scaled by 1e18
+	TotalEffectiveRPLStake     *big.Int              `json:"totalEffectiveRplStake"`
+	SmoothingPoolBalance       *big.Int              `json:"smoothingPoolBalance"`
+	SmoothingPoolMinipoolCount uint64                `json:"smoothingPoolMinipoolCount"` // Staking minipools of nodes in the smoothing pool
+}
+
+// A staking minipool's bond and commission, which set its share of the smoothing pool
+type EstimatorMinipool struct {
+	NodeDepositBalance *big.Int `json:"nodeDepositBalance"`
+	NodeFee            *big.Int `json:"nodeFee"` // Scaled by 1e18
+}
+
+// A node's projected rewards for the current interval
+type NodeRewardsEstimate struct {
+	IntervalEnd      time.Time `json:"intervalEnd"`
+	RPLRewards       *big.Int  `json:"rplRewards"`
+	SmoothingPoolETH *big.Int  `json:"smoothingPoolEth"`
+	RPLAPR           float64   `json:"rplApr"` // The RPL rewards annualized as a fraction of the node's RPL stake
+}
+
+// Get the time the current interval ends
+func (e *Estimator) GetIntervalEnd() time.Time {
+	return e.IntervalStart.Add(e.IntervalDuration)
+}
+
+// Project the RPL rewards all node operators will share for the interval
+func (e *Estimator) EstimateNodeOperatorRPLRewards() *big.Int {
+	total := new(big.Int).Set(e.PendingRPLRewards)
+	if e.Inflation != nil {
+		total.Add(total, e.Inflation.GetNewTokensAt(e.GetIntervalEnd()))
+	}
+	total.Mul(total, e.NodeOperatorRewardsPercent)
+	return total.Div(total, estimatorCalcBase)
+}
+
+// Project the smoothing pool balance at the end of the interval from how much it has collected so far
+func (e *Estimator) EstimateSmoothingPoolBalance(at time.Time) *big.Int {
+	elapsed := at.Sub(e.IntervalStart)
+	if elapsed <= 0 || elapsed >= e.IntervalDuration {
+		return new(big.Int).Set(e.SmoothingPoolBalance)
+	}
+	// Use fractional seconds so the projection still works less than a second into the interval
+	balance := new(big.Float).SetInt(e.SmoothingPoolBalance)
+	balance.Mul(balance, big.NewFloat(e.IntervalDuration.Seconds()/elapsed.Seconds()))
+	projected, _ := balance.Int(nil)
+	return projected
+}
+
+// Project a node's RPL rewards for the interval from its effective RPL stake
+func (e *Estimator) EstimateNodeRPLRewards(effectiveRPLStake *big.Int) *big.Int {
+	if e.TotalEffectiveRPLStake.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	rewards := e.EstimateNodeOperatorRPLRewards()
+	rewards.Mul(rewards, effectiveRPLStake)
+	return rewards.Div(rewards, e.TotalEffectiveRPLStake)
+}
+
+// Project a node's smoothing pool ETH for the interval from its staking minipools
+// Each minipool earns its bond's share of an equal slice of the pool, plus its commission on the rest
+func (e *Estimator) EstimateNodeSmoothingPoolETH(minipools []EstimatorMinipool, at time.Time) *big.Int {
+	if e.SmoothingPoolMinipoolCount == 0 {
+		return big.NewInt(0)
+	}
+	shares := big.NewInt(0)
+	for _, mp := range minipools {
+		share := new(big.Int).Sub(estimatorValidatorEth, mp.NodeDepositBalance)
+		share.Mul(share, mp.NodeFee)
+		share.Div(share, estimatorCalcBase)
+		share.Add(share, mp.NodeDepositBalance)
+		shares.Add(shares, share)
+	}
+	eth := e.EstimateSmoothingPoolBalance(at)
+	eth.Mul(eth, shares)
+	return eth.Div(eth, new(big.Int).Mul(estimatorValidatorEth, new(big.Int).SetUint64(e.SmoothingPoolMinipoolCount)))
+}
+
+// Project a node's rewards for the interval
+// minipools should only include the node's staking minipools, and is ignored if the node isn't in the smoothing pool
+func (e *Estimator) EstimateNodeRewards(rplStake *big.Int, effectiveRPLStake *big.Int, inSmoothingPool bool, minipools []EstimatorMinipool, at time.Time) NodeRewardsEstimate {
+	estimate := NodeRewardsEstimate{
+		IntervalEnd:      e.GetIntervalEnd(),
+		RPLRewards:       e.EstimateNodeRPLRewards(effectiveRPLStake),
+		SmoothingPoolETH: big.NewInt(0),
+	}
+	if inSmoothingPool {
+		estimate.SmoothingPoolETH = e.EstimateNodeSmoothingPoolETH(minipools, at)
+	}
+	if rplStake.Sign() > 0 && e.IntervalDuration > 0 {
+		rewards, _ := new(big.Float).SetInt(estimate.RPLRewards).Float64()
+		stake, _ := new(big.Float).SetInt(rplStake).Float64()
+		estimate.RPLAPR = rewards / stake * secondsPerYear / e.IntervalDuration.Seconds()
+	}
+	return estimate
+}
@@ -11,12 +11,15 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 )
 
 const (
-	rewardsSnapshotSubmittedNodeKey string = "rewards.snapshot.submitted.node.key"
+	rewardsSnapshotSubmittedNodeKey  string = "rewards.snapshot.submitted.node.key"
+	rewardsSnapshotSubmittedCountKey string = "rewards.snapshot.submitted.count"
 )
 
 // Info for a rewards snapshot event
@@ -170,9 +173,60 @@ func GetTrustedNodeSubmitted(rp *rocketpool.RocketPool, nodeAddress common.Addre
 // Check whether or not the given address has submitted specific rewards info
 func GetTrustedNodeSubmittedSpecificRewards(rp *rocketpool.RocketPool, nodeAddress common.Address, submission RewardSubmission, opts *bind.CallOpts) (bool, error) {
 	// NOTE: this doesn't have a view yet so we have to construct it manually, and RLP
+	key, err := getSubmissionKey(rewardsSnapshotSubmittedNodeKey, &nodeAddress, submission)
+	if err != nil {
+		return false, err
+	}
+	result, err := rp.RocketStorage.GetBool(opts, key)
+	if err != nil {
+		return false, fmt.Errorf("error checking if trusted node submitted specific rewards: %w", err)
+	}
+	return result, nil
+}
+
+// Get the number of trusted nodes that have submitted specific rewards info
+func GetSubmissionCount(rp *rocketpool.RocketPool, submission RewardSubmission, opts *bind.CallOpts) (uint64, error) {
+	key, err := getSubmissionKey(rewardsSnapshotSubmittedCountKey, nil, submission)
+	if err != nil {
+		return 0, err
+	}
+	count, err := rp.RocketStorage.GetUint(opts, key)
+	if err != nil {
+		return 0, fmt.Errorf("error getting rewards submission count: %w", err)
+	}
+	return count.Uint64(), nil
+}
+
+// Check whether enough trusted nodes have submitted specific rewards info to reach the consensus threshold
+// The submission that reaches the threshold executes the snapshot, so this is true once the interval is finalized
+func GetSubmissionConsensusReached(rp *rocketpool.RocketPool, submission RewardSubmission, opts *bind.CallOpts) (bool, error) {
+	count, err := GetSubmissionCount(rp, submission, opts)
+	if err != nil {
+		return false, err
+	}
+	memberCount, err := trustednode.GetMemberCount(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	threshold, err := protocol.GetNodeConsensusThresholdRaw(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	if memberCount == 0 {
+		return false, nil
+	}
+
+	// Matches the contract's check, calcBase * submissionCount / memberCount >= threshold
+	ratio := new(big.Int).SetUint64(count)
+	ratio.Mul(ratio, eth.EthToWei(1))
+	ratio.Div(ratio, new(big.Int).SetUint64(memberCount))
+	return ratio.Cmp(threshold) >= 0, nil
+}
+
+// Get the RocketStorage key RocketRewardsPool derives from a submission, keccak256(abi.encode(key, [nodeAddress,] submission))
+func getSubmissionKey(key string, nodeAddress *common.Address, submission RewardSubmission) (common.Hash, error) {
 	stringTy, _ := abi.NewType("string", "string", nil)
 	addressTy, _ := abi.NewType("address", "address", nil)
-
 	submissionTy, _ := abi.NewType("tuple", "struct RewardSubmission", []abi.ArgumentMarshaling{
 		{Name: "rewardIndex", Type: "uint256"},
 		{Name: "executionBlock", Type: "uint256"},
@@ -187,23 +241,20 @@ func GetTrustedNodeSubmittedSpecificRewards(rp *rocketpool.RocketPool, nodeAddre
 		{Name: "userETH", Type: "uint256"},
 	})
 
-	args := abi.Arguments{
-		{Type: stringTy, Name: "key"},
-		{Type: addressTy, Name: "trustedNodeAddress"},
-		{Type: submissionTy, Name: "submission"},
+	args := abi.Arguments{{Type: stringTy, Name: "key"}}
+	values := []interface{}{key}
+	if nodeAddress != nil {
+		args = append(args, abi.Argument{Type: addressTy, Name: "trustedNodeAddress"})
+		values = append(values, *nodeAddress)
 	}
+	args = append(args, abi.Argument{Type: submissionTy, Name: "submission"})
+	values = append(values, &submission)
 
-	bytes, err := args.Pack(rewardsSnapshotSubmittedNodeKey, nodeAddress, &submission)
-	if err != nil {
-		return false, fmt.Errorf("error encoding submission data into ABI format: %w", err)
-	}
-
-	key := crypto.Keccak256Hash(bytes)
-	result, err := rp.RocketStorage.GetBool(opts, key)
+	bytes, err := args.Pack(values...)
 	if err != nil {
-		return false, fmt.Errorf("error checking if trusted node submitted specific rewards: %w", err)
+		return common.Hash{}, fmt.Errorf("error encoding submission data into ABI format: %w", err)
 	}
-	return result, nil
+	return crypto.Keccak256Hash(bytes), nil
 }
 
 // Estimate the gas for submiting a Merkle Tree-based snapshot for a rewards interval
@@ -228,6 +279,20 @@ func SubmitRewardSnapshot(rp *rocketpool.RocketPool, submission RewardSubmission
 	return tx.Hash(), nil
 }
 
+// Get a transaction that submits a Merkle Tree-based snapshot for a rewards interval
+func SubmitRewardSnapshotTransaction(rp *rocketpool.RocketPool, submission RewardSubmission) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("submit rewards snapshot for interval %s", submission.RewardIndex.String()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSubmitRewardSnapshotGas(rp, submission, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SubmitRewardSnapshot(rp, submission, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketRewardsPool", "submitRewardSnapshot", submission),
+	}
+}
+
 // Get the event info for a rewards snapshot using the Atlas getter
 func GetRewardsEvent(rp *rocketpool.RocketPool, index uint64, rocketRewardsPoolAddresses []common.Address, opts *bind.CallOpts) (bool, RewardsEvent, error) {
 	// Get contracts
@@ -0,0 +1,241 @@
+package rocketpool
+
+import (
+	"container/list"
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The hit and miss counts of a call cache
+type CallCacheStats struct {
+	Size   int    `json:"size"`
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// Cache the results of contract calls made against a specific block, so repeated queries for the same block (e.g. during
+// rewards tree generation) don't go back to the execution client
+// Calls are keyed by block, contract and calldata (the method and its arguments), and the least recently used results
+// are dropped once size results are held; calls against the latest block and failed calls are never cached. Results
+// are kept if the block is later reorged out, so only use this for queries against finalized blocks, or clear it with
+// ClearCallCache after a reorg. Like SetMetrics, this drops the cached contract bindings and should be called before
+// the RocketPool is shared between goroutines. Passing a size of 0 removes the cache.
+func (rp *RocketPool) SetCallCache(size int) error {
+	client := rp.Client
+	if cached, ok := client.(*callCacheClient); ok {
+		client = cached.ExecutionClient
+	}
+	if size > 0 {
+		client = &callCacheClient{
+			ExecutionClient: client,
+			cache:           newCallCache(size),
+		}
+	}
+	return rp.setClient(client)
+}
+
+// Get the hit and miss counts of the call cache; returns false if there is no call cache
+func (rp *RocketPool) GetCallCacheStats() (CallCacheStats, bool) {
+	cached, ok := rp.Client.(*callCacheClient)
+	if !ok {
+		return CallCacheStats{}, false
+	}
+	return cached.cache.getStats(), true
+}
+
+// Drop every result held by the call cache, if there is one
+func (rp *RocketPool) ClearCallCache() {
+	if cached, ok := rp.Client.(*callCacheClient); ok {
+		cached.cache.clear()
+	}
+}
+
+// The key of a cached call
+type callCacheKey struct {
+	blockNumber string
+	from        common.Address
+	to          common.Address
+	data        string
+}
+
+// A cached call result
+type callCacheEntry struct {
+	key    callCacheKey
+	result []byte
+}
+
+// A bounded cache of call results that drops the least recently used result when it's full
+type callCache struct {
+	size    int
+	entries map[callCacheKey]*list.Element
+	order   *list.List // Most recently used first
+	hits    uint64
+	misses  uint64
+	lock    sync.Mutex
+}
+
+func newCallCache(size int) *callCache {
+	return &callCache{
+		size:    size,
+		entries: map[callCacheKey]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get a cached result, marking it as the most recently used
+func (c *callCache) get(key callCacheKey) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	element, exists := c.entries[key]
+	if !exists {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*callCacheEntry).result, true
+}
+
+// Add a result, dropping the least recently used one if the cache is full
+func (c *callCache) add(key callCacheKey, result []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if element, exists := c.entries[key]; exists {
+		element.Value.(*callCacheEntry).result = result
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&callCacheEntry{key: key, result: result})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*callCacheEntry).key)
+	}
+}
+
+func (c *callCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = map[callCacheKey]*list.Element{}
+	c.order.Init()
+}
+
+func (c *callCache) getStats() CallCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return CallCacheStats{
+		Size:   c.order.Len(),
+		Hits:   c.hits,
+		Misses: c.misses,
+	}
+}
+
+// An execution client that caches the results of calls made against a specific block
+type callCacheClient struct {
+	ExecutionClient
+	cache *callCache
+}
+
+func (c *callCacheClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	// Calls against the latest or pending block, or that set any transaction fields, aren't cached
+	if blockNumber == nil || blockNumber.Sign() < 0 || call.To == nil || call.Gas != 0 || call.GasPrice != nil || call.GasFeeCap != nil || call.GasTipCap != nil || call.Value != nil {
+		return c.ExecutionClient.CallContract(ctx, call, blockNumber)
+	}
+	key := callCacheKey{
+		blockNumber: blockNumber.String(),
+		from:        call.From,
+		to:          *call.To,
+		data:        string(call.Data),
+	}
+	if result, exists := c.cache.get(key); exists {
+		return common.CopyBytes(result), nil
+	}
+	result, err := c.ExecutionClient.CallContract(ctx, call, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.add(key, common.CopyBytes(result))
+	return result, nil
+}
+
+// Everything other than contract calls goes straight to the wrapped client
+func (c *callCacheClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.ExecutionClient.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *callCacheClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return c.ExecutionClient.HeaderByHash(ctx, hash)
+}
+
+func (c *callCacheClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return c.ExecutionClient.HeaderByNumber(ctx, number)
+}
+
+func (c *callCacheClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return c.ExecutionClient.PendingCodeAt(ctx, account)
+}
+
+func (c *callCacheClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.ExecutionClient.PendingNonceAt(ctx, account)
+}
+
+func (c *callCacheClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return c.ExecutionClient.SuggestGasPrice(ctx)
+}
+
+func (c *callCacheClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return c.ExecutionClient.SuggestGasTipCap(ctx)
+}
+
+func (c *callCacheClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return c.ExecutionClient.EstimateGas(ctx, call)
+}
+
+func (c *callCacheClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.ExecutionClient.SendTransaction(ctx, tx)
+}
+
+func (c *callCacheClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return c.ExecutionClient.FilterLogs(ctx, query)
+}
+
+func (c *callCacheClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.ExecutionClient.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (c *callCacheClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return c.ExecutionClient.TransactionReceipt(ctx, txHash)
+}
+
+func (c *callCacheClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.ExecutionClient.BlockNumber(ctx)
+}
+
+func (c *callCacheClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.ExecutionClient.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *callCacheClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return c.ExecutionClient.TransactionByHash(ctx, hash)
+}
+
+func (c *callCacheClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.ExecutionClient.NonceAt(ctx, account, blockNumber)
+}
+
+func (c *callCacheClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return c.ExecutionClient.SyncProgress(ctx)
+}
+
+func (c *callCacheClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return getFeeHistory(ctx, c.ExecutionClient, blockCount, lastBlock, rewardPercentiles)
+}
+
+// Make sure the cached client still satisfies the client interfaces
+var _ ExecutionClient = (*callCacheClient)(nil)
+var _ FeeHistoryClient = (*callCacheClient)(nil)
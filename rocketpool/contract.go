@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -30,6 +31,7 @@ type Contract struct {
 	Address  *common.Address
 	ABI      *abi.ABI
 	Client   ExecutionClient
+	Name     string // The contract's name in RocketStorage; empty if it isn't a named network contract
 }
 
 // Response for gas limits from network and from user request
@@ -42,7 +44,10 @@ type GasInfo struct {
 func (c *Contract) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
 	results := make([]interface{}, 1)
 	results[0] = result
-	return c.Contract.Call(opts, &results, method, params...)
+	start := time.Now()
+	err := c.Contract.Call(opts, &results, method, params...)
+	observeContractCall(c.Client, ContractCallKind_Call, method, start, err)
+	return classifyError(err)
 }
 
 // Get Gas Limit for transaction
@@ -57,7 +62,7 @@ func (c *Contract) GetTransactionGasInfo(opts *bind.TransactOpts, method string,
 	}
 
 	// Estimate gas limit
-	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, input)
+	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, method, input)
 
 	if err != nil {
 		return response, fmt.Errorf("Error getting transaction gas info: could not estimate gas limit: %w", err)
@@ -77,7 +82,7 @@ func (c *Contract) Transact(opts *bind.TransactOpts, method string, params ...in
 		if err != nil {
 			return nil, fmt.Errorf("error encoding input data: %w", err)
 		}
-		_, safeGasLimit, err := c.estimateGasLimit(opts, input)
+		_, safeGasLimit, err := c.estimateGasLimit(opts, method, input)
 		if err != nil {
 			return nil, err
 		}
@@ -85,7 +90,9 @@ func (c *Contract) Transact(opts *bind.TransactOpts, method string, params ...in
 	}
 
 	// Send transaction
+	start := time.Now()
 	tx, err := c.Contract.Transact(opts, method, params...)
+	observeContractCall(c.Client, ContractCallKind_Transact, method, start, err)
 	if err != nil {
 		return nil, c.normalizeErrorMessage(err)
 	}
@@ -100,7 +107,7 @@ func (c *Contract) GetTransferGasInfo(opts *bind.TransactOpts) (GasInfo, error)
 	response := GasInfo{}
 
 	// Estimate gas limit
-	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, []byte{})
+	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, "", []byte{})
 	if err != nil {
 		return response, fmt.Errorf("Error getting transfer gas info: could not estimate gas limit: %w", err)
 	}
@@ -115,7 +122,7 @@ func (c *Contract) Transfer(opts *bind.TransactOpts) (common.Hash, error) {
 
 	// Estimate gas limit
 	if opts.GasLimit == 0 {
-		_, safeGasLimit, err := c.estimateGasLimit(opts, []byte{})
+		_, safeGasLimit, err := c.estimateGasLimit(opts, "", []byte{})
 		if err != nil {
 			return common.Hash{}, err
 		}
@@ -133,9 +140,11 @@ func (c *Contract) Transfer(opts *bind.TransactOpts) (common.Hash, error) {
 }
 
 // Estimate the expected and safe gas limits for a contract transaction
-func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint64, uint64, error) {
+// Reverts are returned as a SimulationError with the decoded revert reason
+func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, method string, input []byte) (uint64, uint64, error) {
 
 	// Estimate gas limit
+	start := time.Now()
 	gasLimit, err := c.Client.EstimateGas(context.Background(), ethereum.CallMsg{
 		From:     opts.From,
 		To:       c.Address,
@@ -143,9 +152,10 @@ func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint
 		Value:    opts.Value,
 		Data:     input,
 	})
+	observeContractCall(c.Client, ContractCallKind_EstimateGas, method, start, err)
 
 	if err != nil {
-		return 0, 0, fmt.Errorf("error estimating gas needed: %w", c.normalizeErrorMessage(err))
+		return 0, 0, fmt.Errorf("error estimating gas needed: %w", c.newSimulationError(method, err))
 	}
 
 	// Pad and return gas limit
@@ -224,7 +234,7 @@ func (c *Contract) GetTransactionEvents(txReceipt *types.Receipt, eventName stri
 
 }
 
-// Normalize error messages so they're all in ASCII format
+// Normalize error messages so they're all in ASCII format, and tag them with their error kind
 func (c *Contract) normalizeErrorMessage(err error) error {
 	if err == nil {
 		return err
@@ -234,19 +244,19 @@ func (c *Contract) normalizeErrorMessage(err error) error {
 	reg := regexp.MustCompile(NethermindRevertRegex)
 	matches := reg.FindStringSubmatch(err.Error())
 	if matches == nil {
-		return err
+		return classifyError(err)
 	}
 	messageIndex := reg.SubexpIndex("message")
 	if messageIndex == -1 {
-		return err
+		return classifyError(err)
 	}
 	message := matches[messageIndex]
 
 	// Convert the hex message to ASCII
 	bytes, err2 := hex.DecodeString(message)
 	if err2 != nil {
-		return err // Return the original error if decoding failed somehow
+		return classifyError(err) // Return the original error if decoding failed somehow
 	}
 
-	return fmt.Errorf("Reverted: %s", string(bytes))
+	return classifyRevert(fmt.Errorf("Reverted: %s", string(bytes)), string(bytes))
 }
@@ -0,0 +1,210 @@
+package rocketpool
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The manifests of the known deployments, named <network>.json
+// Regenerate them with `go run ./rocketpool/deployments/export` against a synced node when contracts are upgraded
+//
+//go:embed deployments/*.json
+var embeddedDeployments embed.FS
+
+// The contracts the bindings load from RocketStorage by name, which are exported into the embedded manifests
+var BindingContractNames = []string{
+	"rocketAuctionManager",
+	"rocketClaimDAO",
+	"rocketClaimNode",
+	"rocketClaimTrustedNode",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAONodeTrustedSettingsMembers",
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAONodeTrustedSettingsProposals",
+	"rocketDAONodeTrustedSettingsRewards",
+	"rocketDAONodeTrustedUpgrade",
+	"rocketDAOProposal",
+	"rocketDAOProtocol",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolProposals",
+	"rocketDAOProtocolSettingsAuction",
+	"rocketDAOProtocolSettingsDeposit",
+	"rocketDAOProtocolSettingsInflation",
+	"rocketDAOProtocolSettingsMinipool",
+	"rocketDAOProtocolSettingsNetwork",
+	"rocketDAOProtocolSettingsNode",
+	"rocketDAOProtocolSettingsProposals",
+	"rocketDAOProtocolSettingsRewards",
+	"rocketDAOProtocolSettingsSecurity",
+	"rocketDAOProtocolVerifier",
+	"rocketDAOSecurity",
+	"rocketDAOSecurityActions",
+	"rocketDAOSecurityProposals",
+	"rocketDepositPool",
+	"rocketMegapoolDelegate",
+	"rocketMegapoolFactory",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipool",
+	"rocketMinipoolBase",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolDelegate",
+	"rocketMinipoolFactory",
+	"rocketMinipoolManager",
+	"rocketMinipoolPenalty",
+	"rocketMinipoolQueue",
+	"rocketMinipoolStatus",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPenalties",
+	"rocketNetworkPrices",
+	"rocketNetworkVoting",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorDelegate",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+	"addressQueueStorage",
+	"casperDeposit",
+}
+
+// A contract in a deployment manifest
+type DeploymentContract struct {
+	Address common.Address `json:"address"`
+	Abi     string         `json:"abi"` // zlib-compressed and base64-encoded, as stored in RocketStorage
+}
+
+// The contract addresses and ABIs of a Rocket Pool deployment, used to create bindings without querying RocketStorage
+type Deployment struct {
+	Name                 string                        `json:"name"`
+	ChainID              uint64                        `json:"chainId"`
+	RocketStorageAddress common.Address                `json:"rocketStorageAddress"`
+	Contracts            map[string]DeploymentContract `json:"contracts"`
+}
+
+// Get the names of the deployments embedded in this package
+func GetEmbeddedDeploymentNames() []string {
+	entries, err := embeddedDeployments.ReadDir("deployments")
+	if err != nil {
+		return []string{}
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get one of the deployments embedded in this package by network name (e.g. mainnet or holesky)
+func GetEmbeddedDeployment(name string) (*Deployment, error) {
+	file, err := embeddedDeployments.Open("deployments/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no embedded deployment for %s (known deployments: %s)", name, strings.Join(GetEmbeddedDeploymentNames(), ", "))
+	}
+	defer file.Close()
+	return LoadDeployment(file)
+}
+
+// Load a deployment manifest
+func LoadDeployment(reader io.Reader) (*Deployment, error) {
+	var deployment Deployment
+	if err := json.NewDecoder(reader).Decode(&deployment); err != nil {
+		return nil, fmt.Errorf("error decoding deployment manifest: %w", err)
+	}
+	if deployment.Contracts == nil {
+		deployment.Contracts = map[string]DeploymentContract{}
+	}
+	return &deployment, nil
+}
+
+// Read the addresses and ABIs of the given contracts from RocketStorage to create a deployment manifest
+// Contracts with neither an address nor an ABI aren't deployed on the network and are left out
+func ExportDeployment(rp *RocketPool, name string, chainID uint64, contractNames []string, opts *bind.CallOpts) (*Deployment, error) {
+	deployment := &Deployment{
+		Name:                 name,
+		ChainID:              chainID,
+		RocketStorageAddress: *rp.RocketStorageContract.Address,
+		Contracts:            make(map[string]DeploymentContract, len(contractNames)),
+	}
+	for _, contractName := range contractNames {
+		address, err := rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)))
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract %s address: %w", contractName, err)
+		}
+		abiEncoded, err := rp.RocketStorage.GetString(opts, crypto.Keccak256Hash([]byte("contract.abi"), []byte(contractName)))
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract %s ABI: %w", contractName, err)
+		}
+		if address == (common.Address{}) && abiEncoded == "" {
+			continue
+		}
+		deployment.Contracts[contractName] = DeploymentContract{
+			Address: address,
+			Abi:     abiEncoded,
+		}
+	}
+	return deployment, nil
+}
+
+// Create a contract manager that reads contract addresses and ABIs from a deployment manifest instead of RocketStorage
+// The manifest must be created with ExportDeployment against a synced node, listing every contract the bindings use
+// No requests are made to build bindings or encode transactions, so client may be nil for air-gapped signing; calls and
+// transactions made through the bindings still need a client
+func NewOfflineRocketPool(client ExecutionClient, deployment *Deployment) (*RocketPool, error) {
+	if len(deployment.Contracts) == 0 {
+		return nil, fmt.Errorf("the %s deployment manifest has no contracts", deployment.Name)
+	}
+	rp, err := NewRocketPool(client, deployment.RocketStorageAddress)
+	if err != nil {
+		return nil, err
+	}
+	rp.deployment = deployment
+	return rp, nil
+}
+
+// Check if this contract manager reads its contracts from a deployment manifest
+func (rp *RocketPool) IsOffline() bool {
+	return rp.deployment != nil
+}
+
+// Get the address of a contract from the deployment manifest
+func (d *Deployment) getAddress(contractName string) (*common.Address, error) {
+	contract, exists := d.Contracts[contractName]
+	if !exists {
+		return nil, fmt.Errorf("contract %s is not in the %s deployment manifest", contractName, d.Name)
+	}
+	address := contract.Address
+	return &address, nil
+}
+
+// Get the ABI of a contract from the deployment manifest
+func (d *Deployment) getABI(contractName string) (*abi.ABI, error) {
+	contract, exists := d.Contracts[contractName]
+	if !exists {
+		return nil, fmt.Errorf("contract %s is not in the %s deployment manifest", contractName, d.Name)
+	}
+	abi, err := DecodeAbi(contract.Abi)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding contract %s ABI: %w", contractName, err)
+	}
+	return abi, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/rocket-pool/rocketpool-go/config"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Exports the deployment manifest of a network into rocketpool/deployments, for embedding into the library
+// Usage: go run ./rocketpool/deployments/export -network mainnet -rpc http://localhost:8545
+func main() {
+
+	network := flag.String("network", "", "The name of the chain profile to export (e.g. mainnet or holesky)")
+	rpcUrl := flag.String("rpc", "http://localhost:8545", "The URL of a synced execution client on the network")
+	outDir := flag.String("out", "rocketpool/deployments", "The directory to write <network>.json to")
+	flag.Parse()
+
+	// Get the chain profile
+	profile, err := config.GetChainProfile(*network)
+	if err != nil {
+		fmt.Printf("Error getting chain profile: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Connect to the execution client and make sure it's on the right network
+	client, err := ethclient.Dial(*rpcUrl)
+	if err != nil {
+		fmt.Printf("Error connecting to %s: %s\n", *rpcUrl, err.Error())
+		os.Exit(1)
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		fmt.Printf("Error getting chain ID: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if chainID.Uint64() != profile.ChainID {
+		fmt.Printf("The client is on chain %d but %s is chain %d\n", chainID.Uint64(), profile.Name, profile.ChainID)
+		os.Exit(1)
+	}
+
+	// Read the contracts from RocketStorage
+	rp, err := rocketpool.NewRocketPool(client, profile.RocketStorageAddress)
+	if err != nil {
+		fmt.Printf("Error creating contract manager: %s\n", err.Error())
+		os.Exit(1)
+	}
+	deployment, err := rocketpool.ExportDeployment(rp, profile.Name, profile.ChainID, rocketpool.BindingContractNames, nil)
+	if err != nil {
+		fmt.Printf("Error exporting deployment: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Write the manifest
+	data, err := json.MarshalIndent(deployment, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding deployment: %s\n", err.Error())
+		os.Exit(1)
+	}
+	path := filepath.Join(*outDir, profile.Name+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		fmt.Printf("Error writing %s: %s\n", path, err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("Exported %d contracts to %s\n", len(deployment.Contracts), path)
+
+}
@@ -2,6 +2,8 @@ package rocketpool
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -103,3 +105,20 @@ type ExecutionClient interface {
 	// no sync currently running, it returns nil.
 	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
 }
+
+// Returned by wrapped clients when the client they wrap doesn't provide an optional method (e.g. FeeHistory)
+var ErrMethodNotSupported = errors.New("the execution client does not support this method")
+
+// An execution client that can serve eth_feeHistory, such as ethclient.Client
+type FeeHistoryClient interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// Get the fee history from a client, if it supports it
+func getFeeHistory(ctx context.Context, client ExecutionClient, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	historyClient, ok := client.(FeeHistoryClient)
+	if !ok {
+		return nil, fmt.Errorf("error getting fee history: %w", ErrMethodNotSupported)
+	}
+	return historyClient.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
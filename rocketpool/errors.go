@@ -0,0 +1,183 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Error kinds returned by pre-flight checks and contract call wrappers
+// Use errors.Is to check for these; the original error message is preserved
+var (
+	ErrNotRegistered       = errors.New("not registered")
+	ErrSettingDisabled     = errors.New("setting is disabled")
+	ErrOutsideWindow       = errors.New("outside of the allowed time window")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrConsensusNotReached = errors.New("consensus has not been reached")
+	ErrMethodNotAllowed    = errors.New("method is not allowed for this signer")
+	ErrStateNotAvailable   = errors.New("historical state is not available on this node")
+	ErrCredentialsMismatch = errors.New("withdrawal credentials do not match the minipool")
+	ErrNotDeployed         = errors.New("contract is not deployed on this network")
+	ErrNotGuardian         = errors.New("signer is not the guardian")
+)
+
+// Revert reason patterns for each error kind, matched against the whole lowercase revert reason
+// Each pattern is anchored to a contract's revert string (or a family of them, like the "... is currently disabled"
+// setting checks) so unrelated reverts that happen to share a word aren't misclassified
+// These are only matched against the revert reason of a reverted call, never against other error messages
+var revertReasonKinds = []struct {
+	kind     error
+	patterns []*regexp.Regexp
+}{
+	{ErrNotRegistered, []*regexp.Regexp{
+		regexp.MustCompile(`^invalid (trusted )?node$`),           // RocketBase.onlyRegisteredNode / onlyTrustedNode
+		regexp.MustCompile(`^invalid minipool owner$`),            // RocketBase.onlyMinipoolOwner
+		regexp.MustCompile(`^this node is not a trusted member$`), // RocketDAONodeTrustedActions
+		regexp.MustCompile(`^node is not registered$`),
+	}},
+	{ErrSettingDisabled, []*regexp.Regexp{
+		regexp.MustCompile(`^[a-z0-9 -]+ (is|are) currently disabled$`), // e.g. "Node deposits are currently disabled"
+	}},
+	{ErrOutsideWindow, []*regexp.Regexp{
+		regexp.MustCompile(`^[a-z ]+ window has not (yet )?passed$`), // e.g. "Refute window has not yet passed"
+		regexp.MustCompile(`^not enough time has passed`),
+		regexp.MustCompile(`^the withdrawal cooldown period has not passed$`), // RocketNodeStaking.withdrawRPL
+		regexp.MustCompile(`^wait period not satisfied$`),                     // RocketMinipoolBondReducer
+	}},
+	{ErrInsufficientBalance, []*regexp.Regexp{
+		regexp.MustCompile(`^erc20: (transfer amount exceeds (balance|allowance)|insufficient allowance|burn amount exceeds balance)$`),
+		regexp.MustCompile(`^insufficient (eth|reth|rpl) balance`), // e.g. RocketTokenRETH.burn
+		regexp.MustCompile(`^withdrawal amount exceeds node's staked rpl balance$`),
+		regexp.MustCompile(`^node's staked rpl balance after withdrawal is less than required balance$`),
+	}},
+	{ErrConsensusNotReached, []*regexp.Regexp{
+		regexp.MustCompile(`^consensus has not been reached$`), // RocketNetworkBalances / RocketNetworkPrices execute
+		regexp.MustCompile(`^proposal has not succeeded`),      // RocketDAOProposal.execute
+	}},
+}
+
+// Error messages (lowercase) returned by each client when it has pruned the state of the requested block
+// Messages that nodes also return for other reasons, like "header not found" for blocks they haven't seen yet, are left out
+var stateNotAvailableFragments = []string{
+	"missing trie node",                        // Geth (hash-based state)
+	"required historical state unavailable",    // Geth, when tracing
+	"world state unavailable",                  // Besu
+	"distance to target block exceeds maximum", // Reth
+}
+
+// Geth (path-based state) names the missing state root, e.g. "historical state 0x1234... is not available"
+var historicalStateNotAvailablePattern = regexp.MustCompile(`historical state (0x)?[0-9a-f]+ is not available`)
+
+// An error tagged with one of the error kinds
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// Tag an error with an error kind, keeping its message
+func WrapError(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// Create a new error of the given kind with a formatted message
+func NewError(kind error, format string, args ...interface{}) error {
+	return WrapError(kind, fmt.Errorf(format, args...))
+}
+
+// Tag an error with ErrStateNotAvailable if the node couldn't serve it because it doesn't have the requested block's state
+// (e.g. a historical query against a non-archive node)
+func WrapStateNotAvailableError(err error) error {
+	if err == nil || errors.Is(err, ErrStateNotAvailable) {
+		return err
+	}
+	message := strings.ToLower(err.Error())
+	for _, fragment := range stateNotAvailableFragments {
+		if strings.Contains(message, fragment) {
+			return WrapError(ErrStateNotAvailable, err)
+		}
+	}
+	if historicalStateNotAvailablePattern.MatchString(message) {
+		return WrapError(ErrStateNotAvailable, err)
+	}
+	return err
+}
+
+// Tag a contract error with the kind matching its revert reason, if it was caused by a revert and the reason matches a
+// known kind
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if wrapped := WrapStateNotAvailableError(err); wrapped != err {
+		return wrapped
+	}
+	reason, isRevert := getRevertReason(err)
+	if !isRevert {
+		return err
+	}
+	return classifyRevert(err, reason)
+}
+
+// Tag the error of a reverted call with the kind matching its revert reason, if it matches a known kind
+func classifyRevert(err error, reason string) error {
+	for _, revertReasonKind := range revertReasonKinds {
+		if errors.Is(err, revertReasonKind.kind) {
+			return err
+		}
+	}
+	kind := getRevertReasonKind(reason)
+	if kind == nil {
+		return err
+	}
+	return WrapError(kind, err)
+}
+
+// Get the error kind matching a revert reason, or nil if it doesn't match any
+func getRevertReasonKind(reason string) error {
+	reason = strings.ToLower(strings.TrimSpace(reason))
+	for _, revertReasonKind := range revertReasonKinds {
+		for _, pattern := range revertReasonKind.patterns {
+			if pattern.MatchString(reason) {
+				return revertReasonKind.kind
+			}
+		}
+	}
+	return nil
+}
+
+// Get the revert reason of a contract error, if it was caused by a revert
+// The reason is decoded from the revert data when the client returns it, and taken from the client's "execution
+// reverted" message otherwise
+func getRevertReason(err error) (string, bool) {
+	var simErr *SimulationError
+	if errors.As(err, &simErr) {
+		return simErr.Reason, simErr.Reason != ""
+	}
+	if data, hasData := getRevertData(err); hasData && len(data) > 0 {
+		if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+			return reason, true
+		}
+		if isPrintable(data) {
+			return string(data), true
+		}
+		return "", false
+	}
+	reason := getRevertMessage(err)
+	return reason, reason != ""
+}
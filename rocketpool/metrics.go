@@ -0,0 +1,255 @@
+package rocketpool
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/contracts"
+)
+
+// The kind of contract interaction reported to Metrics
+type ContractCallKind string
+
+const (
+	ContractCallKind_Call        ContractCallKind = "call"
+	ContractCallKind_EstimateGas ContractCallKind = "estimateGas"
+	ContractCallKind_Transact    ContractCallKind = "transact"
+)
+
+// Receives operational metrics from the client, its contracts and multicallers
+// The arguments map directly onto Prometheus counters and histograms: count each observation, record its duration in a
+// histogram, and count the observations with a non-nil error; implementations must be safe for concurrent use
+type Metrics interface {
+	// Called after each request to the execution client; method is the JSON-RPC method name (e.g. eth_call)
+	ObserveRPC(method string, duration time.Duration, err error)
+
+	// Called after each call, gas estimate or transaction made through a Contract; method is the contract method name
+	ObserveContractCall(kind ContractCallKind, method string, duration time.Duration, err error)
+
+	// Called after each multicall is executed, with the number of calls in the batch
+	ObserveMulticall(callCount int, duration time.Duration, err error)
+}
+
+// Get the metrics a client reports to, or nil if it isn't instrumented
+func GetMetrics(client ExecutionClient) Metrics {
+	if cached, ok := client.(*callCacheClient); ok {
+		client = cached.ExecutionClient
+	}
+	if instrumented, ok := client.(*metricsClient); ok {
+		return instrumented.metrics
+	}
+	return nil
+}
+
+// Report metrics for every request made through this RocketPool, its contracts and multicallers created from its client
+// The client is wrapped and cached contract bindings are dropped so they're recreated with it; this should be called
+// before the RocketPool is shared between goroutines. Passing nil removes the instrumentation.
+func (rp *RocketPool) SetMetrics(metrics Metrics) error {
+	// Instrument the client under the call cache, so cache hits aren't reported as requests
+	client := rp.Client
+	cached, isCached := client.(*callCacheClient)
+	if isCached {
+		client = cached.ExecutionClient
+	}
+	if instrumented, ok := client.(*metricsClient); ok {
+		client = instrumented.ExecutionClient
+	}
+	if metrics != nil {
+		client = &metricsClient{
+			ExecutionClient: client,
+			metrics:         metrics,
+		}
+	}
+	if isCached {
+		client = &callCacheClient{
+			ExecutionClient: client,
+			cache:           cached.cache,
+		}
+	}
+	return rp.setClient(client)
+}
+
+// Replace the client, rebinding RocketStorage to it and dropping the cached contract bindings that use the old one
+func (rp *RocketPool) setClient(client ExecutionClient) error {
+	// Rebind RocketStorage to the new client
+	rocketStorageAddress := *rp.RocketStorageContract.Address
+	rocketStorage, err := contracts.NewRocketStorage(rocketStorageAddress, client)
+	if err != nil {
+		return err
+	}
+	rp.Client = client
+	rp.RocketStorage = rocketStorage
+	rp.RocketStorageContract = &Contract{
+		Contract: bind.NewBoundContract(rocketStorageAddress, *rp.RocketStorageContract.ABI, client, client, client),
+		Address:  &rocketStorageAddress,
+		ABI:      rp.RocketStorageContract.ABI,
+		Client:   client,
+		Name:     rp.RocketStorageContract.Name,
+	}
+
+	// Drop the bindings that use the old client
+	rp.contractsLock.Lock()
+	rp.contracts = make(map[string]cachedContract)
+	rp.contractsLock.Unlock()
+	return nil
+}
+
+// Report a contract interaction to the client's metrics, if it has any
+func observeContractCall(client ExecutionClient, kind ContractCallKind, method string, start time.Time, err error) {
+	if metrics := GetMetrics(client); metrics != nil {
+		metrics.ObserveContractCall(kind, method, time.Since(start), err)
+	}
+}
+
+// An execution client that reports the latency and result of every request
+type metricsClient struct {
+	ExecutionClient
+	metrics Metrics
+}
+
+func (c *metricsClient) observe(method string, start time.Time, err error) {
+	c.metrics.ObserveRPC(method, time.Since(start), err)
+}
+
+func (c *metricsClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	code, err := c.ExecutionClient.CodeAt(ctx, contract, blockNumber)
+	c.observe("eth_getCode", start, err)
+	return code, err
+}
+
+func (c *metricsClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	result, err := c.ExecutionClient.CallContract(ctx, call, blockNumber)
+	c.observe("eth_call", start, err)
+	return result, err
+}
+
+func (c *metricsClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	start := time.Now()
+	header, err := c.ExecutionClient.HeaderByHash(ctx, hash)
+	c.observe("eth_getBlockByHash", start, err)
+	return header, err
+}
+
+func (c *metricsClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	start := time.Now()
+	header, err := c.ExecutionClient.HeaderByNumber(ctx, number)
+	c.observe("eth_getBlockByNumber", start, err)
+	return header, err
+}
+
+func (c *metricsClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	start := time.Now()
+	code, err := c.ExecutionClient.PendingCodeAt(ctx, account)
+	c.observe("eth_getCode", start, err)
+	return code, err
+}
+
+func (c *metricsClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	start := time.Now()
+	nonce, err := c.ExecutionClient.PendingNonceAt(ctx, account)
+	c.observe("eth_getTransactionCount", start, err)
+	return nonce, err
+}
+
+func (c *metricsClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	start := time.Now()
+	price, err := c.ExecutionClient.SuggestGasPrice(ctx)
+	c.observe("eth_gasPrice", start, err)
+	return price, err
+}
+
+func (c *metricsClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	start := time.Now()
+	tip, err := c.ExecutionClient.SuggestGasTipCap(ctx)
+	c.observe("eth_maxPriorityFeePerGas", start, err)
+	return tip, err
+}
+
+func (c *metricsClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	start := time.Now()
+	gas, err := c.ExecutionClient.EstimateGas(ctx, call)
+	c.observe("eth_estimateGas", start, err)
+	return gas, err
+}
+
+func (c *metricsClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	start := time.Now()
+	err := c.ExecutionClient.SendTransaction(ctx, tx)
+	c.observe("eth_sendRawTransaction", start, err)
+	return err
+}
+
+func (c *metricsClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	start := time.Now()
+	logs, err := c.ExecutionClient.FilterLogs(ctx, query)
+	c.observe("eth_getLogs", start, err)
+	return logs, err
+}
+
+func (c *metricsClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	start := time.Now()
+	sub, err := c.ExecutionClient.SubscribeFilterLogs(ctx, query, ch)
+	c.observe("eth_subscribe", start, err)
+	return sub, err
+}
+
+func (c *metricsClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	start := time.Now()
+	receipt, err := c.ExecutionClient.TransactionReceipt(ctx, txHash)
+	c.observe("eth_getTransactionReceipt", start, err)
+	return receipt, err
+}
+
+func (c *metricsClient) BlockNumber(ctx context.Context) (uint64, error) {
+	start := time.Now()
+	blockNumber, err := c.ExecutionClient.BlockNumber(ctx)
+	c.observe("eth_blockNumber", start, err)
+	return blockNumber, err
+}
+
+func (c *metricsClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	start := time.Now()
+	balance, err := c.ExecutionClient.BalanceAt(ctx, account, blockNumber)
+	c.observe("eth_getBalance", start, err)
+	return balance, err
+}
+
+func (c *metricsClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	start := time.Now()
+	tx, isPending, err := c.ExecutionClient.TransactionByHash(ctx, hash)
+	c.observe("eth_getTransactionByHash", start, err)
+	return tx, isPending, err
+}
+
+func (c *metricsClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	start := time.Now()
+	nonce, err := c.ExecutionClient.NonceAt(ctx, account, blockNumber)
+	c.observe("eth_getTransactionCount", start, err)
+	return nonce, err
+}
+
+func (c *metricsClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	start := time.Now()
+	progress, err := c.ExecutionClient.SyncProgress(ctx)
+	c.observe("eth_syncing", start, err)
+	return progress, err
+}
+
+func (c *metricsClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	start := time.Now()
+	history, err := getFeeHistory(ctx, c.ExecutionClient, blockCount, lastBlock, rewardPercentiles)
+	c.observe("eth_feeHistory", start, err)
+	return history, err
+}
+
+// Make sure the instrumented client still satisfies the client interfaces
+var _ ExecutionClient = (*metricsClient)(nil)
+var _ FeeHistoryClient = (*metricsClient)(nil)
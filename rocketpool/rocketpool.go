@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/rocketpool-go/config"
 	"github.com/rocket-pool/rocketpool-go/contracts"
 )
 
@@ -38,6 +39,8 @@ type RocketPool struct {
 	RocketStorage         *contracts.RocketStorage
 	RocketStorageContract *Contract
 	VersionManager        *VersionManager
+	ChainProfile          *config.ChainProfile // Set if the contract manager was created from a chain profile
+	deployment            *Deployment          // Set for offline contract managers
 	addresses             map[string]cachedAddress
 	abis                  map[string]cachedABI
 	contracts             map[string]cachedContract
@@ -65,6 +68,7 @@ func NewRocketPool(client ExecutionClient, rocketStorageAddress common.Address)
 		Address:  &rocketStorageAddress,
 		ABI:      &rsAbi,
 		Client:   client,
+		Name:     "rocketStorage",
 	}
 
 	// Create and return
@@ -82,9 +86,28 @@ func NewRocketPool(client ExecutionClient, rocketStorageAddress common.Address)
 
 }
 
+// Create new contract manager for a network described by a chain profile, such as config.Mainnet
+// The profile's utility contract addresses are kept on the manager for the helpers that need them
+func NewRocketPoolFromProfile(client ExecutionClient, profile config.ChainProfile) (*RocketPool, error) {
+	if profile.RocketStorageAddress == (common.Address{}) {
+		return nil, fmt.Errorf("chain profile %s has no RocketStorage address", profile.Name)
+	}
+	rp, err := NewRocketPool(client, profile.RocketStorageAddress)
+	if err != nil {
+		return nil, err
+	}
+	rp.ChainProfile = &profile
+	return rp, nil
+}
+
 // Load Rocket Pool contract addresses
 func (rp *RocketPool) GetAddress(contractName string, opts *bind.CallOpts) (*common.Address, error) {
 
+	// Use the deployment manifest if offline
+	if rp.deployment != nil {
+		return rp.deployment.getAddress(contractName)
+	}
+
 	// Check for cached address
 	if opts == nil {
 		if cached, ok := rp.getCachedAddress(contractName); ok {
@@ -146,6 +169,11 @@ func (rp *RocketPool) GetAddresses(opts *bind.CallOpts, contractNames ...string)
 // Load Rocket Pool contract ABIs
 func (rp *RocketPool) GetABI(contractName string, opts *bind.CallOpts) (*abi.ABI, error) {
 
+	// Use the deployment manifest if offline
+	if rp.deployment != nil {
+		return rp.deployment.getABI(contractName)
+	}
+
 	// Check for cached ABI
 	if opts == nil {
 		if cached, ok := rp.getCachedABI(contractName); ok {
@@ -251,6 +279,7 @@ func (rp *RocketPool) GetContract(contractName string, opts *bind.CallOpts) (*Co
 		Address:  address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     contractName,
 	}
 
 	// Cache contract
@@ -306,6 +335,7 @@ func (rp *RocketPool) MakeContract(contractName string, address common.Address,
 		Address:  &address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     contractName,
 	}, nil
 
 }
@@ -363,3 +393,65 @@ func (rp *RocketPool) deleteCachedContract(contractName string) {
 	defer rp.contractsLock.Unlock()
 	delete(rp.contracts, contractName)
 }
+
+// Remove a contract's cached address, ABI and binding so the next lookup loads it from RocketStorage again
+func (rp *RocketPool) InvalidateContract(contractName string) {
+	rp.deleteCachedAddress(contractName)
+	rp.deleteCachedABI(contractName)
+	rp.deleteCachedContract(contractName)
+}
+
+// Remove every cached contract address, ABI and binding
+func (rp *RocketPool) InvalidateAllContracts() {
+	rp.addressesLock.Lock()
+	rp.addresses = make(map[string]cachedAddress)
+	rp.addressesLock.Unlock()
+	rp.abisLock.Lock()
+	rp.abis = make(map[string]cachedABI)
+	rp.abisLock.Unlock()
+	rp.contractsLock.Lock()
+	rp.contracts = make(map[string]cachedContract)
+	rp.contractsLock.Unlock()
+}
+
+// Get the addresses of every contract with a cached address or binding
+func (rp *RocketPool) getCachedContractAddresses() map[string]common.Address {
+	addresses := map[string]common.Address{}
+	rp.addressesLock.RLock()
+	for name, cached := range rp.addresses {
+		addresses[name] = *cached.address
+	}
+	rp.addressesLock.RUnlock()
+	rp.contractsLock.RLock()
+	for name, cached := range rp.contracts {
+		addresses[name] = *cached.contract.Address
+	}
+	rp.contractsLock.RUnlock()
+	return addresses
+}
+
+// Get the names of every contract with a cached address, ABI or binding
+func (rp *RocketPool) getCachedContractNames() []string {
+	names := map[string]bool{}
+	rp.addressesLock.RLock()
+	for name := range rp.addresses {
+		names[name] = true
+	}
+	rp.addressesLock.RUnlock()
+	rp.abisLock.RLock()
+	for name := range rp.abis {
+		names[name] = true
+	}
+	rp.abisLock.RUnlock()
+	rp.contractsLock.RLock()
+	for name := range rp.contracts {
+		names[name] = true
+	}
+	rp.contractsLock.RUnlock()
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+package rocketpool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A signer restricted to a whitelist of contract methods, for handing limited automation keys to bots
+// Every transaction is checked against the whitelist when it's signed, so anything built on these bindings
+// (including batches and the transaction manager) is covered; transactions that aren't allowed fail with ErrMethodNotAllowed
+type SessionKey struct {
+	opts *bind.TransactOpts
+
+	// Allowed method selectors by contract address, and on any address
+	allowed        map[common.Address]map[[4]byte]string
+	allowedAnyAddr map[[4]byte]string
+	lock           sync.RWMutex
+}
+
+// Create a session key that wraps the signer of the given transact options and allows nothing until methods are whitelisted
+func NewSessionKey(opts *bind.TransactOpts) (*SessionKey, error) {
+	if opts == nil || opts.Signer == nil {
+		return nil, fmt.Errorf("session key requires transact options with a signer")
+	}
+	return &SessionKey{
+		opts:           opts,
+		allowed:        map[common.Address]map[[4]byte]string{},
+		allowedAnyAddr: map[[4]byte]string{},
+	}, nil
+}
+
+// Allow methods on a contract
+func (k *SessionKey) Allow(contract *Contract, methods ...string) error {
+	selectors, err := getMethodSelectors(contract.ABI, methods)
+	if err != nil {
+		return err
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	contractSelectors, exists := k.allowed[*contract.Address]
+	if !exists {
+		contractSelectors = map[[4]byte]string{}
+		k.allowed[*contract.Address] = contractSelectors
+	}
+	for selector, name := range selectors {
+		contractSelectors[selector] = name
+	}
+	return nil
+}
+
+// Allow methods on a network contract by name (e.g. "rocketMerkleDistributorMainnet")
+// Note that the contract address is resolved once; the whitelist isn't updated if the contract is upgraded
+func (k *SessionKey) AllowNetworkContract(rp *RocketPool, contractName string, methods ...string) error {
+	contract, err := rp.GetContract(contractName, nil)
+	if err != nil {
+		return err
+	}
+	return k.Allow(contract, methods...)
+}
+
+// Allow methods on any contract implementing the given ABI, for per-node contracts such as minipools and fee distributors
+// The key can then call these methods on any address, so only use this for methods that are safe to call on untrusted contracts
+func (k *SessionKey) AllowOnAnyAddress(contractAbi *abi.ABI, methods ...string) error {
+	selectors, err := getMethodSelectors(contractAbi, methods)
+	if err != nil {
+		return err
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	for selector, name := range selectors {
+		k.allowedAnyAddr[selector] = name
+	}
+	return nil
+}
+
+// Get transact options that sign with the wrapped key, restricted to the whitelisted methods
+// The returned options are a copy, so gas and value settings can be changed per transaction
+func (k *SessionKey) GetTransactOpts() *bind.TransactOpts {
+	opts := *k.opts
+	signer := k.opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if err := k.CheckTransaction(tx); err != nil {
+			return nil, err
+		}
+		return signer(address, tx)
+	}
+	return &opts
+}
+
+// Check whether a transaction is allowed by the whitelist
+func (k *SessionKey) CheckTransaction(tx *types.Transaction) error {
+	if tx.To() == nil {
+		return NewError(ErrMethodNotAllowed, "contract deployments are not allowed for this signer")
+	}
+	data := tx.Data()
+	if len(data) < 4 {
+		return NewError(ErrMethodNotAllowed, "plain transfers to %s are not allowed for this signer", tx.To().Hex())
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	if _, exists := k.allowed[*tx.To()][selector]; exists {
+		return nil
+	}
+	if _, exists := k.allowedAnyAddr[selector]; exists {
+		return nil
+	}
+	return NewError(ErrMethodNotAllowed, "method 0x%x on %s is not allowed for this signer", selector, tx.To().Hex())
+}
+
+// Get the selectors of a set of methods on an ABI
+func getMethodSelectors(contractAbi *abi.ABI, methods []string) (map[[4]byte]string, error) {
+	if contractAbi == nil {
+		return nil, fmt.Errorf("contract ABI is not set")
+	}
+	selectors := map[[4]byte]string{}
+	for _, name := range methods {
+		method, exists := contractAbi.Methods[name]
+		if !exists {
+			return nil, fmt.Errorf("method %s does not exist on the contract ABI", name)
+		}
+		var selector [4]byte
+		copy(selector[:], method.ID)
+		selectors[selector] = name
+	}
+	return selectors, nil
+}
@@ -0,0 +1,183 @@
+package rocketpool
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Selectors of the built-in Solidity revert types
+var (
+	revertErrorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	revertPanicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// Descriptions of the Solidity panic codes
+var panicCodeDescriptions = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// A transaction that reverted while being simulated for gas estimation, with its decoded revert reason
+// The error kinds in errors.go can be checked on it with errors.Is
+type SimulationError struct {
+	Method    string        `json:"method"`    // Empty for transfers
+	Reason    string        `json:"reason"`    // The revert string, custom error or panic description; empty if the revert had no reason
+	ErrorName string        `json:"errorName"` // The name of the custom error, if the revert was one from the contract ABI
+	ErrorArgs []interface{} `json:"errorArgs"` // The arguments of the custom error
+	Data      []byte        `json:"data"`      // The raw revert data, if the client returned it
+	Err       error         `json:"-"`         // The original client error
+}
+
+func (e *SimulationError) Error() string {
+	if e.Reason == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+func (e *SimulationError) Unwrap() error {
+	return e.Err
+}
+func (e *SimulationError) Is(target error) bool {
+	if e.Reason == "" {
+		return false
+	}
+	kind := getRevertReasonKind(e.Reason)
+	return kind != nil && kind == target
+}
+
+// Convert a gas estimation error to a SimulationError if it was caused by a revert
+// Other errors (e.g. connection failures) are normalized and returned as they are
+func (c *Contract) newSimulationError(method string, err error) error {
+	normalized := c.normalizeErrorMessage(err)
+	data, hasData := getRevertData(err)
+	if !hasData && !strings.Contains(strings.ToLower(err.Error()), "revert") {
+		return normalized
+	}
+
+	simErr := &SimulationError{
+		Method: method,
+		Data:   data,
+		Err:    normalized,
+	}
+	if hasData && len(data) > 0 {
+		simErr.Reason, simErr.ErrorName, simErr.ErrorArgs = c.decodeRevertData(data)
+	} else {
+		simErr.Reason = getRevertMessage(err)
+	}
+	return simErr
+}
+
+// Decode revert data into a reason, using the contract ABI for custom errors
+func (c *Contract) decodeRevertData(data []byte) (string, string, []interface{}) {
+	if len(data) < 4 {
+		return fmt.Sprintf("invalid revert data %s", hexutil.Encode(data)), "", nil
+	}
+	selector := data[:4]
+
+	// Revert strings
+	if bytes.Equal(selector, revertErrorSelector) {
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return fmt.Sprintf("invalid revert string %s", hexutil.Encode(data)), "", nil
+		}
+		return reason, "", nil
+	}
+
+	// Panics
+	if bytes.Equal(selector, revertPanicSelector) && len(data) == 36 {
+		code := new(big.Int).SetBytes(data[4:]).Uint64()
+		description, exists := panicCodeDescriptions[code]
+		if !exists {
+			description = "unknown panic"
+		}
+		return fmt.Sprintf("panic 0x%02x (%s)", code, description), "", nil
+	}
+
+	// Custom errors
+	if c.ABI != nil {
+		for _, abiError := range c.ABI.Errors {
+			if !bytes.Equal(selector, abiError.ID[:4]) {
+				continue
+			}
+			unpacked, err := abiError.Unpack(data)
+			if err != nil {
+				break
+			}
+			args, _ := unpacked.([]interface{})
+			argStrings := make([]string, len(args))
+			for i, arg := range args {
+				argStrings[i] = fmt.Sprint(arg)
+			}
+			return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(argStrings, ", ")), abiError.Name, args
+		}
+	}
+
+	// Some clients return the revert string itself rather than its encoding
+	if isPrintable(data) {
+		return string(data), "", nil
+	}
+	return fmt.Sprintf("unknown custom error %s", hexutil.Encode(selector)), "", nil
+}
+
+// Check if revert data is a plain ASCII string
+func isPrintable(data []byte) bool {
+	for _, b := range data {
+		if b < 0x20 || b > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the raw revert data from a client error, if it has any
+func getRevertData(err error) ([]byte, bool) {
+	// Geth-style errors carry the data separately
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if dataString, ok := dataErr.ErrorData().(string); ok {
+			data, decodeErr := hexutil.Decode(dataString)
+			if decodeErr == nil {
+				return data, true
+			}
+		}
+	}
+
+	// Nethermind puts it in the message
+	reg := regexp.MustCompile(NethermindRevertRegex)
+	matches := reg.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return nil, false
+	}
+	data, decodeErr := hex.DecodeString(matches[reg.SubexpIndex("message")])
+	if decodeErr != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Get the revert reason from a client error message, for clients that don't return the revert data
+func getRevertMessage(err error) string {
+	message := err.Error()
+	index := strings.Index(strings.ToLower(message), "execution reverted: ")
+	if index == -1 {
+		return ""
+	}
+	return message[index+len("execution reverted: "):]
+}
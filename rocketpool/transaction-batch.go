@@ -0,0 +1,120 @@
+package rocketpool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A transaction in a batch, made of a binding's gas estimator and action (e.g. EstimateStakeRPLGas and StakeRPL)
+type BatchTransaction struct {
+	Name     string
+	Estimate func(opts *bind.TransactOpts) (GasInfo, error)
+	Submit   func(opts *bind.TransactOpts) (common.Hash, error)
+	Describe func(opts *bind.TransactOpts) (TransactionDescription, error) // Nil if the binding can't describe the transaction
+}
+
+// Get a copy of the transaction that runs a pre-flight check before it's estimated or submitted
+// Builders that offer validation use this when their validate flag is set, so mistakes are caught before any gas is spent
+func (tx BatchTransaction) WithValidation(validate func(opts *bind.TransactOpts) error) BatchTransaction {
+	estimate, submit := tx.Estimate, tx.Submit
+	tx.Estimate = func(opts *bind.TransactOpts) (GasInfo, error) {
+		if err := validate(opts); err != nil {
+			return GasInfo{}, err
+		}
+		return estimate(opts)
+	}
+	tx.Submit = func(opts *bind.TransactOpts) (common.Hash, error) {
+		if err := validate(opts); err != nil {
+			return common.Hash{}, err
+		}
+		return submit(opts)
+	}
+	return tx
+}
+
+// The result of simulating or submitting a transaction in a batch
+type BatchTransactionResult struct {
+	Name    string      `json:"name"`
+	GasInfo GasInfo     `json:"gasInfo"`
+	Hash    common.Hash `json:"hash"`
+	Error   error       `json:"-"`
+}
+
+// A sequence of transactions to simulate and submit together
+type TxBatch struct {
+	Transactions []BatchTransaction
+}
+
+// Create a new, empty transaction batch
+func NewTxBatch() *TxBatch {
+	return &TxBatch{
+		Transactions: []BatchTransaction{},
+	}
+}
+
+// Add a transaction to the batch from a binding's gas estimator, action and describer (e.g.
+// rocketpool.DescribeContractCall); describe may be nil, but the batch can't be described then
+func (b *TxBatch) Add(name string, estimate func(opts *bind.TransactOpts) (GasInfo, error), submit func(opts *bind.TransactOpts) (common.Hash, error), describe func(opts *bind.TransactOpts) (TransactionDescription, error)) {
+	b.AddTransaction(BatchTransaction{
+		Name:     name,
+		Estimate: estimate,
+		Submit:   submit,
+		Describe: describe,
+	})
+}
+
+// Add a transaction built by a binding (e.g. StakeRPLTransaction) to the batch, keeping its description
+func (b *TxBatch) AddTransaction(tx BatchTransaction) {
+	b.Transactions = append(b.Transactions, tx)
+}
+
+// Simulate each transaction in the batch against the current chain state, and get the cumulative gas required
+// Note that each transaction is simulated independently, so transactions that depend on the effects of earlier
+// transactions in the batch (e.g. a stake following an approval) may fail simulation until those have been mined
+func (b *TxBatch) Simulate(opts *bind.TransactOpts) ([]BatchTransactionResult, GasInfo, error) {
+	results := make([]BatchTransactionResult, len(b.Transactions))
+	total := GasInfo{}
+	var batchErr error
+	for i, tx := range b.Transactions {
+		results[i].Name = tx.Name
+		gasInfo, err := tx.Estimate(opts)
+		if err != nil {
+			results[i].Error = err
+			if batchErr == nil {
+				batchErr = fmt.Errorf("error simulating batch transaction %d (%s): %w", i, tx.Name, err)
+			}
+			continue
+		}
+		results[i].GasInfo = gasInfo
+		total.EstGasLimit += gasInfo.EstGasLimit
+		total.SafeGasLimit += gasInfo.SafeGasLimit
+	}
+	return results, total, batchErr
+}
+
+// Submit each transaction in the batch in order through a transaction manager, which assigns sequential nonces
+// If stopOnError is set, the remaining transactions are skipped after the first failure and their results carry
+// an error indicating so
+func (b *TxBatch) Submit(manager *TransactionManager, opts *bind.TransactOpts, stopOnError bool) ([]BatchTransactionResult, error) {
+	results := make([]BatchTransactionResult, len(b.Transactions))
+	var batchErr error
+	for i, tx := range b.Transactions {
+		results[i].Name = tx.Name
+		if batchErr != nil && stopOnError {
+			results[i].Error = fmt.Errorf("skipped due to an earlier failure in the batch")
+			continue
+		}
+		hash, err := manager.Submit(opts, tx.Submit)
+		if err != nil {
+			results[i].Error = err
+			if batchErr == nil {
+				batchErr = fmt.Errorf("error submitting batch transaction %d (%s): %w", i, tx.Name, err)
+			}
+			continue
+		}
+		results[i].Hash = hash
+	}
+	return results, batchErr
+}
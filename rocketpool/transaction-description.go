@@ -0,0 +1,162 @@
+package rocketpool
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// A decoded argument of a contract call
+type TransactionArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// What a transaction will do, for display in wallets and audit logs
+type TransactionDescription struct {
+	ContractName    string                `json:"contractName"` // Empty if the contract isn't a named network contract
+	ContractAddress common.Address        `json:"contractAddress"`
+	Method          string                `json:"method"` // Empty for plain ETH transfers
+	Arguments       []TransactionArgument `json:"arguments"`
+	Value           *big.Int              `json:"value"` // The ETH sent with the transaction, in wei
+	Summary         string                `json:"summary"`
+}
+
+func (d TransactionDescription) String() string {
+	return d.Summary
+}
+
+// Describe a call to one of the contract's methods with the given arguments, without sending anything
+// An empty method describes a plain ETH transfer
+func (c *Contract) DescribeTransaction(method string, params ...interface{}) (TransactionDescription, error) {
+	description := TransactionDescription{
+		ContractName: c.Name,
+		Method:       method,
+		Arguments:    []TransactionArgument{},
+		Value:        big.NewInt(0),
+	}
+	if c.Address != nil {
+		description.ContractAddress = *c.Address
+	}
+	contractName := c.Name
+	if contractName == "" {
+		contractName = description.ContractAddress.Hex()
+	}
+	if method == "" {
+		description.Summary = fmt.Sprintf("transfer ETH to %s", contractName)
+		return description, nil
+	}
+
+	// Name the arguments from the ABI
+	abiMethod, exists := c.ABI.Methods[method]
+	if !exists {
+		return TransactionDescription{}, fmt.Errorf("method %s does not exist on contract %s", method, contractName)
+	}
+	if len(params) != len(abiMethod.Inputs) {
+		return TransactionDescription{}, fmt.Errorf("method %s of contract %s takes %d arguments but %d were provided", method, contractName, len(abiMethod.Inputs), len(params))
+	}
+	args := make([]string, len(params))
+	for i, input := range abiMethod.Inputs {
+		arg := TransactionArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatArgument(params[i]),
+		}
+		description.Arguments = append(description.Arguments, arg)
+		if arg.Name == "" {
+			args[i] = arg.Value
+		} else {
+			args[i] = fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+		}
+	}
+	description.Summary = fmt.Sprintf("call %s(%s) on %s", method, strings.Join(args, ", "), contractName)
+	return description, nil
+}
+
+// Get a describer for a call to a network contract's method, for use as a transaction's Describe function
+// The contract is looked up when the transaction is described, so only its address is read from the network
+func DescribeContractCall(rp *RocketPool, contractName string, method string, params ...interface{}) func(opts *bind.TransactOpts) (TransactionDescription, error) {
+	return func(opts *bind.TransactOpts) (TransactionDescription, error) {
+		contract, err := rp.GetContract(contractName, nil)
+		if err != nil {
+			return TransactionDescription{}, err
+		}
+		return contract.DescribeCall(method, params...)(opts)
+	}
+}
+
+// Get a describer for a call to one of the contract's methods, for use as a transaction's Describe function
+// The value sent with the call is taken from the options it's described with
+func (c *Contract) DescribeCall(method string, params ...interface{}) func(opts *bind.TransactOpts) (TransactionDescription, error) {
+	return func(opts *bind.TransactOpts) (TransactionDescription, error) {
+		description, err := c.DescribeTransaction(method, params...)
+		if err != nil {
+			return TransactionDescription{}, err
+		}
+		if opts != nil && opts.Value != nil {
+			description.Value = new(big.Int).Set(opts.Value)
+		}
+		return description, nil
+	}
+}
+
+// Describe what the transaction will do when sent with the given options, without estimating or sending it
+// Nothing is simulated and no pre-flight checks are run; the transaction's binding must provide a Describe function
+func (tx BatchTransaction) GetDescription(opts *bind.TransactOpts) (TransactionDescription, error) {
+	if tx.Describe == nil {
+		return TransactionDescription{}, fmt.Errorf("error describing transaction %s: it doesn't provide a description", tx.Name)
+	}
+	if opts == nil {
+		opts = &bind.TransactOpts{}
+	}
+	description, err := tx.Describe(opts)
+	if err != nil {
+		return TransactionDescription{}, fmt.Errorf("error describing transaction %s: %w", tx.Name, err)
+	}
+	description.Summary = fmt.Sprintf("%s: %s", tx.Name, description.Summary)
+	if description.Value.Sign() > 0 {
+		description.Summary += fmt.Sprintf(", sending %s wei", description.Value.String())
+	}
+	return description, nil
+}
+
+// Describe each transaction in the batch
+func (b *TxBatch) Describe(opts *bind.TransactOpts) ([]TransactionDescription, error) {
+	descriptions := make([]TransactionDescription, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		description, err := tx.GetDescription(opts)
+		if err != nil {
+			return nil, err
+		}
+		descriptions[i] = description
+	}
+	return descriptions, nil
+}
+
+// Format a contract call argument for display
+func formatArgument(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		if v == nil {
+			return "0"
+		}
+		return v.String()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
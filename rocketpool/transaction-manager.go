@@ -0,0 +1,288 @@
+package rocketpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transaction manager settings
+const (
+	DefaultStuckTransactionTimeout time.Duration = 5 * time.Minute
+	DefaultReplacementFeeBump      uint64        = 10 // percent
+	MinReplacementFeeBump          uint64        = 10 // percent; the minimum replacement bump accepted by geth's txpool
+)
+
+// A transaction submitted through a TransactionManager
+type ManagedTransaction struct {
+	Hash        common.Hash `json:"hash"`
+	Nonce       uint64      `json:"nonce"`
+	GasTipCap   *big.Int    `json:"gasTipCap"`
+	GasFeeCap   *big.Int    `json:"gasFeeCap"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+}
+
+// Manages the lifecycle of transactions sent from a single account:
+// local nonce assignment, EIP-1559 fee suggestion, stuck transaction detection and same-nonce replacement
+type TransactionManager struct {
+	Client       ExecutionClient
+	From         common.Address
+	Signer       bind.SignerFn
+	StuckTimeout time.Duration
+
+	nextNonce *uint64
+	pending   map[uint64]*ManagedTransaction
+	lock      sync.Mutex
+}
+
+// Create new transaction manager for the account in opts
+func NewTransactionManager(client ExecutionClient, opts *bind.TransactOpts) (*TransactionManager, error) {
+	if opts == nil || opts.Signer == nil {
+		return nil, errors.New("transaction manager requires transactor options with a signer")
+	}
+	return &TransactionManager{
+		Client:       client,
+		From:         opts.From,
+		Signer:       opts.Signer,
+		StuckTimeout: DefaultStuckTransactionTimeout,
+		pending:      map[uint64]*ManagedTransaction{},
+	}, nil
+}
+
+// Get the suggested EIP-1559 max fee and priority fee for a new transaction
+// The max fee is set to twice the latest base fee plus the priority fee
+func (m *TransactionManager) SuggestFees() (*big.Int, *big.Int, error) {
+	gasTipCap, err := m.Client.SuggestGasTipCap(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+	}
+	header, err := m.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, nil, errors.New("latest block does not have a base fee; EIP-1559 is not active")
+	}
+	gasFeeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	gasFeeCap.Add(gasFeeCap, gasTipCap)
+	return gasFeeCap, gasTipCap, nil
+}
+
+// Submit a transaction through the manager
+// send is any binding action (e.g. a closure around node.Deposit); it is called with a copy of opts that has the
+// nonce and fees populated (fees are only populated if not already set)
+func (m *TransactionManager) Submit(opts *bind.TransactOpts, send func(opts *bind.TransactOpts) (common.Hash, error)) (common.Hash, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Get the nonce
+	nonce, err := m.getNextNonce()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Populate the transactor
+	txOpts := *opts
+	txOpts.From = m.From
+	if txOpts.Signer == nil {
+		txOpts.Signer = m.Signer
+	}
+	txOpts.Nonce = new(big.Int).SetUint64(nonce)
+	if txOpts.GasFeeCap == nil || txOpts.GasTipCap == nil {
+		gasFeeCap, gasTipCap, err := m.SuggestFees()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if txOpts.GasFeeCap == nil {
+			txOpts.GasFeeCap = gasFeeCap
+		}
+		if txOpts.GasTipCap == nil {
+			txOpts.GasTipCap = gasTipCap
+		}
+	}
+
+	// Send the transaction
+	hash, err := send(&txOpts)
+	if err != nil {
+		// The nonce may or may not have been consumed, so resync it from the network on the next submission
+		m.nextNonce = nil
+		return common.Hash{}, err
+	}
+
+	// Track it
+	m.pending[nonce] = &ManagedTransaction{
+		Hash:        hash,
+		Nonce:       nonce,
+		GasTipCap:   txOpts.GasTipCap,
+		GasFeeCap:   txOpts.GasFeeCap,
+		SubmittedAt: time.Now(),
+	}
+	next := nonce + 1
+	m.nextNonce = &next
+	return hash, nil
+}
+
+// Get the transactions that have been submitted but not yet mined
+func (m *TransactionManager) GetPendingTransactions() ([]ManagedTransaction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.prune(); err != nil {
+		return nil, err
+	}
+	pending := make([]ManagedTransaction, 0, len(m.pending))
+	for _, tx := range m.pending {
+		pending = append(pending, *tx)
+	}
+	return pending, nil
+}
+
+// Get the pending transactions that have been waiting longer than the stuck timeout
+func (m *TransactionManager) GetStuckTransactions() ([]ManagedTransaction, error) {
+	pending, err := m.GetPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	stuck := []ManagedTransaction{}
+	for _, tx := range pending {
+		if time.Since(tx.SubmittedAt) > m.StuckTimeout {
+			stuck = append(stuck, tx)
+		}
+	}
+	return stuck, nil
+}
+
+// Speed up a pending transaction by resubmitting it with the same nonce and fees bumped by feeBumpPercent
+func (m *TransactionManager) SpeedUp(hash common.Hash, feeBumpPercent uint64) (common.Hash, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx, err := m.getPendingTransaction(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return m.replace(tx, tx.To(), tx.Value(), tx.Data(), tx.Gas(), tx.AccessList(), feeBumpPercent)
+}
+
+// Cancel a pending transaction by replacing it with an empty transfer to the sender with the same nonce and fees
+// bumped by feeBumpPercent
+func (m *TransactionManager) Cancel(hash common.Hash, feeBumpPercent uint64) (common.Hash, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx, err := m.getPendingTransaction(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return m.replace(tx, &m.From, big.NewInt(0), []byte{}, 21000, types.AccessList{}, feeBumpPercent)
+}
+
+// Get the next nonce to use, syncing it from the network if required
+func (m *TransactionManager) getNextNonce() (uint64, error) {
+	networkNonce, err := m.Client.PendingNonceAt(context.Background(), m.From)
+	if err != nil {
+		return 0, fmt.Errorf("error getting pending nonce for %s: %w", m.From.Hex(), err)
+	}
+	if m.nextNonce == nil || networkNonce > *m.nextNonce {
+		m.nextNonce = &networkNonce
+	}
+	return *m.nextNonce, nil
+}
+
+// Remove tracked transactions whose nonces have been consumed on chain
+func (m *TransactionManager) prune() error {
+	confirmedNonce, err := m.Client.NonceAt(context.Background(), m.From, nil)
+	if err != nil {
+		return fmt.Errorf("error getting confirmed nonce for %s: %w", m.From.Hex(), err)
+	}
+	for nonce := range m.pending {
+		if nonce < confirmedNonce {
+			delete(m.pending, nonce)
+		}
+	}
+	return nil
+}
+
+// Get a tracked transaction that is still pending
+func (m *TransactionManager) getPendingTransaction(hash common.Hash) (*types.Transaction, error) {
+	tx, isPending, err := m.Client.TransactionByHash(context.Background(), hash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+	}
+	if !isPending {
+		return nil, fmt.Errorf("transaction %s has already been mined", hash.Hex())
+	}
+	managed, exists := m.pending[tx.Nonce()]
+	if !exists || managed.Hash != hash {
+		return nil, fmt.Errorf("transaction %s is not tracked by this transaction manager", hash.Hex())
+	}
+	return tx, nil
+}
+
+// Replace a pending transaction with a new one using the same nonce
+func (m *TransactionManager) replace(original *types.Transaction, to *common.Address, value *big.Int, data []byte, gasLimit uint64, accessList types.AccessList, feeBumpPercent uint64) (common.Hash, error) {
+	if feeBumpPercent < MinReplacementFeeBump {
+		return common.Hash{}, fmt.Errorf("fee bump of %d%% is below the minimum replacement bump of %d%%", feeBumpPercent, MinReplacementFeeBump)
+	}
+
+	// Bump the original fees, and use the current suggestion if it's higher
+	gasTipCap := bumpFee(original.GasTipCap(), feeBumpPercent)
+	gasFeeCap := bumpFee(original.GasFeeCap(), feeBumpPercent)
+	suggestedFeeCap, suggestedTipCap, err := m.SuggestFees()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if suggestedTipCap.Cmp(gasTipCap) > 0 {
+		gasTipCap = suggestedTipCap
+	}
+	if suggestedFeeCap.Cmp(gasFeeCap) > 0 {
+		gasFeeCap = suggestedFeeCap
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasFeeCap = gasTipCap
+	}
+
+	// Build, sign and send the replacement
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    original.ChainId(),
+		Nonce:      original.Nonce(),
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        gasLimit,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: accessList,
+	})
+	signedTx, err := m.Signer(m.From, tx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+	if err := m.Client.SendTransaction(context.Background(), signedTx); err != nil {
+		return common.Hash{}, fmt.Errorf("error sending replacement transaction: %w", err)
+	}
+
+	// Track the replacement
+	m.pending[original.Nonce()] = &ManagedTransaction{
+		Hash:        signedTx.Hash(),
+		Nonce:       original.Nonce(),
+		GasTipCap:   gasTipCap,
+		GasFeeCap:   gasFeeCap,
+		SubmittedAt: time.Now(),
+	}
+	return signedTx.Hash(), nil
+}
+
+// Increase a fee by a percentage, rounding up
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
@@ -0,0 +1,91 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A transaction built by a binding but not signed or sent, for signing with an external signer (e.g. a hardware wallet
+// or a multisig) and submitting separately
+// Gas, fees and nonce are left to the signer
+type UnsignedTransaction struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *big.Int       `json:"value"`
+	Data  hexutil.Bytes  `json:"data"`
+}
+
+// Run a binding's action with transactor options that capture its transaction instead of signing and sending it
+// The sender, value and context are taken from the given options; placeholder gas, fee and nonce values are set so no
+// requests are made to build the transaction. Bindings that read chain state before transacting still need a client.
+// The action must send exactly one transaction.
+func CaptureTransaction(opts *bind.TransactOpts, submit func(opts *bind.TransactOpts) (common.Hash, error)) (UnsignedTransaction, error) {
+	if opts == nil {
+		return UnsignedTransaction{}, errors.New("transact options are required to capture a transaction")
+	}
+	var captured []*types.Transaction
+	from := opts.From
+	captureOpts := &bind.TransactOpts{
+		From:     from,
+		Value:    opts.Value,
+		Context:  opts.Context,
+		Nonce:    big.NewInt(0),
+		GasPrice: big.NewInt(0),
+		GasLimit: MaxGasLimit,
+		NoSend:   true,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			captured = append(captured, tx)
+			return tx, nil
+		},
+	}
+	if _, err := submit(captureOpts); err != nil {
+		return UnsignedTransaction{}, err
+	}
+	switch len(captured) {
+	case 0:
+		return UnsignedTransaction{}, errors.New("the action did not create a transaction")
+	case 1:
+	default:
+		return UnsignedTransaction{}, fmt.Errorf("the action created %d transactions but only one can be captured", len(captured))
+	}
+
+	tx := captured[0]
+	if tx.To() == nil {
+		return UnsignedTransaction{}, errors.New("contract creation transactions can't be captured")
+	}
+	return UnsignedTransaction{
+		From:  from,
+		To:    *tx.To(),
+		Value: new(big.Int).Set(tx.Value()),
+		Data:  tx.Data(),
+	}, nil
+}
+
+// Get the target, value and calldata of the transaction without signing or sending it
+// The options' value is sent unless the transaction sets its own (e.g. a deposit sending its bond)
+func (t BatchTransaction) ToUnsignedTx(opts *bind.TransactOpts) (UnsignedTransaction, error) {
+	tx, err := CaptureTransaction(opts, t.Submit)
+	if err != nil {
+		return UnsignedTransaction{}, fmt.Errorf("error building unsigned transaction for %s: %w", t.Name, err)
+	}
+	return tx, nil
+}
+
+// Get the target, value and calldata of each transaction in the batch without signing or sending them
+func (b *TxBatch) ToUnsignedTxs(opts *bind.TransactOpts) ([]UnsignedTransaction, error) {
+	txs := make([]UnsignedTransaction, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		var err error
+		txs[i], err = tx.ToUnsignedTx(opts)
+		if err != nil {
+			return nil, fmt.Errorf("error building batch transaction %d: %w", i, err)
+		}
+	}
+	return txs, nil
+}
@@ -0,0 +1,254 @@
+package rocketpool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Upgrade watcher settings
+const (
+	DefaultUpgradePollInterval time.Duration = time.Minute
+	upgradeContractName        string        = "rocketDAONodeTrustedUpgrade"
+)
+
+// Events emitted by the upgrade contract when a network contract or ABI changes
+var upgradeEventNames = []string{"ContractUpgraded", "ContractAdded", "ABIUpgraded", "ABIAdded"}
+
+// The event name reported when a cached contract's address in RocketStorage changed without an upgrade event,
+// e.g. when it was set directly by a guardian or a bootstrap proposal
+const AddressChangedEventName string = "AddressChanged"
+
+// A network contract or ABI change seen by an upgrade watcher
+type UpgradeEvent struct {
+	Event        string         `json:"event"`
+	ContractName string         `json:"contractName"` // Empty if the name isn't known to the watcher
+	NameHash     common.Hash    `json:"nameHash"`
+	OldAddress   common.Address `json:"oldAddress"`
+	NewAddress   common.Address `json:"newAddress"`
+	BlockNumber  uint64         `json:"blockNumber"`
+	TxHash       common.Hash    `json:"txHash"`
+}
+
+// Watches the upgrade contract for network contract and ABI changes, and RocketStorage for changes to the addresses of
+// cached contracts, and invalidates the cached bindings of the affected contracts so long-lived processes pick up new
+// deployments without restarting
+// Bindings that were already retrieved (e.g. contracts held by a state container) aren't updated; use OnUpgrade to rebuild them
+type UpgradeWatcher struct {
+	PollInterval time.Duration
+	OnUpgrade    func(event UpgradeEvent) // Called after the cache has been invalidated
+	OnError      func(err error)          // Called when a poll fails in Start; the poll is retried on the next interval
+
+	rp         *RocketPool
+	knownNames map[common.Hash]string
+	lastBlock  uint64
+	lock       sync.Mutex
+}
+
+// Create a new upgrade watcher starting from the latest block
+// Names of contracts that aren't cached yet can be provided so their events are reported by name
+func NewUpgradeWatcher(rp *RocketPool, contractNames ...string) (*UpgradeWatcher, error) {
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	w := &UpgradeWatcher{
+		PollInterval: DefaultUpgradePollInterval,
+		rp:           rp,
+		knownNames:   map[common.Hash]string{},
+		lastBlock:    latestBlock,
+	}
+	w.AddContractNames(upgradeContractName)
+	w.AddContractNames(contractNames...)
+	return w, nil
+}
+
+// Add contract names so their events are reported by name
+func (w *UpgradeWatcher) AddContractNames(contractNames ...string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, name := range contractNames {
+		w.knownNames[crypto.Keccak256Hash([]byte(name))] = name
+	}
+}
+
+// Get the last block that has been checked
+func (w *UpgradeWatcher) GetLastBlock() uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.lastBlock
+}
+
+// Check for upgrades since the last checked block, invalidating the cache for each affected contract
+func (w *UpgradeWatcher) Check() ([]UpgradeEvent, error) {
+	events, err := w.check()
+	if err != nil {
+		return nil, err
+	}
+	if w.OnUpgrade != nil {
+		for _, event := range events {
+			w.OnUpgrade(event)
+		}
+	}
+	return events, nil
+}
+
+// Get the upgrade events since the last checked block and invalidate the cache
+func (w *UpgradeWatcher) check() ([]UpgradeEvent, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	latestBlock, err := w.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	if latestBlock <= w.lastBlock {
+		return []UpgradeEvent{}, nil
+	}
+
+	// An upgrade of the upgrade contract is emitted by the old one, which is then invalidated like any other contract
+	rocketDAONodeTrustedUpgrade, err := w.rp.GetContract(upgradeContractName, nil)
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := []common.Hash{}
+	for _, name := range upgradeEventNames {
+		if event, exists := rocketDAONodeTrustedUpgrade.ABI.Events[name]; exists {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+	logs, err := w.rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: []common.Address{*rocketDAONodeTrustedUpgrade.Address},
+		Topics:    [][]common.Hash{eventIDs},
+		FromBlock: big.NewInt(0).SetUint64(w.lastBlock + 1),
+		ToBlock:   big.NewInt(0).SetUint64(latestBlock),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting upgrade logs: %w", err)
+	}
+
+	// Map the name hashes back to names, including every contract that's been cached since the last check
+	for _, name := range w.rp.getCachedContractNames() {
+		w.knownNames[crypto.Keccak256Hash([]byte(name))] = name
+	}
+
+	// Decode the events
+	events := []UpgradeEvent{}
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		event, err := rocketDAONodeTrustedUpgrade.ABI.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		upgradeEvent := UpgradeEvent{
+			Event:       event.Name,
+			NameHash:    log.Topics[1],
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+		}
+		upgradeEvent.ContractName = w.knownNames[upgradeEvent.NameHash]
+		topicIndex := 1
+		for _, input := range event.Inputs {
+			if !input.Indexed {
+				continue
+			}
+			if topicIndex >= len(log.Topics) {
+				break
+			}
+			switch input.Name {
+			case "oldAddress":
+				upgradeEvent.OldAddress = common.BytesToAddress(log.Topics[topicIndex].Bytes())
+			case "newAddress":
+				upgradeEvent.NewAddress = common.BytesToAddress(log.Topics[topicIndex].Bytes())
+			}
+			topicIndex++
+		}
+		events = append(events, upgradeEvent)
+	}
+
+	// Catch address changes that didn't go through the upgrade contract
+	addressEvents, err := w.checkAddresses(latestBlock, events)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, addressEvents...)
+
+	// Invalidate the cache; if a contract couldn't be identified, drop everything to be safe
+	for _, event := range events {
+		if event.ContractName == "" {
+			w.rp.InvalidateAllContracts()
+			break
+		}
+		w.rp.InvalidateContract(event.ContractName)
+	}
+	w.lastBlock = latestBlock
+	return events, nil
+}
+
+// Compare the addresses of cached contracts with their RocketStorage address keys at the given block, reporting the
+// contracts that changed and weren't covered by an upgrade event
+func (w *UpgradeWatcher) checkAddresses(blockNumber uint64, upgradeEvents []UpgradeEvent) ([]UpgradeEvent, error) {
+	if w.rp.deployment != nil {
+		return []UpgradeEvent{}, nil
+	}
+	reported := map[string]bool{}
+	for _, event := range upgradeEvents {
+		reported[event.ContractName] = true
+	}
+
+	opts := &bind.CallOpts{BlockNumber: big.NewInt(0).SetUint64(blockNumber)}
+	events := []UpgradeEvent{}
+	for name, cachedAddress := range w.rp.getCachedContractAddresses() {
+		if reported[name] {
+			continue
+		}
+		nameHash := crypto.Keccak256Hash([]byte(name))
+		address, err := w.rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.address"), []byte(name)))
+		if err != nil {
+			return nil, fmt.Errorf("error getting contract %s address: %w", name, err)
+		}
+		if address == cachedAddress {
+			continue
+		}
+		events = append(events, UpgradeEvent{
+			Event:        AddressChangedEventName,
+			ContractName: name,
+			NameHash:     nameHash,
+			OldAddress:   cachedAddress,
+			NewAddress:   address,
+			BlockNumber:  blockNumber,
+		})
+	}
+	sort.Slice(events, func(i int, j int) bool { return events[i].ContractName < events[j].ContractName })
+	return events, nil
+}
+
+// Poll for upgrades until the context is cancelled
+func (w *UpgradeWatcher) Start(ctx context.Context) {
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = DefaultUpgradePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.Check(); err != nil && w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+	}
+}
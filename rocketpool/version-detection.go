@@ -0,0 +1,194 @@
+package rocketpool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-version"
+)
+
+// Create a new contract manager, detect the deployed protocol version and select the matching binding set
+func NewRocketPoolWithVersionDetection(client ExecutionClient, rocketStorageAddress common.Address) (*RocketPool, error) {
+	rp, err := NewRocketPool(client, rocketStorageAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rp.VersionManager.DetectVersion(nil); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Get the deployed protocol version
+// Uses the version recorded in RocketStorage by the upgrade contracts when present, and the versions of the contracts
+// that changed in each upgrade otherwise
+func (m *VersionManager) GetProtocolVersion(opts *bind.CallOpts) (*version.Version, error) {
+	// Check the recorded version first
+	recordedVersion, err := m.rp.RocketStorage.GetString(opts, crypto.Keccak256Hash([]byte("protocol.version")))
+	if err != nil {
+		return nil, fmt.Errorf("error getting recorded protocol version: %w", err)
+	}
+	if recordedVersion != "" {
+		if parsed, err := version.NewSemver(recordedVersion); err == nil {
+			return parsed, nil
+		}
+	}
+
+	// Check for v1.4 (Saturn)
+	megapoolFactoryVersion, err := m.getOptionalContractVersion("rocketMegapoolFactory", opts)
+	if err != nil {
+		return nil, err
+	}
+	if megapoolFactoryVersion > 0 {
+		return version.NewSemver("1.4.0")
+	}
+
+	// Check for v1.3.1 (Houston Hotfix)
+	networkVotingVersion, err := m.getOptionalContractVersion("rocketNetworkVoting", opts)
+	if err != nil {
+		return nil, err
+	}
+	if networkVotingVersion > 1 {
+		return version.NewSemver("1.3.1")
+	}
+
+	// Check for v1.3 (Houston)
+	nodeMgrVersion, err := m.getOptionalContractVersion("rocketNodeManager", opts)
+	if err != nil {
+		return nil, err
+	}
+	if nodeMgrVersion > 3 {
+		return version.NewSemver("1.3.0")
+	}
+
+	// Check for v1.2 (Atlas)
+	nodeStakingVersion, err := m.getOptionalContractVersion("rocketNodeStaking", opts)
+	if err != nil {
+		return nil, err
+	}
+	if nodeStakingVersion > 3 {
+		return version.NewSemver("1.2.0")
+	}
+
+	// Check for v1.1 (Redstone)
+	if nodeMgrVersion > 1 {
+		return version.NewSemver("1.1.0")
+	}
+
+	// v1.0 (Classic)
+	return version.NewSemver("1.0.0")
+}
+
+// Detect the deployed protocol version and select the matching binding set as the current one
+func (m *VersionManager) DetectVersion(opts *bind.CallOpts) (*version.Version, error) {
+	protocolVersion, err := m.GetProtocolVersion(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error detecting protocol version: %w", err)
+	}
+	wrapper := m.GetVersionWrapper(protocolVersion)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.protocolVersion = protocolVersion
+	m.current = wrapper
+	return protocolVersion, nil
+}
+
+// Get the binding set for a protocol version; versions newer than every legacy wrapper use the latest bindings
+func (m *VersionManager) GetVersionWrapper(protocolVersion *version.Version) LegacyVersionWrapper {
+	segments := protocolVersion.Segments()
+	switch {
+	case segments[0] == 1 && segments[1] == 0:
+		return m.V1_0_0
+	case segments[0] == 1 && segments[1] == 1 && protocolVersion.Prerelease() != "":
+		return m.V1_1_0_RC1
+	case segments[0] == 1 && segments[1] == 1:
+		return m.V1_1_0
+	case segments[0] == 1 && segments[1] == 2:
+		return m.V1_2_0
+	default:
+		return &latestVersionWrapper{
+			rp:        m.rp,
+			rpVersion: protocolVersion,
+		}
+	}
+}
+
+// Get the protocol version found by DetectVersion, or nil if it hasn't been run
+func (m *VersionManager) GetDetectedVersion() *version.Version {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.protocolVersion
+}
+
+// Get a contract from the binding set selected by DetectVersion, or the latest bindings if it hasn't been run
+// The bindings for contracts that were replaced in an upgrade (node deposits, network prices and the minipool manager,
+// factory and queue) load their contracts through this, so they use the ABIs of the detected version
+func (m *VersionManager) GetContract(contractName string, opts *bind.CallOpts) (*Contract, error) {
+	m.lock.Lock()
+	current := m.current
+	m.lock.Unlock()
+	if current == nil {
+		return m.rp.GetContract(contractName, opts)
+	}
+	return current.GetContract(contractName, opts)
+}
+
+// Get a contract with the given address from the binding set selected by DetectVersion
+func (m *VersionManager) GetContractWithAddress(contractName string, address common.Address) (*Contract, error) {
+	m.lock.Lock()
+	current := m.current
+	m.lock.Unlock()
+	if current == nil {
+		return m.rp.MakeContract(contractName, address, nil)
+	}
+	return current.GetContractWithAddress(contractName, address)
+}
+
+// Get the version of a contract, or 0 if it isn't deployed
+func (m *VersionManager) getOptionalContractVersion(contractName string, opts *bind.CallOpts) (uint8, error) {
+	address, err := m.rp.GetAddress(contractName, opts)
+	if err != nil {
+		return 0, err
+	}
+	if *address == (common.Address{}) {
+		return 0, nil
+	}
+	contractVersion, err := GetContractVersion(m.rp, *address, opts)
+	if err != nil {
+		return 0, fmt.Errorf("error checking %s version: %w", contractName, err)
+	}
+	return contractVersion, nil
+}
+
+// The binding set for the latest deployment, which uses the contracts and ABIs registered in RocketStorage
+type latestVersionWrapper struct {
+	rp        *RocketPool
+	rpVersion *version.Version
+}
+
+// Get the version for this manager
+func (m *latestVersionWrapper) GetVersion() *version.Version {
+	return m.rpVersion
+}
+
+// No contracts have been replaced in the latest deployment
+func (m *latestVersionWrapper) GetVersionedContractName(contractName string) (string, bool) {
+	return "", false
+}
+
+// The latest ABIs are loaded from RocketStorage rather than embedded
+func (m *latestVersionWrapper) GetEncodedABI(contractName string) string {
+	return ""
+}
+
+// Get the contract with the provided name
+func (m *latestVersionWrapper) GetContract(contractName string, opts *bind.CallOpts) (*Contract, error) {
+	return m.rp.GetContract(contractName, opts)
+}
+
+func (m *latestVersionWrapper) GetContractWithAddress(contractName string, address common.Address) (*Contract, error) {
+	return m.rp.MakeContract(contractName, address, nil)
+}
@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -25,7 +26,10 @@ type VersionManager struct {
 	V1_1_0     LegacyVersionWrapper
 	V1_2_0     LegacyVersionWrapper
 
-	rp *RocketPool
+	rp              *RocketPool
+	current         LegacyVersionWrapper
+	protocolVersion *version.Version
+	lock            sync.Mutex
 }
 
 func NewVersionManager(rp *RocketPool) *VersionManager {
@@ -80,6 +84,7 @@ func getLegacyContract(rp *RocketPool, contractName string, m LegacyVersionWrapp
 		Address:  &address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     contractName,
 	}
 
 	return contract, nil
@@ -100,6 +105,7 @@ func getLegacyContractWithAddress(rp *RocketPool, contractName string, address c
 		Address:  &address,
 		ABI:      abi,
 		Client:   rp.Client,
+		Name:     contractName,
 	}
 
 	return contract, nil
@@ -0,0 +1,112 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	trustednodedao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+)
+
+// A Protocol DAO setting and the value to bootstrap it to
+// The value must be a *big.Int, bool or common.Address matching the setting's type
+type ProtocolSettingValue struct {
+	Setting psettings.ProtocolDaoSetting `json:"setting"`
+	Value   any                          `json:"value"`
+}
+
+// An Oracle DAO setting and the value to bootstrap it to
+// The value must be a *big.Int, uint64 or bool
+type OracleDaoSettingValue struct {
+	ContractName string `json:"contractName"`
+	Path         string `json:"path"`
+	Value        any    `json:"value"`
+}
+
+// A registered node to bootstrap into the Oracle DAO
+type OracleDaoMember struct {
+	ID      string         `json:"id"`
+	URL     string         `json:"url"`
+	Address common.Address `json:"address"`
+}
+
+// The settings and Oracle DAO members a fresh deployment should be brought to
+type Settings struct {
+	Protocol         []ProtocolSettingValue  `json:"protocol"`
+	OracleDao        []OracleDaoSettingValue `json:"oracleDao"`
+	OracleDaoMembers []OracleDaoMember       `json:"oracleDaoMembers"`
+}
+
+// Get the bootstrap transactions that bring a fresh deployment to the given settings, in the order they should be sent
+// Every transaction must be sent by the guardian while both DAOs are still in bootstrap mode. The Protocol DAO settings
+// go first, then the Oracle DAO settings, and the members last so they're invited under the final member settings; within
+// each group the declared order is kept. Values are type-checked and duplicate settings or members are rejected before
+// any transaction is built.
+func GetBootstrapTransactions(rp *rocketpool.RocketPool, settings Settings) ([]rocketpool.BatchTransaction, error) {
+	txs := make([]rocketpool.BatchTransaction, 0, len(settings.Protocol)+len(settings.OracleDao)+len(settings.OracleDaoMembers))
+
+	// Protocol DAO settings
+	seen := map[string]bool{}
+	for _, value := range settings.Protocol {
+		key := fmt.Sprintf("%s/%s", value.Setting.ContractName, value.Setting.Path)
+		if seen[key] {
+			return nil, fmt.Errorf("Protocol DAO setting %s of %s is set more than once", value.Setting.Path, value.Setting.ContractName)
+		}
+		seen[key] = true
+		tx, err := value.Setting.BootstrapTransaction(rp, value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error creating bootstrap transaction for Protocol DAO setting %s: %w", value.Setting.Path, err)
+		}
+		txs = append(txs, tx)
+	}
+
+	// Oracle DAO settings
+	seen = map[string]bool{}
+	for _, value := range settings.OracleDao {
+		key := fmt.Sprintf("%s/%s", value.ContractName, value.Path)
+		if seen[key] {
+			return nil, fmt.Errorf("Oracle DAO setting %s of %s is set more than once", value.Path, value.ContractName)
+		}
+		seen[key] = true
+		tx, err := tnsettings.BootstrapByPathTransaction(rp, value.ContractName, value.Path, value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error creating bootstrap transaction for Oracle DAO setting %s: %w", value.Path, err)
+		}
+		txs = append(txs, tx)
+	}
+
+	// Oracle DAO members
+	seenMembers := map[common.Address]bool{}
+	seenIds := map[string]bool{}
+	for _, member := range settings.OracleDaoMembers {
+		if seenMembers[member.Address] {
+			return nil, fmt.Errorf("Oracle DAO member %s is added more than once", member.Address.Hex())
+		}
+		if seenIds[member.ID] {
+			return nil, fmt.Errorf("Oracle DAO member ID %s is used more than once", member.ID)
+		}
+		seenMembers[member.Address] = true
+		seenIds[member.ID] = true
+		txs = append(txs, bootstrapMemberTransaction(rp, member))
+	}
+
+	return txs, nil
+}
+
+// Get the transaction info for bootstrapping an Oracle DAO member
+func bootstrapMemberTransaction(rp *rocketpool.RocketPool, member OracleDaoMember) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("bootstrap Oracle DAO member %s", member.ID),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return trustednodedao.EstimateBootstrapMemberGas(rp, member.ID, member.URL, member.Address, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return trustednodedao.BootstrapMember(rp, member.ID, member.URL, member.Address, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrusted", "bootstrapMember", member.ID, member.URL, member.Address),
+	}
+}
@@ -0,0 +1,65 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Get the settings in the snapshot along with their contract names and paths
+func (d *ProtocolDaoSettingsDetails) GetSettingValues() []types.SettingValue {
+	settings := []struct {
+		setting ProtocolDaoSetting
+		value   *big.Int
+	}{
+		{VotePhase1TimeSetting, d.Proposals.VotePhase1Time},
+		{VotePhase2TimeSetting, d.Proposals.VotePhase2Time},
+		{VoteDelayTimeSetting, d.Proposals.VoteDelayTime},
+		{ExecuteTimeSetting, d.Proposals.ExecuteTime},
+		{ProposalBondSetting, d.Proposals.ProposalBond},
+		{ChallengeBondSetting, d.Proposals.ChallengeBond},
+		{ChallengePeriodSetting, d.Proposals.ChallengePeriod},
+		{ProposalQuorumSetting, d.Proposals.Quorum},
+		{ProposalVetoQuorumSetting, d.Proposals.VetoQuorum},
+		{ProposalMaxBlockAgeSetting, d.Proposals.MaxBlockAge},
+		{SecurityMembersQuorumSetting, d.Security.MembersQuorum},
+		{SecurityMembersLeaveTimeSetting, d.Security.MembersLeaveTime},
+		{SecurityProposalVoteTimeSetting, d.Security.ProposalVoteTime},
+		{SecurityProposalExecuteTimeSetting, d.Security.ProposalExecuteTime},
+		{SecurityProposalActionTimeSetting, d.Security.ProposalActionTime},
+		{MaximumStakeForVotingPowerSetting, d.MaximumStakeForVotingPower},
+		{MaximumPenaltyCountSetting, d.MaximumPenaltyCount},
+	}
+	values := make([]types.SettingValue, len(settings))
+	for i, setting := range settings {
+		values[i] = types.SettingValue{
+			ContractName: setting.setting.ContractName,
+			Path:         setting.setting.Path,
+			Value:        setting.value,
+		}
+	}
+	return values
+}
+
+// Get the settings that changed from a to b
+func Diff(a, b *ProtocolDaoSettingsDetails) []types.SettingChange {
+	return types.DiffSettingValues(a.GetSettingValues(), b.GetSettingValues())
+}
+
+// Get the Protocol DAO settings that changed between two blocks
+func GetSettingsChanges(rp *rocketpool.RocketPool, multicallAddress common.Address, fromBlock, toBlock *big.Int) ([]types.SettingChange, error) {
+	oldSettings, err := GetProtocolDaoSettingsDetails(rp, multicallAddress, &bind.CallOpts{BlockNumber: fromBlock})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Protocol DAO settings at block %s: %w", fromBlock.String(), err)
+	}
+	newSettings, err := GetProtocolDaoSettingsDetails(rp, multicallAddress, &bind.CallOpts{BlockNumber: toBlock})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Protocol DAO settings at block %s: %w", toBlock.String(), err)
+	}
+	return Diff(oldSettings, newSettings), nil
+}
@@ -0,0 +1,253 @@
+package protocol
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// A Protocol DAO setting that can be bootstrapped by the guardian or changed by a proposal
+type ProtocolDaoSetting struct {
+	ContractName string                    `json:"contractName"`
+	Path         string                    `json:"path"`
+	Type         types.ProposalSettingType `json:"type"`
+}
+
+// The settings introduced or moved to the Protocol DAO in Houston
+var (
+	VotePhase1TimeSetting              = ProtocolDaoSetting{ProposalsSettingsContractName, VotePhase1TimeSettingPath, types.ProposalSettingType_Uint256}
+	VotePhase2TimeSetting              = ProtocolDaoSetting{ProposalsSettingsContractName, VotePhase2TimeSettingPath, types.ProposalSettingType_Uint256}
+	VoteDelayTimeSetting               = ProtocolDaoSetting{ProposalsSettingsContractName, VoteDelayTimeSettingPath, types.ProposalSettingType_Uint256}
+	ExecuteTimeSetting                 = ProtocolDaoSetting{ProposalsSettingsContractName, ExecuteTimeSettingPath, types.ProposalSettingType_Uint256}
+	ProposalBondSetting                = ProtocolDaoSetting{ProposalsSettingsContractName, ProposalBondSettingPath, types.ProposalSettingType_Uint256}
+	ChallengeBondSetting               = ProtocolDaoSetting{ProposalsSettingsContractName, ChallengeBondSettingPath, types.ProposalSettingType_Uint256}
+	ChallengePeriodSetting             = ProtocolDaoSetting{ProposalsSettingsContractName, ChallengePeriodSettingPath, types.ProposalSettingType_Uint256}
+	ProposalQuorumSetting              = ProtocolDaoSetting{ProposalsSettingsContractName, ProposalQuorumSettingPath, types.ProposalSettingType_Uint256}
+	ProposalVetoQuorumSetting          = ProtocolDaoSetting{ProposalsSettingsContractName, ProposalVetoQuorumSettingPath, types.ProposalSettingType_Uint256}
+	ProposalMaxBlockAgeSetting         = ProtocolDaoSetting{ProposalsSettingsContractName, ProposalMaxBlockAgeSettingPath, types.ProposalSettingType_Uint256}
+	SecurityMembersQuorumSetting       = ProtocolDaoSetting{SecuritySettingsContractName, SecurityMembersQuorumSettingPath, types.ProposalSettingType_Uint256}
+	SecurityMembersLeaveTimeSetting    = ProtocolDaoSetting{SecuritySettingsContractName, SecurityMembersLeaveTimeSettingPath, types.ProposalSettingType_Uint256}
+	SecurityProposalVoteTimeSetting    = ProtocolDaoSetting{SecuritySettingsContractName, SecurityProposalVoteTimeSettingPath, types.ProposalSettingType_Uint256}
+	SecurityProposalExecuteTimeSetting = ProtocolDaoSetting{SecuritySettingsContractName, SecurityProposalExecuteTimeSettingPath, types.ProposalSettingType_Uint256}
+	SecurityProposalActionTimeSetting  = ProtocolDaoSetting{SecuritySettingsContractName, SecurityProposalActionTimeSettingPath, types.ProposalSettingType_Uint256}
+	MaximumStakeForVotingPowerSetting  = ProtocolDaoSetting{NodeSettingsContractName, MaximumStakeForVotingPowerSettingPath, types.ProposalSettingType_Uint256}
+	MaximumPenaltyCountSetting         = ProtocolDaoSetting{MinipoolSettingsContractName, MaximumPenaltyCountSettingPath, types.ProposalSettingType_Uint256}
+)
+
+// Proposal settings
+type ProposalsSettingsDetails struct {
+	VotePhase1Time  *big.Int `json:"votePhase1Time"`
+	VotePhase2Time  *big.Int `json:"votePhase2Time"`
+	VoteDelayTime   *big.Int `json:"voteDelayTime"`
+	ExecuteTime     *big.Int `json:"executeTime"`
+	ProposalBond    *big.Int `json:"proposalBond"`
+	ChallengeBond   *big.Int `json:"challengeBond"`
+	ChallengePeriod *big.Int `json:"challengePeriod"`
+	Quorum          *big.Int `json:"quorum"`
+	VetoQuorum      *big.Int `json:"vetoQuorum"`
+	MaxBlockAge     *big.Int `json:"maxBlockAge"`
+}
+
+// Security council settings
+type SecuritySettingsDetails struct {
+	MembersQuorum       *big.Int `json:"membersQuorum"`
+	MembersLeaveTime    *big.Int `json:"membersLeaveTime"`
+	ProposalVoteTime    *big.Int `json:"proposalVoteTime"`
+	ProposalExecuteTime *big.Int `json:"proposalExecuteTime"`
+	ProposalActionTime  *big.Int `json:"proposalActionTime"`
+}
+
+// The raw values of the Protocol DAO settings
+type ProtocolDaoSettingsDetails struct {
+	Proposals ProposalsSettingsDetails `json:"proposals"`
+	Security  SecuritySettingsDetails  `json:"security"`
+
+	// Houston node and minipool settings
+	MaximumStakeForVotingPower *big.Int `json:"maximumStakeForVotingPower"`
+	MaximumPenaltyCount        *big.Int `json:"maximumPenaltyCount"`
+}
+
+// Get the Protocol DAO settings using a multicaller
+func GetProtocolDaoSettingsDetails(rp *rocketpool.RocketPool, multicallAddress common.Address, opts *bind.CallOpts) (*ProtocolDaoSettingsDetails, error) {
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+	if err != nil {
+		return nil, err
+	}
+	details := &ProtocolDaoSettingsDetails{}
+	if err := AddProtocolDaoSettingsCalls(rp, mc, details, opts); err != nil {
+		return nil, err
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error executing multicall: %w", err)
+	}
+	return details, nil
+}
+
+// Add the calls for the Protocol DAO settings to a multicaller
+func AddProtocolDaoSettingsCalls(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, details *ProtocolDaoSettingsDetails, opts *bind.CallOpts) error {
+	proposalsSettingsContract, err := getProposalsSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+	securitySettingsContract, err := getSecuritySettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+	nodeSettingsContract, err := getNodeSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+	minipoolSettingsContract, err := getMinipoolSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+
+	// Proposals
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VotePhase1Time, "getVotePhase1Time")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VotePhase2Time, "getVotePhase2Time")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VoteDelayTime, "getVoteDelayTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ExecuteTime, "getExecuteTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ProposalBond, "getProposalBond")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ChallengeBond, "getChallengeBond")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ChallengePeriod, "getChallengePeriod")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.Quorum, "getProposalQuorum")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VetoQuorum, "getProposalVetoQuorum")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.MaxBlockAge, "getProposalMaxBlockAge")
+
+	// Security council
+	mc.AddCall(securitySettingsContract, &details.Security.MembersQuorum, "getQuorum")
+	mc.AddCall(securitySettingsContract, &details.Security.MembersLeaveTime, "getLeaveTime")
+	mc.AddCall(securitySettingsContract, &details.Security.ProposalVoteTime, "getVoteTime")
+	mc.AddCall(securitySettingsContract, &details.Security.ProposalExecuteTime, "getExecuteTime")
+	mc.AddCall(securitySettingsContract, &details.Security.ProposalActionTime, "getActionTime")
+
+	// Node and minipool
+	mc.AddCall(nodeSettingsContract, &details.MaximumStakeForVotingPower, "getMaximumStakeForVotingPower")
+	mc.AddCall(minipoolSettingsContract, &details.MaximumPenaltyCount, "getMaximumPenaltyCount")
+	return nil
+}
+
+// Get the transaction info for setting this setting directly while the Protocol DAO is in bootstrap mode
+// The value must be a *big.Int, bool or common.Address matching the setting's type
+func (s ProtocolDaoSetting) BootstrapTransaction(rp *rocketpool.RocketPool, value any) (rocketpool.BatchTransaction, error) {
+	name := fmt.Sprintf("bootstrap %s", s.Path)
+	switch s.Type {
+	case types.ProposalSettingType_Uint256:
+		uintValue, ok := value.(*big.Int)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateBootstrapUintGas(rp, s.ContractName, s.Path, uintValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return protocol.BootstrapUint(rp, s.ContractName, s.Path, uintValue, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketDAOProtocol", "bootstrapSettingUint", s.ContractName, s.Path, uintValue),
+		}, nil
+	case types.ProposalSettingType_Bool:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateBootstrapBoolGas(rp, s.ContractName, s.Path, boolValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return protocol.BootstrapBool(rp, s.ContractName, s.Path, boolValue, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketDAOProtocol", "bootstrapSettingBool", s.ContractName, s.Path, boolValue),
+		}, nil
+	case types.ProposalSettingType_Address:
+		addressValue, ok := value.(common.Address)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateBootstrapAddressGas(rp, s.ContractName, s.Path, addressValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return protocol.BootstrapAddress(rp, s.ContractName, s.Path, addressValue, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketDAOProtocol", "bootstrapSettingAddress", s.ContractName, s.Path, addressValue),
+		}, nil
+	default:
+		return rocketpool.BatchTransaction{}, fmt.Errorf("unknown setting type %d for %s", s.Type, s.Path)
+	}
+}
+
+// Get the transaction info for proposing a new value for this setting, with the message the proposal is raised with
+// The value must be a *big.Int, bool or common.Address matching the setting's type
+func (s ProtocolDaoSetting) ProposeTransaction(rp *rocketpool.RocketPool, message string, value any, blockNumber uint32, treeNodes []types.VotingTreeNode) (rocketpool.BatchTransaction, error) {
+	name := fmt.Sprintf("propose setting %s", s.Path)
+	switch s.Type {
+	case types.ProposalSettingType_Uint256:
+		uintValue, ok := value.(*big.Int)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateProposeSetUintGas(rp, message, s.ContractName, s.Path, uintValue, blockNumber, treeNodes, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				_, hash, err := protocol.ProposeSetUint(rp, message, s.ContractName, s.Path, uintValue, blockNumber, treeNodes, opts)
+				return hash, err
+			},
+			Describe: protocol.DescribeProposal(rp, message, blockNumber, treeNodes, "proposalSettingUint", s.ContractName, s.Path, uintValue),
+		}, nil
+	case types.ProposalSettingType_Bool:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateProposeSetBoolGas(rp, message, s.ContractName, s.Path, boolValue, blockNumber, treeNodes, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				_, hash, err := protocol.ProposeSetBool(rp, message, s.ContractName, s.Path, boolValue, blockNumber, treeNodes, opts)
+				return hash, err
+			},
+			Describe: protocol.DescribeProposal(rp, message, blockNumber, treeNodes, "proposalSettingBool", s.ContractName, s.Path, boolValue),
+		}, nil
+	case types.ProposalSettingType_Address:
+		addressValue, ok := value.(common.Address)
+		if !ok {
+			return rocketpool.BatchTransaction{}, s.typeError(value)
+		}
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return protocol.EstimateProposeSetAddressGas(rp, message, s.ContractName, s.Path, addressValue, blockNumber, treeNodes, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				_, hash, err := protocol.ProposeSetAddress(rp, message, s.ContractName, s.Path, addressValue, blockNumber, treeNodes, opts)
+				return hash, err
+			},
+			Describe: protocol.DescribeProposal(rp, message, blockNumber, treeNodes, "proposalSettingAddress", s.ContractName, s.Path, addressValue),
+		}, nil
+	default:
+		return rocketpool.BatchTransaction{}, fmt.Errorf("unknown setting type %d for %s", s.Type, s.Path)
+	}
+}
+
+// Get the error for a value that doesn't match the setting's type
+func (s ProtocolDaoSetting) typeError(value any) error {
+	return fmt.Errorf("value %v has type %T, which doesn't match the type of %s", value, value, s.Path)
+}
@@ -23,6 +23,7 @@ const (
 	MaximumMinipoolCountSettingPath               string = "minipool.maximum.count"
 	MinipoolUserDistributeWindowStartSettingPath  string = "minipool.user.distribute.window.start"
 	MinipoolUserDistributeWindowLengthSettingPath string = "minipool.user.distribute.window.length"
+	MaximumPenaltyCountSettingPath                string = "minipool.maximum.penalty.count"
 )
 
 // Minipool withdrawable event submissions currently enabled
@@ -153,6 +154,51 @@ func EstimateProposeMinipoolUserDistributeWindowLengthGas(rp *rocketpool.RocketP
 	return protocol.EstimateProposeSetUintGas(rp, fmt.Sprintf("set %s", MinipoolUserDistributeWindowLengthSettingPath), MinipoolSettingsContractName, MinipoolUserDistributeWindowLengthSettingPath, value, blockNumber, treeNodes, opts)
 }
 
+// The maximum number of penalties that can be applied to a minipool
+func GetMaximumPenaltyCount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	minipoolSettingsContract, err := getMinipoolSettingsContract(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := minipoolSettingsContract.Call(opts, value, "getMaximumPenaltyCount"); err != nil {
+		return 0, fmt.Errorf("error getting maximum penalty count: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
+func ProposeMaximumPenaltyCount(rp *rocketpool.RocketPool, value *big.Int, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	return protocol.ProposeSetUint(rp, fmt.Sprintf("set %s", MaximumPenaltyCountSettingPath), MinipoolSettingsContractName, MaximumPenaltyCountSettingPath, value, blockNumber, treeNodes, opts)
+}
+func EstimateProposeMaximumPenaltyCountGas(rp *rocketpool.RocketPool, value *big.Int, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return protocol.EstimateProposeSetUintGas(rp, fmt.Sprintf("set %s", MaximumPenaltyCountSettingPath), MinipoolSettingsContractName, MaximumPenaltyCountSettingPath, value, blockNumber, treeNodes, opts)
+}
+
+// The balance a minipool's validator is launched with
+func GetLaunchBalance(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	minipoolSettingsContract, err := getMinipoolSettingsContract(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := minipoolSettingsContract.Call(opts, value, "getLaunchBalance"); err != nil {
+		return nil, fmt.Errorf("error getting minipool launch balance: %w", err)
+	}
+	return *value, nil
+}
+
+// The amount of ETH deposited to the Beacon Chain when a minipool is created, before it's staked
+func GetPreLaunchValue(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	minipoolSettingsContract, err := getMinipoolSettingsContract(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := minipoolSettingsContract.Call(opts, value, "getPreLaunchValue"); err != nil {
+		return nil, fmt.Errorf("error getting minipool prelaunch value: %w", err)
+	}
+	return *value, nil
+}
+
 // Get contracts
 var minipoolSettingsContractLock sync.Mutex
 
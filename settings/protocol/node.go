@@ -23,6 +23,7 @@ const (
 	VacantMinipoolsEnabledSettingPath           string = "node.vacant.minipools.enabled"
 	MinimumPerMinipoolStakeSettingPath          string = "node.per.minipool.stake.minimum"
 	MaximumPerMinipoolStakeSettingPath          string = "node.per.minipool.stake.maximum"
+	MaximumStakeForVotingPowerSettingPath       string = "node.voting.power.stake.maximum"
 )
 
 // Node registrations currently enabled
@@ -165,6 +166,34 @@ func GetMaximumPerMinipoolStakeRaw(rp *rocketpool.RocketPool, opts *bind.CallOpt
 	return *value, nil
 }
 
+// The maximum RPL stake that counts towards voting power, as a fraction of the node's bonded ETH
+func GetMaximumStakeForVotingPower(rp *rocketpool.RocketPool, opts *bind.CallOpts) (float64, error) {
+	value, err := GetMaximumStakeForVotingPowerRaw(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	return eth.WeiToEth(value), nil
+}
+func ProposeMaximumStakeForVotingPower(rp *rocketpool.RocketPool, value *big.Int, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	return protocol.ProposeSetUint(rp, fmt.Sprintf("set %s", MaximumStakeForVotingPowerSettingPath), NodeSettingsContractName, MaximumStakeForVotingPowerSettingPath, value, blockNumber, treeNodes, opts)
+}
+func EstimateProposeMaximumStakeForVotingPowerGas(rp *rocketpool.RocketPool, value *big.Int, blockNumber uint32, treeNodes []types.VotingTreeNode, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return protocol.EstimateProposeSetUintGas(rp, fmt.Sprintf("set %s", MaximumStakeForVotingPowerSettingPath), NodeSettingsContractName, MaximumStakeForVotingPowerSettingPath, value, blockNumber, treeNodes, opts)
+}
+
+// The maximum RPL stake that counts towards voting power, as a fraction of the node's bonded ETH
+func GetMaximumStakeForVotingPowerRaw(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	nodeSettingsContract, err := getNodeSettingsContract(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := nodeSettingsContract.Call(opts, value, "getMaximumStakeForVotingPower"); err != nil {
+		return nil, fmt.Errorf("error getting maximum RPL stake for voting power: %w", err)
+	}
+	return *value, nil
+}
+
 // Get contracts
 var nodeSettingsContractLock sync.Mutex
 
@@ -0,0 +1,137 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	trustednodedao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Get a setting's value by its path, for settings that don't have a dedicated getter yet
+// The getter is inferred from the type of out, which must be a **big.Int, *bool or *common.Address
+func GetSettingByPath(rp *rocketpool.RocketPool, contractName string, settingPath string, out any, opts *bind.CallOpts) error {
+	settingsContract, method, err := getSettingByPathGetter(rp, contractName, settingPath, out, opts)
+	if err != nil {
+		return err
+	}
+	if err := settingsContract.Call(opts, out, method, settingPath); err != nil {
+		return fmt.Errorf("error getting %s setting %s: %w", contractName, settingPath, err)
+	}
+	return nil
+}
+
+// Add a call for a setting's value by its path to a multicaller
+// The getter is inferred from the type of out, which must be a **big.Int, *bool or *common.Address
+func AddGetSettingByPathCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, contractName string, settingPath string, out any, opts *bind.CallOpts) error {
+	settingsContract, method, err := getSettingByPathGetter(rp, contractName, settingPath, out, opts)
+	if err != nil {
+		return err
+	}
+	return mc.AddCall(settingsContract, out, method, settingPath)
+}
+
+// Get the transaction info for setting a setting by its path while the DAO is in bootstrap mode
+// The setter is inferred from the type of value, which must be a *big.Int, uint64 or bool
+func BootstrapByPathTransaction(rp *rocketpool.RocketPool, contractName string, settingPath string, value any) (rocketpool.BatchTransaction, error) {
+	name := fmt.Sprintf("bootstrap %s", settingPath)
+	switch typedValue := value.(type) {
+	case *big.Int, uint64:
+		uintValue := toBigInt(typedValue)
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return trustednodedao.EstimateBootstrapUintGas(rp, contractName, settingPath, uintValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return trustednodedao.BootstrapUint(rp, contractName, settingPath, uintValue, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrusted", "bootstrapSettingUint", contractName, settingPath, uintValue),
+		}, nil
+	case bool:
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return trustednodedao.EstimateBootstrapBoolGas(rp, contractName, settingPath, typedValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				return trustednodedao.BootstrapBool(rp, contractName, settingPath, typedValue, opts)
+			},
+			Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrusted", "bootstrapSettingBool", contractName, settingPath, typedValue),
+		}, nil
+	default:
+		return rocketpool.BatchTransaction{}, fmt.Errorf("value %v for setting %s has unsupported type %T", value, settingPath, value)
+	}
+}
+
+// Get the transaction info for proposing a new value for a setting by its path
+// The setter is inferred from the type of value, which must be a *big.Int, uint64 or bool
+func ProposeByPathTransaction(rp *rocketpool.RocketPool, contractName string, settingPath string, value any) (rocketpool.BatchTransaction, error) {
+	message := fmt.Sprintf("set %s", settingPath)
+	name := fmt.Sprintf("propose %s", message)
+	switch typedValue := value.(type) {
+	case *big.Int, uint64:
+		uintValue := toBigInt(typedValue)
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return trustednodedao.EstimateProposeSetUintGas(rp, message, contractName, settingPath, uintValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				_, hash, err := trustednodedao.ProposeSetUint(rp, message, contractName, settingPath, uintValue, opts)
+				return hash, err
+			},
+			Describe: trustednodedao.DescribeProposal(rp, message, "proposalSettingUint", contractName, settingPath, uintValue),
+		}, nil
+	case bool:
+		return rocketpool.BatchTransaction{
+			Name: name,
+			Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return trustednodedao.EstimateProposeSetBoolGas(rp, message, contractName, settingPath, typedValue, opts)
+			},
+			Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+				_, hash, err := trustednodedao.ProposeSetBool(rp, message, contractName, settingPath, typedValue, opts)
+				return hash, err
+			},
+			Describe: trustednodedao.DescribeProposal(rp, message, "proposalSettingBool", contractName, settingPath, typedValue),
+		}, nil
+	default:
+		return rocketpool.BatchTransaction{}, fmt.Errorf("value %v for setting %s has unsupported type %T", value, settingPath, value)
+	}
+}
+
+// Get the settings contract and getter method for a setting's output type
+func getSettingByPathGetter(rp *rocketpool.RocketPool, contractName string, settingPath string, out any, opts *bind.CallOpts) (*rocketpool.Contract, string, error) {
+	var method string
+	switch out.(type) {
+	case **big.Int:
+		method = "getSettingUint"
+	case *bool:
+		method = "getSettingBool"
+	case *common.Address:
+		method = "getSettingAddress"
+	default:
+		return nil, "", fmt.Errorf("output for setting %s has unsupported type %T", settingPath, out)
+	}
+	settingsContract, err := rp.GetContract(contractName, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return settingsContract, method, nil
+}
+
+// Convert a uint setting value to a big.Int
+func toBigInt(value any) *big.Int {
+	switch typedValue := value.(type) {
+	case *big.Int:
+		return typedValue
+	case uint64:
+		return new(big.Int).SetUint64(typedValue)
+	default:
+		return nil
+	}
+}
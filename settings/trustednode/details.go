@@ -0,0 +1,146 @@
+package trustednode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Member settings
+type MembersSettingsDetails struct {
+	Quorum                 *big.Int `json:"quorum"`
+	RPLBond                *big.Int `json:"rplBond"`
+	MinipoolUnbondedMax    *big.Int `json:"minipoolUnbondedMax"`
+	MinipoolUnbondedMinFee *big.Int `json:"minipoolUnbondedMinFee"`
+	ChallengeCooldown      *big.Int `json:"challengeCooldown"`
+	ChallengeWindow        *big.Int `json:"challengeWindow"`
+	ChallengeCost          *big.Int `json:"challengeCost"`
+}
+
+// Minipool settings
+type MinipoolSettingsDetails struct {
+	ScrubPeriod               *big.Int `json:"scrubPeriod"`
+	PromotionScrubPeriod      *big.Int `json:"promotionScrubPeriod"`
+	ScrubPenaltyEnabled       bool     `json:"scrubPenaltyEnabled"`
+	BondReductionWindowStart  *big.Int `json:"bondReductionWindowStart"`
+	BondReductionWindowLength *big.Int `json:"bondReductionWindowLength"`
+}
+
+// Proposal settings
+type ProposalsSettingsDetails struct {
+	CooldownTime  *big.Int `json:"cooldownTime"`
+	VoteTime      *big.Int `json:"voteTime"`
+	VoteDelayTime *big.Int `json:"voteDelayTime"`
+	ExecuteTime   *big.Int `json:"executeTime"`
+	ActionTime    *big.Int `json:"actionTime"`
+}
+
+// The raw values of the Oracle DAO settings
+type OracleDaoSettingsDetails struct {
+	Members   MembersSettingsDetails   `json:"members"`
+	Minipool  MinipoolSettingsDetails  `json:"minipool"`
+	Proposals ProposalsSettingsDetails `json:"proposals"`
+}
+
+// Get the Oracle DAO settings using a multicaller
+func GetOracleDaoSettingsDetails(rp *rocketpool.RocketPool, multicallAddress common.Address, opts *bind.CallOpts) (*OracleDaoSettingsDetails, error) {
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+	if err != nil {
+		return nil, err
+	}
+	details := &OracleDaoSettingsDetails{}
+	if err := AddOracleDaoSettingsCalls(rp, mc, details, opts); err != nil {
+		return nil, err
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error executing multicall: %w", err)
+	}
+	return details, nil
+}
+
+// Add the calls for the Oracle DAO settings to a multicaller
+func AddOracleDaoSettingsCalls(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, details *OracleDaoSettingsDetails, opts *bind.CallOpts) error {
+	membersSettingsContract, err := getMembersSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+	minipoolSettingsContract, err := getMinipoolSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+	proposalsSettingsContract, err := getProposalsSettingsContract(rp, opts)
+	if err != nil {
+		return err
+	}
+
+	// Members
+	mc.AddCall(membersSettingsContract, &details.Members.Quorum, "getQuorum")
+	mc.AddCall(membersSettingsContract, &details.Members.RPLBond, "getRPLBond")
+	mc.AddCall(membersSettingsContract, &details.Members.MinipoolUnbondedMax, "getMinipoolUnbondedMax")
+	mc.AddCall(membersSettingsContract, &details.Members.MinipoolUnbondedMinFee, "getMinipoolUnbondedMinFee")
+	mc.AddCall(membersSettingsContract, &details.Members.ChallengeCooldown, "getChallengeCooldown")
+	mc.AddCall(membersSettingsContract, &details.Members.ChallengeWindow, "getChallengeWindow")
+	mc.AddCall(membersSettingsContract, &details.Members.ChallengeCost, "getChallengeCost")
+
+	// Minipool
+	mc.AddCall(minipoolSettingsContract, &details.Minipool.ScrubPeriod, "getScrubPeriod")
+	mc.AddCall(minipoolSettingsContract, &details.Minipool.PromotionScrubPeriod, "getPromotionScrubPeriod")
+	mc.AddCall(minipoolSettingsContract, &details.Minipool.ScrubPenaltyEnabled, "getScrubPenaltyEnabled")
+	mc.AddCall(minipoolSettingsContract, &details.Minipool.BondReductionWindowStart, "getBondReductionWindowStart")
+	mc.AddCall(minipoolSettingsContract, &details.Minipool.BondReductionWindowLength, "getBondReductionWindowLength")
+
+	// Proposals
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.CooldownTime, "getCooldownTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VoteTime, "getVoteTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.VoteDelayTime, "getVoteDelayTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ExecuteTime, "getExecuteTime")
+	mc.AddCall(proposalsSettingsContract, &details.Proposals.ActionTime, "getActionTime")
+	return nil
+}
+
+// Get the settings in the snapshot along with their contract names and paths
+func (d *OracleDaoSettingsDetails) GetSettingValues() []types.SettingValue {
+	return []types.SettingValue{
+		{ContractName: MembersSettingsContractName, Path: QuorumSettingPath, Value: d.Members.Quorum},
+		{ContractName: MembersSettingsContractName, Path: RPLBondSettingPath, Value: d.Members.RPLBond},
+		{ContractName: MembersSettingsContractName, Path: MinipoolUnbondedMaxSettingPath, Value: d.Members.MinipoolUnbondedMax},
+		{ContractName: MembersSettingsContractName, Path: MinipoolUnbondedMinFeeSettingPath, Value: d.Members.MinipoolUnbondedMinFee},
+		{ContractName: MembersSettingsContractName, Path: ChallengeCooldownSettingPath, Value: d.Members.ChallengeCooldown},
+		{ContractName: MembersSettingsContractName, Path: ChallengeWindowSettingPath, Value: d.Members.ChallengeWindow},
+		{ContractName: MembersSettingsContractName, Path: ChallengeCostSettingPath, Value: d.Members.ChallengeCost},
+		{ContractName: MinipoolSettingsContractName, Path: ScrubPeriodPath, Value: d.Minipool.ScrubPeriod},
+		{ContractName: MinipoolSettingsContractName, Path: PromotionScrubPeriodPath, Value: d.Minipool.PromotionScrubPeriod},
+		{ContractName: MinipoolSettingsContractName, Path: ScrubPenaltyEnabledPath, Value: d.Minipool.ScrubPenaltyEnabled},
+		{ContractName: MinipoolSettingsContractName, Path: BondReductionWindowStartPath, Value: d.Minipool.BondReductionWindowStart},
+		{ContractName: MinipoolSettingsContractName, Path: BondReductionWindowLengthPath, Value: d.Minipool.BondReductionWindowLength},
+		{ContractName: ProposalsSettingsContractName, Path: CooldownTimeSettingPath, Value: d.Proposals.CooldownTime},
+		{ContractName: ProposalsSettingsContractName, Path: VoteTimeSettingPath, Value: d.Proposals.VoteTime},
+		{ContractName: ProposalsSettingsContractName, Path: VoteDelayTimeSettingPath, Value: d.Proposals.VoteDelayTime},
+		{ContractName: ProposalsSettingsContractName, Path: ExecuteTimeSettingPath, Value: d.Proposals.ExecuteTime},
+		{ContractName: ProposalsSettingsContractName, Path: ActionTimeSettingPath, Value: d.Proposals.ActionTime},
+	}
+}
+
+// Get the settings that changed from a to b
+func Diff(a, b *OracleDaoSettingsDetails) []types.SettingChange {
+	return types.DiffSettingValues(a.GetSettingValues(), b.GetSettingValues())
+}
+
+// Get the Oracle DAO settings that changed between two blocks
+func GetSettingsChanges(rp *rocketpool.RocketPool, multicallAddress common.Address, fromBlock, toBlock *big.Int) ([]types.SettingChange, error) {
+	oldSettings, err := GetOracleDaoSettingsDetails(rp, multicallAddress, &bind.CallOpts{BlockNumber: fromBlock})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Oracle DAO settings at block %s: %w", fromBlock.String(), err)
+	}
+	newSettings, err := GetOracleDaoSettingsDetails(rp, multicallAddress, &bind.CallOpts{BlockNumber: toBlock})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Oracle DAO settings at block %s: %w", toBlock.String(), err)
+	}
+	return Diff(oldSettings, newSettings), nil
+}
@@ -44,6 +44,31 @@ func EstimateProposeQuorumGas(rp *rocketpool.RocketPool, value float64, opts *bi
 	return trustednodedao.EstimateProposeSetUintGas(rp, fmt.Sprintf("set %s", QuorumSettingPath), MembersSettingsContractName, QuorumSettingPath, eth.EthToWei(value), opts)
 }
 
+// Get the transaction info for proposing a new member quorum threshold
+// If validate is set, values outside 0 to 1 are rejected and the proposer must be a member
+func ProposeQuorumTransaction(rp *rocketpool.RocketPool, value float64, validate bool) rocketpool.BatchTransaction {
+	tx := rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose setting %s to %f", QuorumSettingPath, value),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateProposeQuorumGas(rp, value, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			_, hash, err := ProposeQuorum(rp, value, opts)
+			return hash, err
+		},
+		Describe: trustednodedao.DescribeProposal(rp, fmt.Sprintf("set %s", QuorumSettingPath), "proposalSettingUint", MembersSettingsContractName, QuorumSettingPath, eth.EthToWei(value)),
+	}
+	if !validate {
+		return tx
+	}
+	return tx.WithValidation(func(opts *bind.TransactOpts) error {
+		if value < 0 || value > 1 {
+			return fmt.Errorf("quorum %f must be between 0 and 1", value)
+		}
+		return trustednodedao.CheckMember(rp, opts.From, nil)
+	})
+}
+
 // RPL bond required for a member
 func GetRPLBond(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
 	membersSettingsContract, err := getMembersSettingsContract(rp, opts)
@@ -6,8 +6,11 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 
+	trustednodedao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 )
 
 // Config
@@ -29,6 +32,71 @@ func GetNetworkEnabled(rp *rocketpool.RocketPool, network *big.Int, opts *bind.C
 	return (*value), nil
 }
 
+// Get whether or not each rewards network in [firstNetwork, firstNetwork + count) is enabled, using a single multicall
+func GetNetworksEnabled(rp *rocketpool.RocketPool, multicallAddress common.Address, firstNetwork uint64, count uint64, opts *bind.CallOpts) ([]bool, error) {
+	rewardsSettingsContract, err := getRewardsSettingsContract(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallAddress)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make([]bool, count)
+	if count == 0 {
+		return enabled, nil
+	}
+	for i := uint64(0); i < count; i++ {
+		mc.AddCall(rewardsSettingsContract, &enabled[i], "getNetworkEnabled", new(big.Int).SetUint64(firstNetwork+i))
+	}
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return nil, fmt.Errorf("error checking if networks %d to %d are enabled: %w", firstNetwork, firstNetwork+count-1, err)
+	}
+	return enabled, nil
+}
+
+// Get the setting path that enables a rewards network
+// The contract keys each network's flag by the path followed by the 32-byte network ID, so the generic bool setters can
+// set it with this path
+func GetNetworkEnabledPath(network *big.Int) string {
+	return NetworkEnabledPath + string(common.LeftPadBytes(network.Bytes(), 32))
+}
+
+// Get the transaction info for enabling or disabling a rewards network while the DAO is in bootstrap mode
+func BootstrapNetworkEnabledTransaction(rp *rocketpool.RocketPool, network *big.Int, enabled bool) rocketpool.BatchTransaction {
+	settingPath := GetNetworkEnabledPath(network)
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("bootstrap %s for network %s", NetworkEnabledPath, network.String()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return trustednodedao.EstimateBootstrapBoolGas(rp, RewardsSettingsContractName, settingPath, enabled, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return trustednodedao.BootstrapBool(rp, RewardsSettingsContractName, settingPath, enabled, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketDAONodeTrusted", "bootstrapSettingBool", RewardsSettingsContractName, settingPath, enabled),
+	}
+}
+
+// Get the transaction info for proposing to enable or disable a rewards network
+func ProposeNetworkEnabledTransaction(rp *rocketpool.RocketPool, network *big.Int, enabled bool) rocketpool.BatchTransaction {
+	settingPath := GetNetworkEnabledPath(network)
+	message := fmt.Sprintf("set %s for network %s", NetworkEnabledPath, network.String())
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("propose %s", message),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return trustednodedao.EstimateProposeSetBoolGas(rp, message, RewardsSettingsContractName, settingPath, enabled, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			_, hash, err := trustednodedao.ProposeSetBool(rp, message, RewardsSettingsContractName, settingPath, enabled, opts)
+			return hash, err
+		},
+		Describe: trustednodedao.DescribeProposal(rp, message, "proposalSettingBool", RewardsSettingsContractName, settingPath, enabled),
+	}
+}
+
 // Get contracts
 var rewardsSettingsContractLock sync.Mutex
 
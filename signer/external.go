@@ -0,0 +1,26 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Create a signer for an account held by a remote signer such as clef
+// The endpoint is the signer's RPC endpoint (e.g. the path to clef's IPC socket); the remote signer shows its own
+// confirmation prompt, so confirm is an extra local check and may be nil
+func NewExternalSigner(endpoint string, address common.Address, chainID *big.Int, confirm ConfirmFunc) (*WalletSigner, error) {
+	wallet, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to external signer at %s: %w", endpoint, err)
+	}
+	for _, account := range wallet.Accounts() {
+		if account.Address == address {
+			return NewWalletSigner(wallet, account, chainID, confirm)
+		}
+	}
+	wallet.Close()
+	return nil, fmt.Errorf("external signer at %s does not hold account %s", endpoint, address.Hex())
+}
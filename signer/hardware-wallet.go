@@ -0,0 +1,98 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+)
+
+// The derivation path of the first account in the standard Ethereum path (m/44'/60'/0'/0/0)
+var DefaultDerivationPath = accounts.DefaultBaseDerivationPath
+
+// Called when a Trezor needs its PIN or passphrase; needed is usbwallet.ErrTrezorPINNeeded or
+// usbwallet.ErrTrezorPassphraseNeeded
+// A PIN must be entered in the positions shown on the device's scrambled keypad
+type TrezorUnlockFunc func(needed error) (string, error)
+
+// Create a signer for an account on the first connected Ledger
+// The Ethereum app must be open on the device
+func NewLedgerSigner(path accounts.DerivationPath, chainID *big.Int, confirm ConfirmFunc) (*WalletSigner, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ledger devices: %w", err)
+	}
+	wallet, err := getFirstWallet(hub, "Ledger")
+	if err != nil {
+		return nil, err
+	}
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("error opening Ledger: %w", err)
+	}
+	return newHardwareWalletSigner(wallet, path, chainID, confirm)
+}
+
+// Create a signer for an account on the first connected Trezor
+// Newer devices are found over WebUSB, and older ones over HID
+func NewTrezorSigner(path accounts.DerivationPath, chainID *big.Int, unlock TrezorUnlockFunc, confirm ConfirmFunc) (*WalletSigner, error) {
+	hub, err := usbwallet.NewTrezorHubWithWebUSB()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Trezor devices: %w", err)
+	}
+	wallet, err := getFirstWallet(hub, "Trezor")
+	if err != nil {
+		hub, hidErr := usbwallet.NewTrezorHubWithHID()
+		if hidErr != nil {
+			return nil, err
+		}
+		wallet, err = getFirstWallet(hub, "Trezor")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Open the device, entering the PIN and passphrase as it asks for them
+	err = wallet.Open("")
+	for i := 0; i < 2 && (errors.Is(err, usbwallet.ErrTrezorPINNeeded) || errors.Is(err, usbwallet.ErrTrezorPassphraseNeeded)); i++ {
+		if unlock == nil {
+			return nil, fmt.Errorf("error opening Trezor: %w", err)
+		}
+		secret, unlockErr := unlock(err)
+		if unlockErr != nil {
+			wallet.Close()
+			return nil, fmt.Errorf("error unlocking Trezor: %w", unlockErr)
+		}
+		err = wallet.Open(secret)
+	}
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("error opening Trezor: %w", err)
+	}
+	return newHardwareWalletSigner(wallet, path, chainID, confirm)
+}
+
+// Get the first wallet of a USB hub
+func getFirstWallet(hub *usbwallet.Hub, deviceName string) (accounts.Wallet, error) {
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no %s devices found", deviceName)
+	}
+	return wallets[0], nil
+}
+
+// Derive an account on an open hardware wallet and create a signer for it
+func newHardwareWalletSigner(wallet accounts.Wallet, path accounts.DerivationPath, chainID *big.Int, confirm ConfirmFunc) (*WalletSigner, error) {
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("error deriving account at %s: %w", path.String(), err)
+	}
+	signer, err := NewWalletSigner(wallet, account, chainID, confirm)
+	if err != nil {
+		wallet.Close()
+		return nil, err
+	}
+	return signer, nil
+}
@@ -0,0 +1,90 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Returned when the confirmation callback declines to sign a transaction
+var ErrTransactionRejected = errors.New("transaction was rejected")
+
+// Called with each transaction before it's sent to the wallet for signing, so the user can review it
+// Return false to reject the transaction; the wallet is never asked to sign it
+type ConfirmFunc func(account common.Address, tx *types.Transaction) (bool, error)
+
+// A signer backed by a wallet that holds its own keys, such as a hardware wallet or a remote signer
+type WalletSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+	chainID *big.Int
+	confirm ConfirmFunc
+
+	// Hardware wallets can only handle one request at a time
+	lock sync.Mutex
+}
+
+// Create a signer for an account of an open wallet
+// confirm may be nil to sign every transaction without asking
+func NewWalletSigner(wallet accounts.Wallet, account accounts.Account, chainID *big.Int, confirm ConfirmFunc) (*WalletSigner, error) {
+	if !wallet.Contains(account) {
+		return nil, fmt.Errorf("wallet %s does not contain account %s", wallet.URL().String(), account.Address.Hex())
+	}
+	return &WalletSigner{
+		wallet:  wallet,
+		account: account,
+		chainID: chainID,
+		confirm: confirm,
+	}, nil
+}
+
+// Get the address of the signing account
+func (s *WalletSigner) GetAddress() common.Address {
+	return s.account.Address
+}
+
+// Get transact options that sign with the wallet
+// Each call returns a new copy, so gas and value settings can be changed per transaction
+func (s *WalletSigner) GetTransactOpts() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    s.account.Address,
+		Signer:  s.SignTx,
+		Context: context.Background(),
+	}
+}
+
+// Sign a transaction with the wallet, after it's been confirmed
+func (s *WalletSigner) SignTx(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	if address != s.account.Address {
+		return nil, bind.ErrNotAuthorized
+	}
+	if s.confirm != nil {
+		confirmed, err := s.confirm(address, tx)
+		if err != nil {
+			return nil, fmt.Errorf("error confirming transaction: %w", err)
+		}
+		if !confirmed {
+			return nil, ErrTransactionRejected
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	signedTx, err := s.wallet.SignTx(s.account, tx, s.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction with wallet %s: %w", s.wallet.URL().String(), err)
+	}
+	return signedTx, nil
+}
+
+// Close the wallet
+func (s *WalletSigner) Close() error {
+	return s.wallet.Close()
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Get the RocketStorage key for a path, hashed the same way as keccak256(abi.encodePacked(...)) in the contracts
+// Use the path element helpers below to encode non-string elements
+func Key(pathElems ...[]byte) common.Hash {
+	return crypto.Keccak256Hash(pathElems...)
+}
+
+// Encode a string path element
+func StringElem(value string) []byte {
+	return []byte(value)
+}
+
+// Encode an address path element
+func AddressElem(address common.Address) []byte {
+	return address.Bytes()
+}
+
+// Encode a uint256 path element
+func UintElem(value *big.Int) []byte {
+	return math.U256Bytes(big.NewInt(0).Set(value))
+}
+
+// Encode a uint256 path element from a uint64
+func Uint64Elem(value uint64) []byte {
+	return UintElem(big.NewInt(0).SetUint64(value))
+}
+
+// Get the key of a network contract's address
+func ContractAddressKey(contractName string) common.Hash {
+	return Key(StringElem("contract.address"), StringElem(contractName))
+}
+
+// Get the key of a network contract's encoded ABI
+func ContractAbiKey(contractName string) common.Hash {
+	return Key(StringElem("contract.abi"), StringElem(contractName))
+}
+
+// Get the key of the flag set for every registered network contract address
+func ContractExistsKey(address common.Address) common.Hash {
+	return Key(StringElem("contract.exists"), AddressElem(address))
+}
+
+// Get the key of a network contract's name by address
+func ContractNameKey(address common.Address) common.Hash {
+	return Key(StringElem("contract.name"), AddressElem(address))
+}
+
+// Get the key of the block Rocket Pool was deployed on
+func DeployBlockKey() common.Hash {
+	return Key(StringElem("deploy.block"))
+}
+
+// Get the key of a minipool's penalty count
+func MinipoolPenaltyCountKey(minipoolAddress common.Address) common.Hash {
+	return Key(StringElem("network.penalties.penalty"), AddressElem(minipoolAddress))
+}
+
+// Get the key of a minipool's penalty rate (before the maximum penalty rate is applied)
+func MinipoolPenaltyRateKey(minipoolAddress common.Address) common.Hash {
+	return Key(StringElem("minipool.penalty.rate"), AddressElem(minipoolAddress))
+}
+
+// Get the key of a trusted node's RPL price submission
+func PricesSubmittedKey(nodeAddress common.Address, block *big.Int, slotTimestamp *big.Int, rplPrice *big.Int) common.Hash {
+	return Key(StringElem("network.prices.submitted.node.key"), AddressElem(nodeAddress), UintElem(block), UintElem(slotTimestamp), UintElem(rplPrice))
+}
+
+// Get the key of the number of trusted nodes that have submitted an RPL price
+func PricesSubmissionCountKey(block *big.Int, slotTimestamp *big.Int, rplPrice *big.Int) common.Hash {
+	return Key(StringElem("network.prices.submitted.count"), UintElem(block), UintElem(slotTimestamp), UintElem(rplPrice))
+}
+
+// Get the key of a trusted node's penalty submission for a minipool at a block
+func PenaltySubmittedKey(nodeAddress common.Address, minipoolAddress common.Address, block *big.Int) common.Hash {
+	return Key(StringElem("network.penalties.submitted.node"), AddressElem(nodeAddress), AddressElem(minipoolAddress), UintElem(block))
+}
+
+// Get the key of the number of penalty submissions for a minipool at a block
+func PenaltySubmissionCountKey(minipoolAddress common.Address, block *big.Int) common.Hash {
+	return Key(StringElem("network.penalties.submitted.count"), AddressElem(minipoolAddress), UintElem(block))
+}
+
+// Get the key of the flag set once the penalty for a minipool at a block has been applied
+func PenaltyExecutedKey(minipoolAddress common.Address, block *big.Int) common.Hash {
+	return Key(StringElem("network.penalties.executed"), AddressElem(minipoolAddress), UintElem(block))
+}
+
+// Get the key of a challenge on a Protocol DAO proposal's voting tree node
+// The value packs the challenger address, the challenge timestamp, and the challenge state
+func ProposalChallengeKey(proposalId uint64, index uint64) common.Hash {
+	return Key(StringElem("dao.protocol.proposal.challenge"), Uint64Elem(proposalId), Uint64Elem(index))
+}
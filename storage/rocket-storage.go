@@ -6,7 +6,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
@@ -42,6 +41,20 @@ func SetWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Address,
 	return tx.Hash(), nil
 }
 
+// Get the transaction info for setting a node's withdrawal address
+func SetWithdrawalAddressTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address, withdrawalAddress common.Address, confirm bool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("set node %s withdrawal address to %s", nodeAddress.Hex(), withdrawalAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSetWithdrawalAddressGas(rp, nodeAddress, withdrawalAddress, confirm, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SetWithdrawalAddress(rp, nodeAddress, withdrawalAddress, confirm, opts)
+		},
+		Describe: rp.RocketStorageContract.DescribeCall("setWithdrawalAddress", nodeAddress, withdrawalAddress, confirm),
+	}
+}
+
 // Estimate the gas of ConfirmWithdrawalAddress
 func EstimateConfirmWithdrawalAddressGas(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	return rp.RocketStorageContract.GetTransactionGasInfo(opts, "confirmWithdrawalAddress", nodeAddress)
@@ -56,13 +69,103 @@ func ConfirmWithdrawalAddress(rp *rocketpool.RocketPool, nodeAddress common.Addr
 	return tx.Hash(), nil
 }
 
+// Get the transaction info for confirming a node's pending withdrawal address; must be sent from the pending address
+func ConfirmWithdrawalAddressTransaction(rp *rocketpool.RocketPool, nodeAddress common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("confirm node %s withdrawal address", nodeAddress.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateConfirmWithdrawalAddressGas(rp, nodeAddress, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ConfirmWithdrawalAddress(rp, nodeAddress, opts)
+		},
+		Describe: rp.RocketStorageContract.DescribeCall("confirmWithdrawalAddress", nodeAddress),
+	}
+}
+
 // Get the number of the block that Rocket Pool was deployed on
 func GetDeployBlock(rp *rocketpool.RocketPool) (*big.Int, error) {
-	deployBlockHash := crypto.Keccak256Hash([]byte("deploy.block"))
-	deployBlock, err := rp.RocketStorage.GetUint(nil, deployBlockHash)
+	deployBlock, err := rp.RocketStorage.GetUint(nil, DeployBlockKey())
 	if err != nil {
 		return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
 	}
 
 	return deployBlock, nil
 }
+
+// Get the address of the guardian
+func GetGuardian(rp *rocketpool.RocketPool, opts *bind.CallOpts) (common.Address, error) {
+	guardian := new(common.Address)
+	if err := rp.RocketStorageContract.Call(opts, guardian, "getGuardian"); err != nil {
+		return common.Address{}, fmt.Errorf("error getting guardian address: %w", err)
+	}
+	return *guardian, nil
+}
+
+// Check that an address is the guardian, returning an ErrNotGuardian error if it isn't
+func CheckGuardian(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) error {
+	guardian, err := GetGuardian(rp, opts)
+	if err != nil {
+		return err
+	}
+	if address != guardian {
+		return rocketpool.NewError(rocketpool.ErrNotGuardian, "%s is not the guardian; only the guardian (%s) can do this", address.Hex(), guardian.Hex())
+	}
+	return nil
+}
+
+// Estimate the gas of SetGuardian
+func EstimateSetGuardianGas(rp *rocketpool.RocketPool, newGuardian common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return rp.RocketStorageContract.GetTransactionGasInfo(opts, "setGuardian", newGuardian)
+}
+
+// Start transferring the guardian role to a new address, which must then confirm it; only callable by the guardian
+func SetGuardian(rp *rocketpool.RocketPool, newGuardian common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	tx, err := rp.RocketStorageContract.Transact(opts, "setGuardian", newGuardian)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error setting new guardian %s: %w", newGuardian.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the transaction info for starting a guardian transfer; must be sent from the current guardian
+func SetGuardianTransaction(rp *rocketpool.RocketPool, newGuardian common.Address) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("set new guardian to %s", newGuardian.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSetGuardianGas(rp, newGuardian, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SetGuardian(rp, newGuardian, opts)
+		},
+		Describe: rp.RocketStorageContract.DescribeCall("setGuardian", newGuardian),
+	}
+}
+
+// Estimate the gas of ConfirmGuardian
+func EstimateConfirmGuardianGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return rp.RocketStorageContract.GetTransactionGasInfo(opts, "confirmGuardian")
+}
+
+// Accept the guardian role; only callable by the address set with SetGuardian
+func ConfirmGuardian(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	tx, err := rp.RocketStorageContract.Transact(opts, "confirmGuardian")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error confirming guardian: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the transaction info for accepting the guardian role; must be sent from the new guardian
+func ConfirmGuardianTransaction(rp *rocketpool.RocketPool) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: "confirm guardian",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateConfirmGuardianGas(rp, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ConfirmGuardian(rp, opts)
+		},
+		Describe: rp.RocketStorageContract.DescribeCall("confirmGuardian"),
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// Get a uint value from RocketStorage by path
+func GetUint(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (*big.Int, error) {
+	value := new(*big.Int)
+	if err := getValue(rp, opts, value, "getUint", pathElems); err != nil {
+		return nil, err
+	}
+	return *value, nil
+}
+
+// Get an int value from RocketStorage by path
+func GetInt(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (*big.Int, error) {
+	value := new(*big.Int)
+	if err := getValue(rp, opts, value, "getInt", pathElems); err != nil {
+		return nil, err
+	}
+	return *value, nil
+}
+
+// Get an address value from RocketStorage by path
+func GetAddress(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (common.Address, error) {
+	value := new(common.Address)
+	if err := getValue(rp, opts, value, "getAddress", pathElems); err != nil {
+		return common.Address{}, err
+	}
+	return *value, nil
+}
+
+// Get a bool value from RocketStorage by path
+func GetBool(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (bool, error) {
+	value := new(bool)
+	if err := getValue(rp, opts, value, "getBool", pathElems); err != nil {
+		return false, err
+	}
+	return *value, nil
+}
+
+// Get a bytes32 value from RocketStorage by path
+func GetBytes32(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (common.Hash, error) {
+	value := new([32]byte)
+	if err := getValue(rp, opts, value, "getBytes32", pathElems); err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(*value), nil
+}
+
+// Get a string value from RocketStorage by path
+func GetString(rp *rocketpool.RocketPool, opts *bind.CallOpts, pathElems ...[]byte) (string, error) {
+	value := new(string)
+	if err := getValue(rp, opts, value, "getString", pathElems); err != nil {
+		return "", err
+	}
+	return *value, nil
+}
+
+// Add a call for a uint value from RocketStorage to a multicaller
+func AddGetUintCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output **big.Int, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getUint", [32]byte(key))
+}
+
+// Add a call for an int value from RocketStorage to a multicaller
+func AddGetIntCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output **big.Int, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getInt", [32]byte(key))
+}
+
+// Add a call for an address value from RocketStorage to a multicaller
+func AddGetAddressCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output *common.Address, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getAddress", [32]byte(key))
+}
+
+// Add a call for a bool value from RocketStorage to a multicaller
+func AddGetBoolCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output *bool, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getBool", [32]byte(key))
+}
+
+// Add a call for a bytes32 value from RocketStorage to a multicaller
+func AddGetBytes32Call(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output *[32]byte, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getBytes32", [32]byte(key))
+}
+
+// Add a call for a string value from RocketStorage to a multicaller
+func AddGetStringCall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, output *string, key common.Hash) error {
+	return mc.AddCall(rp.RocketStorageContract, output, "getString", [32]byte(key))
+}
+
+// Get a value from RocketStorage by path
+func getValue(rp *rocketpool.RocketPool, opts *bind.CallOpts, output interface{}, method string, pathElems [][]byte) error {
+	key := Key(pathElems...)
+	if err := rp.RocketStorageContract.Call(opts, output, method, [32]byte(key)); err != nil {
+		return fmt.Errorf("error getting storage value %s: %w", key.Hex(), err)
+	}
+	return nil
+}
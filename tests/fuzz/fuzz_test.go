@@ -0,0 +1,193 @@
+package fuzz
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/json"
+)
+
+// Proposal methods used as the target ABI when fuzzing payload decoding; covers every argument kind the formatter
+// handles (addresses, hashes, fixed and dynamic bytes, strings, integers and bools)
+const proposalAbi string = `[
+	{"type":"function","name":"proposalInvite","inputs":[{"name":"_id","type":"string"},{"name":"_url","type":"string"},{"name":"_nodeAddress","type":"address"}]},
+	{"type":"function","name":"proposalLeave","inputs":[{"name":"_nodeAddress","type":"address"}]},
+	{"type":"function","name":"proposalKick","inputs":[{"name":"_nodeAddress","type":"address"},{"name":"_rplFine","type":"uint256"}]},
+	{"type":"function","name":"proposalSettingUint","inputs":[{"name":"_settingContractName","type":"string"},{"name":"_settingPath","type":"string"},{"name":"_value","type":"uint256"}]},
+	{"type":"function","name":"proposalSettingBool","inputs":[{"name":"_settingContractName","type":"string"},{"name":"_settingPath","type":"string"},{"name":"_value","type":"bool"}]},
+	{"type":"function","name":"proposalSettingAddress","inputs":[{"name":"_settingContractName","type":"string"},{"name":"_settingPath","type":"string"},{"name":"_value","type":"address"}]},
+	{"type":"function","name":"proposalUpgrade","inputs":[{"name":"_type","type":"string"},{"name":"_name","type":"string"},{"name":"_contractAbi","type":"string"},{"name":"_contractAddress","type":"address"}]},
+	{"type":"function","name":"proposalSettingHash","inputs":[{"name":"_settingPath","type":"bytes32"},{"name":"_value","type":"bytes32"}]},
+	{"type":"function","name":"proposalSettingBytes","inputs":[{"name":"_settingPath","type":"bytes4"},{"name":"_value","type":"bytes"}]}
+]`
+
+// Verifier events used when fuzzing the event decoders; matches RocketDAOProtocolVerifier
+const verifierAbi string = `[
+	{"type":"event","name":"RootSubmitted","inputs":[
+		{"name":"proposalID","type":"uint256","indexed":true},
+		{"name":"proposer","type":"address","indexed":true},
+		{"name":"blockNumber","type":"uint32","indexed":false},
+		{"name":"index","type":"uint256","indexed":false},
+		{"name":"root","type":"tuple","indexed":false,"components":[{"name":"sum","type":"uint256"},{"name":"hash","type":"bytes32"}]},
+		{"name":"treeNodes","type":"tuple[]","indexed":false,"components":[{"name":"sum","type":"uint256"},{"name":"hash","type":"bytes32"}]},
+		{"name":"timestamp","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"ChallengeSubmitted","inputs":[
+		{"name":"proposalID","type":"uint256","indexed":true},
+		{"name":"challenger","type":"address","indexed":true},
+		{"name":"index","type":"uint256","indexed":false},
+		{"name":"timestamp","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"ProposalBondBurned","inputs":[
+		{"name":"proposalID","type":"uint256","indexed":true},
+		{"name":"proposer","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"timestamp","type":"uint256","indexed":false}
+	]}
+]`
+
+// The ProposalBondBurned event, which the protocol package only decodes internally
+type proposalBondBurned struct {
+	ProposalID *big.Int       `json:"proposalId"`
+	Proposer   common.Address `json:"proposer"`
+	Amount     *big.Int       `json:"amount"`
+	Timestamp  time.Time      `json:"timestamp" event:"timestamp,unix"`
+}
+
+// Parse a test ABI
+func parseAbi(f *testing.F, abiJson string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJson))
+	if err != nil {
+		f.Fatal(err)
+	}
+	return &parsed
+}
+
+// Malformed proposal payloads must produce errors rather than panics
+func FuzzProposalPayload(f *testing.F) {
+
+	// Seed with a valid payload for each argument kind
+	contractAbi := parseAbi(f, proposalAbi)
+	address := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	seeds := [][]interface{}{
+		{"proposalInvite", "id", "https://example.com", address},
+		{"proposalKick", address, big.NewInt(1000)},
+		{"proposalSettingBool", "rocketDAONodeTrustedSettingsMembers", "members.quorum", true},
+		{"proposalSettingHash", common.HexToHash("0x01"), common.HexToHash("0x02")},
+		{"proposalSettingBytes", [4]byte{1, 2, 3, 4}, []byte{5, 6, 7}},
+	}
+	for _, seed := range seeds {
+		payload, err := contractAbi.Pack(seed[0].(string), seed[1:]...)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(payload)
+	}
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		dao.FormatProposalPayload(contractAbi, payload)
+	})
+
+}
+
+// Malformed verifier logs must produce errors rather than panics
+// The first byte selects how many 32-byte topics follow (mod 4); the rest of the input is the log data
+func FuzzVerifierLogs(f *testing.F) {
+
+	contractAbi := parseAbi(f, verifierAbi)
+	contract := &rocketpool.Contract{ABI: contractAbi}
+	f.Add([]byte{3})
+	f.Add(append([]byte{2}, make([]byte, 2*common.HashLength+4*32)...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == 0 {
+			return
+		}
+		topicCount := int(data[0] % 4)
+		data = data[1:]
+		if len(data) < topicCount*common.HashLength {
+			return
+		}
+		log := ethtypes.Log{
+			Topics: make([]common.Hash, topicCount),
+		}
+		for i := range log.Topics {
+			log.Topics[i] = common.BytesToHash(data[i*common.HashLength : (i+1)*common.HashLength])
+		}
+		log.Data = data[topicCount*common.HashLength:]
+
+		// The first topic is the event ID, so it's replaced for each decoder
+		withEventID := func(eventName string) ethtypes.Log {
+			eventLog := log
+			if topicCount > 0 {
+				eventLog.Topics = append([]common.Hash{contractAbi.Events[eventName].ID}, log.Topics[1:]...)
+			}
+			return eventLog
+		}
+		eth.DecodeEvent[protocol.RootSubmitted](contract, "RootSubmitted", withEventID("RootSubmitted"))
+		eth.DecodeEvent[protocol.ChallengeSubmitted](contract, "ChallengeSubmitted", withEventID("ChallengeSubmitted"))
+		eth.DecodeEvent[proposalBondBurned](contract, "ProposalBondBurned", withEventID("ProposalBondBurned"))
+	})
+
+}
+
+// Every native type value that unmarshals successfully must survive a marshal / unmarshal round trip unchanged
+func FuzzJSONConversions(f *testing.F) {
+
+	f.Add([]byte(`"0x` + strings.Repeat("ab", types.ValidatorPubkeyLength) + `"`))
+	f.Add([]byte(`"Active"`))
+	f.Add([]byte(`"Staking"`))
+	f.Add([]byte(`"Full"`))
+	f.Add([]byte(`1`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var pubkey types.ValidatorPubkey
+		if pubkey.UnmarshalJSON(data) == nil {
+			checkRoundTrip(t, &pubkey, &types.ValidatorPubkey{})
+		}
+		var signature types.ValidatorSignature
+		if signature.UnmarshalJSON(data) == nil {
+			checkRoundTrip(t, &signature, &types.ValidatorSignature{})
+		}
+		var proposalState types.ProposalState
+		if proposalState.UnmarshalJSON(data) == nil {
+			checkRoundTrip(t, &proposalState, new(types.ProposalState))
+		}
+		var minipoolStatus types.MinipoolStatus
+		if minipoolStatus.UnmarshalJSON(data) == nil {
+			checkRoundTrip(t, &minipoolStatus, new(types.MinipoolStatus))
+		}
+		var minipoolDeposit types.MinipoolDeposit
+		if minipoolDeposit.UnmarshalJSON(data) == nil {
+			checkRoundTrip(t, &minipoolDeposit, new(types.MinipoolDeposit))
+		}
+	})
+
+}
+
+// Marshal a value, unmarshal it into a fresh value and fail if the two don't match
+func checkRoundTrip(t *testing.T, value interface{}, decoded interface{}) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("error marshalling %#v: %s", value, err.Error())
+	}
+	if err := json.Unmarshal(encoded, decoded); err != nil {
+		t.Fatalf("error unmarshalling %s: %s", string(encoded), err.Error())
+	}
+	if fmt.Sprintf("%v", value) != fmt.Sprintf("%v", decoded) {
+		t.Fatalf("round trip mismatch: %v != %v", value, decoded)
+	}
+}
@@ -0,0 +1,157 @@
+package ledger
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/ledger"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The cash flow events of a v3 minipool
+const minipoolEventsAbi string = `[
+	{"type":"event","name":"EtherDeposited","inputs":[{"name":"from","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"EtherWithdrawn","inputs":[{"name":"to","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]},
+	{"type":"event","name":"EtherWithdrawalProcessed","inputs":[{"name":"executed","type":"address","indexed":true},{"name":"nodeAmount","type":"uint256","indexed":false},{"name":"userAmount","type":"uint256","indexed":false},{"name":"totalBalance","type":"uint256","indexed":false},{"name":"time","type":"uint256","indexed":false}]}
+]`
+
+// Ledger test addresses
+var (
+	nodeAddress        = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	otherAddress       = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	minipoolAddress    = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	depositPoolAddress = common.HexToAddress("0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65")
+)
+
+// Builds minipool logs in chain order
+type logBuilder struct {
+	t           *testing.T
+	contractAbi *abi.ABI
+	logs        []types.Log
+}
+
+// Add a log for an event, in a new transaction unless sameTx is set
+func (b *logBuilder) add(sameTx bool, event string, sender common.Address, values ...interface{}) {
+	data, err := b.contractAbi.Events[event].Inputs.NonIndexed().Pack(values...)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	block := uint64(len(b.logs) + 1)
+	txHash := common.BigToHash(new(big.Int).SetUint64(block))
+	if sameTx && len(b.logs) > 0 {
+		last := b.logs[len(b.logs)-1]
+		block = last.BlockNumber
+		txHash = last.TxHash
+	}
+	b.logs = append(b.logs, types.Log{
+		Address:     minipoolAddress,
+		Topics:      []common.Hash{b.contractAbi.Events[event].ID, common.BytesToHash(sender.Bytes())},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      txHash,
+		Index:       uint(len(b.logs)),
+	})
+}
+
+// Decode the logs and get the total ETH the node received
+func (b *logBuilder) decode() ([]ledger.Entry, *big.Int) {
+	entries, err := ledger.DecodeMinipoolEntries(b.contractAbi, depositPoolAddress, nodeAddress, minipoolAddress, b.logs)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	l := ledger.Ledger{Entries: entries}
+	return entries, l.GetTotal(ledger.Asset_ETH)
+}
+
+// Create a log builder with the node and user deposits of an 8 ETH minipool
+func newLogBuilder(t *testing.T) *logBuilder {
+	contractAbi, err := abi.JSON(strings.NewReader(minipoolEventsAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &logBuilder{t: t, contractAbi: &contractAbi}
+	b.add(false, "EtherDeposited", nodeAddress, eth.EthToWei(8), big.NewInt(1))
+	b.add(false, "EtherDeposited", depositPoolAddress, eth.EthToWei(24), big.NewInt(2))
+	return b
+}
+
+// Check the types of a set of entries
+func checkEntryTypes(t *testing.T, entries []ledger.Entry, expected ...ledger.EntryType) {
+	if len(entries) != len(expected) {
+		t.Fatalf("Incorrect entry count %d, expected %d", len(entries), len(expected))
+	}
+	for i, entry := range entries {
+		if entry.Type != expected[i] {
+			t.Errorf("Incorrect type %s for entry %d, expected %s", entry.Type, i, expected[i])
+		}
+	}
+}
+
+func TestOwnerDistribution(t *testing.T) {
+
+	// The owner distributes 0.5 ETH of rewards and the node's share is paid out in the same transaction
+	b := newLogBuilder(t)
+	b.add(false, "EtherWithdrawalProcessed", nodeAddress, eth.EthToWei(0.2), eth.EthToWei(0.3), eth.EthToWei(0.5), big.NewInt(3))
+	b.add(true, "EtherWithdrawn", nodeAddress, eth.EthToWei(0.2), big.NewInt(3))
+	entries, total := b.decode()
+	checkEntryTypes(t, entries, ledger.EntryType_NodeDeposit, ledger.EntryType_UserDeposit, ledger.EntryType_Distribution)
+	if expected := eth.EthToWei(-7.8); total.Cmp(expected) != 0 {
+		t.Errorf("Incorrect total %s, expected %s", total.String(), expected.String())
+	}
+
+}
+
+func TestSkimThenRefund(t *testing.T) {
+
+	// Someone else skims 0.5 ETH, which credits the node's share to the refund balance without paying it out
+	b := newLogBuilder(t)
+	b.add(false, "EtherWithdrawalProcessed", otherAddress, eth.EthToWei(0.2), eth.EthToWei(0.3), eth.EthToWei(0.5), big.NewInt(3))
+
+	// The owner later refunds the share along with a 1 ETH refund from somewhere else
+	b.add(false, "EtherWithdrawn", nodeAddress, eth.EthToWei(1.2), big.NewInt(4))
+
+	// The share is only counted by the distribution
+	entries, total := b.decode()
+	checkEntryTypes(t, entries, ledger.EntryType_NodeDeposit, ledger.EntryType_UserDeposit, ledger.EntryType_Distribution, ledger.EntryType_Refund)
+	if entries[2].Amount.Cmp(eth.EthToWei(0.2)) != 0 {
+		t.Errorf("Incorrect distribution amount %s", entries[2].Amount.String())
+	}
+	if entries[3].Amount.Cmp(eth.EthToWei(1)) != 0 {
+		t.Errorf("Incorrect refund amount %s", entries[3].Amount.String())
+	}
+	if expected := eth.EthToWei(-6.8); total.Cmp(expected) != 0 {
+		t.Errorf("Incorrect total %s, expected %s", total.String(), expected.String())
+	}
+
+	// A later refund with nothing credited is counted in full
+	b.add(false, "EtherWithdrawn", nodeAddress, eth.EthToWei(0.5), big.NewInt(5))
+	entries, _ = b.decode()
+	if len(entries) != 5 || entries[4].Type != ledger.EntryType_Refund || entries[4].Amount.Cmp(eth.EthToWei(0.5)) != 0 {
+		t.Errorf("Incorrect entries %+v", entries)
+	}
+
+}
+
+func TestSkimsThenPartialRefunds(t *testing.T) {
+
+	// Two skims credit 0.3 ETH, which is paid out over two refunds
+	b := newLogBuilder(t)
+	b.add(false, "EtherWithdrawalProcessed", otherAddress, eth.EthToWei(0.1), eth.EthToWei(0.2), eth.EthToWei(0.3), big.NewInt(3))
+	b.add(false, "EtherWithdrawalProcessed", otherAddress, eth.EthToWei(0.2), eth.EthToWei(0.4), eth.EthToWei(0.6), big.NewInt(4))
+	b.add(false, "EtherWithdrawn", nodeAddress, eth.EthToWei(0.25), big.NewInt(5))
+	b.add(false, "EtherWithdrawn", nodeAddress, eth.EthToWei(0.15), big.NewInt(6))
+	entries, total := b.decode()
+	checkEntryTypes(t, entries, ledger.EntryType_NodeDeposit, ledger.EntryType_UserDeposit, ledger.EntryType_Distribution, ledger.EntryType_Distribution, ledger.EntryType_Refund)
+	if entries[4].Amount.Cmp(eth.EthToWei(0.1)) != 0 {
+		t.Errorf("Incorrect refund amount %s", entries[4].Amount.String())
+	}
+	if expected := eth.EthToWei(-7.6); total.Cmp(expected) != 0 {
+		t.Errorf("Incorrect total %s, expected %s", total.String(), expected.String())
+	}
+
+}
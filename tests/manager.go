@@ -0,0 +1,242 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	trustednodesettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils"
+)
+
+// Manages a connection to a development chain (Hardhat, Anvil or Ganache) for integration tests
+// Unlike the testutils helpers it doesn't assume the default provider or deployment, so it can be used to test code
+// built on top of this package
+type TestManager struct {
+	RocketPool *rocketpool.RocketPool
+	Client     *ethclient.Client
+	RpcClient  *rpc.Client
+}
+
+// Create a test manager for the given provider and RocketStorage deployment
+func NewTestManager(providerAddress string, rocketStorageAddress common.Address) (*TestManager, error) {
+	rpcClient, err := rpc.Dial(providerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", providerAddress, err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	rp, err := rocketpool.NewRocketPool(client, rocketStorageAddress)
+	if err != nil {
+		rpcClient.Close()
+		return nil, err
+	}
+	return &TestManager{
+		RocketPool: rp,
+		Client:     client,
+		RpcClient:  rpcClient,
+	}, nil
+}
+
+// Create a test manager for the default local provider and deployment
+func NewDefaultTestManager() (*TestManager, error) {
+	return NewTestManager(Eth1ProviderAddress, common.HexToAddress(RocketStorageAddress))
+}
+
+// Close the connection to the provider
+func (m *TestManager) Close() {
+	m.RpcClient.Close()
+}
+
+// ========================
+// === Snapshot Control ===
+// ========================
+
+// Take a snapshot of the chain state, returning its ID
+func (m *TestManager) TakeSnapshot() (string, error) {
+	var snapshotId string
+	if err := m.RpcClient.Call(&snapshotId, "evm_snapshot"); err != nil {
+		return "", fmt.Errorf("error taking snapshot: %w", err)
+	}
+	return snapshotId, nil
+}
+
+// Revert the chain state to a snapshot
+// A snapshot can only be reverted to once
+func (m *TestManager) RevertSnapshot(snapshotId string) error {
+	var success bool
+	if err := m.RpcClient.Call(&success, "evm_revert", snapshotId); err != nil {
+		return fmt.Errorf("error reverting to snapshot %s: %w", snapshotId, err)
+	}
+	if !success {
+		return fmt.Errorf("provider could not revert to snapshot %s", snapshotId)
+	}
+	return nil
+}
+
+// Run a function against a snapshot of the chain state, reverting all of its changes afterwards even if it panics
+func (m *TestManager) RunWithSnapshot(fn func()) (err error) {
+	snapshotId, err := m.TakeSnapshot()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if revertErr := m.RevertSnapshot(snapshotId); revertErr != nil && err == nil {
+			err = revertErr
+		}
+	}()
+	fn()
+	return nil
+}
+
+// ====================
+// === Time Control ===
+// ====================
+
+// Mine a number of blocks
+func (m *TestManager) MineBlocks(numBlocks int) error {
+	for i := 0; i < numBlocks; i++ {
+		if err := m.RpcClient.Call(nil, "evm_mine"); err != nil {
+			return fmt.Errorf("error mining block: %w", err)
+		}
+	}
+	return nil
+}
+
+// Fast forward the chain time and mine a block with the new time
+func (m *TestManager) IncreaseTime(duration time.Duration) error {
+	if err := m.RpcClient.Call(nil, "evm_increaseTime", uint64(duration.Seconds())); err != nil {
+		return fmt.Errorf("error increasing time: %w", err)
+	}
+	return m.MineBlocks(1)
+}
+
+// Get the timestamp of the latest block
+func (m *TestManager) GetLatestBlockTime() (time.Time, error) {
+	header, err := m.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// Fast forward the chain time until the latest block is after the given time; does nothing if it already is
+func (m *TestManager) AdvancePast(target time.Time) error {
+	latestTime, err := m.GetLatestBlockTime()
+	if err != nil {
+		return err
+	}
+	if latestTime.After(target) {
+		return nil
+	}
+	return m.IncreaseTime(target.Sub(latestTime) + time.Second)
+}
+
+// Fast forward the chain time past the minipool scrub period
+func (m *TestManager) AdvancePastScrubPeriod() error {
+	scrubPeriod, err := trustednodesettings.GetScrubPeriod(m.RocketPool, nil)
+	if err != nil {
+		return err
+	}
+	return m.IncreaseTime(time.Duration(scrubPeriod+1) * time.Second)
+}
+
+// Fast forward the chain time until voting has started on a trusted node DAO proposal
+func (m *TestManager) AdvanceToProposalVoting(proposalId uint64) error {
+	startTime, err := dao.GetProposalStartTime(m.RocketPool, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	return m.AdvancePast(time.Unix(int64(startTime), 0))
+}
+
+// Fast forward the chain time past the end of voting on a trusted node DAO proposal
+func (m *TestManager) AdvancePastProposalVoting(proposalId uint64) error {
+	endTime, err := dao.GetProposalEndTime(m.RocketPool, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	return m.AdvancePast(time.Unix(int64(endTime), 0))
+}
+
+// Fast forward the chain time until voting has started on a Protocol DAO proposal
+func (m *TestManager) AdvanceToProtocolProposalVoting(proposalId uint64) error {
+	startTime, err := protocol.GetProposalVotingStartTime(m.RocketPool, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	return m.AdvancePast(startTime)
+}
+
+// Fast forward the chain time past the first voting phase of a Protocol DAO proposal
+func (m *TestManager) AdvancePastProtocolProposalPhase1(proposalId uint64) error {
+	endTime, err := protocol.GetProposalPhase1EndTime(m.RocketPool, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	return m.AdvancePast(endTime)
+}
+
+// Fast forward the chain time past the second voting phase of a Protocol DAO proposal
+func (m *TestManager) AdvancePastProtocolProposalPhase2(proposalId uint64) error {
+	endTime, err := protocol.GetProposalPhase2EndTime(m.RocketPool, proposalId, nil)
+	if err != nil {
+		return err
+	}
+	return m.AdvancePast(endTime)
+}
+
+// ================
+// === Accounts ===
+// ================
+
+// Set the ETH balance of an account
+// Uses hardhat_setBalance, which Hardhat and Anvil both support
+func (m *TestManager) FundAccount(address common.Address, amount *big.Int) error {
+	if err := m.RpcClient.Call(nil, "hardhat_setBalance", address, hexutil.EncodeBig(amount)); err != nil {
+		return fmt.Errorf("error setting balance of %s: %w", address.Hex(), err)
+	}
+	return nil
+}
+
+// ===========
+// === DAO ===
+// ===========
+
+// Add a registered node to the trusted node DAO while it's in bootstrap mode
+// The guardian invites the node, then the node approves and pays its RPL bond, so it must already hold enough RPL
+func (m *TestManager) BootstrapOracleDaoMember(guardianOpts *bind.TransactOpts, nodeOpts *bind.TransactOpts, id, url string) error {
+	hash, err := trustednode.BootstrapMember(m.RocketPool, id, url, nodeOpts.From, guardianOpts)
+	if err != nil {
+		return err
+	}
+	if _, err := utils.WaitForTransaction(m.Client, hash); err != nil {
+		return err
+	}
+
+	txs, err := trustednode.JoinTransactions(m.RocketPool, nodeOpts.From, nil)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		opts := *nodeOpts
+		hash, err := tx.Submit(&opts)
+		if err != nil {
+			return fmt.Errorf("error submitting %s: %w", tx.Name, err)
+		}
+		if _, err := utils.WaitForTransaction(m.Client, hash); err != nil {
+			return fmt.Errorf("error waiting for %s: %w", tx.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package prediction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Prediction test addresses
+var (
+	factoryAddress = common.HexToAddress("0x7B8c48256CaF462670f84c7e849cab216922B8D3")
+	baseAddress    = common.HexToAddress("0x560656C8947564363497E9C78A8BDEff8d3EFF77")
+	nodeAddress    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// The factory method used for address lookups
+const factoryAbi string = `[{"type":"function","name":"getExpectedAddress","stateMutability":"view","inputs":[{"name":"_nodeOperator","type":"address"},{"name":"_salt","type":"uint256"}],"outputs":[{"name":"","type":"address"}]}]`
+
+// Create an offline contract manager with the factory and the given minipool base address
+func newOfflineRocketPool(t *testing.T, minipoolBase common.Address) *rocketpool.RocketPool {
+	encodedAbi, err := rocketpool.EncodeAbiStr(factoryAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := rocketpool.NewOfflineRocketPool(nil, &rocketpool.Deployment{
+		Name: "test",
+		Contracts: map[string]rocketpool.DeploymentContract{
+			"rocketMinipoolFactory": {Address: factoryAddress, Abi: encodedAbi},
+			"rocketMinipoolBase":    {Address: minipoolBase, Abi: encodedAbi},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rp
+}
+
+func TestPredictMinipoolAddress(t *testing.T) {
+
+	// Build the EIP-1167 clone init code of the base contract
+	rp := newOfflineRocketPool(t, baseAddress)
+	initCode := common.FromHex("0x3d602d80600a3d3981f3363d3d373d3d3d363d73" + baseAddress.Hex()[2:] + "5af43d82803e903d91602b57fd5bf3")
+	if len(initCode) != 55 {
+		t.Fatalf("Incorrect init code length %d", len(initCode))
+	}
+
+	// Check the init hash
+	initHash, err := minipool.GetMinipoolInitHash(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initHash != crypto.Keccak256Hash(initCode) {
+		t.Errorf("Incorrect init hash %s", initHash.Hex())
+	}
+
+	// Check the predicted addresses
+	salts := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 255)}
+	addresses, err := minipool.PredictMinipoolAddresses(rp, nodeAddress, salts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, salt := range salts {
+		saltBytes := common.BigToHash(salt)
+		nodeSalt := crypto.Keccak256(nodeAddress.Bytes(), saltBytes.Bytes())
+		expected := common.BytesToAddress(crypto.Keccak256([]byte{0xff}, factoryAddress.Bytes(), nodeSalt, crypto.Keccak256(initCode))[12:])
+		if addresses[i] != expected {
+			t.Errorf("Incorrect address %s for salt %s, expected %s", addresses[i].Hex(), salt.String(), expected.Hex())
+		}
+	}
+	if addresses[0] == addresses[1] {
+		t.Error("Predicted address does not depend on the salt")
+	}
+
+	// Check the single prediction matches the batch
+	address, err := minipool.PredictMinipoolAddress(rp, nodeAddress, salts[1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != addresses[1] {
+		t.Errorf("Incorrect address %s, expected %s", address.Hex(), addresses[1].Hex())
+	}
+
+}
+
+func TestPredictMinipoolAddressWithoutBase(t *testing.T) {
+
+	rp := newOfflineRocketPool(t, common.Address{})
+	if _, err := minipool.PredictMinipoolAddress(rp, nodeAddress, big.NewInt(0), nil); err == nil {
+		t.Error("Expected an error predicting an address without a minipool base contract")
+	}
+
+}
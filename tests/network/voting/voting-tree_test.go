@@ -0,0 +1,135 @@
+package voting
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Get a leaf the way RocketDAOProtocolVerifier.getMerkleLeaf does: keccak256(abi.encodePacked(sum))
+func getLeaf(sum int64) types.VotingTreeNode {
+	value := big.NewInt(sum)
+	return types.VotingTreeNode{
+		Sum:  value,
+		Hash: crypto.Keccak256Hash(common.LeftPadBytes(value.Bytes(), 32)),
+	}
+}
+
+// Get a parent the way RocketDAOProtocolVerifier.getMerkleParent does:
+// keccak256(abi.encodePacked(left.hash, left.sum, right.hash, right.sum))
+func getParent(left types.VotingTreeNode, right types.VotingTreeNode) types.VotingTreeNode {
+	packed := []byte{}
+	packed = append(packed, left.Hash.Bytes()...)
+	packed = append(packed, common.LeftPadBytes(left.Sum.Bytes(), 32)...)
+	packed = append(packed, right.Hash.Bytes()...)
+	packed = append(packed, common.LeftPadBytes(right.Sum.Bytes(), 32)...)
+	return types.VotingTreeNode{
+		Sum:  new(big.Int).Add(left.Sum, right.Sum),
+		Hash: crypto.Keccak256Hash(packed),
+	}
+}
+
+// Check that two tree nodes match
+func checkNode(t *testing.T, name string, node types.VotingTreeNode, expected types.VotingTreeNode) {
+	if node.Sum.Cmp(expected.Sum) != 0 || node.Hash != expected.Hash {
+		t.Errorf("Incorrect %s %s / %s, expected %s / %s", name, node.Sum.String(), node.Hash.Hex(), expected.Sum.String(), expected.Hash.Hex())
+	}
+}
+
+// Get a snapshot of three nodes, where the third delegates to the first
+func getVotingInfos() []types.NodeVotingInfo {
+	nodes := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")}
+	return []types.NodeVotingInfo{
+		{NodeAddress: nodes[0], VotingPower: big.NewInt(10), Delegate: nodes[0]},
+		{NodeAddress: nodes[1], VotingPower: big.NewInt(20), Delegate: nodes[1]},
+		{NodeAddress: nodes[2], VotingPower: big.NewInt(5), Delegate: nodes[0]},
+	}
+}
+
+func TestVotingTree(t *testing.T) {
+
+	// Create the tree; three nodes are padded to four leaves and delegated power goes to the delegate's leaf
+	tree := network.NewVotingTree(100, getVotingInfos())
+	leaves := []types.VotingTreeNode{getLeaf(15), getLeaf(20), getLeaf(0), getLeaf(0)}
+	left := getParent(leaves[0], leaves[1])
+	right := getParent(leaves[2], leaves[3])
+	root := getParent(left, right)
+
+	// Check the structure
+	if tree.BlockNumber != 100 {
+		t.Errorf("Incorrect block number %d", tree.BlockNumber)
+	}
+	if tree.GetDepth() != 2 {
+		t.Fatalf("Incorrect depth %d", tree.GetDepth())
+	}
+	checkNode(t, "root", tree.GetRoot(), root)
+	if tree.GetRoot().Sum.Int64() != 35 {
+		t.Errorf("Incorrect total voting power %s", tree.GetRoot().Sum.String())
+	}
+
+	// Check the nodes by index, where the root is 1 and the children of i are 2i and 2i+1
+	expected := []types.VotingTreeNode{root, left, right, leaves[0], leaves[1], leaves[2], leaves[3]}
+	for i, node := range expected {
+		actual, exists := tree.GetNode(uint64(i + 1))
+		if !exists {
+			t.Fatalf("Node %d does not exist", i+1)
+		}
+		checkNode(t, "node", actual, node)
+	}
+	for _, index := range []uint64{0, 8} {
+		if _, exists := tree.GetNode(index); exists {
+			t.Errorf("Node %d exists", index)
+		}
+	}
+
+}
+
+func TestVotingTreePollard(t *testing.T) {
+
+	tree := network.NewVotingTree(100, getVotingInfos())
+
+	// The pollard is one round below the root
+	pollard := tree.GetPollard(1)
+	if len(pollard) != 2 {
+		t.Fatalf("Incorrect pollard size %d", len(pollard))
+	}
+	checkNode(t, "pollard node", pollard[0], getParent(getLeaf(15), getLeaf(20)))
+	checkNode(t, "pollard node", pollard[1], getParent(getLeaf(0), getLeaf(0)))
+
+	// The pollard's nodes hash back up to the root
+	checkNode(t, "pollard root", getParent(pollard[0], pollard[1]), tree.GetRoot())
+
+	// Rounds deeper than the tree give the leaves
+	pollard = tree.GetPollard(5)
+	if len(pollard) != 4 {
+		t.Fatalf("Incorrect pollard size %d", len(pollard))
+	}
+	checkNode(t, "pollard leaf", pollard[0], getLeaf(15))
+
+	// The pollard is a copy, so changing it doesn't change the tree
+	pollard[0] = getLeaf(1)
+	if node, _ := tree.GetNode(4); node.Sum.Int64() != 15 {
+		t.Error("Changing the pollard changed the tree")
+	}
+
+}
+
+func TestVotingTreeSingleNode(t *testing.T) {
+
+	// A single node is both the root and the only leaf
+	infos := getVotingInfos()[:1]
+	tree := network.NewVotingTree(1, infos)
+	if tree.GetDepth() != 0 {
+		t.Errorf("Incorrect depth %d", tree.GetDepth())
+	}
+	checkNode(t, "root", tree.GetRoot(), getLeaf(10))
+	if pollard := tree.GetPollard(2); len(pollard) != 1 {
+		t.Errorf("Incorrect pollard size %d", len(pollard))
+	}
+
+}
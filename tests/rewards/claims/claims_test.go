@@ -0,0 +1,156 @@
+package claims
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// A node's rewards in the test tree
+type nodeRewards struct {
+	address          common.Address
+	rewardNetwork    uint64
+	collateralRPL    *big.Int
+	oracleDaoRPL     *big.Int
+	smoothingPoolETH *big.Int
+}
+
+// Get the test rewards of four nodes
+func getNodeRewards() []nodeRewards {
+	return []nodeRewards{
+		{common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), 0, eth.EthToWei(10), big.NewInt(0), eth.EthToWei(0.5)},
+		{common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"), 0, eth.EthToWei(20), eth.EthToWei(1), big.NewInt(0)},
+		{common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906"), 1, eth.EthToWei(5), big.NewInt(0), eth.EthToWei(0.25)},
+		{common.HexToAddress("0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"), 0, big.NewInt(1), big.NewInt(0), big.NewInt(1)},
+	}
+}
+
+// Get a node's leaf: keccak256(abi.encodePacked(address, network, rpl, eth))
+func getLeaf(node nodeRewards) common.Hash {
+	rpl := new(big.Int).Add(node.collateralRPL, node.oracleDaoRPL)
+	return crypto.Keccak256Hash(
+		node.address.Bytes(),
+		common.LeftPadBytes(new(big.Int).SetUint64(node.rewardNetwork).Bytes(), 32),
+		common.LeftPadBytes(rpl.Bytes(), 32),
+		common.LeftPadBytes(node.smoothingPoolETH.Bytes(), 32),
+	)
+}
+
+// Hash a pair of nodes in sorted order
+func hashPair(a common.Hash, b common.Hash) common.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+}
+
+// Build the rewards file of a two-level tree over the four nodes
+func getRewardsFile(t *testing.T, nodes []nodeRewards) ([]byte, common.Hash) {
+	leaves := make([]common.Hash, len(nodes))
+	for i, node := range nodes {
+		leaves[i] = getLeaf(node)
+	}
+	parents := []common.Hash{hashPair(leaves[0], leaves[1]), hashPair(leaves[2], leaves[3])}
+	root := hashPair(parents[0], parents[1])
+
+	nodeRewards := map[string]any{}
+	for i, node := range nodes {
+		proof := []string{leaves[i^1].Hex(), parents[(i/2)^1].Hex()}
+		nodeRewards[strings.ToLower(node.address.Hex())] = map[string]any{
+			"rewardNetwork":    node.rewardNetwork,
+			"collateralRpl":    node.collateralRPL.String(),
+			"oracleDaoRpl":     node.oracleDaoRPL.String(),
+			"smoothingPoolEth": node.smoothingPoolETH.String(),
+			"merkleProof":      proof,
+		}
+	}
+	data, err := json.Marshal(map[string]any{
+		"index":       7,
+		"merkleRoot":  root.Hex(),
+		"nodeRewards": nodeRewards,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, root
+}
+
+func TestVerifyIntervalClaim(t *testing.T) {
+
+	nodes := getNodeRewards()
+	data, root := getRewardsFile(t, nodes)
+
+	// Every node's claim proves against the root
+	for _, node := range nodes {
+		claim, exists, err := rewards.GetIntervalClaimFromFile(data, node.address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Fatalf("Node %s is missing from the rewards file", node.address.Hex())
+		}
+		if claim.Index != 7 || claim.MerkleRoot != root || claim.RewardNetwork != node.rewardNetwork {
+			t.Errorf("Incorrect claim %+v", claim)
+		}
+		if claim.GetAmountRPL().Cmp(new(big.Int).Add(node.collateralRPL, node.oracleDaoRPL)) != 0 {
+			t.Errorf("Incorrect RPL amount %s", claim.GetAmountRPL().String())
+		}
+		if !rewards.VerifyIntervalClaim(node.address, claim) {
+			t.Errorf("Claim for node %s does not verify", node.address.Hex())
+		}
+	}
+
+	// Tampered claims don't verify
+	claim, _, err := rewards.GetIntervalClaimFromFile(data, nodes[0].address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewards.VerifyIntervalClaim(nodes[1].address, claim) {
+		t.Error("Claim verified for another node")
+	}
+	tampered := claim
+	tampered.SmoothingPoolETH = eth.EthToWei(0.6)
+	if rewards.VerifyIntervalClaim(nodes[0].address, tampered) {
+		t.Error("Claim with a changed ETH amount verified")
+	}
+	tampered = claim
+	tampered.RewardNetwork = 1
+	if rewards.VerifyIntervalClaim(nodes[0].address, tampered) {
+		t.Error("Claim with a changed reward network verified")
+	}
+	tampered = claim
+	tampered.MerkleProof = claim.MerkleProof[:1]
+	if rewards.VerifyIntervalClaim(nodes[0].address, tampered) {
+		t.Error("Claim with a truncated proof verified")
+	}
+
+}
+
+func TestGetIntervalClaimFromFile(t *testing.T) {
+
+	data, _ := getRewardsFile(t, getNodeRewards())
+
+	// Nodes without rewards
+	if _, exists, err := rewards.GetIntervalClaimFromFile(data, common.HexToAddress("0x01")); err != nil || exists {
+		t.Errorf("Incorrect result for a node without rewards: exists %t, error %v", exists, err)
+	}
+
+	// Invalid files
+	if _, _, err := rewards.GetIntervalClaimFromFile([]byte("{"), common.HexToAddress("0x01")); err == nil {
+		t.Error("Expected an error decoding an invalid file")
+	}
+	node := getNodeRewards()[0].address
+	invalid := []byte(`{"index":1,"merkleRoot":"0x00","nodeRewards":{"` + node.Hex() + `":{"collateralRpl":"ten"}}}`)
+	if _, _, err := rewards.GetIntervalClaimFromFile(invalid, node); err == nil {
+		t.Error("Expected an error decoding an invalid amount")
+	}
+
+}
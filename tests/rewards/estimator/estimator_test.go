@@ -0,0 +1,101 @@
+package estimator
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Create an estimator for a 28 day interval with 1000 RPL pending, 70% of it for node operators
+func getEstimator() *rewards.Estimator {
+	return &rewards.Estimator{
+		IntervalStart:              time.Unix(1700000000, 0),
+		IntervalDuration:           28 * 24 * time.Hour,
+		PendingRPLRewards:          eth.EthToWei(1000),
+		NodeOperatorRewardsPercent: eth.EthToWei(0.7),
+		TotalEffectiveRPLStake:     eth.EthToWei(1000),
+		SmoothingPoolBalance:       eth.EthToWei(1),
+		SmoothingPoolMinipoolCount: 10,
+	}
+}
+
+func TestEstimateSmoothingPoolBalance(t *testing.T) {
+
+	estimator := getEstimator()
+
+	// A quarter of the way through the interval, the balance is projected to grow fourfold
+	balance := estimator.EstimateSmoothingPoolBalance(estimator.IntervalStart.Add(7 * 24 * time.Hour))
+	if balance.Cmp(eth.EthToWei(4)) != 0 {
+		t.Errorf("Incorrect projected balance %s", balance.String())
+	}
+
+	// The balance is used as is at the start of the interval and after it ends
+	for _, at := range []time.Time{estimator.IntervalStart, estimator.IntervalStart.Add(-time.Hour), estimator.GetIntervalEnd()} {
+		if balance := estimator.EstimateSmoothingPoolBalance(at); balance.Cmp(estimator.SmoothingPoolBalance) != 0 {
+			t.Errorf("Incorrect balance %s at %s", balance.String(), at)
+		}
+	}
+
+	// Less than a second into the interval
+	estimator.IntervalDuration = 10 * time.Second
+	balance = estimator.EstimateSmoothingPoolBalance(estimator.IntervalStart.Add(500 * time.Millisecond))
+	if balance.Cmp(eth.EthToWei(20)) != 0 {
+		t.Errorf("Incorrect projected balance %s", balance.String())
+	}
+
+}
+
+func TestEstimateNodeRewards(t *testing.T) {
+
+	// Minipools with 8 and 16 ETH bonds
+	estimator := getEstimator()
+	minipools := []rewards.EstimatorMinipool{
+		{NodeDepositBalance: eth.EthToWei(8), NodeFee: eth.EthToWei(0.14)},
+		{NodeDepositBalance: eth.EthToWei(16), NodeFee: eth.EthToWei(0.05)},
+	}
+	at := estimator.IntervalStart.Add(7 * 24 * time.Hour)
+	estimate := estimator.EstimateNodeRewards(eth.EthToWei(100), eth.EthToWei(100), true, minipools, at)
+
+	// Check the interval end
+	if !estimate.IntervalEnd.Equal(estimator.GetIntervalEnd()) {
+		t.Errorf("Incorrect interval end %s", estimate.IntervalEnd)
+	}
+
+	// 10% of the effective stake earns 10% of the 700 RPL for node operators
+	if estimate.RPLRewards.Cmp(eth.EthToWei(70)) != 0 {
+		t.Errorf("Incorrect RPL rewards %s", estimate.RPLRewards.String())
+	}
+
+	// The minipools earn 8 + 24 * 14% and 16 + 16 * 5% of the 32 ETH slices of a projected 4 ETH pool over 10 minipools
+	if expected := eth.EthToWei(0.352); estimate.SmoothingPoolETH.Cmp(expected) != 0 {
+		t.Errorf("Incorrect smoothing pool ETH %s, expected %s", estimate.SmoothingPoolETH.String(), expected.String())
+	}
+
+	// 70 RPL on a 100 RPL stake every 28 days
+	if expected := 0.7 * 365 / 28; math.Abs(estimate.RPLAPR-expected) > 1e-9 {
+		t.Errorf("Incorrect RPL APR %f, expected %f", estimate.RPLAPR, expected)
+	}
+
+	// Nodes outside the smoothing pool get no ETH
+	estimate = estimator.EstimateNodeRewards(eth.EthToWei(100), eth.EthToWei(100), false, minipools, at)
+	if estimate.SmoothingPoolETH.Sign() != 0 {
+		t.Errorf("Incorrect smoothing pool ETH %s", estimate.SmoothingPoolETH.String())
+	}
+
+}
+
+func TestEstimateEmptyNetwork(t *testing.T) {
+
+	estimator := getEstimator()
+	estimator.TotalEffectiveRPLStake = big.NewInt(0)
+	estimator.SmoothingPoolMinipoolCount = 0
+	estimate := estimator.EstimateNodeRewards(big.NewInt(0), big.NewInt(0), true, nil, estimator.IntervalStart.Add(time.Hour))
+	if estimate.RPLRewards.Sign() != 0 || estimate.SmoothingPoolETH.Sign() != 0 || estimate.RPLAPR != 0 {
+		t.Errorf("Incorrect estimate %+v", estimate)
+	}
+
+}
@@ -0,0 +1,81 @@
+package batch
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+)
+
+// The token methods used by the fixed-supply RPL swap
+const tokenAbi string = `[
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"swapTokens","stateMutability":"nonpayable","inputs":[{"name":"amount","type":"uint256"}],"outputs":[]}
+]`
+
+// Batch test addresses
+var (
+	rplAddress      = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	fixedRplAddress = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+)
+
+// Create an offline contract manager with the RPL token contracts
+func newOfflineRocketPool(t *testing.T) *rocketpool.RocketPool {
+	encodedAbi, err := rocketpool.EncodeAbiStr(tokenAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := rocketpool.NewOfflineRocketPool(nil, &rocketpool.Deployment{
+		Name: "test",
+		Contracts: map[string]rocketpool.DeploymentContract{
+			"rocketTokenRPL":            {Address: rplAddress, Abi: encodedAbi},
+			"rocketTokenRPLFixedSupply": {Address: fixedRplAddress, Abi: encodedAbi},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rp
+}
+
+func TestDescribeBatch(t *testing.T) {
+
+	// Add the swap transactions built by the bindings
+	rp := newOfflineRocketPool(t)
+	batch := rocketpool.NewTxBatch()
+	amount := big.NewInt(1000)
+	for _, tx := range tokens.NewSwapFixedSupplyRPLTransactions(rp, rplAddress, amount, big.NewInt(0)) {
+		batch.AddTransaction(tx)
+	}
+
+	// Add a transaction from its parts
+	batch.Add("swap again", nil, nil, rocketpool.DescribeContractCall(rp, "rocketTokenRPL", "swapTokens", amount))
+
+	// Describe the batch
+	descriptions, err := batch.Describe(&bind.TransactOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descriptions) != 3 {
+		t.Fatalf("Incorrect description count %d", len(descriptions))
+	}
+	if descriptions[0].ContractAddress != fixedRplAddress || descriptions[0].Method != "approve" {
+		t.Errorf("Incorrect approval description %+v", descriptions[0])
+	}
+	for _, description := range descriptions[1:] {
+		if description.ContractAddress != rplAddress || description.Method != "swapTokens" {
+			t.Errorf("Incorrect swap description %+v", description)
+		}
+	}
+
+	// Transactions without a describer can't be described
+	batch.Add("undescribed", nil, nil, nil)
+	if _, err := batch.Describe(&bind.TransactOpts{}); err == nil {
+		t.Error("Expected an error describing a transaction without a describer")
+	}
+
+}
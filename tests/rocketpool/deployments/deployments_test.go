@@ -0,0 +1,129 @@
+package deployments
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/config"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The deposit pool method used for offline encoding
+const depositPoolAbi string = `[{"type":"function","name":"deposit","stateMutability":"payable","inputs":[],"outputs":[]}]`
+
+func TestEmbeddedDeployments(t *testing.T) {
+
+	// Mainnet and Holesky are embedded and match their chain profiles
+	names := rocketpool.GetEmbeddedDeploymentNames()
+	if len(names) != 2 || names[0] != "holesky" || names[1] != "mainnet" {
+		t.Fatalf("Incorrect embedded deployments %v", names)
+	}
+	for _, name := range names {
+		deployment, err := rocketpool.GetEmbeddedDeployment(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		profile, err := config.GetChainProfile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deployment.Name != name || deployment.ChainID != profile.ChainID || deployment.RocketStorageAddress != profile.RocketStorageAddress {
+			t.Errorf("Incorrect %s deployment %s / %d / %s", name, deployment.Name, deployment.ChainID, deployment.RocketStorageAddress.Hex())
+		}
+	}
+
+	// Unknown deployments
+	if _, err := rocketpool.GetEmbeddedDeployment("sepolia"); err == nil {
+		t.Error("Expected an error getting an unknown deployment")
+	}
+
+}
+
+// Encode a deposit with an offline contract manager and check it against the manifest
+func checkOfflineDeposit(t *testing.T, deployment *rocketpool.Deployment) {
+
+	// Create the offline contract manager without a client
+	rp, err := rocketpool.NewOfflineRocketPool(nil, deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every binding contract in the manifest has a valid ABI
+	for _, contractName := range rocketpool.BindingContractNames {
+		if _, exists := deployment.Contracts[contractName]; !exists {
+			continue
+		}
+		if _, err := rp.GetABI(contractName, nil); err != nil {
+			t.Errorf("Error decoding contract %s ABI: %s", contractName, err.Error())
+		}
+	}
+
+	// Encode the deposit
+	opts := &bind.TransactOpts{
+		From:  common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Value: big.NewInt(1e18),
+	}
+	tx, err := rocketpool.CaptureTransaction(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return deposit.Deposit(rp, opts)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositPool := deployment.Contracts["rocketDepositPool"]
+	contractAbi, err := rp.GetABI("rocketDepositPool", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.To != depositPool.Address || tx.From != opts.From || tx.Value.Cmp(opts.Value) != 0 {
+		t.Errorf("Incorrect transaction %s -> %s, value %s", tx.From.Hex(), tx.To.Hex(), tx.Value.String())
+	}
+	if !bytes.Equal(tx.Data, contractAbi.Methods["deposit"].ID) {
+		t.Errorf("Incorrect calldata %s", tx.Data.String())
+	}
+
+}
+
+func TestOfflineTransactions(t *testing.T) {
+
+	// A manifest in the format written by ExportDeployment
+	encodedAbi, err := rocketpool.EncodeAbiStr(depositPoolAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"name":"test","chainId":31337,"rocketStorageAddress":"0x70a5F2eB9e4C003B105399b471DAeDbC8d00B1c5","contracts":{` +
+		`"rocketDepositPool":{"address":"0x2279B7A0a67DB372996a5FaB50D91eAA73d2eBe6","abi":"` + encodedAbi + `"}}}`
+	deployment, err := rocketpool.LoadDeployment(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOfflineDeposit(t, deployment)
+
+	// Manifests without contracts are rejected
+	if _, err := rocketpool.NewOfflineRocketPool(nil, &rocketpool.Deployment{Name: "empty"}); err == nil {
+		t.Error("Expected an error creating a contract manager from an empty manifest")
+	}
+
+}
+
+func TestEmbeddedOfflineTransactions(t *testing.T) {
+
+	for _, name := range rocketpool.GetEmbeddedDeploymentNames() {
+		t.Run(name, func(t *testing.T) {
+			deployment, err := rocketpool.GetEmbeddedDeployment(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(deployment.Contracts) == 0 {
+				t.Skipf("The %s manifest has no contracts; run go run ./rocketpool/deployments/export -network %s against a synced node", name, name)
+			}
+			checkOfflineDeposit(t, deployment)
+		})
+	}
+
+}
@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Error kinds that revert reasons can be classified as
+var revertKinds = []error{
+	rocketpool.ErrNotRegistered,
+	rocketpool.ErrSettingDisabled,
+	rocketpool.ErrOutsideWindow,
+	rocketpool.ErrInsufficientBalance,
+	rocketpool.ErrConsensusNotReached,
+}
+
+func TestRevertReasonKinds(t *testing.T) {
+
+	// Contract revert reasons and the kind they should be classified as; nil means no kind
+	tests := []struct {
+		reason string
+		kind   error
+	}{
+		// Registration
+		{"Invalid node", rocketpool.ErrNotRegistered},
+		{"Invalid trusted node", rocketpool.ErrNotRegistered},
+		{"Invalid minipool owner", rocketpool.ErrNotRegistered},
+		{"Invalid or outdated network contract", nil},
+		{"Invalid node address", nil},
+
+		// Disabled settings
+		{"Node deposits are currently disabled", rocketpool.ErrSettingDisabled},
+		{"Deposits into Rocket Pool are currently disabled", rocketpool.ErrSettingDisabled},
+		{"Submitting balances is currently disabled", rocketpool.ErrSettingDisabled},
+		{"Setting is disabled for this minipool", nil},
+
+		// Time windows
+		{"Refute window has not yet passed", rocketpool.ErrOutsideWindow},
+		{"Not enough time has passed since the last bond reduction", rocketpool.ErrOutsideWindow},
+		{"The withdrawal cooldown period has not passed", rocketpool.ErrOutsideWindow},
+		{"Wait period not satisfied", rocketpool.ErrOutsideWindow},
+		{"Invalid window size", nil},
+
+		// Balances
+		{"ERC20: transfer amount exceeds balance", rocketpool.ErrInsufficientBalance},
+		{"ERC20: insufficient allowance", rocketpool.ErrInsufficientBalance},
+		{"Insufficient ETH balance for exchange", rocketpool.ErrInsufficientBalance},
+		{"Withdrawal amount exceeds node's staked RPL balance", rocketpool.ErrInsufficientBalance},
+		{"Minipool has insufficient balance to distribute", nil},
+		{"Invalid RPL stake amount", nil},
+
+		// Consensus
+		{"Consensus has not been reached", rocketpool.ErrConsensusNotReached},
+		{"Proposal has not succeeded, has expired or has already been executed", rocketpool.ErrConsensusNotReached},
+		{"Consensus setting must be >= 51%", nil},
+		{"Network balances for an equal or higher block are set", nil},
+	}
+
+	for _, test := range tests {
+		simErr := &rocketpool.SimulationError{Reason: test.reason, Err: errors.New("execution reverted")}
+		for _, kind := range revertKinds {
+			if is := errors.Is(simErr, kind); is != (kind == test.kind) {
+				t.Errorf("Incorrect classification of revert reason %q as %q: %t", test.reason, kind.Error(), is)
+			}
+		}
+	}
+
+}
@@ -0,0 +1,46 @@
+package inflation
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/tokens"
+)
+
+func TestSupplyAt(t *testing.T) {
+
+	// 50% inflation per day, with the supply rounded down after each day like the token contract
+	calcTime := time.Unix(1700000000, 0)
+	calc := tokens.NewInflationCalc(big.NewInt(5), big.NewInt(15e17), 24*time.Hour, calcTime)
+
+	tests := []struct {
+		at        time.Time
+		intervals uint64
+		supply    int64
+	}{
+		{calcTime.Add(-time.Hour), 0, 5},
+		{calcTime.Add(23 * time.Hour), 0, 5},
+		{calcTime.Add(24 * time.Hour), 1, 7},
+		{calcTime.Add(47 * time.Hour), 1, 7},
+		{calcTime.Add(48 * time.Hour), 2, 10},
+		{calcTime.Add(72 * time.Hour), 3, 15},
+	}
+	for _, test := range tests {
+		if intervals := calc.GetIntervalsPassed(test.at); intervals != test.intervals {
+			t.Errorf("Incorrect intervals passed at %s: expected %d, got %d", test.at, test.intervals, intervals)
+		}
+		if supply := calc.GetSupplyAt(test.at); supply.Cmp(big.NewInt(test.supply)) != 0 {
+			t.Errorf("Incorrect supply at %s: expected %d, got %s", test.at, test.supply, supply.String())
+		}
+		if newTokens := calc.GetNewTokensAt(test.at); newTokens.Cmp(big.NewInt(test.supply-5)) != 0 {
+			t.Errorf("Incorrect new tokens at %s: expected %d, got %s", test.at, test.supply-5, newTokens.String())
+		}
+	}
+
+	// The snapshot isn't modified
+	if calc.TotalSupply.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Incorrect snapshot supply %s", calc.TotalSupply.String())
+	}
+
+}
@@ -0,0 +1,129 @@
+package permit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/rocket-pool/rocketpool-go/tokens"
+)
+
+// Permit test values
+const (
+	ownerKey     string = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	tokenName    string = "Rocket Pool Protocol"
+	tokenAddress string = "0xD33526068D116cE69F19A9ee46F0bd304F21A51f"
+)
+
+// Get the EIP-712 typed data of a permit, for hashing with go-ethereum's implementation
+func getTypedData(permit tokens.Permit, chainID *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              tokenName,
+			Version:           tokens.RPLPermitDomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: tokenAddress,
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    permit.Owner.Hex(),
+			"spender":  permit.Spender.Hex(),
+			"value":    (*math.HexOrDecimal256)(permit.Value),
+			"nonce":    (*math.HexOrDecimal256)(permit.Nonce),
+			"deadline": (*math.HexOrDecimal256)(permit.Deadline),
+		},
+	}
+}
+
+func TestSignPermit(t *testing.T) {
+
+	// Create a permit
+	key, err := crypto.HexToECDSA(ownerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(1)
+	permit := tokens.Permit{
+		Owner:    crypto.PubkeyToAddress(key.PublicKey),
+		Spender:  common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Value:    new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)),
+		Nonce:    big.NewInt(7),
+		Deadline: big.NewInt(1700000000),
+	}
+
+	// Check the digest matches go-ethereum's EIP-712 hashing
+	domainSeparator := tokens.GetPermitDomainSeparator(tokenName, tokens.RPLPermitDomainVersion, chainID, common.HexToAddress(tokenAddress))
+	expectedDigest, _, err := apitypes.TypedDataAndHash(getTypedData(permit, chainID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := permit.GetDigest(domainSeparator)
+	if digest != common.BytesToHash(expectedDigest) {
+		t.Errorf("Incorrect permit digest %s, expected %s", digest.Hex(), hexutil.Encode(expectedDigest))
+	}
+
+	// Sign the permit
+	signed, err := tokens.SignPermit(permit, domainSeparator, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.V != 27 && signed.V != 28 {
+		t.Errorf("Incorrect signature V %d", signed.V)
+	}
+
+	// Check the signature recovers to the owner
+	signature := append(append(signed.R.Bytes(), signed.S.Bytes()...), signed.V-27)
+	pubkey, err := crypto.SigToPub(digest.Bytes(), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*pubkey) != permit.Owner {
+		t.Errorf("Incorrect signer %s, expected %s", crypto.PubkeyToAddress(*pubkey).Hex(), permit.Owner.Hex())
+	}
+
+	// A different chain must give a different digest
+	otherDomainSeparator := tokens.GetPermitDomainSeparator(tokenName, tokens.RPLPermitDomainVersion, big.NewInt(17000), common.HexToAddress(tokenAddress))
+	if permit.GetDigest(otherDomainSeparator) == digest {
+		t.Error("Permit digest does not depend on the chain ID")
+	}
+
+}
+
+func TestSignPermitWrongOwner(t *testing.T) {
+
+	key, err := crypto.HexToECDSA(ownerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	permit := tokens.Permit{
+		Owner:    common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Spender:  crypto.PubkeyToAddress(key.PublicKey),
+		Value:    big.NewInt(1),
+		Nonce:    big.NewInt(0),
+		Deadline: big.NewInt(1700000000),
+	}
+	if _, err := tokens.SignPermit(permit, common.Hash{}, key); err == nil {
+		t.Error("Expected an error signing a permit for another owner")
+	}
+
+}
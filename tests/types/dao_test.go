@@ -0,0 +1,88 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestDiffSettingValues(t *testing.T) {
+
+	// Settings
+	oldValues := []types.SettingValue{
+		{ContractName: "rocketDAOProtocolSettingsDeposit", Path: "deposit.enabled", Value: true},
+		{ContractName: "rocketDAOProtocolSettingsDeposit", Path: "deposit.fee", Value: big.NewInt(500)},
+		{ContractName: "rocketDAOProtocolSettingsNetwork", Path: "network.reth.deposit.delay", Value: big.NewInt(5760)},
+		{ContractName: "rocketDAOProtocolSettingsSecurity", Path: "security.address", Value: common.HexToAddress("0x01")},
+		{ContractName: "rocketDAOProtocolSettingsNetwork", Path: "network.removed", Value: big.NewInt(1)},
+	}
+	newValues := []types.SettingValue{
+		{ContractName: "rocketDAOProtocolSettingsDeposit", Path: "deposit.enabled", Value: false},
+		{ContractName: "rocketDAOProtocolSettingsDeposit", Path: "deposit.fee", Value: big.NewInt(500)},
+		{ContractName: "rocketDAOProtocolSettingsNetwork", Path: "network.reth.deposit.delay", Value: big.NewInt(0)},
+		{ContractName: "rocketDAOProtocolSettingsSecurity", Path: "security.address", Value: common.HexToAddress("0x01")},
+		{ContractName: "rocketDAOProtocolSettingsNetwork", Path: "network.added", Value: big.NewInt(1)},
+		{ContractName: "rocketDAOProtocolSettingsDeposit", Path: "deposit.fee.other", Value: big.NewInt(500)},
+	}
+
+	// Get the changes
+	changes := types.DiffSettingValues(oldValues, newValues)
+	if len(changes) != 2 {
+		t.Fatalf("Incorrect change count %d: %+v", len(changes), changes)
+	}
+
+	// Check the changes are in the order of the new values
+	if changes[0].Path != "deposit.enabled" || changes[0].OldValue != true || changes[0].NewValue != false {
+		t.Errorf("Incorrect change %+v", changes[0])
+	}
+	if changes[1].Path != "network.reth.deposit.delay" || changes[1].ContractName != "rocketDAOProtocolSettingsNetwork" {
+		t.Errorf("Incorrect change %+v", changes[1])
+	}
+	if changes[1].OldValue.(*big.Int).Int64() != 5760 || changes[1].NewValue.(*big.Int).Int64() != 0 {
+		t.Errorf("Incorrect change values %v -> %v", changes[1].OldValue, changes[1].NewValue)
+	}
+
+}
+
+func TestDiffSettingValuesTypes(t *testing.T) {
+
+	// Values of different types are always different
+	oldValues := []types.SettingValue{
+		{ContractName: "a", Path: "int.to.bool", Value: big.NewInt(1)},
+		{ContractName: "a", Path: "nil.int", Value: (*big.Int)(nil)},
+		{ContractName: "a", Path: "both.nil", Value: (*big.Int)(nil)},
+		{ContractName: "a", Path: "address", Value: common.HexToAddress("0x01")},
+	}
+	newValues := []types.SettingValue{
+		{ContractName: "a", Path: "int.to.bool", Value: true},
+		{ContractName: "a", Path: "nil.int", Value: big.NewInt(0)},
+		{ContractName: "a", Path: "both.nil", Value: (*big.Int)(nil)},
+		{ContractName: "a", Path: "address", Value: common.HexToAddress("0x02")},
+	}
+	changes := types.DiffSettingValues(oldValues, newValues)
+	changed := map[string]bool{}
+	for _, change := range changes {
+		changed[change.Path] = true
+	}
+	for _, path := range []string{"int.to.bool", "nil.int", "address"} {
+		if !changed[path] {
+			t.Errorf("Setting %s was not reported as changed", path)
+		}
+	}
+	if changed["both.nil"] {
+		t.Error("Setting both.nil was reported as changed")
+	}
+
+	// Settings with the same path on different contracts are separate
+	changes = types.DiffSettingValues(
+		[]types.SettingValue{{ContractName: "a", Path: "p", Value: true}},
+		[]types.SettingValue{{ContractName: "b", Path: "p", Value: false}},
+	)
+	if len(changes) != 0 {
+		t.Errorf("Incorrect change count %d", len(changes))
+	}
+
+}
@@ -0,0 +1,110 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/utils/beacon"
+)
+
+// The test header and its SSZ hash tree root
+var (
+	testHeader = beacon.BlockHeader{
+		Slot:          12345,
+		ProposerIndex: 678,
+		ParentRoot:    common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"),
+		StateRoot:     common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222"),
+		BodyRoot:      common.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333"),
+	}
+	testHeaderRoot = common.HexToHash("0x0239b592de3a9fc7ab229ce2c1c1240dfbd3c8a7d21813806d71d08d37be6627")
+)
+
+// Serve the test block and its header, with the given state root in the block and block root in the header
+func newHeaderServer(t *testing.T, blockStateRoot common.Hash, headerRoot common.Hash) *httptest.Server {
+	block := fmt.Sprintf(`{"data":{"message":{"slot":"12345","state_root":"%s","body":{"execution_payload":{"block_number":"100","block_hash":"0x44","timestamp":"1700000000"}}}}}`, blockStateRoot.Hex())
+	header := fmt.Sprintf(`{"data":{"root":"%s","canonical":true,"header":{"message":{"slot":"12345","proposer_index":"678","parent_root":"%s","state_root":"%s","body_root":"%s"}}}}`,
+		headerRoot.Hex(), testHeader.ParentRoot.Hex(), testHeader.StateRoot.Hex(), testHeader.BodyRoot.Hex())
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v2/beacon/blocks/12345":
+			w.Write([]byte(block))
+		case "/eth/v1/beacon/headers/12345":
+			w.Write([]byte(header))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// Map the test slot and verify its state root
+func verifyTestSlot(t *testing.T, server *httptest.Server) (beacon.ExecutionBlockInfo, error) {
+	mapper := beacon.NewBlockMapper(beacon.NewHttpBlockProvider(server.URL), beacon.BeaconConfig{SecondsPerSlot: 12, SlotsPerEpoch: 32})
+	info, err := mapper.GetElBlockForSlot(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info, mapper.VerifyStateRoot(info)
+}
+
+// A block provider that can't get headers
+type blocksOnlyProvider struct{}
+
+// Get a block with no execution payload
+func (p blocksOnlyProvider) GetExecutionBlock(ctx context.Context, blockID string) (beacon.ExecutionBlockInfo, bool, error) {
+	return beacon.ExecutionBlockInfo{}, true, nil
+}
+
+func TestBlockHeaderHashTreeRoot(t *testing.T) {
+
+	if root := testHeader.HashTreeRoot(); root != testHeaderRoot {
+		t.Errorf("Incorrect hash tree root %s", root.Hex())
+	}
+
+	// Every field is committed to
+	changed := testHeader
+	changed.ProposerIndex++
+	if changed.HashTreeRoot() == testHeaderRoot {
+		t.Error("Changing the proposer index didn't change the root")
+	}
+
+}
+
+func TestVerifyStateRoot(t *testing.T) {
+
+	// The block's state root matches its header
+	server := newHeaderServer(t, testHeader.StateRoot, testHeaderRoot)
+	defer server.Close()
+	info, err := verifyTestSlot(t, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.BeaconStateRoot != testHeader.StateRoot || info.ElBlockNumber != 100 {
+		t.Errorf("Incorrect block info %+v", info)
+	}
+
+	// The block reports a different state root than its header
+	server = newHeaderServer(t, common.HexToHash("0x55"), testHeaderRoot)
+	defer server.Close()
+	if _, err := verifyTestSlot(t, server); err == nil {
+		t.Error("Expected an error verifying a mismatched state root")
+	}
+
+	// The header doesn't hash to its block root
+	server = newHeaderServer(t, testHeader.StateRoot, common.HexToHash("0x66"))
+	defer server.Close()
+	if _, err := verifyTestSlot(t, server); err == nil {
+		t.Error("Expected an error verifying a header that doesn't match its root")
+	}
+
+	// The provider can't get headers
+	mapper := beacon.NewBlockMapper(blocksOnlyProvider{}, beacon.BeaconConfig{SecondsPerSlot: 12, SlotsPerEpoch: 32})
+	if err := mapper.VerifyStateRoot(beacon.ExecutionBlockInfo{Slot: 1}); err == nil {
+		t.Error("Expected an error verifying without a header provider")
+	}
+
+}
@@ -0,0 +1,115 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/beacon"
+)
+
+// Get a test pubkey filled with a single byte
+func getPubkey(b byte) types.ValidatorPubkey {
+	var pubkey types.ValidatorPubkey
+	for i := range pubkey {
+		pubkey[i] = b
+	}
+	return pubkey
+}
+
+// Serve a Beacon node with two validators over epochs 10 and 11
+// Validator 1 misses its epoch 11 attestation and its proposal; validator 2 activates in epoch 11
+func newPerformanceServer(t *testing.T) *httptest.Server {
+	validators := fmt.Sprintf(`{"data":[
+		{"index":"1","status":"active_ongoing","validator":{"pubkey":"0x%s","slashed":false,"activation_epoch":"0","exit_epoch":"18446744073709551615"}},
+		{"index":"2","status":"active_slashed","validator":{"pubkey":"0x%s","slashed":true,"activation_epoch":"11","exit_epoch":"18446744073709551615"}}
+	]}`, getPubkey(1).Hex(), getPubkey(2).Hex())
+	rewards := map[string]string{
+		"10": `{"data":{"total_rewards":[{"validator_index":"1","source":"100"},{"validator_index":"2","source":"0"}]}}`,
+		"11": `{"data":{"total_rewards":[{"validator_index":"1","source":"-100"},{"validator_index":"2","source":"100"}]}}`,
+	}
+	duties := map[string]string{
+		"10": `{"data":[{"validator_index":"2","slot":"320"},{"validator_index":"9","slot":"321"}]}`,
+		"11": `{"data":[{"validator_index":"1","slot":"352"}]}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/eth/v1/beacon/states/head/validators":
+			w.Write([]byte(validators))
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/rewards/attestations/"):
+			var indices []string
+			if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&indices) != nil || len(indices) != 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(rewards[strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/rewards/attestations/")]))
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/validator/duties/proposer/"):
+			w.Write([]byte(duties[strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/duties/proposer/")]))
+		case r.URL.Path == "/eth/v1/beacon/headers/320":
+			w.Write([]byte(`{"data":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestHttpPerformanceProvider(t *testing.T) {
+
+	// Get the performance
+	server := newPerformanceServer(t)
+	defer server.Close()
+	provider := beacon.NewHttpPerformanceProvider(server.URL, 10, 11)
+	unknown := getPubkey(3)
+	performances, err := provider.GetValidatorPerformance(context.Background(), []types.ValidatorPubkey{getPubkey(2), unknown, getPubkey(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the validators are returned in the requested order, without the unknown one
+	if len(performances) != 2 {
+		t.Fatalf("Incorrect performance count %d", len(performances))
+	}
+	if performances[0].Pubkey != getPubkey(2) || performances[1].Pubkey != getPubkey(1) {
+		t.Errorf("Incorrect performance order %s, %s", performances[0].Pubkey.Hex(), performances[1].Pubkey.Hex())
+	}
+
+	// Check the validator that activated in the range
+	activated := performances[0]
+	if !activated.Active || !activated.Slashed {
+		t.Errorf("Incorrect status: active %t, slashed %t", activated.Active, activated.Slashed)
+	}
+	if activated.AttestationsExpected != 1 || activated.AttestationsIncluded != 1 {
+		t.Errorf("Incorrect attestations %d / %d", activated.AttestationsIncluded, activated.AttestationsExpected)
+	}
+	if activated.ProposalsExpected != 1 || activated.ProposalsMissed != 0 {
+		t.Errorf("Incorrect proposals: %d expected, %d missed", activated.ProposalsExpected, activated.ProposalsMissed)
+	}
+
+	// Check the validator that missed duties
+	missing := performances[1]
+	if missing.AttestationsExpected != 2 || missing.AttestationsIncluded != 1 {
+		t.Errorf("Incorrect attestations %d / %d", missing.AttestationsIncluded, missing.AttestationsExpected)
+	}
+	if missing.ProposalsExpected != 1 || missing.ProposalsMissed != 1 {
+		t.Errorf("Incorrect proposals: %d expected, %d missed", missing.ProposalsExpected, missing.ProposalsMissed)
+	}
+	if effectiveness, ok := missing.GetAttestationEffectiveness(); !ok || effectiveness != 0.5 {
+		t.Errorf("Incorrect attestation effectiveness %f", effectiveness)
+	}
+
+}
+
+func TestHttpPerformanceProviderInvalidRange(t *testing.T) {
+
+	provider := beacon.NewHttpPerformanceProvider("http://localhost", 11, 10)
+	if _, err := provider.GetValidatorPerformance(context.Background(), []types.ValidatorPubkey{getPubkey(1)}); err == nil {
+		t.Error("Expected an error for an end epoch before the start epoch")
+	}
+
+}
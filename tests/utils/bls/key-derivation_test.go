@@ -0,0 +1,120 @@
+package bls
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/bls"
+)
+
+// The EIP-2333 test vectors
+var keyDerivationVectors = []struct {
+	seed       string
+	masterKey  string
+	childIndex uint32
+	childKey   string
+}{
+	{
+		seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		masterKey:  "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		childIndex: 0,
+		childKey:   "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		seed:       "3141592653589793238462643383279502884197169399375105820974944592",
+		masterKey:  "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		childIndex: 3141592653,
+		childKey:   "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		seed:       "0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+		masterKey:  "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		childIndex: 4294967295,
+		childKey:   "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		seed:       "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		masterKey:  "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		childIndex: 42,
+		childKey:   "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+// Check a secret key against a decimal test vector value
+func checkKey(t *testing.T, name string, key *bls.SecretKey, expected string) {
+	value := new(big.Int).SetBytes(key.Bytes())
+	if value.String() != expected {
+		t.Errorf("Incorrect %s %s, expected %s", name, value.String(), expected)
+	}
+}
+
+func TestKeyDerivationVectors(t *testing.T) {
+
+	for i, vector := range keyDerivationVectors {
+		seed, err := hex.DecodeString(vector.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Derive the master key
+		masterKey, err := bls.DeriveMasterKey(seed)
+		if err != nil {
+			t.Fatalf("Vector %d: %s", i, err.Error())
+		}
+		checkKey(t, "master key", masterKey, vector.masterKey)
+
+		// Derive the child key
+		childKey, err := bls.DeriveChildKey(masterKey, vector.childIndex)
+		if err != nil {
+			t.Fatalf("Vector %d: %s", i, err.Error())
+		}
+		checkKey(t, "child key", childKey, vector.childKey)
+	}
+
+}
+
+func TestDeriveKeyFromPath(t *testing.T) {
+
+	seed, err := hex.DecodeString(keyDerivationVectors[0].seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The master key's path has no indices
+	masterKey, err := bls.DeriveKeyFromPath(seed, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkKey(t, "master key", masterKey, keyDerivationVectors[0].masterKey)
+
+	// Deriving by path must match deriving each child in turn
+	pathKey, err := bls.DeriveKeyFromPath(seed, bls.GetValidatorSigningKeyPath(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	indices, err := bls.ParseKeyPath(bls.GetValidatorSigningKeyPath(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 5 {
+		t.Fatalf("Incorrect signing key path length %d", len(indices))
+	}
+	key := masterKey
+	for _, index := range indices {
+		if key, err = bls.DeriveChildKey(key, index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hex.EncodeToString(pathKey.Bytes()) != hex.EncodeToString(key.Bytes()) {
+		t.Error("Incorrect key derived from path")
+	}
+
+	// Invalid paths
+	for _, path := range []string{"", "x/12381", "m/12381/abc", "m//0"} {
+		if _, err := bls.ParseKeyPath(path); err == nil {
+			t.Errorf("Expected an error parsing path %q", path)
+		}
+	}
+
+}
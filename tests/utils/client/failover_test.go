@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/client"
+)
+
+// A JSON-RPC error with a code
+type rpcError struct {
+	code    int
+	message string
+}
+
+func (e rpcError) Error() string  { return e.message }
+func (e rpcError) ErrorCode() int { return e.code }
+
+// A JSON-RPC error with data, such as a revert
+type dataError struct {
+	rpcError
+}
+
+func (e dataError) ErrorData() interface{} { return "0x" }
+
+// An execution client that returns fixed results; unimplemented methods panic
+type mockClient struct {
+	rocketpool.ExecutionClient
+	name        string
+	callErr     error
+	calls       int
+	latestBlock uint64
+	syncing     bool
+}
+
+func (c *mockClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	if c.callErr != nil {
+		return nil, c.callErr
+	}
+	return []byte(c.name), nil
+}
+
+func (c *mockClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.latestBlock, nil
+}
+
+func (c *mockClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if c.syncing {
+		return &ethereum.SyncProgress{}, nil
+	}
+	return nil, nil
+}
+
+// Create a failover client over mock clients
+func newFailoverClient(t *testing.T, mocks ...*mockClient) *client.FailoverClient {
+	names := make([]string, len(mocks))
+	clients := make([]rocketpool.ExecutionClient, len(mocks))
+	for i, mock := range mocks {
+		names[i] = mock.name
+		clients[i] = mock
+	}
+	failoverClient, err := client.NewFailoverClientFromClients(names, clients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return failoverClient
+}
+
+func TestFailover(t *testing.T) {
+
+	// Fail over from an endpoint that's over its request limit
+	primary := &mockClient{name: "primary", callErr: rpcError{code: -32005, message: "limit exceeded"}}
+	backup := &mockClient{name: "backup"}
+	failoverClient := newFailoverClient(t, primary, backup)
+	var failedOver string
+	failoverClient.OnFailover = func(from string, to string, err error) {
+		failedOver = from + ">" + to
+	}
+	result, err := failoverClient.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != "backup" {
+		t.Errorf("Incorrect result %s", string(result))
+	}
+	if failedOver != "primary>backup" {
+		t.Errorf("Incorrect failover %s", failedOver)
+	}
+
+	// Check the failure was recorded against the primary
+	statuses := failoverClient.GetStatuses()
+	if statuses[0].ErrorRate != 1 || statuses[0].Healthy || statuses[0].LastError != "limit exceeded" {
+		t.Errorf("Incorrect primary status %+v", statuses[0])
+	}
+	if statuses[1].ErrorRate != 0 || !statuses[1].Healthy {
+		t.Errorf("Incorrect backup status %+v", statuses[1])
+	}
+
+	// The unhealthy primary is tried last
+	if _, err := failoverClient.CallContract(context.Background(), ethereum.CallMsg{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if primary.calls != 1 || backup.calls != 2 {
+		t.Errorf("Incorrect call counts: primary %d, backup %d", primary.calls, backup.calls)
+	}
+
+}
+
+func TestFailoverPassesThroughReverts(t *testing.T) {
+
+	// A revert means the endpoint served the request, so it's returned without failing over
+	revert := dataError{rpcError{code: 3, message: "execution reverted: nope"}}
+	primary := &mockClient{name: "primary", callErr: revert}
+	backup := &mockClient{name: "backup"}
+	failoverClient := newFailoverClient(t, primary, backup)
+	_, err := failoverClient.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if !errors.Is(err, revert) {
+		t.Errorf("Incorrect error %v", err)
+	}
+	if backup.calls != 0 {
+		t.Errorf("Incorrect backup call count %d", backup.calls)
+	}
+	if status := failoverClient.GetStatuses()[0]; status.ErrorRate != 0 {
+		t.Errorf("Incorrect primary error rate %f", status.ErrorRate)
+	}
+
+}
+
+func TestFailoverAllEndpointsFail(t *testing.T) {
+
+	missingState := rpcError{code: -32000, message: "missing trie node abc"}
+	primary := &mockClient{name: "primary", callErr: missingState}
+	backup := &mockClient{name: "backup", callErr: missingState}
+	failoverClient := newFailoverClient(t, primary, backup)
+	_, err := failoverClient.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err == nil || !errors.Is(err, missingState) || !strings.Contains(err.Error(), "all endpoints failed") {
+		t.Errorf("Incorrect error %v", err)
+	}
+	if primary.calls != 1 || backup.calls != 1 {
+		t.Errorf("Incorrect call counts: primary %d, backup %d", primary.calls, backup.calls)
+	}
+
+}
+
+func TestFailoverHealthCheck(t *testing.T) {
+
+	// Score endpoints by sync status and block lag
+	lagging := &mockClient{name: "lagging", latestBlock: 90}
+	syncing := &mockClient{name: "syncing", latestBlock: 100, syncing: true}
+	synced := &mockClient{name: "synced", latestBlock: 100}
+	failoverClient := newFailoverClient(t, lagging, syncing, synced)
+	statuses := failoverClient.CheckHealth(context.Background())
+	if statuses[0].Healthy || statuses[0].BlockLag != 10 || statuses[0].Score != 0 {
+		t.Errorf("Incorrect lagging status %+v", statuses[0])
+	}
+	if statuses[1].Healthy || !statuses[1].Syncing || statuses[1].Score != 0 {
+		t.Errorf("Incorrect syncing status %+v", statuses[1])
+	}
+	if !statuses[2].Healthy || statuses[2].BlockLag != 0 || statuses[2].Score != 1 {
+		t.Errorf("Incorrect synced status %+v", statuses[2])
+	}
+
+	// Requests go to the healthy endpoint first, even though it's last in order of preference
+	result, err := failoverClient.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != "synced" {
+		t.Errorf("Incorrect result %s", string(result))
+	}
+
+}
@@ -0,0 +1,163 @@
+package events
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Test events with indexed, tuple and timestamp parameters
+const eventsAbi string = `[
+	{"type":"event","name":"RootSubmitted","inputs":[
+		{"name":"proposalID","type":"uint256","indexed":true},
+		{"name":"proposer","type":"address","indexed":true},
+		{"name":"blockNumber","type":"uint32","indexed":false},
+		{"name":"root","type":"tuple","indexed":false,"components":[{"name":"sum","type":"uint256"},{"name":"hash","type":"bytes32"}]},
+		{"name":"treeNodes","type":"tuple[]","indexed":false,"components":[{"name":"sum","type":"uint256"},{"name":"hash","type":"bytes32"}]},
+		{"name":"time","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"Other","inputs":[{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+// The decoded RootSubmitted event
+type rootSubmitted struct {
+	ProposalID  *big.Int               // Matched by name
+	Proposer    common.Address         // Indexed
+	BlockNumber uint64                 // Converted from uint32
+	Root        types.VotingTreeNode   // Tuple
+	Nodes       []types.VotingTreeNode `event:"treeNodes"`
+	Timestamp   time.Time              `event:"time,unix"`
+	Ignored     string                 `event:"-"`
+}
+
+// A tree node as it's packed by the ABI package
+type packedNode struct {
+	Sum  *big.Int
+	Hash [32]byte
+}
+
+// Create a RootSubmitted log
+func getRootSubmittedLog(t *testing.T, contractAbi *abi.ABI, proposalID int64, proposer common.Address) ethtypes.Log {
+	event := contractAbi.Events["RootSubmitted"]
+	data, err := event.Inputs.NonIndexed().Pack(
+		uint32(1234),
+		packedNode{Sum: big.NewInt(100), Hash: common.HexToHash("0xaa")},
+		[]packedNode{{Sum: big.NewInt(60), Hash: common.HexToHash("0xbb")}, {Sum: big.NewInt(40), Hash: common.HexToHash("0xcc")}},
+		big.NewInt(1700000000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ethtypes.Log{
+		Topics: []common.Hash{event.ID, common.BigToHash(big.NewInt(proposalID)), common.BytesToHash(proposer.Bytes())},
+		Data:   data,
+	}
+}
+
+// Create a contract with the test events
+func getContract(t *testing.T) *rocketpool.Contract {
+	contractAbi, err := abi.JSON(strings.NewReader(eventsAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &rocketpool.Contract{ABI: &contractAbi}
+}
+
+func TestDecodeEvents(t *testing.T) {
+
+	// Create the logs, with an event that should be skipped in between
+	contract := getContract(t)
+	proposer := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	otherData, err := contract.ABI.Events["Other"].Inputs.Pack(big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs := []ethtypes.Log{
+		getRootSubmittedLog(t, contract.ABI, 5, proposer),
+		{Topics: []common.Hash{contract.ABI.Events["Other"].ID}, Data: otherData},
+		{},
+		getRootSubmittedLog(t, contract.ABI, 6, proposer),
+	}
+
+	// Decode the events
+	events, err := eth.DecodeEvents[rootSubmitted](contract, "RootSubmitted", logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Incorrect event count %d", len(events))
+	}
+	if events[0].ProposalID.Int64() != 5 || events[1].ProposalID.Int64() != 6 {
+		t.Errorf("Incorrect proposal IDs %s, %s", events[0].ProposalID.String(), events[1].ProposalID.String())
+	}
+
+	// Check the decoded fields
+	event := events[0]
+	if event.Proposer != proposer {
+		t.Errorf("Incorrect proposer %s", event.Proposer.Hex())
+	}
+	if event.BlockNumber != 1234 {
+		t.Errorf("Incorrect block number %d", event.BlockNumber)
+	}
+	if event.Root.Sum.Int64() != 100 || event.Root.Hash != common.HexToHash("0xaa") {
+		t.Errorf("Incorrect root %s / %s", event.Root.Sum.String(), event.Root.Hash.Hex())
+	}
+	if len(event.Nodes) != 2 || event.Nodes[1].Sum.Int64() != 40 || event.Nodes[1].Hash != common.HexToHash("0xcc") {
+		t.Errorf("Incorrect tree nodes %+v", event.Nodes)
+	}
+	if !event.Timestamp.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Incorrect timestamp %s", event.Timestamp.String())
+	}
+	if event.Ignored != "" {
+		t.Errorf("Incorrect ignored field %s", event.Ignored)
+	}
+
+}
+
+func TestDecodeEventErrors(t *testing.T) {
+
+	contract := getContract(t)
+	log := getRootSubmittedLog(t, contract.ABI, 5, common.Address{})
+
+	// Unknown event
+	if _, err := eth.DecodeEvents[rootSubmitted](contract, "Missing", []ethtypes.Log{log}); err == nil {
+		t.Error("Expected an error decoding an unknown event")
+	}
+
+	// Log for another event
+	if _, err := eth.DecodeEvent[rootSubmitted](contract, "Other", log); err == nil {
+		t.Error("Expected an error decoding a log for another event")
+	}
+
+	// Missing topics
+	truncated := log
+	truncated.Topics = log.Topics[:2]
+	if _, err := eth.DecodeEvent[rootSubmitted](contract, "RootSubmitted", truncated); err == nil {
+		t.Error("Expected an error decoding a log with missing topics")
+	}
+
+	// Truncated data
+	truncated = log
+	truncated.Data = log.Data[:40]
+	if _, err := eth.DecodeEvent[rootSubmitted](contract, "RootSubmitted", truncated); err == nil {
+		t.Error("Expected an error decoding a log with truncated data")
+	}
+
+	// Unconvertible field
+	type badEvent struct {
+		Root common.Address
+	}
+	if _, err := eth.DecodeEvent[badEvent](contract, "RootSubmitted", log); err == nil {
+		t.Error("Expected an error decoding a tuple into an address")
+	}
+
+}
@@ -0,0 +1,185 @@
+package parse
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Get a big.Int from a decimal string
+func getBig(t *testing.T, value string) *big.Int {
+	result, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		t.Fatalf("invalid test value %s", value)
+	}
+	return result
+}
+
+func TestParseRawPercent(t *testing.T) {
+
+	// Valid percentages
+	for value, expected := range map[string]string{
+		"5%":                   "50000000000000000",
+		" 12.5 ":               "125000000000000000",
+		"100%":                 "1000000000000000000",
+		"0.0000000000000001%":  "1",
+		"0":                    "0",
+		"0.10000000000000000%": "1000000000000000",
+	} {
+		raw, err := eth.ParseRawPercent(value)
+		if err != nil {
+			t.Errorf("Error parsing %q: %s", value, err.Error())
+			continue
+		}
+		if raw.String() != expected {
+			t.Errorf("Incorrect raw percent %s for %q, expected %s", raw.String(), value, expected)
+		}
+	}
+
+	// Invalid percentages
+	for _, value := range []string{"", "%", "abc", "1.2.3", "0.00000000000000001%", "5%%"} {
+		if _, err := eth.ParseRawPercent(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+
+}
+
+func TestParseDuration(t *testing.T) {
+
+	// Valid durations
+	for value, expected := range map[string]time.Duration{
+		"28d":           28 * 24 * time.Hour,
+		"1h 30m":        90 * time.Minute,
+		"90m":           90 * time.Minute,
+		"1d 2h 3m 4s":   26*time.Hour + 3*time.Minute + 4*time.Second,
+		" 0s ":          0,
+		"1500ms 500ms":  2 * time.Second,
+		"106751d":       106751 * 24 * time.Hour,
+		"2562047h 47m ": 2562047*time.Hour + 47*time.Minute,
+	} {
+		duration, err := eth.ParseDuration(value)
+		if err != nil {
+			t.Errorf("Error parsing %q: %s", value, err.Error())
+			continue
+		}
+		if duration != expected {
+			t.Errorf("Incorrect duration %s for %q, expected %s", duration, value, expected)
+		}
+	}
+
+	// Invalid durations, including fractions of a second, negative parts and overflows
+	for _, value := range []string{"", "abc", "1.5d", "500ms", "-1h", "1d -1h", "106752d", "106751d 24h", "99999999999999999999d", "9223372036s 1s"} {
+		if _, err := eth.ParseDuration(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+
+}
+
+func TestRawDurations(t *testing.T) {
+
+	// Parse a raw duration
+	raw, err := eth.ParseRawDuration("1d 1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Int64() != 86401 {
+		t.Errorf("Incorrect raw duration %s", raw.String())
+	}
+
+	// Convert it back
+	duration, err := eth.RawSecondsToDuration(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duration != 24*time.Hour+time.Second {
+		t.Errorf("Incorrect duration %s", duration)
+	}
+	if eth.DurationToRawSeconds(duration).Cmp(raw) != 0 {
+		t.Errorf("Incorrect raw seconds %s", eth.DurationToRawSeconds(duration).String())
+	}
+
+	// Invalid raw durations
+	for _, value := range []*big.Int{nil, big.NewInt(-1), getBig(t, "9223372037"), getBig(t, "18446744073709551616")} {
+		if _, err := eth.RawSecondsToDuration(value); err == nil {
+			t.Errorf("Expected an error converting %v", value)
+		}
+	}
+
+}
+
+func TestCompareRawSettings(t *testing.T) {
+
+	// Percentages
+	raw := getBig(t, "50000000000000000")
+	for threshold, expected := range map[string]int{"4%": 1, "5%": 0, "5.01%": -1} {
+		result, err := eth.CompareRawPercent(raw, threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != expected {
+			t.Errorf("Incorrect comparison %d with %s, expected %d", result, threshold, expected)
+		}
+	}
+	if _, err := eth.CompareRawPercent(raw, "five"); err == nil {
+		t.Error("Expected an error comparing with an invalid percentage")
+	}
+
+	// Durations
+	raw = big.NewInt(28 * 86400)
+	for threshold, expected := range map[string]int{"27d 23h": 1, "28d": 0, "672h 1s": -1} {
+		result, err := eth.CompareRawDuration(raw, threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != expected {
+			t.Errorf("Incorrect comparison %d with %s, expected %d", result, threshold, expected)
+		}
+	}
+	if _, err := eth.CompareRawDuration(raw, "4w"); err == nil {
+		t.Error("Expected an error comparing with an invalid duration")
+	}
+
+}
+
+func TestFormatDeltas(t *testing.T) {
+
+	// Raw deltas
+	if delta := eth.GetRawDelta(big.NewInt(10), big.NewInt(4)); delta.Int64() != -6 {
+		t.Errorf("Incorrect raw delta %s", delta.String())
+	}
+
+	// Percentage deltas
+	for _, test := range []struct {
+		current  string
+		proposed string
+		expected string
+	}{
+		{"50000000000000000", "75000000000000000", "+2.5%"},
+		{"75000000000000000", "50000000000000000", "-2.5%"},
+		{"50000000000000000", "50000000000000000", "0%"},
+	} {
+		if delta := eth.FormatRawPercentDelta(getBig(t, test.current), getBig(t, test.proposed), 4); delta != test.expected {
+			t.Errorf("Incorrect percent delta %s, expected %s", delta, test.expected)
+		}
+	}
+
+	// Duration deltas
+	for _, test := range []struct {
+		current  time.Duration
+		proposed time.Duration
+		expected string
+	}{
+		{24 * time.Hour, 48 * time.Hour, "+1d"},
+		{48 * time.Hour, 36 * time.Hour, "-12h"},
+		{time.Hour, time.Hour, "0s"},
+	} {
+		if delta := eth.FormatDurationDelta(test.current, test.proposed); delta != test.expected {
+			t.Errorf("Incorrect duration delta %s, expected %s", delta, test.expected)
+		}
+	}
+
+}
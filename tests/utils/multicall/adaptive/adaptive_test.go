@@ -0,0 +1,124 @@
+package adaptive
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+)
+
+// A contract method that doubles its argument
+const doublerAbi string = `[{"type":"function","name":"double","stateMutability":"view","inputs":[{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]}]`
+
+// A call result as it's packed by the multicall contract
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// An execution client that runs tryAggregate against the doubler, rejecting multicalls with more than maxCalls calls
+type fakeClient struct {
+	rocketpool.ExecutionClient
+	multicallAbi abi.ABI
+	doublerAbi   abi.ABI
+	maxCalls     int
+	lock         sync.Mutex
+	requests     int
+}
+
+// Run a multicall
+func (c *fakeClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.lock.Lock()
+	c.requests++
+	c.lock.Unlock()
+
+	args, err := c.multicallAbi.Methods["tryAggregate"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	calls := *abi.ConvertType(args[1], new([]multicall.MultiCall)).(*[]multicall.MultiCall)
+	if len(calls) > c.maxCalls {
+		return nil, errors.New("execution reverted: out of gas")
+	}
+
+	results := make([]multicallResult, len(calls))
+	for i, subcall := range calls {
+		inputs, err := c.doublerAbi.Methods["double"].Inputs.Unpack(subcall.CallData[4:])
+		if err != nil {
+			return nil, err
+		}
+		output, err := c.doublerAbi.Methods["double"].Outputs.Pack(new(big.Int).Mul(inputs[0].(*big.Int), big.NewInt(2)))
+		if err != nil {
+			return nil, err
+		}
+		results[i] = multicallResult{Success: true, ReturnData: output}
+	}
+	return c.multicallAbi.Methods["tryAggregate"].Outputs.Pack(results)
+}
+
+// Counts the requests it allows
+type countingLimiter struct {
+	lock  sync.Mutex
+	waits int
+}
+
+// Allow a request
+func (l *countingLimiter) WaitForRequest() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.waits++
+}
+
+func TestAdaptiveBatcherRateLimit(t *testing.T) {
+
+	// Create the client and the doubler
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall.MulticallABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(doublerAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{multicallAbi: multicallAbi, doublerAbi: contractAbi, maxCalls: 2}
+	address := common.HexToAddress("0x01")
+	doubler := &rocketpool.Contract{Address: &address, ABI: &contractAbi}
+
+	// Queue 8 calls in one batch
+	limiter := &countingLimiter{}
+	batcher := multicall.NewAdaptiveBatcher(client, common.HexToAddress("0x02"))
+	batcher.RateLimiter = limiter
+	outputs := make([]*big.Int, 8)
+	for i := range outputs {
+		if err := batcher.AddCall(doubler, &outputs[i], "double", big.NewInt(int64(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The batch of 8 is rejected and split into 4 and 4, which are rejected and split into four batches of 2
+	if _, err := batcher.FlexibleCall(true, &bind.CallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	for i, output := range outputs {
+		if output == nil || output.Int64() != int64(i*2) {
+			t.Errorf("Incorrect output %v for call %d", output, i)
+		}
+	}
+	if client.requests != 7 {
+		t.Errorf("Incorrect request count %d", client.requests)
+	}
+	if limiter.waits != client.requests {
+		t.Errorf("Incorrect rate limiter waits %d for %d requests", limiter.waits, client.requests)
+	}
+
+}
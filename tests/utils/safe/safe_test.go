@@ -0,0 +1,170 @@
+package safe
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/safe"
+)
+
+const testAbi string = `[{"type":"function","name":"deposit","stateMutability":"payable","inputs":[{"name":"amount","type":"uint256"}],"outputs":[]}]`
+
+// Get a transaction that calls deposit on a contract without a client, optionally setting its own value
+func getDepositTransaction(t *testing.T, address common.Address, amount *big.Int, value *big.Int) rocketpool.BatchTransaction {
+	parsed, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := bind.NewBoundContract(address, parsed, nil, nil, nil)
+	return rocketpool.BatchTransaction{
+		Name: "deposit",
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			if value != nil {
+				txOpts := *opts
+				txOpts.Value = value
+				opts = &txOpts
+			}
+			tx, err := contract.Transact(opts, "deposit", amount)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		},
+	}
+}
+
+func TestNewTransactions(t *testing.T) {
+
+	// Transactions
+	safeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	target := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	ownValue := big.NewInt(16)
+	optsValue := big.NewInt(8)
+	txs, err := safe.NewTransactions(&bind.TransactOpts{From: safeAddress, Value: optsValue},
+		getDepositTransaction(t, target, big.NewInt(1), ownValue),
+		getDepositTransaction(t, target, big.NewInt(2), nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Incorrect transaction count %d", len(txs))
+	}
+
+	// Check the targets and values
+	for i, tx := range txs {
+		if tx.To != target {
+			t.Errorf("Incorrect transaction %d target %s", i, tx.To.Hex())
+		}
+		if tx.Operation != safe.Operation_Call {
+			t.Errorf("Incorrect transaction %d operation %d", i, tx.Operation)
+		}
+	}
+	if txs[0].Value.Cmp(ownValue) != 0 {
+		t.Errorf("Incorrect transaction value %s, expected the transaction's own value", txs[0].Value.String())
+	}
+	if txs[1].Value.Cmp(optsValue) != 0 {
+		t.Errorf("Incorrect transaction value %s, expected the options' value", txs[1].Value.String())
+	}
+
+	// Check the calldata
+	expectedData := hexutil.MustDecode("0xb6b55f25" + "0000000000000000000000000000000000000000000000000000000000000002")
+	if !bytes.Equal(txs[1].Data, expectedData) {
+		t.Errorf("Incorrect transaction data %s", txs[1].Data.String())
+	}
+
+}
+
+func TestNewMultiSendTransaction(t *testing.T) {
+
+	// Transactions
+	target1 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	target2 := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	txs := []safe.Transaction{
+		{To: target1, Value: big.NewInt(1), Data: hexutil.MustDecode("0xdeadbeef"), Operation: safe.Operation_Call},
+		{To: target2, Data: []byte{}, Operation: safe.Operation_Call},
+	}
+
+	// Encode the multisend
+	multiSend, err := safe.NewMultiSendTransaction(safe.MultiSendCallOnlyAddress, txs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if multiSend.To != safe.MultiSendCallOnlyAddress {
+		t.Errorf("Incorrect multisend target %s", multiSend.To.Hex())
+	}
+	if multiSend.Operation != safe.Operation_DelegateCall {
+		t.Errorf("Incorrect multisend operation %d", multiSend.Operation)
+	}
+	if multiSend.Value.Sign() != 0 {
+		t.Errorf("Incorrect multisend value %s", multiSend.Value.String())
+	}
+
+	// Check the packed transactions
+	packed := "00" + "2222222222222222222222222222222222222222" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"deadbeef" +
+		"00" + "3333333333333333333333333333333333333333" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000"
+	expectedData := hexutil.MustDecode("0x8d80ff0a" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"00000000000000000000000000000000000000000000000000000000000000ae" +
+		packed + strings.Repeat("0", 36))
+	if !bytes.Equal(multiSend.Data, expectedData) {
+		t.Errorf("Incorrect multisend data %s", multiSend.Data.String())
+	}
+
+	// MultiSendCallOnly rejects delegate calls
+	txs[1].Operation = safe.Operation_DelegateCall
+	if _, err := safe.NewMultiSendTransaction(safe.MultiSendCallOnlyAddress, txs); err == nil {
+		t.Error("Expected an error encoding a delegate call for MultiSendCallOnly")
+	}
+	if _, err := safe.NewMultiSendTransaction(safe.MultiSendAddress, txs); err != nil {
+		t.Errorf("Unexpected error encoding a delegate call for MultiSend: %s", err)
+	}
+
+	// Empty batches are rejected
+	if _, err := safe.NewMultiSendTransaction(safe.MultiSendAddress, []safe.Transaction{}); err == nil {
+		t.Error("Expected an error encoding an empty multisend")
+	}
+
+}
+
+func TestTransactionJson(t *testing.T) {
+
+	// The value is serialized as a decimal string
+	tx := safe.Transaction{
+		To:        common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Value:     big.NewInt(1000000000000000000),
+		Data:      hexutil.MustDecode("0xdeadbeef"),
+		Operation: safe.Operation_Call,
+	}
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"value":"1000000000000000000"`) {
+		t.Errorf("Incorrect transaction JSON %s", string(data))
+	}
+
+	// Round trip
+	var decoded safe.Transaction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.To != tx.To || decoded.Value.Cmp(tx.Value) != 0 || !bytes.Equal(decoded.Data, tx.Data) || decoded.Operation != tx.Operation {
+		t.Errorf("Incorrect decoded transaction %+v", decoded)
+	}
+
+}
@@ -0,0 +1,146 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+// Get the details of a staking minipool with the given balance and shares, in ETH
+func getMinipool(address byte, balance float64, nodeShare float64, refund float64) *state.NativeMinipoolDetails {
+	return &state.NativeMinipoolDetails{
+		MinipoolAddress:      common.BytesToAddress([]byte{address}),
+		Version:              3,
+		Status:               types.Staking,
+		DistributableBalance: eth.EthToWei(balance),
+		NodeShareOfBalance:   eth.EthToWei(nodeShare),
+		UserShareOfBalance:   eth.EthToWei(balance - nodeShare),
+		NodeRefundBalance:    eth.EthToWei(refund),
+	}
+}
+
+func TestPlanMinipoolDistributions(t *testing.T) {
+
+	// Minipools to plan
+	skimLarge := getMinipool(1, 1, 0.5, 0)
+	skimSmall := getMinipool(2, 0.001, 0.0005, 0)
+	exited := getMinipool(3, 32, 8, 0.5)
+	notExited := getMinipool(4, 16, 4, 0)
+	oldVersion := getMinipool(5, 1, 0.5, 0)
+	oldVersion.Version = 2
+	dissolved := getMinipool(6, 1, 0.5, 0)
+	dissolved.Status = types.Dissolved
+	notLoaded := getMinipool(7, 1, 0.5, 0)
+	notLoaded.NodeShareOfBalance = nil
+	empty := getMinipool(8, 0, 0, 0)
+	minipools := []*state.NativeMinipoolDetails{skimSmall, skimLarge, exited, notExited, oldVersion, dissolved, notLoaded, empty}
+	beaconBalances := []*big.Int{eth.EthToWei(32), eth.EthToWei(32), big.NewInt(0), eth.EthToWei(16), eth.EthToWei(32), big.NewInt(0), eth.EthToWei(32), eth.EthToWei(32)}
+
+	// Plan at 10 gwei
+	gasPrice := eth.GweiToWei(10)
+	plan, err := state.PlanMinipoolDistributions(minipools, beaconBalances, gasPrice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the profitable distributions are sorted by profit
+	if len(plan.Profitable) != 2 {
+		t.Fatalf("Incorrect profitable count %d", len(plan.Profitable))
+	}
+	if plan.Profitable[0].MinipoolAddress != exited.MinipoolAddress || plan.Profitable[1].MinipoolAddress != skimLarge.MinipoolAddress {
+		t.Errorf("Incorrect profitable order %s, %s", plan.Profitable[0].MinipoolAddress.Hex(), plan.Profitable[1].MinipoolAddress.Hex())
+	}
+
+	// Check the exit distribution
+	exit := plan.Profitable[0]
+	if exit.Type != state.DistributionType_Exit || exit.GasLimit != state.ExitDistributionGas {
+		t.Errorf("Incorrect exit distribution type %s with gas limit %d", exit.Type, exit.GasLimit)
+	}
+	expectedGasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(state.ExitDistributionGas))
+	if exit.GasCost.Cmp(expectedGasCost) != 0 {
+		t.Errorf("Incorrect gas cost %s", exit.GasCost.String())
+	}
+	if exit.NodeTotal.Cmp(eth.EthToWei(8.5)) != 0 {
+		t.Errorf("Incorrect node total %s", exit.NodeTotal.String())
+	}
+	if expectedProfit := new(big.Int).Sub(eth.EthToWei(8.5), expectedGasCost); exit.Profit.Cmp(expectedProfit) != 0 {
+		t.Errorf("Incorrect profit %s", exit.Profit.String())
+	}
+
+	// Check the skim that costs more gas than it pays
+	if len(plan.Unprofitable) != 1 || plan.Unprofitable[0].MinipoolAddress != skimSmall.MinipoolAddress {
+		t.Fatalf("Incorrect unprofitable distributions %v", plan.Unprofitable)
+	}
+	if plan.Unprofitable[0].Type != state.DistributionType_Skim || plan.Unprofitable[0].Profit.Sign() >= 0 {
+		t.Errorf("Incorrect unprofitable distribution type %s with profit %s", plan.Unprofitable[0].Type, plan.Unprofitable[0].Profit.String())
+	}
+
+	// Check the skipped minipools
+	skipped := map[common.Address]bool{}
+	for _, skip := range plan.Skipped {
+		skipped[skip.MinipoolAddress] = true
+		if skip.Reason == "" {
+			t.Errorf("Minipool %s was skipped without a reason", skip.MinipoolAddress.Hex())
+		}
+	}
+	for _, minipool := range []*state.NativeMinipoolDetails{notExited, oldVersion, dissolved, notLoaded, empty} {
+		if !skipped[minipool.MinipoolAddress] {
+			t.Errorf("Minipool %s was not skipped", minipool.MinipoolAddress.Hex())
+		}
+	}
+	if len(plan.Skipped) != 5 {
+		t.Errorf("Incorrect skipped count %d", len(plan.Skipped))
+	}
+
+	// Check the totals only include the profitable distributions
+	expectedNodeTotal := new(big.Int).Add(exit.NodeTotal, plan.Profitable[1].NodeTotal)
+	if plan.TotalNodeTotal.Cmp(expectedNodeTotal) != 0 {
+		t.Errorf("Incorrect total node total %s", plan.TotalNodeTotal.String())
+	}
+	expectedTotalGasCost := new(big.Int).Add(exit.GasCost, plan.Profitable[1].GasCost)
+	if plan.TotalGasCost.Cmp(expectedTotalGasCost) != 0 {
+		t.Errorf("Incorrect total gas cost %s", plan.TotalGasCost.String())
+	}
+
+}
+
+func TestPlanMinipoolDistributionsMismatch(t *testing.T) {
+
+	if _, err := state.PlanMinipoolDistributions([]*state.NativeMinipoolDetails{getMinipool(1, 1, 0.5, 0)}, []*big.Int{}, big.NewInt(1)); err == nil {
+		t.Error("Expected an error for mismatched beacon balances")
+	}
+
+}
+
+func TestPlanMinipoolDistributionsUnknownBeaconBalance(t *testing.T) {
+
+	// Skims don't need the Beacon balance, but exits can't be told apart from large skims without it
+	skim := getMinipool(1, 1, 0.5, 0)
+	exited := getMinipool(2, 32, 8, 0)
+	plan, err := state.PlanMinipoolDistributions([]*state.NativeMinipoolDetails{skim, exited}, []*big.Int{nil, nil}, eth.GweiToWei(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Profitable) != 1 || plan.Profitable[0].MinipoolAddress != skim.MinipoolAddress || plan.Profitable[0].Type != state.DistributionType_Skim {
+		t.Errorf("Incorrect profitable distributions %v", plan.Profitable)
+	}
+	if len(plan.Skipped) != 1 || plan.Skipped[0].MinipoolAddress != exited.MinipoolAddress || plan.Skipped[0].Reason == "" {
+		t.Errorf("Incorrect skipped minipools %v", plan.Skipped)
+	}
+
+	// The exit threshold uses the balance minus the refund, like the contract's totalBalance
+	refunded := getMinipool(3, 7.9, 2, 0.5)
+	plan, err = state.PlanMinipoolDistributions([]*state.NativeMinipoolDetails{refunded}, []*big.Int{eth.EthToWei(32)}, eth.GweiToWei(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Profitable) != 1 || plan.Profitable[0].Type != state.DistributionType_Skim {
+		t.Errorf("Incorrect distribution of a refunded minipool below the exit threshold %v", plan.Profitable)
+	}
+
+}
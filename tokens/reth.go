@@ -9,7 +9,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 )
 
 //
@@ -149,6 +151,19 @@ func GetRETHExchangeRate(rp *rocketpool.RocketPool, opts *bind.CallOpts) (float6
 	return eth.WeiToEth(*exchangeRate), nil
 }
 
+// Get the current ETH : rETH exchange rate as a wei-denominated fraction (1e18 = 1:1)
+func GetRETHExchangeRateRaw(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketTokenRETH, err := getRocketTokenRETH(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	exchangeRate := new(*big.Int)
+	if err := rocketTokenRETH.Call(opts, exchangeRate, "getExchangeRate"); err != nil {
+		return nil, fmt.Errorf("error getting rETH exchange rate: %w", err)
+	}
+	return *exchangeRate, nil
+}
+
 // Get the total amount of ETH collateral available for rETH trades
 func GetRETHTotalCollateral(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
 	rocketTokenRETH, err := getRocketTokenRETH(rp, opts)
@@ -197,6 +212,56 @@ func BurnRETH(rp *rocketpool.RocketPool, amount *big.Int, opts *bind.TransactOpt
 	return tx.Hash(), nil
 }
 
+// Get the amount of rETH a deposit of the given amount of ETH into the deposit pool would mint, net of the deposit fee
+func GetRETHOutputOfDeposit(rp *rocketpool.RocketPool, ethAmount *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+	depositFee, err := protocol.GetDepositFee(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	fee := big.NewInt(0).Mul(ethAmount, depositFee)
+	fee.Div(fee, eth.EthToWei(1))
+	return GetRETHValueOfETH(rp, big.NewInt(0).Sub(ethAmount, fee), opts)
+}
+
+// Details of the rETH token, retrieved together
+type RETHDetails struct {
+	TotalSupply     *big.Int `json:"totalSupply"`
+	ExchangeRate    *big.Int `json:"exchangeRate"`
+	TotalCollateral *big.Int `json:"totalCollateral"`
+	CollateralRate  *big.Int `json:"collateralRate"`
+	ContractBalance *big.Int `json:"contractBalance"`
+}
+
+// Get the rETH token details in a single multicall
+func GetRETHDetails(rp *rocketpool.RocketPool, multicallerAddress common.Address, opts *bind.CallOpts) (RETHDetails, error) {
+	rocketTokenRETH, err := getRocketTokenRETH(rp, opts)
+	if err != nil {
+		return RETHDetails{}, err
+	}
+	mc, err := multicall.NewMultiCaller(rp.Client, multicallerAddress)
+	if err != nil {
+		return RETHDetails{}, err
+	}
+
+	details := RETHDetails{}
+	mc.AddCall(rocketTokenRETH, &details.TotalSupply, "totalSupply")
+	mc.AddCall(rocketTokenRETH, &details.ExchangeRate, "getExchangeRate")
+	mc.AddCall(rocketTokenRETH, &details.TotalCollateral, "getTotalCollateral")
+	mc.AddCall(rocketTokenRETH, &details.CollateralRate, "getCollateralRate")
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	if _, err := mc.FlexibleCall(true, opts); err != nil {
+		return RETHDetails{}, fmt.Errorf("error getting rETH details: %w", err)
+	}
+
+	details.ContractBalance, err = contractETHBalance(rp, rocketTokenRETH, opts)
+	if err != nil {
+		return RETHDetails{}, err
+	}
+	return details, nil
+}
+
 //
 // Contracts
 //
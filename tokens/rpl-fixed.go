@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	"fmt"
 	"math/big"
 	"sync"
 
@@ -77,6 +78,20 @@ func ApproveFixedSupplyRPL(rp *rocketpool.RocketPool, spender common.Address, am
 	return approve(rocketTokenFixedSupplyRPL, "fixed-supply RPL", spender, amount, opts)
 }
 
+// Get a transaction that approves a fixed-supply RPL spender
+func ApproveFixedSupplyRPLTransaction(rp *rocketpool.RocketPool, spender common.Address, amount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: fmt.Sprintf("approve %s to spend fixed-supply RPL", spender.Hex()),
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateApproveFixedSupplyRPLGas(rp, spender, amount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return ApproveFixedSupplyRPL(rp, spender, amount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketTokenRPLFixedSupply", "approve", spender, amount),
+	}
+}
+
 // Estimate the gas of TransferFromFixedSupplyRPL
 func EstimateTransferFromFixedSupplyRPLGas(rp *rocketpool.RocketPool, from, to common.Address, amount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketTokenFixedSupplyRPL, err := getRocketTokenRPLFixedSupply(rp, nil)
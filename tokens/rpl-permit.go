@@ -0,0 +1,162 @@
+package tokens
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// EIP-712 type hashes for EIP-2612 permits
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	permitTypeHash       = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+)
+
+// The EIP-712 domain version the RPL token uses for permits
+const RPLPermitDomainVersion string = "1"
+
+// An EIP-2612 permit allowing a spender to transfer an owner's tokens without a separate approval transaction
+type Permit struct {
+	Owner    common.Address `json:"owner"`
+	Spender  common.Address `json:"spender"`
+	Value    *big.Int       `json:"value"`
+	Nonce    *big.Int       `json:"nonce"`
+	Deadline *big.Int       `json:"deadline"`
+}
+
+// A signed EIP-2612 permit
+type SignedPermit struct {
+	Permit
+	V uint8       `json:"v"`
+	R common.Hash `json:"r"`
+	S common.Hash `json:"s"`
+}
+
+// Get the permit nonce of an RPL holder
+// Returns an error if the deployed RPL token doesn't support EIP-2612
+func GetRPLPermitNonce(rp *rocketpool.RocketPool, owner common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := rocketTokenRPL.ABI.Methods["nonces"]; !exists {
+		return nil, fmt.Errorf("the RPL token does not support EIP-2612 permits")
+	}
+	nonce := new(*big.Int)
+	if err := rocketTokenRPL.Call(opts, nonce, "nonces", owner); err != nil {
+		return nil, fmt.Errorf("error getting RPL permit nonce: %w", err)
+	}
+	return *nonce, nil
+}
+
+// Get the EIP-712 domain separator for RPL permits
+func GetRPLPermitDomainSeparator(rp *rocketpool.RocketPool, chainID *big.Int, opts *bind.CallOpts) (common.Hash, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	name := new(string)
+	if err := rocketTokenRPL.Call(opts, name, "name"); err != nil {
+		return common.Hash{}, fmt.Errorf("error getting RPL token name: %w", err)
+	}
+	return GetPermitDomainSeparator(*name, RPLPermitDomainVersion, chainID, *rocketTokenRPL.Address), nil
+}
+
+// Create a new RPL permit for the owner's current nonce
+func NewRPLPermit(rp *rocketpool.RocketPool, owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, opts *bind.CallOpts) (Permit, error) {
+	nonce, err := GetRPLPermitNonce(rp, owner, opts)
+	if err != nil {
+		return Permit{}, err
+	}
+	return Permit{
+		Owner:    owner,
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}, nil
+}
+
+// Sign an RPL permit with the owner's key
+func SignRPLPermit(rp *rocketpool.RocketPool, permit Permit, chainID *big.Int, key *ecdsa.PrivateKey, opts *bind.CallOpts) (SignedPermit, error) {
+	domainSeparator, err := GetRPLPermitDomainSeparator(rp, chainID, opts)
+	if err != nil {
+		return SignedPermit{}, err
+	}
+	return SignPermit(permit, domainSeparator, key)
+}
+
+// Get the EIP-712 domain separator of a token
+func GetPermitDomainSeparator(name string, version string, chainID *big.Int, verifyingContract common.Address) common.Hash {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(name)),
+		crypto.Keccak256([]byte(version)),
+		math.U256Bytes(big.NewInt(0).Set(chainID)),
+		common.LeftPadBytes(verifyingContract.Bytes(), 32),
+	)
+}
+
+// Get the EIP-712 digest of a permit, which is what the owner signs
+func (p Permit) GetDigest(domainSeparator common.Hash) common.Hash {
+	structHash := crypto.Keccak256Hash(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(p.Owner.Bytes(), 32),
+		common.LeftPadBytes(p.Spender.Bytes(), 32),
+		math.U256Bytes(big.NewInt(0).Set(p.Value)),
+		math.U256Bytes(big.NewInt(0).Set(p.Nonce)),
+		math.U256Bytes(big.NewInt(0).Set(p.Deadline)),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// Sign a permit for a token with the given domain separator
+func SignPermit(permit Permit, domainSeparator common.Hash, key *ecdsa.PrivateKey) (SignedPermit, error) {
+	if crypto.PubkeyToAddress(key.PublicKey) != permit.Owner {
+		return SignedPermit{}, fmt.Errorf("key does not belong to permit owner %s", permit.Owner.Hex())
+	}
+	digest := permit.GetDigest(domainSeparator)
+	signature, err := crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		return SignedPermit{}, fmt.Errorf("error signing permit: %w", err)
+	}
+	return SignedPermit{
+		Permit: permit,
+		V:      signature[64] + 27,
+		R:      common.BytesToHash(signature[0:32]),
+		S:      common.BytesToHash(signature[32:64]),
+	}, nil
+}
+
+// Estimate the gas of PermitRPL
+func EstimatePermitRPLGas(rp *rocketpool.RocketPool, permit SignedPermit, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketTokenRPL.GetTransactionGasInfo(opts, "permit", permit.Owner, permit.Spender, permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+}
+
+// Submit a signed RPL permit, approving the spender on the owner's behalf
+// Requires an RPL token that supports EIP-2612
+func PermitRPL(rp *rocketpool.RocketPool, permit SignedPermit, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, exists := rocketTokenRPL.ABI.Methods["permit"]; !exists {
+		return common.Hash{}, fmt.Errorf("the RPL token does not support EIP-2612 permits")
+	}
+	tx, err := rocketTokenRPL.Transact(opts, "permit", permit.Owner, permit.Spender, permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error submitting RPL permit: %w", err)
+	}
+	return tx.Hash(), nil
+}
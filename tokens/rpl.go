@@ -145,6 +145,54 @@ func SwapFixedSupplyRPLForRPL(rp *rocketpool.RocketPool, amount *big.Int, opts *
 	return tx.Hash(), nil
 }
 
+// Get a transaction that swaps fixed-supply RPL for new RPL tokens; the RPL token contract must be approved to spend the
+// fixed-supply RPL first
+func SwapFixedSupplyRPLForRPLTransaction(rp *rocketpool.RocketPool, amount *big.Int) rocketpool.BatchTransaction {
+	return rocketpool.BatchTransaction{
+		Name: "swap fixed-supply RPL for new RPL",
+		Estimate: func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return EstimateSwapFixedSupplyRPLForRPLGas(rp, amount, opts)
+		},
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			return SwapFixedSupplyRPLForRPL(rp, amount, opts)
+		},
+		Describe: rocketpool.DescribeContractCall(rp, "rocketTokenRPL", "swapTokens", amount),
+	}
+}
+
+// Get the transactions that swap all of an address's fixed-supply RPL for new RPL tokens, approving the RPL token
+// contract to spend it first if its allowance is too low
+// Returns no transactions if the address doesn't hold any fixed-supply RPL
+func GetSwapAllFixedSupplyRPLTransactions(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) ([]rocketpool.BatchTransaction, error) {
+	balance, err := GetFixedSupplyRPLBalance(rp, address, opts)
+	if err != nil {
+		return nil, err
+	}
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := GetFixedSupplyRPLAllowance(rp, address, *rocketTokenRPL.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewSwapFixedSupplyRPLTransactions(rp, *rocketTokenRPL.Address, balance, allowance), nil
+}
+
+// Get the transactions that swap an amount of fixed-supply RPL for new RPL tokens, given the RPL token contract's
+// address and its current allowance
+// Returns no transactions if the amount is zero
+func NewSwapFixedSupplyRPLTransactions(rp *rocketpool.RocketPool, rplAddress common.Address, amount *big.Int, allowance *big.Int) []rocketpool.BatchTransaction {
+	txs := []rocketpool.BatchTransaction{}
+	if amount.Sign() <= 0 {
+		return txs
+	}
+	if allowance.Cmp(amount) < 0 {
+		txs = append(txs, ApproveFixedSupplyRPLTransaction(rp, rplAddress, amount))
+	}
+	return append(txs, SwapFixedSupplyRPLForRPLTransaction(rp, amount))
+}
+
 // Get the RPL inflation interval rate
 func GetRPLInflationIntervalRate(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
 	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
@@ -171,6 +219,90 @@ func GetRPLInflationIntervalStartTime(rp *rocketpool.RocketPool, opts *bind.Call
 	return time.Unix((*value).Int64(), 0), nil
 }
 
+// Get the length of an RPL inflation interval
+func GetRPLInflationIntervalTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Duration, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := rocketTokenRPL.Call(opts, value, "getInflationIntervalTime"); err != nil {
+		return 0, fmt.Errorf("error getting RPL inflation interval time: %w", err)
+	}
+	return time.Duration((*value).Int64()) * time.Second, nil
+}
+
+// Get the time inflation was last calculated, which full intervals are counted from
+func GetRPLInflationCalcTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Time, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	value := new(*big.Int)
+	if err := rocketTokenRPL.Call(opts, value, "getInflationCalcTime"); err != nil {
+		return time.Time{}, fmt.Errorf("error getting RPL inflation calculation time: %w", err)
+	}
+	return time.Unix((*value).Int64(), 0), nil
+}
+
+// Get the number of full inflation intervals that have passed since inflation was last minted
+func GetRPLInflationIntervalsPassed(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := rocketTokenRPL.Call(opts, value, "getInflationIntervalsPassed"); err != nil {
+		return 0, fmt.Errorf("error getting RPL inflation intervals passed: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
+
+// Get the time the next inflation interval completes and new RPL can be minted
+// If this is in the past, inflation is waiting to be minted
+func GetRPLNextInflationMintTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Time, error) {
+	calcTime, err := GetRPLInflationCalcTime(rp, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	intervalTime, err := GetRPLInflationIntervalTime(rp, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calcTime.Add(intervalTime), nil
+}
+
+// Get the amount of RPL that would be minted if inflation were minted now
+func GetRPLInflationPendingAmount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := rocketTokenRPL.Call(opts, value, "inflationCalculate"); err != nil {
+		return nil, fmt.Errorf("error calculating pending RPL inflation: %w", err)
+	}
+	return *value, nil
+}
+
+// Get the circulating RPL supply
+// This is the total supply minus the RPL held by the token contract, which is reserved for fixed-supply RPL swaps
+func GetRPLCirculatingSupply(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketTokenRPL, err := getRocketTokenRPL(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	totalSupply, err := totalSupply(rocketTokenRPL, "RPL", opts)
+	if err != nil {
+		return nil, err
+	}
+	swapReserve, err := balanceOf(rocketTokenRPL, "RPL", *rocketTokenRPL.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(totalSupply, swapReserve), nil
+}
+
 //
 // Contracts
 //
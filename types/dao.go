@@ -121,3 +121,69 @@ func (s *ProposalState) UnmarshalJSON(data []byte) error {
 	}
 	return err
 }
+
+// The value of a DAO setting at a point in time
+type SettingValue struct {
+	ContractName string `json:"contractName"`
+	Path         string `json:"path"`
+	Value        any    `json:"value"` // A *big.Int, bool or common.Address
+}
+
+// A DAO setting that changed between two snapshots
+type SettingChange struct {
+	ContractName string `json:"contractName"`
+	Path         string `json:"path"`
+	OldValue     any    `json:"oldValue"`
+	NewValue     any    `json:"newValue"`
+}
+
+// Compare two lists of setting values, returning the settings whose value changed
+// Settings are matched by contract name and path; settings missing from either list are ignored
+func DiffSettingValues(a, b []SettingValue) []SettingChange {
+	type settingKey struct {
+		contractName string
+		path         string
+	}
+	oldValues := make(map[settingKey]any, len(a))
+	for _, setting := range a {
+		oldValues[settingKey{setting.ContractName, setting.Path}] = setting.Value
+	}
+
+	changes := []SettingChange{}
+	for _, setting := range b {
+		oldValue, exists := oldValues[settingKey{setting.ContractName, setting.Path}]
+		if !exists || settingValuesEqual(oldValue, setting.Value) {
+			continue
+		}
+		changes = append(changes, SettingChange{
+			ContractName: setting.ContractName,
+			Path:         setting.Path,
+			OldValue:     oldValue,
+			NewValue:     setting.Value,
+		})
+	}
+	return changes
+}
+
+// Check if two setting values are equal
+func settingValuesEqual(a, b any) bool {
+	switch aValue := a.(type) {
+	case *big.Int:
+		bValue, ok := b.(*big.Int)
+		if !ok {
+			return false
+		}
+		if aValue == nil || bValue == nil {
+			return aValue == bValue
+		}
+		return aValue.Cmp(bValue) == 0
+	case bool:
+		bValue, ok := b.(bool)
+		return ok && aValue == bValue
+	case common.Address:
+		bValue, ok := b.(common.Address)
+		return ok && aValue == bValue
+	default:
+		return a == b
+	}
+}
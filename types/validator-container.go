@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The kind of contract that holds a node's validators
+type ValidatorContainerType uint8
+
+const (
+	ValidatorContainerType_Minipool ValidatorContainerType = iota // One validator per contract (up to Houston)
+	ValidatorContainerType_Megapool                               // All of a node's validators in one contract (Saturn)
+)
+
+// The surface shared by minipools and megapools, so code that works with a node's validators can handle both
+type IValidatorContainer interface {
+	GetContainerType() ValidatorContainerType
+	GetAddress() common.Address
+	GetNodeAddress(opts *bind.CallOpts) (common.Address, error)
+	GetValidatorPubkeys(opts *bind.CallOpts) ([]ValidatorPubkey, error)
+}
@@ -0,0 +1,280 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Balance fetcher defaults
+const (
+	DefaultPageSize    int           = 64 // Validator IDs per request; 64 pubkeys keep the query string under the common 8 KB URL limit
+	DefaultThreadLimit int           = 4
+	DefaultMaxRetries  int           = 3
+	DefaultRetryDelay  time.Duration = 1 * time.Second
+	DefaultHttpTimeout time.Duration = 30 * time.Second
+)
+
+// Wei per gwei, for converting Beacon balances
+var weiPerGwei = big.NewInt(1e9)
+
+// The balance of a single validator on the Beacon Chain
+type ValidatorBalance struct {
+	Index                 string
+	Pubkey                types.ValidatorPubkey
+	Balance               uint64 // gwei
+	WithdrawalCredentials common.Hash
+}
+
+// Something that can get the balances of a page of validators from a Beacon node
+// IDs may be validator indices or 0x-prefixed pubkeys; validators that don't exist are omitted from the response
+type BalanceProvider interface {
+	GetValidatorBalances(ctx context.Context, stateID string, ids []string) ([]ValidatorBalance, error)
+}
+
+// Gets validator balances from the standard Beacon node HTTP API
+type HttpBalanceProvider struct {
+	Url    string
+	Client *http.Client
+}
+
+// Create a new HTTP balance provider for a Beacon node
+func NewHttpBalanceProvider(beaconUrl string) *HttpBalanceProvider {
+	return &HttpBalanceProvider{
+		Url: strings.TrimSuffix(beaconUrl, "/"),
+		Client: &http.Client{
+			Timeout: DefaultHttpTimeout,
+		},
+	}
+}
+
+// Response from the Beacon API validators endpoint
+type validatorsResponse struct {
+	Data []struct {
+		Index     string `json:"index"`
+		Balance   string `json:"balance"`
+		Validator struct {
+			Pubkey                string `json:"pubkey"`
+			WithdrawalCredentials string `json:"withdrawal_credentials"`
+		} `json:"validator"`
+	} `json:"data"`
+}
+
+// Get the balances of a page of validators
+func (p *HttpBalanceProvider) GetValidatorBalances(ctx context.Context, stateID string, ids []string) ([]ValidatorBalance, error) {
+	query := url.Values{}
+	query.Set("id", strings.Join(ids, ","))
+	requestUrl := fmt.Sprintf("%s/eth/v1/beacon/states/%s/validators?%s", p.Url, url.PathEscape(stateID), query.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating validators request: %w", err)
+	}
+
+	response, err := p.Client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting validators: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading validators response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &httpError{StatusCode: response.StatusCode, Body: string(body)}
+	}
+
+	var validators validatorsResponse
+	if err := json.Unmarshal(body, &validators); err != nil {
+		return nil, fmt.Errorf("error decoding validators response: %w", err)
+	}
+	balances := make([]ValidatorBalance, len(validators.Data))
+	for i, validator := range validators.Data {
+		balances[i].Index = validator.Index
+		balances[i].Balance, err = strconv.ParseUint(validator.Balance, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing balance of validator %s: %w", validator.Index, err)
+		}
+		balances[i].Pubkey, err = types.HexToValidatorPubkey(strings.TrimPrefix(validator.Validator.Pubkey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing pubkey of validator %s: %w", validator.Index, err)
+		}
+		balances[i].WithdrawalCredentials = common.HexToHash(validator.Validator.WithdrawalCredentials)
+	}
+	return balances, nil
+}
+
+// An unsuccessful HTTP response from a Beacon node
+type httpError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("beacon node responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Check if a request can be retried; client errors other than rate limiting won't succeed on a retry
+func isRetryable(err error) bool {
+	if httpErr, ok := err.(*httpError); ok {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return true
+}
+
+// Gets the balances of large sets of validators in pages, retrying failed pages and returning the balances in the
+// same order as the requested validators
+// To read the balances at a snapshot's slot, use its verified state root (see state.Snapshot.GetBeaconStateID) as the
+// state ID
+type BalanceFetcher struct {
+	Provider    BalanceProvider
+	PageSize    int
+	ThreadLimit int
+	MaxRetries  int
+	RetryDelay  time.Duration
+}
+
+// Create a new balance fetcher with the default paging and retry settings
+func NewBalanceFetcher(provider BalanceProvider) *BalanceFetcher {
+	return &BalanceFetcher{
+		Provider:    provider,
+		PageSize:    DefaultPageSize,
+		ThreadLimit: DefaultThreadLimit,
+		MaxRetries:  DefaultMaxRetries,
+		RetryDelay:  DefaultRetryDelay,
+	}
+}
+
+// Get the balances of validators by index, in wei
+// Validators that aren't on the Beacon Chain at the given state have a balance of zero
+func (f *BalanceFetcher) GetBalancesByIndex(stateID string, indices []uint64) ([]*big.Int, error) {
+	ids := make([]string, len(indices))
+	for i, index := range indices {
+		ids[i] = strconv.FormatUint(index, 10)
+	}
+	return f.getBalances(stateID, ids, func(balance ValidatorBalance) string {
+		return balance.Index
+	})
+}
+
+// Get the balances of validators by pubkey, in wei; the result can be passed straight to
+// state.CalculateCompleteMinipoolShares when the pubkeys come from the same minipool details
+// Validators that aren't on the Beacon Chain at the given state have a balance of zero
+func (f *BalanceFetcher) GetBalancesByPubkey(stateID string, pubkeys []types.ValidatorPubkey) ([]*big.Int, error) {
+	ids := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		ids[i] = "0x" + pubkey.Hex()
+	}
+	return f.getBalances(stateID, ids, func(balance ValidatorBalance) string {
+		return "0x" + balance.Pubkey.Hex()
+	})
+}
+
+// Get the withdrawal credentials of validators by pubkey
+// Validators that aren't on the Beacon Chain at the given state are omitted from the result
+func (f *BalanceFetcher) GetWithdrawalCredentialsByPubkey(stateID string, pubkeys []types.ValidatorPubkey) (map[types.ValidatorPubkey]common.Hash, error) {
+	ids := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		ids[i] = "0x" + pubkey.Hex()
+	}
+	var lock sync.Mutex
+	credentials := make(map[types.ValidatorPubkey]common.Hash, len(pubkeys))
+	err := f.getPages(stateID, ids, func(start int, end int, page []ValidatorBalance) {
+		lock.Lock()
+		defer lock.Unlock()
+		for _, validator := range page {
+			credentials[validator.Pubkey] = validator.WithdrawalCredentials
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// Get the balances of validators in pages, matching each response to its request with the given key function
+func (f *BalanceFetcher) getBalances(stateID string, ids []string, getKey func(ValidatorBalance) string) ([]*big.Int, error) {
+	balances := make([]*big.Int, len(ids))
+	err := f.getPages(stateID, ids, func(start int, end int, page []ValidatorBalance) {
+		pageBalances := make(map[string]uint64, len(page))
+		for _, balance := range page {
+			pageBalances[getKey(balance)] = balance.Balance
+		}
+		for j := start; j < end; j++ {
+			balance := big.NewInt(0).SetUint64(pageBalances[ids[j]])
+			balances[j] = balance.Mul(balance, weiPerGwei)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// Get the validators in pages, passing each page and the range of IDs it was requested with to the handler
+// Pages run in parallel, so the handler must be safe for concurrent use
+func (f *BalanceFetcher) getPages(stateID string, ids []string, handlePage func(start int, end int, page []ValidatorBalance)) error {
+	pageSize := f.PageSize
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	threadLimit := f.ThreadLimit
+	if threadLimit < 1 {
+		threadLimit = 1
+	}
+
+	// Sync
+	count := len(ids)
+	var wg errgroup.Group
+	wg.SetLimit(threadLimit)
+
+	// Run the pages
+	for i := 0; i < count; i += pageSize {
+		i := i
+		max := i + pageSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			page, err := f.getPage(stateID, ids[i:max])
+			if err != nil {
+				return fmt.Errorf("error getting validators %d to %d: %w", i, max-1, err)
+			}
+			handlePage(i, max, page)
+			return nil
+		})
+	}
+
+	return wg.Wait()
+}
+
+// Get a single page of balances, retrying with a linear backoff
+func (f *BalanceFetcher) getPage(stateID string, ids []string) ([]ValidatorBalance, error) {
+	var err error
+	for attempt := 0; attempt <= f.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * f.RetryDelay)
+		}
+		var page []ValidatorBalance
+		page, err = f.Provider.GetValidatorBalances(context.Background(), stateID, ids)
+		if err == nil {
+			return page, nil
+		}
+		if !isRetryable(err) {
+			break
+		}
+	}
+	return nil, err
+}
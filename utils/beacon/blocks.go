@@ -0,0 +1,281 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The number of empty slots GetElBlockForSlot will walk back over before giving up
+const DefaultMaxMissedSlots uint64 = 64
+
+// The execution block carried by a Beacon block
+// Blocks from before the merge have no execution payload, so their EL fields are zero
+type ExecutionBlockInfo struct {
+	Slot            uint64      `json:"slot"`
+	BeaconStateRoot common.Hash `json:"beaconStateRoot"`
+	ElBlockNumber   uint64      `json:"elBlockNumber"`
+	ElBlockHash     common.Hash `json:"elBlockHash"`
+	ElTimestamp     uint64      `json:"elTimestamp"`
+}
+
+// The chain parameters needed to convert between slots, epochs and times
+type BeaconConfig struct {
+	GenesisTime    uint64 `json:"genesisTime"`
+	SecondsPerSlot uint64 `json:"secondsPerSlot"`
+	SlotsPerEpoch  uint64 `json:"slotsPerEpoch"`
+}
+
+// Something that can get the execution block of a Beacon block
+// Block IDs may be a slot, a 0x-prefixed block root, or a tag such as "head" or "finalized"; the bool is false if there
+// was no block, such as for a missed slot
+type BlockProvider interface {
+	GetExecutionBlock(ctx context.Context, blockID string) (ExecutionBlockInfo, bool, error)
+}
+
+// Gets Beacon blocks from the standard Beacon node HTTP API
+type HttpBlockProvider struct {
+	Url    string
+	Client *http.Client
+}
+
+// Create a new HTTP block provider for a Beacon node
+func NewHttpBlockProvider(beaconUrl string) *HttpBlockProvider {
+	return &HttpBlockProvider{
+		Url: strings.TrimSuffix(beaconUrl, "/"),
+		Client: &http.Client{
+			Timeout: DefaultHttpTimeout,
+		},
+	}
+}
+
+// Response from the Beacon API block endpoint
+type blockResponse struct {
+	Data struct {
+		Message struct {
+			Slot      string `json:"slot"`
+			StateRoot string `json:"state_root"`
+			Body      struct {
+				ExecutionPayload *struct {
+					BlockNumber string `json:"block_number"`
+					BlockHash   string `json:"block_hash"`
+					Timestamp   string `json:"timestamp"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// Response from the Beacon API genesis endpoint
+type genesisResponse struct {
+	Data struct {
+		GenesisTime string `json:"genesis_time"`
+	} `json:"data"`
+}
+
+// Response from the Beacon API spec endpoint
+type specResponse struct {
+	Data struct {
+		SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+		SlotsPerEpoch  string `json:"SLOTS_PER_EPOCH"`
+	} `json:"data"`
+}
+
+// Get the execution block of a Beacon block
+func (p *HttpBlockProvider) GetExecutionBlock(ctx context.Context, blockID string) (ExecutionBlockInfo, bool, error) {
+	var block blockResponse
+	found, err := p.get(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", url.PathEscape(blockID)), &block)
+	if err != nil || !found {
+		return ExecutionBlockInfo{}, false, err
+	}
+
+	message := block.Data.Message
+	info := ExecutionBlockInfo{
+		BeaconStateRoot: common.HexToHash(message.StateRoot),
+	}
+	info.Slot, err = strconv.ParseUint(message.Slot, 10, 64)
+	if err != nil {
+		return ExecutionBlockInfo{}, false, fmt.Errorf("error parsing slot of block %s: %w", blockID, err)
+	}
+	if payload := message.Body.ExecutionPayload; payload != nil {
+		info.ElBlockNumber, err = strconv.ParseUint(payload.BlockNumber, 10, 64)
+		if err != nil {
+			return ExecutionBlockInfo{}, false, fmt.Errorf("error parsing execution block number of block %s: %w", blockID, err)
+		}
+		info.ElTimestamp, err = strconv.ParseUint(payload.Timestamp, 10, 64)
+		if err != nil {
+			return ExecutionBlockInfo{}, false, fmt.Errorf("error parsing execution timestamp of block %s: %w", blockID, err)
+		}
+		info.ElBlockHash = common.HexToHash(payload.BlockHash)
+	}
+	return info, true, nil
+}
+
+// Get the chain parameters from the Beacon node
+func (p *HttpBlockProvider) GetBeaconConfig(ctx context.Context) (BeaconConfig, error) {
+	var genesis genesisResponse
+	if _, err := p.get(ctx, "/eth/v1/beacon/genesis", &genesis); err != nil {
+		return BeaconConfig{}, err
+	}
+	var spec specResponse
+	if _, err := p.get(ctx, "/eth/v1/config/spec", &spec); err != nil {
+		return BeaconConfig{}, err
+	}
+
+	var config BeaconConfig
+	var err error
+	config.GenesisTime, err = strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return BeaconConfig{}, fmt.Errorf("error parsing genesis time: %w", err)
+	}
+	config.SecondsPerSlot, err = strconv.ParseUint(spec.Data.SecondsPerSlot, 10, 64)
+	if err != nil {
+		return BeaconConfig{}, fmt.Errorf("error parsing seconds per slot: %w", err)
+	}
+	config.SlotsPerEpoch, err = strconv.ParseUint(spec.Data.SlotsPerEpoch, 10, 64)
+	if err != nil {
+		return BeaconConfig{}, fmt.Errorf("error parsing slots per epoch: %w", err)
+	}
+	return config, nil
+}
+
+// Make a GET request to the Beacon node and decode the response; returns false if the resource wasn't found
+func (p *HttpBlockProvider) get(ctx context.Context, path string, out any) (bool, error) {
+	return p.request(ctx, http.MethodGet, path, nil, out)
+}
+
+// Make a request to the Beacon node, with a JSON body if it isn't nil, and decode the response; returns false if the
+// resource wasn't found
+func (p *HttpBlockProvider) request(ctx context.Context, method string, path string, body any, out any) (bool, error) {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("error encoding request body for %s: %w", path, err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, p.Url+path, requestBody)
+	if err != nil {
+		return false, fmt.Errorf("error creating request for %s: %w", path, err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := p.Client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("error requesting %s: %w", path, err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s response: %w", path, err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, &httpError{StatusCode: response.StatusCode, Body: string(responseBody)}
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return false, fmt.Errorf("error decoding %s response: %w", path, err)
+	}
+	return true, nil
+}
+
+// Maps Beacon slots and epochs to the execution blocks whose state they reflect, and back
+type BlockMapper struct {
+	Provider       BlockProvider
+	Config         BeaconConfig
+	MaxMissedSlots uint64
+}
+
+// Create a new block mapper
+func NewBlockMapper(provider BlockProvider, config BeaconConfig) *BlockMapper {
+	return &BlockMapper{
+		Provider:       provider,
+		Config:         config,
+		MaxMissedSlots: DefaultMaxMissedSlots,
+	}
+}
+
+// Get the execution block whose state a slot reflects
+// If the slot was missed, this is the block of the latest slot before it that had one
+func (m *BlockMapper) GetElBlockForSlot(slot uint64) (ExecutionBlockInfo, error) {
+	for offset := uint64(0); offset <= m.MaxMissedSlots && offset <= slot; offset++ {
+		info, found, err := m.Provider.GetExecutionBlock(context.Background(), strconv.FormatUint(slot-offset, 10))
+		if err != nil {
+			return ExecutionBlockInfo{}, fmt.Errorf("error getting Beacon block for slot %d: %w", slot-offset, err)
+		}
+		if found {
+			return info, nil
+		}
+	}
+	return ExecutionBlockInfo{}, fmt.Errorf("no Beacon block found in the %d slots up to slot %d", m.MaxMissedSlots+1, slot)
+}
+
+// Get the execution block whose state the end of an epoch reflects
+func (m *BlockMapper) GetElBlockForEpoch(epoch uint64) (ExecutionBlockInfo, error) {
+	return m.GetElBlockForSlot(m.GetLastSlotOfEpoch(epoch))
+}
+
+// Get the slot that includes an execution block
+func (m *BlockMapper) GetSlotForElBlock(client rocketpool.ExecutionClient, blockNumber uint64) (uint64, error) {
+	header, err := client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("error getting header for block %d: %w", blockNumber, err)
+	}
+	slot, err := m.GetSlotForTime(header.Time)
+	if err != nil {
+		return 0, err
+	}
+
+	// Make sure the Beacon node agrees, since pre-merge blocks and non-standard chains don't follow the slot timing
+	info, found, err := m.Provider.GetExecutionBlock(context.Background(), strconv.FormatUint(slot, 10))
+	if err != nil {
+		return 0, fmt.Errorf("error getting Beacon block for slot %d: %w", slot, err)
+	}
+	if !found || info.ElBlockNumber != blockNumber {
+		return 0, fmt.Errorf("execution block %d is not included in slot %d", blockNumber, slot)
+	}
+	return slot, nil
+}
+
+// Get the epoch that includes an execution block
+func (m *BlockMapper) GetEpochForElBlock(client rocketpool.ExecutionClient, blockNumber uint64) (uint64, error) {
+	slot, err := m.GetSlotForElBlock(client, blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return m.GetEpochForSlot(slot), nil
+}
+
+// Get the slot in progress at a time
+func (m *BlockMapper) GetSlotForTime(timestamp uint64) (uint64, error) {
+	if timestamp < m.Config.GenesisTime {
+		return 0, fmt.Errorf("time %d is before Beacon genesis at %d", timestamp, m.Config.GenesisTime)
+	}
+	return (timestamp - m.Config.GenesisTime) / m.Config.SecondsPerSlot, nil
+}
+
+// Get the epoch a slot is in
+func (m *BlockMapper) GetEpochForSlot(slot uint64) uint64 {
+	return slot / m.Config.SlotsPerEpoch
+}
+
+// Get the last slot of an epoch
+func (m *BlockMapper) GetLastSlotOfEpoch(epoch uint64) uint64 {
+	return (epoch+1)*m.Config.SlotsPerEpoch - 1
+}
@@ -0,0 +1,116 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A Beacon block header and the block root the Beacon node reported for it
+type BlockHeader struct {
+	Root          common.Hash `json:"root"`
+	Slot          uint64      `json:"slot"`
+	ProposerIndex uint64      `json:"proposerIndex"`
+	ParentRoot    common.Hash `json:"parentRoot"`
+	StateRoot     common.Hash `json:"stateRoot"`
+	BodyRoot      common.Hash `json:"bodyRoot"`
+}
+
+// Something that can get Beacon block headers
+// Block IDs are the same as BlockProvider's; the bool is false if there was no block, such as for a missed slot
+type HeaderProvider interface {
+	GetBlockHeader(ctx context.Context, blockID string) (BlockHeader, bool, error)
+}
+
+// Response from the Beacon API headers endpoint
+type headerResponse struct {
+	Data struct {
+		Root   string `json:"root"`
+		Header struct {
+			Message struct {
+				Slot          string `json:"slot"`
+				ProposerIndex string `json:"proposer_index"`
+				ParentRoot    string `json:"parent_root"`
+				StateRoot     string `json:"state_root"`
+				BodyRoot      string `json:"body_root"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+// Get the header of a Beacon block
+func (p *HttpBlockProvider) GetBlockHeader(ctx context.Context, blockID string) (BlockHeader, bool, error) {
+	var response headerResponse
+	found, err := p.get(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%s", url.PathEscape(blockID)), &response)
+	if err != nil || !found {
+		return BlockHeader{}, false, err
+	}
+
+	message := response.Data.Header.Message
+	header := BlockHeader{
+		Root:       common.HexToHash(response.Data.Root),
+		ParentRoot: common.HexToHash(message.ParentRoot),
+		StateRoot:  common.HexToHash(message.StateRoot),
+		BodyRoot:   common.HexToHash(message.BodyRoot),
+	}
+	header.Slot, err = strconv.ParseUint(message.Slot, 10, 64)
+	if err != nil {
+		return BlockHeader{}, false, fmt.Errorf("error parsing slot of header %s: %w", blockID, err)
+	}
+	header.ProposerIndex, err = strconv.ParseUint(message.ProposerIndex, 10, 64)
+	if err != nil {
+		return BlockHeader{}, false, fmt.Errorf("error parsing proposer index of header %s: %w", blockID, err)
+	}
+	return header, true, nil
+}
+
+// Get the SSZ hash tree root of the header, which is the root of the block it belongs to
+func (h BlockHeader) HashTreeRoot() common.Hash {
+	// The five fields are merkleized as eight 32-byte leaves, padded with zero leaves
+	leaves := make([][32]byte, 8)
+	binary.LittleEndian.PutUint64(leaves[0][:8], h.Slot)
+	binary.LittleEndian.PutUint64(leaves[1][:8], h.ProposerIndex)
+	leaves[2] = h.ParentRoot
+	leaves[3] = h.StateRoot
+	leaves[4] = h.BodyRoot
+	for len(leaves) > 1 {
+		parents := make([][32]byte, len(leaves)/2)
+		for i := range parents {
+			parents[i] = sha256.Sum256(append(leaves[2*i][:], leaves[2*i+1][:]...))
+		}
+		leaves = parents
+	}
+	return leaves[0]
+}
+
+// Check that the Beacon state root of a block is the one committed to by the block's header
+// The header must hash to the block root the Beacon node reports for it, so a state root that was altered or taken
+// from another block is rejected. The mapper's provider must also be a HeaderProvider.
+func (m *BlockMapper) VerifyStateRoot(info ExecutionBlockInfo) error {
+	headerProvider, ok := m.Provider.(HeaderProvider)
+	if !ok {
+		return fmt.Errorf("the block provider can't get block headers to verify the state root of slot %d", info.Slot)
+	}
+	header, found, err := headerProvider.GetBlockHeader(context.Background(), strconv.FormatUint(info.Slot, 10))
+	if err != nil {
+		return fmt.Errorf("error getting block header for slot %d: %w", info.Slot, err)
+	}
+	if !found {
+		return fmt.Errorf("no block header found for slot %d", info.Slot)
+	}
+	if header.Slot != info.Slot {
+		return fmt.Errorf("block header for slot %d is for slot %d", info.Slot, header.Slot)
+	}
+	if root := header.HashTreeRoot(); root != header.Root {
+		return fmt.Errorf("block header for slot %d hashes to %s, not its block root %s", info.Slot, root.Hex(), header.Root.Hex())
+	}
+	if header.StateRoot != info.BeaconStateRoot {
+		return fmt.Errorf("state root %s of slot %d doesn't match its block header's state root %s", info.BeaconStateRoot.Hex(), info.Slot, header.StateRoot.Hex())
+	}
+	return nil
+}
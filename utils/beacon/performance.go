@@ -0,0 +1,271 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// The duty performance of a validator over a range of epochs
+type ValidatorPerformance struct {
+	Pubkey               types.ValidatorPubkey
+	Active               bool
+	Slashed              bool
+	AttestationsExpected uint64
+	AttestationsIncluded uint64
+	ProposalsExpected    uint64
+	ProposalsMissed      uint64
+}
+
+// Something that can report the duty performance of validators, such as HttpPerformanceProvider or a monitoring
+// database
+// Validators the provider has no data for are omitted from the response
+type PerformanceProvider interface {
+	GetValidatorPerformance(ctx context.Context, pubkeys []types.ValidatorPubkey) ([]ValidatorPerformance, error)
+}
+
+// Get the fraction of the validator's expected attestations that were included, and whether it had any duties to
+// measure it by
+func (p ValidatorPerformance) GetAttestationEffectiveness() (float64, bool) {
+	if p.AttestationsExpected == 0 {
+		return 0, false
+	}
+	return float64(p.AttestationsIncluded) / float64(p.AttestationsExpected), true
+}
+
+// Gets validator performance over a range of epochs from the standard Beacon node HTTP API
+// An attestation counts as included if it earned the source reward for its epoch; a proposal counts as missed if there
+// is no block in its slot. The node must be able to serve the attestation rewards and proposer duties of every epoch in
+// the range, which usually means the range has to be finalized and not pruned.
+// Validator statuses are read from StateID, which defaults to the head state; set it to a snapshot's verified state
+// root (see state.Snapshot.GetBeaconStateID) so the statuses match the snapshot's slot.
+type HttpPerformanceProvider struct {
+	HttpBlockProvider
+	StartEpoch  uint64
+	EndEpoch    uint64 // Inclusive
+	StateID     string
+	PageSize    int
+	ThreadLimit int
+}
+
+// Create a new HTTP performance provider for a Beacon node, covering the given range of epochs (inclusive)
+func NewHttpPerformanceProvider(beaconUrl string, startEpoch uint64, endEpoch uint64) *HttpPerformanceProvider {
+	return &HttpPerformanceProvider{
+		HttpBlockProvider: *NewHttpBlockProvider(beaconUrl),
+		StartEpoch:        startEpoch,
+		EndEpoch:          endEpoch,
+		StateID:           "head",
+		PageSize:          DefaultPageSize,
+		ThreadLimit:       DefaultThreadLimit,
+	}
+}
+
+// Response from the Beacon API validators endpoint, with the fields needed for performance
+type validatorStatusResponse struct {
+	Data []struct {
+		Index     string `json:"index"`
+		Status    string `json:"status"`
+		Validator struct {
+			Pubkey          string `json:"pubkey"`
+			Slashed         bool   `json:"slashed"`
+			ActivationEpoch string `json:"activation_epoch"`
+			ExitEpoch       string `json:"exit_epoch"`
+		} `json:"validator"`
+	} `json:"data"`
+}
+
+// Response from the Beacon API attestation rewards endpoint
+type attestationRewardsResponse struct {
+	Data struct {
+		TotalRewards []struct {
+			ValidatorIndex string `json:"validator_index"`
+			Source         string `json:"source"`
+		} `json:"total_rewards"`
+	} `json:"data"`
+}
+
+// Response from the Beacon API proposer duties endpoint
+type proposerDutiesResponse struct {
+	Data []struct {
+		ValidatorIndex string `json:"validator_index"`
+		Slot           string `json:"slot"`
+	} `json:"data"`
+}
+
+// A validator being measured, with the epochs it was eligible for duties in
+type measuredValidator struct {
+	performance     *ValidatorPerformance
+	activationEpoch uint64
+	exitEpoch       uint64
+}
+
+// Get the performance of validators over the provider's range of epochs
+func (p *HttpPerformanceProvider) GetValidatorPerformance(ctx context.Context, pubkeys []types.ValidatorPubkey) ([]ValidatorPerformance, error) {
+	if p.EndEpoch < p.StartEpoch {
+		return nil, fmt.Errorf("end epoch %d is before start epoch %d", p.EndEpoch, p.StartEpoch)
+	}
+
+	// Get the validators' indices and status
+	validators, err := p.getValidators(ctx, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return []ValidatorPerformance{}, nil
+	}
+	indices := make([]string, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+
+	// Measure each epoch
+	var lock sync.Mutex
+	var wg errgroup.Group
+	threadLimit := p.ThreadLimit
+	if threadLimit < 1 {
+		threadLimit = 1
+	}
+	wg.SetLimit(threadLimit)
+	for epoch := p.StartEpoch; epoch <= p.EndEpoch; epoch++ {
+		epoch := epoch
+		wg.Go(func() error {
+			return p.measureEpoch(ctx, epoch, indices, validators, &lock)
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return in the order the validators were requested
+	performances := make([]ValidatorPerformance, 0, len(validators))
+	byPubkey := make(map[types.ValidatorPubkey]*ValidatorPerformance, len(validators))
+	for _, validator := range validators {
+		byPubkey[validator.performance.Pubkey] = validator.performance
+	}
+	for _, pubkey := range pubkeys {
+		if performance, exists := byPubkey[pubkey]; exists {
+			performances = append(performances, *performance)
+			delete(byPubkey, pubkey)
+		}
+	}
+	return performances, nil
+}
+
+// Get the validators that exist on the Beacon Chain, keyed by index
+func (p *HttpPerformanceProvider) getValidators(ctx context.Context, pubkeys []types.ValidatorPubkey) (map[string]*measuredValidator, error) {
+	pageSize := p.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	validators := map[string]*measuredValidator{}
+	for start := 0; start < len(pubkeys); start += pageSize {
+		end := start + pageSize
+		if end > len(pubkeys) {
+			end = len(pubkeys)
+		}
+		ids := make([]string, end-start)
+		for i, pubkey := range pubkeys[start:end] {
+			ids[i] = "0x" + pubkey.Hex()
+		}
+
+		query := url.Values{}
+		query.Set("id", strings.Join(ids, ","))
+		var response validatorStatusResponse
+		stateID := p.StateID
+		if stateID == "" {
+			stateID = "head"
+		}
+		if _, err := p.get(ctx, fmt.Sprintf("/eth/v1/beacon/states/%s/validators?%s", url.PathEscape(stateID), query.Encode()), &response); err != nil {
+			return nil, err
+		}
+		for _, validator := range response.Data {
+			pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(validator.Validator.Pubkey, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("error parsing pubkey of validator %s: %w", validator.Index, err)
+			}
+			activationEpoch, err := strconv.ParseUint(validator.Validator.ActivationEpoch, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing activation epoch of validator %s: %w", validator.Index, err)
+			}
+			exitEpoch, err := strconv.ParseUint(validator.Validator.ExitEpoch, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing exit epoch of validator %s: %w", validator.Index, err)
+			}
+			validators[validator.Index] = &measuredValidator{
+				performance: &ValidatorPerformance{
+					Pubkey:  pubkey,
+					Active:  strings.HasPrefix(validator.Status, "active"),
+					Slashed: validator.Validator.Slashed,
+				},
+				activationEpoch: activationEpoch,
+				exitEpoch:       exitEpoch,
+			}
+		}
+	}
+	return validators, nil
+}
+
+// Add a single epoch's attestations and proposals to the validators' performance
+func (p *HttpPerformanceProvider) measureEpoch(ctx context.Context, epoch uint64, indices []string, validators map[string]*measuredValidator, lock *sync.Mutex) error {
+	// Get the attestation rewards
+	var rewards attestationRewardsResponse
+	found, err := p.request(ctx, http.MethodPost, fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), indices, &rewards)
+	if err != nil {
+		return fmt.Errorf("error getting attestation rewards for epoch %d: %w", epoch, err)
+	}
+	if !found {
+		return fmt.Errorf("attestation rewards for epoch %d are not available", epoch)
+	}
+
+	// Get the proposer duties and check each of the validators' slots for a block
+	var duties proposerDutiesResponse
+	if _, err := p.get(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), &duties); err != nil {
+		return fmt.Errorf("error getting proposer duties for epoch %d: %w", epoch, err)
+	}
+	missed := map[string]bool{}
+	for _, duty := range duties.Data {
+		if _, exists := validators[duty.ValidatorIndex]; !exists {
+			continue
+		}
+		var header struct{}
+		found, err := p.get(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%s", url.PathEscape(duty.Slot)), &header)
+		if err != nil {
+			return fmt.Errorf("error getting block header for slot %s: %w", duty.Slot, err)
+		}
+		missed[duty.ValidatorIndex] = !found
+	}
+
+	// Update the performance
+	lock.Lock()
+	defer lock.Unlock()
+	for _, reward := range rewards.Data.TotalRewards {
+		validator, exists := validators[reward.ValidatorIndex]
+		if !exists || epoch < validator.activationEpoch || epoch >= validator.exitEpoch {
+			continue
+		}
+		source, ok := new(big.Int).SetString(reward.Source, 10)
+		if !ok {
+			return fmt.Errorf("error parsing source reward of validator %s in epoch %d", reward.ValidatorIndex, epoch)
+		}
+		validator.performance.AttestationsExpected++
+		if source.Sign() > 0 {
+			validator.performance.AttestationsIncluded++
+		}
+	}
+	for index, wasMissed := range missed {
+		performance := validators[index].performance
+		performance.ProposalsExpected++
+		if wasMissed {
+			performance.ProposalsMissed++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Domain separation tag for the Ethereum consensus layer's proof-of-possession BLS signature scheme
+const SignatureDST string = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// Length of a serialized BLS secret key
+const SecretKeyLength = 32 // bytes
+
+// A BLS12-381 secret key for a validator
+type SecretKey struct {
+	value *bls12381.Fr
+}
+
+// Create a secret key from its 32-byte big-endian serialization
+func SecretKeyFromBytes(value []byte) (*SecretKey, error) {
+	if len(value) != SecretKeyLength {
+		return nil, fmt.Errorf("invalid secret key length %d, expected %d", len(value), SecretKeyLength)
+	}
+	scalar := big.NewInt(0).SetBytes(value)
+	if scalar.Sign() == 0 {
+		return nil, errors.New("secret key cannot be zero")
+	}
+	if scalar.Cmp(bls12381.NewG1().Q()) >= 0 {
+		return nil, errors.New("secret key is not less than the curve order")
+	}
+	return &SecretKey{
+		value: bls12381.NewFr().FromBytes(value),
+	}, nil
+}
+
+// Serialize the secret key
+func (k *SecretKey) Bytes() []byte {
+	return k.value.ToBytes()
+}
+
+// Get the validator pubkey corresponding to this secret key
+func (k *SecretKey) PublicKey() types.ValidatorPubkey {
+	g1 := bls12381.NewG1()
+	point := g1.New()
+	g1.MulScalar(point, g1.One(), k.value)
+	return types.BytesToValidatorPubkey(g1.ToCompressed(point))
+}
+
+// Sign a message (typically a 32-byte signing root) with this secret key
+func (k *SecretKey) Sign(message []byte) (types.ValidatorSignature, error) {
+	g2 := bls12381.NewG2()
+	point, err := g2.HashToCurve(message, []byte(SignatureDST))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("error hashing message to curve: %w", err)
+	}
+	g2.MulScalar(point, point, k.value)
+	return types.BytesToValidatorSignature(g2.ToCompressed(point)), nil
+}
+
+// Parse a hex-encoded validator pubkey, with or without the 0x prefix, and check that it's a valid BLS public key
+func ParsePubkey(value string) (types.ValidatorPubkey, error) {
+	pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+	if err := ValidatePubkey(pubkey); err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+	return pubkey, nil
+}
+
+// Check that a validator pubkey is a valid BLS public key
+// Valid keys are compressed, non-infinity points in the G1 subgroup
+func ValidatePubkey(pubkey types.ValidatorPubkey) error {
+	_, err := decodePubkey(pubkey)
+	return err
+}
+
+// Check that a validator signature is a valid BLS signature point
+// This doesn't check what was signed; use Verify for that
+func ValidateSignature(signature types.ValidatorSignature) error {
+	_, err := decodeSignature(signature)
+	return err
+}
+
+// Verify a signature of a message (typically a 32-byte signing root) against a validator pubkey
+// Returns an error if the pubkey or signature isn't a valid point; returns false if they are valid but the signature doesn't match
+func Verify(pubkey types.ValidatorPubkey, message []byte, signature types.ValidatorSignature) (bool, error) {
+	pubkeyPoint, err := decodePubkey(pubkey)
+	if err != nil {
+		return false, err
+	}
+	signaturePoint, err := decodeSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	messagePoint, err := bls12381.NewG2().HashToCurve(message, []byte(SignatureDST))
+	if err != nil {
+		return false, fmt.Errorf("error hashing message to curve: %w", err)
+	}
+
+	// Check e(pubkey, H(message)) == e(G1, signature)
+	engine := bls12381.NewEngine()
+	engine.AddPair(pubkeyPoint, messagePoint)
+	engine.AddPairInv(bls12381.NewG1().One(), signaturePoint)
+	return engine.Check(), nil
+}
+
+// Decode a pubkey into a G1 point, checking that it's a valid public key
+func decodePubkey(pubkey types.ValidatorPubkey) (*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+	point, err := g1.FromCompressed(pubkey.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error decoding pubkey: %w", err)
+	}
+	if g1.IsZero(point) || !g1.InCorrectSubgroup(point) {
+		return nil, errors.New("pubkey is not a valid point in the G1 subgroup")
+	}
+	return point, nil
+}
+
+// Decode a signature into a G2 point, checking that it's in the right subgroup
+func decodeSignature(signature types.ValidatorSignature) (*bls12381.PointG2, error) {
+	g2 := bls12381.NewG2()
+	point, err := g2.FromCompressed(signature.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature: %w", err)
+	}
+	if !g2.InCorrectSubgroup(point) {
+		return nil, errors.New("signature is not a valid point in the G2 subgroup")
+	}
+	return point, nil
+}
@@ -0,0 +1,151 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/crypto/hkdf"
+)
+
+// EIP-2334 key paths for validator keys, formatted with the validator's index in the wallet
+const (
+	ValidatorWithdrawalKeyPath string = "m/12381/3600/%d/0"
+	ValidatorSigningKeyPath    string = "m/12381/3600/%d/0/0"
+)
+
+// The minimum length of a seed for master key derivation
+const MinSeedLength = 32 // bytes
+
+// EIP-2333 derivation settings
+const (
+	keygenSalt      string = "BLS-SIG-KEYGEN-SALT-"
+	keygenOkmLength int    = 48
+	lamportChunks   int    = 255
+)
+
+// Derive a master secret key from a seed as described in EIP-2333
+func DeriveMasterKey(seed []byte) (*SecretKey, error) {
+	if len(seed) < MinSeedLength {
+		return nil, fmt.Errorf("invalid seed length %d, expected at least %d", len(seed), MinSeedLength)
+	}
+	return hkdfModR(seed)
+}
+
+// Derive the child secret key at an index from a parent secret key as described in EIP-2333
+func DeriveChildKey(parent *SecretKey, index uint32) (*SecretKey, error) {
+	lamportPubkey, err := parentKeyToLamportPubkey(parent, index)
+	if err != nil {
+		return nil, err
+	}
+	return hkdfModR(lamportPubkey)
+}
+
+// Derive the secret key at an EIP-2334 path (e.g. m/12381/3600/0/0/0) from a seed
+func DeriveKeyFromPath(seed []byte, path string) (*SecretKey, error) {
+	indices, err := ParseKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := DeriveMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		key, err = DeriveChildKey(key, index)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving child key %d of path %s: %w", index, path, err)
+		}
+	}
+	return key, nil
+}
+
+// Get the signing key path of the validator at an index in the wallet
+func GetValidatorSigningKeyPath(index uint32) string {
+	return fmt.Sprintf(ValidatorSigningKeyPath, index)
+}
+
+// Get the withdrawal key path of the validator at an index in the wallet
+func GetValidatorWithdrawalKeyPath(index uint32) string {
+	return fmt.Sprintf(ValidatorWithdrawalKeyPath, index)
+}
+
+// Parse an EIP-2334 key path into its child indices
+// Paths start with "m"; the master key's path "m" has no indices
+func ParseKeyPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid key path %s: paths must start with m", path)
+	}
+	indices := make([]uint32, len(parts)-1)
+	for i, part := range parts[1:] {
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key path %s: invalid index %s", path, part)
+		}
+		indices[i] = uint32(index)
+	}
+	return indices, nil
+}
+
+// Derive a secret key from input key material with HKDF, retrying with a new salt until the key is non-zero
+func hkdfModR(ikm []byte) (*SecretKey, error) {
+	order := bls12381.NewG1().Q()
+	salt := []byte(keygenSalt)
+	input := append(append([]byte{}, ikm...), 0)
+	info := []byte{0, byte(keygenOkmLength)}
+	for {
+		hash := sha256.Sum256(salt)
+		salt = hash[:]
+		okm := make([]byte, keygenOkmLength)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, input, salt, info), okm); err != nil {
+			return nil, fmt.Errorf("error expanding key material: %w", err)
+		}
+		scalar := new(big.Int).SetBytes(okm)
+		scalar.Mod(scalar, order)
+		if scalar.Sign() != 0 {
+			return SecretKeyFromBytes(scalar.FillBytes(make([]byte, SecretKeyLength)))
+		}
+	}
+}
+
+// Get the compressed Lamport public key used as the input key material of a child key
+func parentKeyToLamportPubkey(parent *SecretKey, index uint32) ([]byte, error) {
+	salt := []byte{byte(index >> 24), byte(index >> 16), byte(index >> 8), byte(index)}
+	ikm := parent.Bytes()
+	notIkm := make([]byte, len(ikm))
+	for i, b := range ikm {
+		notIkm[i] = ^b
+	}
+
+	lamportPubkey := make([]byte, 0, 2*lamportChunks*sha256.Size)
+	for _, material := range [][]byte{ikm, notIkm} {
+		chunks, err := ikmToLamportKey(material, salt)
+		if err != nil {
+			return nil, err
+		}
+		for _, chunk := range chunks {
+			hash := sha256.Sum256(chunk)
+			lamportPubkey = append(lamportPubkey, hash[:]...)
+		}
+	}
+	compressed := sha256.Sum256(lamportPubkey)
+	return compressed[:], nil
+}
+
+// Get the chunks of a Lamport secret key from input key material
+func ikmToLamportKey(ikm []byte, salt []byte) ([][]byte, error) {
+	okm := make([]byte, lamportChunks*sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm); err != nil {
+		return nil, fmt.Errorf("error expanding Lamport key material: %w", err)
+	}
+	chunks := make([][]byte, lamportChunks)
+	for i := range chunks {
+		chunks[i] = okm[i*sha256.Size : (i+1)*sha256.Size]
+	}
+	return chunks, nil
+}
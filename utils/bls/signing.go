@@ -0,0 +1,89 @@
+package bls
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-ssz"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Signature domain types
+var (
+	DomainDeposit       = [4]byte{0x03, 0x00, 0x00, 0x00}
+	DomainVoluntaryExit = [4]byte{0x04, 0x00, 0x00, 0x00}
+)
+
+// Fork data used in signature domain computation
+type forkData struct {
+	CurrentVersion        []byte `ssz-size:"4"`
+	GenesisValidatorsRoot []byte `ssz-size:"32"`
+}
+
+// Signing data wrapping an object root and its domain
+type signingData struct {
+	ObjectRoot []byte `ssz-size:"32"`
+	Domain     []byte `ssz-size:"32"`
+}
+
+// The deposit message that gets signed
+type depositMessage struct {
+	Pubkey                []byte `ssz-size:"48"`
+	WithdrawalCredentials []byte `ssz-size:"32"`
+	Amount                uint64
+}
+
+// Compute the signature domain for a domain type, fork version and genesis validators root
+func ComputeDomain(domainType [4]byte, forkVersion [4]byte, genesisValidatorsRoot common.Hash) ([]byte, error) {
+	forkDataRoot, err := ssz.HashTreeRoot(forkData{
+		CurrentVersion:        forkVersion[:],
+		GenesisValidatorsRoot: genesisValidatorsRoot.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error computing fork data root: %w", err)
+	}
+	domain := make([]byte, 32)
+	copy(domain[0:4], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain, nil
+}
+
+// Compute the root that gets signed for an object root in a signature domain
+func ComputeSigningRoot(objectRoot common.Hash, domain []byte) (common.Hash, error) {
+	signingRoot, err := ssz.HashTreeRoot(signingData{
+		ObjectRoot: objectRoot.Bytes(),
+		Domain:     domain,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error computing signing root: %w", err)
+	}
+	return signingRoot, nil
+}
+
+// Get the root a validator key signs for a deposit; amount is in gwei
+// Deposits are valid across forks, so they're always signed with the genesis fork version and no validators root
+func GetDepositSigningRoot(pubkey types.ValidatorPubkey, withdrawalCredentials common.Hash, amount uint64, genesisForkVersion [4]byte) (common.Hash, error) {
+	domain, err := ComputeDomain(DomainDeposit, genesisForkVersion, common.Hash{})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	messageRoot, err := ssz.HashTreeRoot(depositMessage{
+		Pubkey:                pubkey.Bytes(),
+		WithdrawalCredentials: withdrawalCredentials.Bytes(),
+		Amount:                amount,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error computing deposit message root: %w", err)
+	}
+	return ComputeSigningRoot(messageRoot, domain)
+}
+
+// Check that a deposit's signature was made by its pubkey over its withdrawal credentials and amount
+func VerifyDepositSignature(pubkey types.ValidatorPubkey, withdrawalCredentials common.Hash, amount uint64, signature types.ValidatorSignature, genesisForkVersion [4]byte) (bool, error) {
+	signingRoot, err := GetDepositSigningRoot(pubkey, withdrawalCredentials, amount, genesisForkVersion)
+	if err != nil {
+		return false, err
+	}
+	return Verify(pubkey, signingRoot[:], signature)
+}
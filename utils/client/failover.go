@@ -0,0 +1,540 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Failover client defaults
+const (
+	DefaultMaxBlockLag         uint64        = 5
+	DefaultMaxErrorRate        float64       = 0.5
+	DefaultErrorWindow         int           = 50
+	DefaultHealthCheckInterval time.Duration = 15 * time.Second
+	DefaultHealthCheckTimeout  time.Duration = 5 * time.Second
+)
+
+// JSON-RPC error codes that mean the endpoint couldn't serve the request, rather than the request being invalid
+var failoverErrorCodes = map[int]bool{
+	-32005: true, // Limit exceeded
+}
+
+// JSON-RPC error message fragments (lowercase) that mean the endpoint is behind or missing state
+var failoverErrorMessages = []string{
+	"header not found",
+	"missing trie node",
+	"unknown block",
+	"rate limit",
+}
+
+// The health of a single endpoint
+type EndpointStatus struct {
+	Name        string    `json:"name"`
+	Healthy     bool      `json:"healthy"`
+	Reachable   bool      `json:"reachable"`
+	Syncing     bool      `json:"syncing"`
+	LatestBlock uint64    `json:"latestBlock"`
+	BlockLag    uint64    `json:"blockLag"`  // Blocks behind the most advanced endpoint
+	ErrorRate   float64   `json:"errorRate"` // Share of failed requests over the error window
+	Score       float64   `json:"score"`     // 0 (unusable) to 1 (perfect); higher scores are preferred
+	LastError   string    `json:"lastError,omitempty"`
+	LastChecked time.Time `json:"lastChecked"`
+}
+
+// An execution client endpoint behind a failover client
+type endpoint struct {
+	name    string
+	client  rocketpool.ExecutionClient
+	results []bool // Ring buffer of recent request outcomes; true means failed
+	next    int
+	status  EndpointStatus
+}
+
+// An execution client that spreads requests over several endpoints, scoring them by sync status, block lag and error
+// rate, and failing over to the next best endpoint when one can't serve a request
+// Errors returned by a node that did serve the request (e.g. reverts) are passed through without failing over
+type FailoverClient struct {
+	MaxBlockLag   uint64  // Endpoints further behind than this are unhealthy
+	MaxErrorRate  float64 // Endpoints with a higher error rate are unhealthy
+	StickyPrimary bool    // Always use the first endpoint while it's healthy, instead of the best scoring one
+
+	// Metrics hooks
+	OnRequest     func(endpoint string, method string, duration time.Duration, err error)
+	OnFailover    func(from string, to string, err error)
+	OnHealthCheck func(statuses []EndpointStatus)
+
+	endpoints   []*endpoint
+	errorWindow int
+	lock        sync.Mutex
+}
+
+// Create a failover client that connects to each of the given RPC URLs, in order of preference
+func NewFailoverClient(urls ...string) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("at least one endpoint is required")
+	}
+	names := make([]string, len(urls))
+	clients := make([]rocketpool.ExecutionClient, len(urls))
+	for i, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to endpoint %d: %w", i, err)
+		}
+		names[i] = url
+		clients[i] = client
+	}
+	return NewFailoverClientFromClients(names, clients)
+}
+
+// Create a failover client from existing clients, in order of preference; names are used in statuses and metrics hooks
+func NewFailoverClientFromClients(names []string, clients []rocketpool.ExecutionClient) (*FailoverClient, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("at least one endpoint is required")
+	}
+	if len(names) != len(clients) {
+		return nil, fmt.Errorf("got %d names for %d clients", len(names), len(clients))
+	}
+	c := &FailoverClient{
+		MaxBlockLag:  DefaultMaxBlockLag,
+		MaxErrorRate: DefaultMaxErrorRate,
+		errorWindow:  DefaultErrorWindow,
+		endpoints:    make([]*endpoint, len(clients)),
+	}
+	for i, client := range clients {
+		c.endpoints[i] = &endpoint{
+			name:    names[i],
+			client:  client,
+			results: make([]bool, 0, c.errorWindow),
+			status: EndpointStatus{
+				Name:      names[i],
+				Healthy:   true,
+				Reachable: true,
+				Score:     1,
+			},
+		}
+	}
+	return c, nil
+}
+
+// Get the current status of every endpoint, in order of preference
+func (c *FailoverClient) GetStatuses() []EndpointStatus {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	statuses := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		statuses[i] = e.status
+	}
+	return statuses
+}
+
+// Check the sync status and latest block of every endpoint and rescore them
+func (c *FailoverClient) CheckHealth(ctx context.Context) []EndpointStatus {
+	type checkResult struct {
+		reachable   bool
+		syncing     bool
+		latestBlock uint64
+		err         error
+	}
+	results := make([]checkResult, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		i, e := i, e
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+			defer cancel()
+			progress, err := e.client.SyncProgress(checkCtx)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			latestBlock, err := e.client.BlockNumber(checkCtx)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i] = checkResult{
+				reachable:   true,
+				syncing:     progress != nil,
+				latestBlock: latestBlock,
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Get the most advanced block
+	var highestBlock uint64
+	for _, result := range results {
+		if result.reachable && result.latestBlock > highestBlock {
+			highestBlock = result.latestBlock
+		}
+	}
+
+	// Update the statuses
+	c.lock.Lock()
+	now := time.Now()
+	for i, e := range c.endpoints {
+		result := results[i]
+		e.status.LastChecked = now
+		e.status.Reachable = result.reachable
+		e.status.Syncing = result.syncing
+		if result.reachable {
+			e.status.LatestBlock = result.latestBlock
+			e.status.BlockLag = highestBlock - result.latestBlock
+		} else if result.err != nil {
+			e.status.LastError = result.err.Error()
+		}
+		c.rescore(e)
+	}
+	c.lock.Unlock()
+
+	statuses := c.GetStatuses()
+	if c.OnHealthCheck != nil {
+		c.OnHealthCheck(statuses)
+	}
+	return statuses
+}
+
+// Run health checks on an interval until the context is cancelled
+func (c *FailoverClient) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	c.CheckHealth(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckHealth(ctx)
+		}
+	}
+}
+
+// Run a request against the best endpoint, failing over to the others in order if it can't be served
+func (c *FailoverClient) run(method string, request func(client rocketpool.ExecutionClient) error) error {
+	var lastErr error
+	var lastName string
+	for _, e := range c.getCandidates() {
+		if lastErr != nil && c.OnFailover != nil {
+			c.OnFailover(lastName, e.name, lastErr)
+		}
+		start := time.Now()
+		err := request(e.client)
+		if c.OnRequest != nil {
+			c.OnRequest(e.name, method, time.Since(start), err)
+		}
+		if err == nil || !shouldFailover(err) {
+			c.recordResult(e, nil)
+			return err
+		}
+		c.recordResult(e, err)
+		lastErr = err
+		lastName = e.name
+	}
+	return fmt.Errorf("all endpoints failed %s, last error: %w", method, lastErr)
+}
+
+// Get the endpoints in the order they should be tried
+func (c *FailoverClient) getCandidates() []*endpoint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	candidates := make([]*endpoint, len(c.endpoints))
+	copy(candidates, c.endpoints)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.status.Healthy != b.status.Healthy {
+			return a.status.Healthy
+		}
+		if c.StickyPrimary && a.status.Healthy {
+			return false // Keep the order of preference among healthy endpoints
+		}
+		return a.status.Score > b.status.Score
+	})
+	return candidates
+}
+
+// Record the outcome of a request and rescore the endpoint
+func (c *FailoverClient) recordResult(e *endpoint, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	failed := err != nil
+	if len(e.results) < c.errorWindow {
+		e.results = append(e.results, failed)
+	} else {
+		e.results[e.next] = failed
+		e.next = (e.next + 1) % c.errorWindow
+	}
+	if failed {
+		e.status.LastError = err.Error()
+	}
+	c.rescore(e)
+}
+
+// Update an endpoint's error rate, score and health
+func (c *FailoverClient) rescore(e *endpoint) {
+	failures := 0
+	for _, failed := range e.results {
+		if failed {
+			failures++
+		}
+	}
+	e.status.ErrorRate = 0
+	if len(e.results) > 0 {
+		e.status.ErrorRate = float64(failures) / float64(len(e.results))
+	}
+
+	e.status.Healthy = e.status.Reachable && !e.status.Syncing && e.status.BlockLag <= c.MaxBlockLag && e.status.ErrorRate <= c.MaxErrorRate
+	if !e.status.Reachable || e.status.Syncing {
+		e.status.Score = 0
+		return
+	}
+	lagPenalty := float64(e.status.BlockLag) / float64(c.MaxBlockLag+1)
+	if lagPenalty > 1 {
+		lagPenalty = 1
+	}
+	e.status.Score = (1 - e.status.ErrorRate) * (1 - lagPenalty)
+}
+
+// Check whether an error means the endpoint couldn't serve the request
+func shouldFailover(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		if failoverErrorCodes[rpcErr.ErrorCode()] {
+			return true
+		}
+		message := strings.ToLower(rpcErr.Error())
+		for _, fragment := range failoverErrorMessages {
+			if strings.Contains(message, fragment) {
+				return true
+			}
+		}
+		return false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		return false // e.g. execution reverted
+	}
+	return true
+}
+
+// ExecutionClient implementation
+
+func (c *FailoverClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.run("CodeAt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.run("CallContract", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var result *types.Header
+	err := c.run("HeaderByHash", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.HeaderByHash(ctx, hash)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var result *types.Header
+	err := c.run("HeaderByNumber", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result []byte
+	err := c.run("PendingCodeAt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := c.run("PendingNonceAt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.run("SuggestGasPrice", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.run("SuggestGasTipCap", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var result uint64
+	err := c.run("EstimateGas", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.EstimateGas(ctx, call)
+		return err
+	})
+	return result, err
+}
+
+// Broadcasting the same signed transaction through another endpoint is safe, so sends fail over like any other request
+func (c *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.run("SendTransaction", func(client rocketpool.ExecutionClient) error {
+		return client.SendTransaction(ctx, tx)
+	})
+}
+
+func (c *FailoverClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	err := c.run("FilterLogs", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return result, err
+}
+
+// The subscription is made on the best endpoint; if that endpoint drops later, the subscription's error channel fires
+// and the caller has to resubscribe, which will pick the next best endpoint
+func (c *FailoverClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var result ethereum.Subscription
+	err := c.run("SubscribeFilterLogs", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var result *types.Receipt
+	err := c.run("TransactionReceipt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := c.run("BlockNumber", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.BlockNumber(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result *big.Int
+	err := c.run("BalanceAt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var result *types.Transaction
+	var isPending bool
+	err := c.run("TransactionByHash", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, isPending, err = client.TransactionByHash(ctx, hash)
+		return err
+	})
+	return result, isPending, err
+}
+
+func (c *FailoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result uint64
+	err := c.run("NonceAt", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *FailoverClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var result *ethereum.SyncProgress
+	err := c.run("SyncProgress", func(client rocketpool.ExecutionClient) error {
+		var err error
+		result, err = client.SyncProgress(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Only endpoints whose client supports eth_feeHistory (e.g. ethclient.Client) can serve this
+func (c *FailoverClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	var result *ethereum.FeeHistory
+	err := c.run("FeeHistory", func(client rocketpool.ExecutionClient) error {
+		historyClient, ok := client.(rocketpool.FeeHistoryClient)
+		if !ok {
+			return fmt.Errorf("error getting fee history: %w", rocketpool.ErrMethodNotSupported)
+		}
+		var err error
+		result, err = historyClient.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return err
+	})
+	return result, err
+}
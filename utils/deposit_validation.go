@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A deposit contract deposit with the result of checking its signature
+type ValidatedDeposit struct {
+	DepositData
+	ValidSignature bool `json:"validSignature"`
+}
+
+// The deposits for a pubkey, checked against the withdrawal credentials the validator is expected to have
+type DepositValidation struct {
+	Pubkey                        rptypes.ValidatorPubkey `json:"pubkey"`
+	ExpectedWithdrawalCredentials common.Hash             `json:"expectedWithdrawalCredentials"`
+	Deposits                      []ValidatedDeposit      `json:"deposits"` // Sorted by block and transaction index
+
+	// The first deposit with a valid signature, which creates the validator and sets its withdrawal credentials
+	// Nil if there isn't one; deposits with invalid signatures are ignored by the Beacon Chain until then
+	InitialDeposit *ValidatedDeposit `json:"initialDeposit"`
+
+	// The initial deposit set different withdrawal credentials than expected
+	CredentialsMismatch bool `json:"credentialsMismatch"`
+
+	// Transactions of deposits with different withdrawal credentials than expected, whether or not they were the initial deposit
+	MismatchedDeposits []common.Hash `json:"mismatchedDeposits"`
+}
+
+// Check a deposit's signature
+// The genesis fork version is the one of the chain the deposit contract belongs to, e.g. 0x00000000 for mainnet
+// Pubkeys and signatures that aren't valid curve points are treated like any other invalid signature, as the Beacon Chain does
+func ValidateDeposit(deposit DepositData, genesisForkVersion [4]byte) ValidatedDeposit {
+	valid, err := minipool.VerifyDepositSignature(deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature, genesisForkVersion)
+	return ValidatedDeposit{
+		DepositData:    deposit,
+		ValidSignature: err == nil && valid,
+	}
+}
+
+// Check the deposits for a pubkey against its expected withdrawal credentials
+// The deposits must be sorted in the order they were made, as GetDeposits returns them
+func ValidatePubkeyDeposits(pubkey rptypes.ValidatorPubkey, expectedWithdrawalCredentials common.Hash, deposits []DepositData, genesisForkVersion [4]byte) DepositValidation {
+	validation := DepositValidation{
+		Pubkey:                        pubkey,
+		ExpectedWithdrawalCredentials: expectedWithdrawalCredentials,
+		Deposits:                      make([]ValidatedDeposit, len(deposits)),
+		MismatchedDeposits:            []common.Hash{},
+	}
+	for i, deposit := range deposits {
+		validated := ValidateDeposit(deposit, genesisForkVersion)
+		validation.Deposits[i] = validated
+		if deposit.WithdrawalCredentials != expectedWithdrawalCredentials {
+			validation.MismatchedDeposits = append(validation.MismatchedDeposits, deposit.TxHash)
+		}
+		if validation.InitialDeposit == nil && validated.ValidSignature {
+			validation.InitialDeposit = &validation.Deposits[i]
+			validation.CredentialsMismatch = deposit.WithdrawalCredentials != expectedWithdrawalCredentials
+		}
+	}
+	return validation
+}
+
+// Scan the deposit contract for deposits to the given pubkeys and check them against their expected withdrawal credentials
+// Pubkeys without any deposits are included with no deposits and no initial deposit
+func ValidateDeposits(rp *rocketpool.RocketPool, expectedWithdrawalCredentials map[rptypes.ValidatorPubkey]common.Hash, genesisForkVersion [4]byte, startBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) (map[rptypes.ValidatorPubkey]DepositValidation, error) {
+	// Get the deposits
+	pubkeys := make(map[rptypes.ValidatorPubkey]bool, len(expectedWithdrawalCredentials))
+	for pubkey := range expectedWithdrawalCredentials {
+		pubkeys[pubkey] = true
+	}
+	deposits, err := GetDeposits(rp, pubkeys, startBlock, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposits: %w", err)
+	}
+
+	// Check them
+	validations := make(map[rptypes.ValidatorPubkey]DepositValidation, len(expectedWithdrawalCredentials))
+	for pubkey, credentials := range expectedWithdrawalCredentials {
+		validations[pubkey] = ValidatePubkeyDeposits(pubkey, credentials, deposits[pubkey], genesisForkVersion)
+	}
+	return validations, nil
+}
@@ -0,0 +1,132 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Token decimals of the common denominations
+const (
+	EthDecimals  uint8 = 18
+	GweiDecimals uint8 = 9
+)
+
+// Convert wei to an exact eth amount
+func WeiToEthRat(wei *big.Int) *big.Rat {
+	return UnitsToRat(wei, EthDecimals)
+}
+
+// Convert wei to an exact gwei amount
+func WeiToGweiRat(wei *big.Int) *big.Rat {
+	return UnitsToRat(wei, EthDecimals-GweiDecimals)
+}
+
+// Convert an exact eth amount to wei, truncating anything smaller than a wei
+func EthRatToWei(eth *big.Rat) *big.Int {
+	return RatToUnits(eth, EthDecimals)
+}
+
+// Convert an exact gwei amount to wei, truncating anything smaller than a wei
+func GweiRatToWei(gwei *big.Rat) *big.Int {
+	return RatToUnits(gwei, EthDecimals-GweiDecimals)
+}
+
+// Format wei as an eth amount without losing precision, e.g. "1.5"
+func WeiToEthString(wei *big.Int) string {
+	return FormatUnits(wei, EthDecimals)
+}
+
+// Format wei as a gwei amount without losing precision, e.g. "0.000000001"
+func WeiToGweiString(wei *big.Int) string {
+	return FormatUnits(wei, EthDecimals-GweiDecimals)
+}
+
+// Parse a decimal eth amount (e.g. "1.5") into wei without going through a float
+func EthStringToWei(eth string) (*big.Int, error) {
+	return ParseUnits(eth, EthDecimals)
+}
+
+// Parse a decimal gwei amount (e.g. "2.5") into wei without going through a float
+func GweiStringToWei(gwei string) (*big.Int, error) {
+	return ParseUnits(gwei, EthDecimals-GweiDecimals)
+}
+
+// Convert an amount in a token's base units to an exact amount of whole tokens
+func UnitsToRat(amount *big.Int, decimals uint8) *big.Rat {
+	if amount == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(amount, getUnitScale(decimals))
+}
+
+// Convert an exact amount of whole tokens to the token's base units, truncating anything smaller than a base unit
+func RatToUnits(amount *big.Rat, decimals uint8) *big.Int {
+	if amount == nil {
+		return big.NewInt(0)
+	}
+	units := new(big.Int).Mul(amount.Num(), getUnitScale(decimals))
+	return units.Quo(units, amount.Denom())
+}
+
+// Format an amount in a token's base units as a decimal amount of whole tokens without losing precision
+// Trailing zeros in the fraction are removed, e.g. 1500000000000000000 with 18 decimals is "1.5"
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+	whole, fraction := new(big.Int).QuoRem(new(big.Int).Abs(amount), getUnitScale(decimals), new(big.Int))
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	if fraction.Sign() == 0 {
+		return sign + whole.String()
+	}
+	fractionString := fmt.Sprintf("%0*s", int(decimals), fraction.String())
+	return sign + whole.String() + "." + strings.TrimRight(fractionString, "0")
+}
+
+// Parse a decimal amount of whole tokens (e.g. "1.5") into the token's base units
+// Returns an error if the amount has more decimal places than the token, rather than silently rounding it
+func ParseUnits(value string, decimals uint8) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount '%s'", value)
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid amount '%s': only digits and a single decimal point are allowed", value)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("invalid amount '%s': it has more than %d decimal places", value, decimals)
+	}
+
+	unitDigits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	if unitDigits == "" {
+		unitDigits = "0"
+	}
+	units, _ := new(big.Int).SetString(unitDigits, 10)
+	if negative {
+		units.Neg(units)
+	}
+	return units, nil
+}
+
+// Get the number of base units in a whole token
+func getUnitScale(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// Check if a string only contains decimal digits
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
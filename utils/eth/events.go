@@ -0,0 +1,139 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/internal/abiconv"
+)
+
+// Struct tag used to map event parameters onto fields
+// The format is `event:"name[,unix]"`; name is the parameter name in the ABI, and unix converts a timestamp into a time.Time
+// Untagged fields are matched to the parameter whose camel-cased name equals the field name; `event:"-"` skips a field
+const eventTag string = "event"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Decode the logs matching the given event into structs of type T
+// Logs for other events are skipped, but the contract address is not checked so logs from previous versions of the
+// contract can be decoded too
+func DecodeEvents[T any](contract *rocketpool.Contract, eventName string, logs []types.Log) ([]T, error) {
+	event, exists := contract.ABI.Events[eventName]
+	if !exists {
+		return nil, fmt.Errorf("event %s does not exist on the contract", eventName)
+	}
+	events := make([]T, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		var decoded T
+		if err := decodeEvent(event, log, &decoded); err != nil {
+			return nil, err
+		}
+		events = append(events, decoded)
+	}
+	return events, nil
+}
+
+// Decode a single log of the given event into a struct of type T
+func DecodeEvent[T any](contract *rocketpool.Contract, eventName string, log types.Log) (T, error) {
+	var decoded T
+	event, exists := contract.ABI.Events[eventName]
+	if !exists {
+		return decoded, fmt.Errorf("event %s does not exist on the contract", eventName)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return decoded, fmt.Errorf("log in tx %s is not a %s event", log.TxHash.Hex(), eventName)
+	}
+	err := decodeEvent(event, log, &decoded)
+	return decoded, err
+}
+
+// Unpack a log's data and topics into the fields of out, which must be a pointer to a struct
+func decodeEvent(event abi.Event, log types.Log, out any) error {
+	target := reflect.ValueOf(out).Elem()
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode %s event into non-struct type %s", event.Name, target.Type())
+	}
+
+	// Get the log info values
+	values := map[string]any{}
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return fmt.Errorf("error unpacking %s event data in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+
+	// Get the topic values
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(log.Topics) < len(indexed)+1 {
+		return fmt.Errorf("%s event had %d topics but at least %d are required", event.Name, len(log.Topics), len(indexed)+1)
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return fmt.Errorf("error parsing %s event topics in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+
+	// Map the parameters onto the struct fields
+	paramNames := make(map[string]string, len(event.Inputs))
+	for _, input := range event.Inputs {
+		paramNames[abi.ToCamelCase(input.Name)] = input.Name
+	}
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, unix := paramNames[field.Name], false
+		if tag, exists := field.Tag.Lookup(eventTag); exists {
+			if tag == "-" {
+				continue
+			}
+			var options string
+			name, options, _ = strings.Cut(tag, ",")
+			unix = options == "unix"
+		}
+		value, exists := values[name]
+		if name == "" || !exists {
+			continue
+		}
+		if err := setEventField(target.Field(i), value, unix); err != nil {
+			return fmt.Errorf("error setting field %s of %s event from parameter %s: %w", field.Name, event.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// Assign a decoded event parameter to a struct field
+func setEventField(field reflect.Value, value any, unix bool) error {
+	if unix {
+		if field.Type() != timeType {
+			return fmt.Errorf("unix option requires a time.Time field, not %s", field.Type())
+		}
+		var seconds int64
+		switch v := value.(type) {
+		case *big.Int:
+			seconds = v.Int64()
+		default:
+			rv := reflect.ValueOf(value)
+			if !rv.CanConvert(reflect.TypeOf(seconds)) {
+				return fmt.Errorf("cannot convert %T to a timestamp", value)
+			}
+			seconds = rv.Convert(reflect.TypeOf(seconds)).Int()
+		}
+		field.Set(reflect.ValueOf(time.Unix(seconds, 0)))
+		return nil
+	}
+
+	return abiconv.SetValue(field, value)
+}
@@ -0,0 +1,85 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A block tag that execution clients resolve to a block
+type BlockTag string
+
+const (
+	BlockTag_Latest    BlockTag = "latest"
+	BlockTag_Safe      BlockTag = "safe"      // The latest block justified by the Beacon chain, which is unlikely to be reorged
+	BlockTag_Finalized BlockTag = "finalized" // The latest block finalized by the Beacon chain, which can't be reorged
+)
+
+// A client that can make raw JSON-RPC requests, such as *rpc.Client
+// ethclient can only request blocks by number, so the safe and finalized tags need the underlying RPC client
+type RpcCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Get the header of the block a tag currently resolves to
+func GetHeaderByTag(caller RpcCaller, tag BlockTag) (*types.Header, error) {
+	var header *types.Header
+	if err := caller.CallContext(context.Background(), &header, "eth_getBlockByNumber", string(tag), false); err != nil {
+		return nil, fmt.Errorf("error getting %s block: %w", tag, err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("client has no %s block", tag)
+	}
+	return header, nil
+}
+
+// The number and hash of a block, as reported by the client
+type BlockRef struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Get the number and hash of the block a tag currently resolves to
+// The hash is taken from the client's response rather than computed from the header, since the header type may not
+// include every field newer forks add to the block hash
+func GetBlockRefByTag(caller RpcCaller, tag BlockTag) (BlockRef, error) {
+	var block *struct {
+		Number *hexutil.Big `json:"number"`
+		Hash   common.Hash  `json:"hash"`
+	}
+	if err := caller.CallContext(context.Background(), &block, "eth_getBlockByNumber", string(tag), false); err != nil {
+		return BlockRef{}, fmt.Errorf("error getting %s block: %w", tag, err)
+	}
+	if block == nil || block.Number == nil {
+		return BlockRef{}, fmt.Errorf("client has no %s block", tag)
+	}
+	return BlockRef{
+		Number: block.Number.ToInt().Uint64(),
+		Hash:   block.Hash,
+	}, nil
+}
+
+// Get call options for the block a tag currently resolves to, so a set of queries all read the same block
+func GetCallOptsForTag(caller RpcCaller, tag BlockTag) (*bind.CallOpts, error) {
+	header, err := GetHeaderByTag(caller, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &bind.CallOpts{
+		BlockNumber: new(big.Int).Set(header.Number),
+	}, nil
+}
+
+// Check whether a block has been finalized, so anything computed from its state can't be invalidated by a reorg
+func IsBlockFinalized(caller RpcCaller, blockNumber uint64) (bool, error) {
+	header, err := GetHeaderByTag(caller, BlockTag_Finalized)
+	if err != nil {
+		return false, err
+	}
+	return header.Number.Uint64() >= blockNumber, nil
+}
@@ -0,0 +1,82 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The raw value of 1% in percentage settings, which are scaled by 1e18
+var rawPercentScale = big.NewInt(1e16)
+
+// Format a raw percentage setting (scaled by 1e18, so 1e18 is 100%) as a percentage with up to the given number of decimal places
+// Trailing zeros are removed, e.g. 50000000000000000 is "5%" and 125000000000000000 is "12.5%"
+func FormatRawPercent(raw *big.Int, maxDecimals int) string {
+	if raw == nil {
+		return "0%"
+	}
+	percent := new(big.Rat).SetFrac(raw, rawPercentScale).FloatString(maxDecimals)
+	if strings.Contains(percent, ".") {
+		percent = strings.TrimRight(strings.TrimRight(percent, "0"), ".")
+	}
+	if percent == "-0" {
+		percent = "0"
+	}
+	return percent + "%"
+}
+
+// Format the change from a current raw percentage setting to a proposed one, with an explicit sign (e.g. "+2.5%" or "-1%")
+func FormatRawPercentDelta(current *big.Int, proposed *big.Int, maxDecimals int) string {
+	delta := FormatRawPercent(GetRawDelta(current, proposed), maxDecimals)
+	if strings.HasPrefix(delta, "-") || delta == "0%" {
+		return delta
+	}
+	return "+" + delta
+}
+
+// Format a duration setting in days, hours, minutes and seconds, leaving out the units that are zero
+// e.g. 28 days is "28d" and 90 minutes is "1h 30m"; anything shorter than a second is rounded down
+func FormatDuration(duration time.Duration) string {
+	if duration < 0 {
+		return "-" + FormatDuration(-duration)
+	}
+	seconds := int64(duration / time.Second)
+	if seconds == 0 {
+		return "0s"
+	}
+	parts := []string{}
+	units := []struct {
+		suffix  string
+		seconds int64
+	}{
+		{"d", 86400},
+		{"h", 3600},
+		{"m", 60},
+		{"s", 1},
+	}
+	for _, unit := range units {
+		if count := seconds / unit.seconds; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", count, unit.suffix))
+			seconds %= unit.seconds
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Format the change from a current duration setting to a proposed one, with an explicit sign (e.g. "+1d" or "-12h")
+func FormatDurationDelta(current time.Duration, proposed time.Duration) string {
+	delta := FormatDuration(proposed - current)
+	if strings.HasPrefix(delta, "-") || delta == "0s" {
+		return delta
+	}
+	return "+" + delta
+}
+
+// Shorten an address for display to its first and last four hex characters, e.g. "0x1234...abcd"
+func ShortenAddress(address common.Address) string {
+	hex := address.Hex()
+	return hex[:6] + "..." + hex[len(hex)-4:]
+}
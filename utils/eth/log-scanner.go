@@ -0,0 +1,195 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+)
+
+// Error message fragments (lowercase) returned by providers when a log query covers too many blocks or results
+// Generic fragments like "limit exceeded" are left out on purpose, since providers use them for rate limiting too and
+// splitting the range would only make that worse
+var logRangeTooLargeFragments = []string{
+	"query returned more than",
+	"log response size exceeded",
+	"response size should not greater than",
+	"exceed maximum block range",
+	"exceeds maximum range limit",
+	"block range is too wide",
+	"block range too large",
+	"eth_getlogs is limited to",
+	"eth_getlogs and eth_newfilter are limited",
+	"query timeout exceeded", // Geth, when the range takes too long to search
+}
+
+// Options for ScanLogs
+type LogScanOptions struct {
+	IntervalSize    *big.Int              // The number of blocks per query before any splitting; nil to start with the whole range
+	MinIntervalSize uint64                // The smallest range that will be split further; ranges this size that still fail return the error
+	Concurrency     int                   // The maximum number of queries in flight at once; values below 1 mean 1
+	Progress        func(LogScanProgress) // Called after each range is fetched; calls are serialized
+}
+
+// The progress of a log scan
+type LogScanProgress struct {
+	FromBlock       uint64 `json:"fromBlock"` // The start of the range that was just fetched
+	ToBlock         uint64 `json:"toBlock"`   // The end of the range that was just fetched
+	CompletedBlocks uint64 `json:"completedBlocks"`
+	TotalBlocks     uint64 `json:"totalBlocks"`
+	LogCount        int    `json:"logCount"` // The number of logs found so far
+}
+
+// Check if a log query failed because the provider considered its range or result set too large
+func IsLogRangeTooLargeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, fragment := range logRangeTooLargeFragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Gets the logs for a particular log request, fetching ranges concurrently and splitting any range the provider
+// rejects as too large in half until it succeeds
+// fromBlock defaults to the Rocket Pool deploy block and toBlock to the latest block; logs are returned in block order
+func ScanLogs(rp *rocketpool.RocketPool, addressFilter []common.Address, topicFilter [][]common.Hash, fromBlock, toBlock *big.Int, options LogScanOptions) ([]types.Log, error) {
+	// Get the bounds
+	if fromBlock == nil {
+		var err error
+		fromBlock, err = storage.GetDeployBlock(rp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if toBlock == nil {
+		latestBlock, err := rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		toBlock = big.NewInt(0).SetUint64(latestBlock)
+	}
+	start := fromBlock.Uint64()
+	end := toBlock.Uint64()
+	if end < start {
+		return []types.Log{}, nil
+	}
+
+	// Split the range into the initial intervals
+	totalBlocks := end - start + 1
+	intervalSize := totalBlocks
+	if options.IntervalSize != nil && options.IntervalSize.Sign() > 0 && options.IntervalSize.Uint64() < totalBlocks {
+		intervalSize = options.IntervalSize.Uint64()
+	}
+	minIntervalSize := options.MinIntervalSize
+	if minIntervalSize < 1 {
+		minIntervalSize = 1
+	}
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	intervalCount := (totalBlocks + intervalSize - 1) / intervalSize
+	results := make([][]types.Log, intervalCount)
+
+	// Report progress
+	var progressLock sync.Mutex
+	var completedBlocks uint64
+	var logCount int
+	reportProgress := func(from, to uint64, count int) {
+		progressLock.Lock()
+		defer progressLock.Unlock()
+		completedBlocks += to - from + 1
+		logCount += count
+		if options.Progress != nil {
+			options.Progress(LogScanProgress{
+				FromBlock:       from,
+				ToBlock:         to,
+				CompletedBlocks: completedBlocks,
+				TotalBlocks:     totalBlocks,
+				LogCount:        logCount,
+			})
+		}
+	}
+
+	// Fetch a range, splitting it in half if it's too large
+	var fetch func(from, to uint64) ([]types.Log, error)
+	fetch = func(from, to uint64) ([]types.Log, error) {
+		logs, err := rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			Addresses: addressFilter,
+			Topics:    topicFilter,
+			FromBlock: big.NewInt(0).SetUint64(from),
+			ToBlock:   big.NewInt(0).SetUint64(to),
+		})
+		if err == nil {
+			reportProgress(from, to, len(logs))
+			return logs, nil
+		}
+		if !IsLogRangeTooLargeError(err) || to-from+1 <= minIntervalSize {
+			return nil, fmt.Errorf("error getting logs for blocks %d to %d: %w", from, to, err)
+		}
+		mid := from + (to-from)/2
+		lower, err := fetch(from, mid)
+		if err != nil {
+			return nil, err
+		}
+		upper, err := fetch(mid+1, to)
+		if err != nil {
+			return nil, err
+		}
+		return append(lower, upper...), nil
+	}
+
+	// Run the intervals concurrently
+	var wg errgroup.Group
+	wg.SetLimit(concurrency)
+	for i := uint64(0); i < intervalCount; i++ {
+		i := i
+		wg.Go(func() error {
+			from := start + i*intervalSize
+			to := from + intervalSize - 1
+			if to > end {
+				to = end
+			}
+			logs, err := fetch(from, to)
+			if err != nil {
+				return err
+			}
+			results[i] = logs
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return
+	logs := make([]types.Log, 0, logCount)
+	for _, result := range results {
+		logs = append(logs, result...)
+	}
+	return logs, nil
+}
+
+// Scans the logs of a contract across every address it has been deployed at, like FilterContractLogs
+func ScanContractLogs(rp *rocketpool.RocketPool, contractName string, topicFilter [][]common.Hash, fromBlock, toBlock *big.Int, options LogScanOptions, opts *bind.CallOpts) ([]types.Log, error) {
+	addresses, err := getContractAddresses(rp, contractName, options.IntervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ScanLogs(rp, addresses, topicFilter, fromBlock, toBlock, options)
+}
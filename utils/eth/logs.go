@@ -10,7 +10,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/storage"
 )
 
 type FilterQuery struct {
@@ -21,6 +20,16 @@ type FilterQuery struct {
 }
 
 func FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q FilterQuery, intervalSize *big.Int, opts *bind.CallOpts) ([]types.Log, error) {
+	addresses, err := getContractAddresses(rp, contractName, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Perform the desired getLogs call and return results
+	return GetLogs(rp, addresses, q.Topics, intervalSize, q.FromBlock, q.ToBlock, q.BlockHash)
+}
+
+// Get all the addresses a contract has ever been deployed at, ending with the current one
+func getContractAddresses(rp *rocketpool.RocketPool, contractName string, intervalSize *big.Int, opts *bind.CallOpts) ([]common.Address, error) {
 	rocketDaoNodeTrustedUpgrade, err := rp.GetContract("rocketDAONodeTrustedUpgrade", opts)
 	if err != nil {
 		return nil, err
@@ -44,81 +53,21 @@ func FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q Filter
 		return nil, err
 	}
 	addresses = append(addresses, *currentAddress)
-	// Perform the desired getLogs call and return results
-	return GetLogs(rp, addresses, q.Topics, intervalSize, q.FromBlock, q.ToBlock, q.BlockHash)
+	return addresses, nil
 }
 
 // Gets the logs for a particular log request, breaking the calls into batches if necessary
+// The batches are fetched with ScanLogs, so any batch the provider rejects as too large is split further; a query for a
+// single block hash is sent as one call
 func GetLogs(rp *rocketpool.RocketPool, addressFilter []common.Address, topicFilter [][]common.Hash, intervalSize, fromBlock, toBlock *big.Int, blockHash *common.Hash) ([]types.Log, error) {
-	var logs []types.Log
-
-	// Get the block that Rocket Pool was deployed on as the lower bound if one wasn't specified
-	if fromBlock == nil {
-		var err error
-		fromBlock, err = storage.GetDeployBlock(rp)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if intervalSize == nil {
-		// Handle unlimited intervals with a single call
-		logs, err := rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+	if blockHash != nil {
+		return rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
 			Addresses: addressFilter,
 			Topics:    topicFilter,
-			FromBlock: fromBlock,
-			ToBlock:   toBlock,
 			BlockHash: blockHash,
 		})
-		if err != nil {
-			return nil, err
-		}
-		return logs, nil
-	} else {
-		// Get the latest block
-		if toBlock == nil {
-			latestBlock, err := rp.Client.BlockNumber(context.Background())
-			if err != nil {
-				return nil, err
-			}
-			toBlock = big.NewInt(0)
-			toBlock.SetUint64(latestBlock)
-		}
-
-		// Set the start and end, clamping on the latest block
-		intervalSize := big.NewInt(0).Sub(intervalSize, big.NewInt(1))
-		start := big.NewInt(0).Set(fromBlock)
-		end := big.NewInt(0).Add(start, intervalSize)
-		if end.Cmp(toBlock) == 1 {
-			end.Set(toBlock)
-		}
-		for {
-			// Get the logs using the current interval
-			newLogs, err := rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
-				Addresses: addressFilter,
-				Topics:    topicFilter,
-				FromBlock: start,
-				ToBlock:   end,
-				BlockHash: blockHash,
-			})
-			if err != nil {
-				return nil, err
-			}
-
-			// Append the logs to the total list
-			logs = append(logs, newLogs...)
-
-			// Return once we've finished iterating
-			if end.Cmp(toBlock) == 0 {
-				return logs, nil
-			}
-
-			// Update to the next interval (end+1 : that + interval - 1)
-			start.Add(end, big.NewInt(1))
-			end.Add(start, intervalSize)
-			if end.Cmp(toBlock) == 1 {
-				end.Set(toBlock)
-			}
-		}
 	}
+	return ScanLogs(rp, addressFilter, topicFilter, fromBlock, toBlock, LogScanOptions{
+		IntervalSize: intervalSize,
+	})
 }
@@ -0,0 +1,120 @@
+package eth
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The decimal places of a percentage in raw percentage settings, which are scaled by 1e18 (so 1% is 1e16)
+const rawPercentDecimals uint8 = 16
+
+// Parse a percentage (e.g. "5%" or "12.5") into a raw percentage setting value, scaled by 1e18
+// Returns an error instead of rounding if the percentage is more precise than the raw value can hold
+func ParseRawPercent(value string) (*big.Int, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(value), "%")
+	raw, err := ParseUnits(trimmed, rawPercentDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing percentage '%s': %w", value, err)
+	}
+	return raw, nil
+}
+
+// The longest day count that fits in a time.Duration
+var maxDurationDays = uint64(math.MaxInt64 / int64(24*time.Hour))
+
+// Parse a duration setting, either in the format FormatDuration produces (e.g. "28d" or "1h 30m") or as a Go duration (e.g. "90m")
+// Settings are stored in whole seconds, so durations with a fraction of a second are rejected, as are negative durations
+// and durations too long for a time.Duration
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("invalid duration ''")
+	}
+
+	var duration time.Duration
+	for _, part := range strings.Fields(value) {
+		var partDuration time.Duration
+		if strings.HasSuffix(part, "d") {
+			days := strings.TrimSuffix(part, "d")
+			count, err := strconv.ParseUint(days, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration '%s': invalid day count %s", value, days)
+			}
+			if count > maxDurationDays {
+				return 0, fmt.Errorf("invalid duration '%s': %s days is too long", value, days)
+			}
+			partDuration = time.Duration(count) * 24 * time.Hour
+		} else {
+			var err error
+			partDuration, err = time.ParseDuration(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration '%s': %w", value, err)
+			}
+			if partDuration < 0 {
+				return 0, fmt.Errorf("invalid duration '%s': durations can't be negative", value)
+			}
+		}
+		if partDuration > math.MaxInt64-duration {
+			return 0, fmt.Errorf("invalid duration '%s': it is too long", value)
+		}
+		duration += partDuration
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("invalid duration '%s': settings can't hold fractions of a second", value)
+	}
+	return duration, nil
+}
+
+// Parse a duration setting (see ParseDuration) into the raw value in seconds that the settings contracts store
+func ParseRawDuration(value string) (*big.Int, error) {
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	return DurationToRawSeconds(duration), nil
+}
+
+// Convert a duration setting into the raw value in seconds that the settings contracts store
+func DurationToRawSeconds(duration time.Duration) *big.Int {
+	return big.NewInt(int64(duration / time.Second))
+}
+
+// Convert a raw duration setting in seconds into a duration
+// Returns an error if the raw value is negative or too long for a time.Duration
+func RawSecondsToDuration(raw *big.Int) (time.Duration, error) {
+	if raw == nil || raw.Sign() < 0 {
+		return 0, fmt.Errorf("invalid raw duration %v: durations can't be negative", raw)
+	}
+	if !raw.IsInt64() || raw.Int64() > int64(math.MaxInt64/time.Second) {
+		return 0, fmt.Errorf("invalid raw duration %s: it is too long", raw.String())
+	}
+	return time.Duration(raw.Int64()) * time.Second, nil
+}
+
+// Compare a raw percentage setting against a human-readable threshold (e.g. "5%"), returning -1, 0 or +1 like big.Int.Cmp
+func CompareRawPercent(raw *big.Int, threshold string) (int, error) {
+	rawThreshold, err := ParseRawPercent(threshold)
+	if err != nil {
+		return 0, err
+	}
+	return raw.Cmp(rawThreshold), nil
+}
+
+// Compare a raw duration setting in seconds against a human-readable threshold (e.g. "28d"), returning -1, 0 or +1 like
+// big.Int.Cmp
+func CompareRawDuration(raw *big.Int, threshold string) (int, error) {
+	rawThreshold, err := ParseRawDuration(threshold)
+	if err != nil {
+		return 0, err
+	}
+	return raw.Cmp(rawThreshold), nil
+}
+
+// Get the change from a current raw setting value to a proposed one
+func GetRawDelta(current *big.Int, proposed *big.Int) *big.Int {
+	return new(big.Int).Sub(proposed, current)
+}
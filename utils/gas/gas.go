@@ -0,0 +1,211 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Fee oracle settings
+const (
+	DefaultFeeHistoryBlocks  uint64  = 20
+	DefaultBaseFeeMultiplier float64 = 2
+
+	// Priority fee percentiles of the transactions in recent blocks for each speed
+	PercentileSlow     float64 = 10
+	PercentileStandard float64 = 50
+	PercentileFast     float64 = 90
+)
+
+// Execution clients that support eth_feeHistory (e.g. ethclient.Client)
+type FeeHistoryClient = rocketpool.FeeHistoryClient
+
+// Suggested EIP-1559 fees for a new transaction
+type FeeSuggestion struct {
+	BaseFee        *big.Int `json:"baseFee"` // The base fee of the next block
+	MaxFee         *big.Int `json:"maxFee"`
+	MaxPriorityFee *big.Int `json:"maxPriorityFee"`
+}
+
+// A way of choosing the fees of a transaction
+type Strategy interface {
+	// Get the fees to use for a new transaction
+	GetFees(ctx context.Context) (FeeSuggestion, error)
+}
+
+// A strategy that always uses the same fees
+type FixedStrategy struct {
+	MaxFee         *big.Int
+	MaxPriorityFee *big.Int
+}
+
+// Get the fixed fees
+func (s *FixedStrategy) GetFees(ctx context.Context) (FeeSuggestion, error) {
+	if s.MaxFee == nil || s.MaxPriorityFee == nil {
+		return FeeSuggestion{}, errors.New("fixed gas strategy requires a max fee and a max priority fee")
+	}
+	return FeeSuggestion{
+		MaxFee:         s.MaxFee,
+		MaxPriorityFee: s.MaxPriorityFee,
+	}, nil
+}
+
+// A strategy that suggests fees from the base fees and priority fees of recent blocks
+type FeeOracle struct {
+	Client            rocketpool.ExecutionClient
+	BlockCount        uint64   // The number of recent blocks to analyze
+	Percentile        float64  // The priority fee percentile of the transactions in each block to use
+	BaseFeeMultiplier float64  // The max fee covers the next block's base fee times this, so it survives base fee increases
+	MinPriorityFee    *big.Int // Optional
+	MaxFeeCap         *big.Int // Optional; suggestions above this return an error rather than being submitted
+}
+
+// Create a new fee oracle using the given priority fee percentile (e.g. PercentileStandard)
+func NewFeeOracle(client rocketpool.ExecutionClient, percentile float64) *FeeOracle {
+	return &FeeOracle{
+		Client:            client,
+		BlockCount:        DefaultFeeHistoryBlocks,
+		Percentile:        percentile,
+		BaseFeeMultiplier: DefaultBaseFeeMultiplier,
+	}
+}
+
+// Get the suggested fees
+// Uses eth_feeHistory when the client supports it, and the client's own priority fee suggestion otherwise
+// Wrapped clients (e.g. one with metrics) always provide FeeHistory, and report ErrMethodNotSupported if the client
+// they wrap doesn't
+func (o *FeeOracle) GetFees(ctx context.Context) (FeeSuggestion, error) {
+	var baseFee, priorityFee *big.Int
+	err := rocketpool.ErrMethodNotSupported
+	if historyClient, ok := o.Client.(FeeHistoryClient); ok {
+		baseFee, priorityFee, err = o.getFeesFromHistory(ctx, historyClient)
+	}
+	if errors.Is(err, rocketpool.ErrMethodNotSupported) {
+		baseFee, priorityFee, err = o.getFeesFromClient(ctx)
+	}
+	if err != nil {
+		return FeeSuggestion{}, err
+	}
+
+	// Apply the limits
+	if o.MinPriorityFee != nil && priorityFee.Cmp(o.MinPriorityFee) < 0 {
+		priorityFee = new(big.Int).Set(o.MinPriorityFee)
+	}
+	multiplier := o.BaseFeeMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	maxFee, _ := new(big.Float).Mul(new(big.Float).SetInt(baseFee), big.NewFloat(multiplier)).Int(nil)
+	maxFee.Add(maxFee, priorityFee)
+	if o.MaxFeeCap != nil && maxFee.Cmp(o.MaxFeeCap) > 0 {
+		return FeeSuggestion{}, fmt.Errorf("suggested max fee of %s wei is greater than the cap of %s wei", maxFee.String(), o.MaxFeeCap.String())
+	}
+
+	return FeeSuggestion{
+		BaseFee:        baseFee,
+		MaxFee:         maxFee,
+		MaxPriorityFee: priorityFee,
+	}, nil
+}
+
+// Get the next block's base fee and the median priority fee percentile of recent blocks
+func (o *FeeOracle) getFeesFromHistory(ctx context.Context, client FeeHistoryClient) (*big.Int, *big.Int, error) {
+	blockCount := o.BlockCount
+	if blockCount == 0 {
+		blockCount = DefaultFeeHistoryBlocks
+	}
+	history, err := client.FeeHistory(ctx, blockCount, nil, []float64{o.Percentile})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, nil, errors.New("fee history does not have any base fees; EIP-1559 is not active")
+	}
+
+	// The last base fee is the one for the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	// Use the median across blocks so a single block full of high bids doesn't skew the suggestion; empty blocks report
+	// a reward of 0 so they're skipped
+	rewards := []*big.Int{}
+	for _, blockRewards := range history.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil && blockRewards[0].Sign() > 0 {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+	if len(rewards) == 0 {
+		priorityFee, err := o.Client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+		}
+		return baseFee, priorityFee, nil
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	return baseFee, new(big.Int).Set(rewards[len(rewards)/2]), nil
+}
+
+// Get the latest base fee and the client's priority fee suggestion
+func (o *FeeOracle) getFeesFromClient(ctx context.Context) (*big.Int, *big.Int, error) {
+	priorityFee, err := o.Client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+	}
+	header, err := o.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, nil, errors.New("latest block does not have a base fee; EIP-1559 is not active")
+	}
+	return header.BaseFee, priorityFee, nil
+}
+
+// Get a copy of opts with the fees from a strategy
+// Fees that are already set in opts are kept, and legacy gas prices are cleared so the transaction uses EIP-1559
+func ApplyStrategy(strategy Strategy, opts *bind.TransactOpts) (*bind.TransactOpts, error) {
+	txOpts := *opts
+	if txOpts.GasFeeCap != nil && txOpts.GasTipCap != nil {
+		return &txOpts, nil
+	}
+	fees, err := strategy.GetFees(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting transaction fees: %w", err)
+	}
+	if txOpts.GasFeeCap == nil {
+		txOpts.GasFeeCap = fees.MaxFee
+	}
+	if txOpts.GasTipCap == nil {
+		txOpts.GasTipCap = fees.MaxPriorityFee
+	}
+	if txOpts.GasTipCap.Cmp(txOpts.GasFeeCap) > 0 {
+		txOpts.GasTipCap = new(big.Int).Set(txOpts.GasFeeCap)
+	}
+	txOpts.GasPrice = nil
+	return &txOpts, nil
+}
+
+// Wrap a transaction info (e.g. deposit.DepositTransaction) so it's submitted with the fees from a strategy
+func WithStrategy(tx rocketpool.BatchTransaction, strategy Strategy) rocketpool.BatchTransaction {
+	submit := tx.Submit
+	return rocketpool.BatchTransaction{
+		Name:     tx.Name,
+		Estimate: tx.Estimate,
+		Describe: tx.Describe,
+		Submit: func(opts *bind.TransactOpts) (common.Hash, error) {
+			txOpts, err := ApplyStrategy(strategy, opts)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return submit(txOpts)
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package abiconv
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Set a destination to a value decoded by the ABI package, converting it if the types differ
+// Tuples and slices of them are converted with the ABI package's conversion, which copies fields by name
+func SetValue(destination reflect.Value, value any) (err error) {
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(destination.Type()) {
+		destination.Set(rv)
+		return nil
+	}
+	if rv.Type().ConvertibleTo(destination.Type()) {
+		destination.Set(rv.Convert(destination.Type()))
+		return nil
+	}
+
+	// Fall back to the ABI package's conversion for tuples and slices of them, which panics if it fails
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot convert %T to %s: %v", value, destination.Type(), r)
+		}
+	}()
+	converted := abi.ConvertType(value, reflect.New(destination.Type()).Interface())
+	destination.Set(reflect.ValueOf(converted).Elem())
+	return nil
+}
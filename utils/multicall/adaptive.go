@@ -0,0 +1,218 @@
+package multicall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// Adaptive batcher defaults
+const (
+	DefaultGasPerCall       uint64 = 50000
+	DefaultMaxGasPerBatch   uint64 = 50000000
+	DefaultMaxCallDataSize  int    = 128 * 1024 // bytes
+	DefaultMinBatchSize     int    = 1
+	DefaultBatchThreadLimit int    = threadLimit
+)
+
+// Error message fragments (lowercase) that indicate a provider rejected a multicall for being too large
+// Generic fragments like "limit" or "exceeds" are left out on purpose, since they also match rate limiting and
+// insufficient funds errors that splitting the batch won't fix
+var batchTooLargeErrors = []string{
+	"out of gas",
+	"gas required exceeds allowance",
+	"exceeds block gas limit",
+	"execution aborted (timeout",
+	"request entity too large",
+	"content length too large",
+	"response too large",
+	"response size exceeded",
+}
+
+// A call queued in an adaptive batcher
+type batchedCall struct {
+	call       Call
+	gasPerCall uint64
+}
+
+// Runs an arbitrary number of calls through the multicaller, splitting them into batches that fit a gas budget and
+// calldata size limit, and retrying with smaller batches when the provider rejects a batch as too large
+type AdaptiveBatcher struct {
+	Client             rocketpool.ExecutionClient
+	MulticallerAddress common.Address
+	GasPerCall         uint64
+	MaxGasPerBatch     uint64
+	MaxCallDataSize    int
+	MinBatchSize       int
+	ThreadLimit        int
+	Pin                *PinnedBlock
+	Profiler           *Profiler
+	RateLimiter        RateLimiter
+	calls              []batchedCall
+}
+
+// Create new adaptive batcher with the default limits
+func NewAdaptiveBatcher(client rocketpool.ExecutionClient, multicallerAddress common.Address) *AdaptiveBatcher {
+	return &AdaptiveBatcher{
+		Client:             client,
+		MulticallerAddress: multicallerAddress,
+		GasPerCall:         DefaultGasPerCall,
+		MaxGasPerBatch:     DefaultMaxGasPerBatch,
+		MaxCallDataSize:    DefaultMaxCallDataSize,
+		MinBatchSize:       DefaultMinBatchSize,
+		ThreadLimit:        DefaultBatchThreadLimit,
+		calls:              []batchedCall{},
+	}
+}
+
+// Add a call, using the default gas estimate for it
+func (b *AdaptiveBatcher) AddCall(contract *rocketpool.Contract, output interface{}, method string, args ...interface{}) error {
+	return b.AddCallWithGas(0, contract, output, method, args...)
+}
+
+// Add a call with an explicit gas estimate, for calls that are much more expensive than the default
+func (b *AdaptiveBatcher) AddCallWithGas(gas uint64, contract *rocketpool.Contract, output interface{}, method string, args ...interface{}) error {
+	callData, err := contract.ABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("error adding call [%s]: %w", method, err)
+	}
+	b.calls = append(b.calls, batchedCall{
+		call: Call{
+			Method:   method,
+			Target:   *contract.Address,
+			CallData: callData,
+			Contract: contract,
+			output:   output,
+		},
+		gasPerCall: gas,
+	})
+	return nil
+}
+
+// Move the calls queued in a multicaller into the batcher, using the default gas estimate for each one
+// This lets code that builds its calls with a MultiCaller have them split to fit the batcher's limits
+func (b *AdaptiveBatcher) AddCallsFrom(caller *MultiCaller) {
+	for _, call := range caller.calls {
+		b.calls = append(b.calls, batchedCall{call: call})
+	}
+	caller.calls = []Call{}
+}
+
+// Get the number of calls that have been added
+func (b *AdaptiveBatcher) GetCallCount() int {
+	return len(b.calls)
+}
+
+// Execute all of the added calls and get the detailed result of each one, in the order they were added
+func (b *AdaptiveBatcher) Execute(requireSuccess bool, opts *bind.CallOpts) ([]CallResult, error) {
+	calls := b.calls
+	b.calls = []batchedCall{}
+
+	// Sync
+	var wg errgroup.Group
+	threads := b.ThreadLimit
+	if threads < 1 {
+		threads = 1
+	}
+	wg.SetLimit(threads)
+	results := make([]CallResult, len(calls))
+
+	// Run each batch
+	for _, batch := range b.getBatches(calls) {
+		batch := batch
+		wg.Go(func() error {
+			return b.executeBatch(calls, batch[0], batch[1], results, requireSuccess, opts)
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Execute all of the added calls like MultiCaller.FlexibleCall, failing if any successful call's output can't be unpacked
+func (b *AdaptiveBatcher) FlexibleCall(requireSuccess bool, opts *bind.CallOpts) ([]Result, error) {
+	callResults, err := b.Execute(requireSuccess, opts)
+	if err != nil {
+		return nil, err
+	}
+	return getFlexibleResults(callResults)
+}
+
+// Split calls into [start, end) ranges that fit within the gas budget and calldata size limit
+func (b *AdaptiveBatcher) getBatches(calls []batchedCall) [][2]int {
+	batches := [][2]int{}
+	start := 0
+	var gas uint64
+	size := 0
+	for i, call := range calls {
+		callGas := call.gasPerCall
+		if callGas == 0 {
+			callGas = b.GasPerCall
+		}
+		callSize := len(call.call.CallData) + common.AddressLength
+		if i > start && ((b.MaxGasPerBatch > 0 && gas+callGas > b.MaxGasPerBatch) || (b.MaxCallDataSize > 0 && size+callSize > b.MaxCallDataSize)) {
+			batches = append(batches, [2]int{start, i})
+			start = i
+			gas = 0
+			size = 0
+		}
+		gas += callGas
+		size += callSize
+	}
+	if start < len(calls) {
+		batches = append(batches, [2]int{start, len(calls)})
+	}
+	return batches
+}
+
+// Execute the calls in [start, end), splitting the range in half and retrying if the provider rejects it as too large
+func (b *AdaptiveBatcher) executeBatch(calls []batchedCall, start int, end int, results []CallResult, requireSuccess bool, opts *bind.CallOpts) error {
+	mc, err := NewMultiCaller(b.Client, b.MulticallerAddress)
+	if err != nil {
+		return err
+	}
+	mc.Pin = b.Pin
+	mc.Profiler = b.Profiler
+	mc.RateLimiter = b.RateLimiter
+	for _, call := range calls[start:end] {
+		mc.calls = append(mc.calls, call.call)
+	}
+
+	batchResults, err := mc.DetailedCall(requireSuccess, opts)
+	if err != nil {
+		minBatchSize := b.MinBatchSize
+		if minBatchSize < 1 {
+			minBatchSize = 1
+		}
+		if end-start <= minBatchSize || !isBatchTooLargeError(err) {
+			return fmt.Errorf("error executing calls %d to %d: %w", start, end-1, err)
+		}
+		mid := start + (end-start)/2
+		if err := b.executeBatch(calls, start, mid, results, requireSuccess, opts); err != nil {
+			return err
+		}
+		return b.executeBatch(calls, mid, end, results, requireSuccess, opts)
+	}
+
+	for i, result := range batchResults {
+		result.Index = start + i
+		results[start+i] = result
+	}
+	return nil
+}
+
+// Check if an error indicates that a multicall was rejected for being too large
+func isBatchTooLargeError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range batchTooLargeErrors {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
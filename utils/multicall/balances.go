@@ -23,6 +23,8 @@ type BalanceBatcher struct {
 	Client          rocketpool.ExecutionClient
 	ABI             abi.ABI
 	ContractAddress common.Address
+	Pin             *PinnedBlock
+	RateLimiter     RateLimiter
 }
 
 func NewBalanceBatcher(client rocketpool.ExecutionClient, address common.Address) (*BalanceBatcher, error) {
@@ -39,6 +41,11 @@ func NewBalanceBatcher(client rocketpool.ExecutionClient, address common.Address
 }
 
 func (b *BalanceBatcher) GetEthBalances(addresses []common.Address, opts *bind.CallOpts) ([]*big.Int, error) {
+	if b.Pin != nil {
+		if err := b.Pin.VerifyCallOpts(opts); err != nil {
+			return nil, err
+		}
+	}
 
 	// Sync
 	count := len(addresses)
@@ -64,9 +71,10 @@ func (b *BalanceBatcher) GetEthBalances(addresses []common.Address, opts *bind.C
 				return fmt.Errorf("error creating calldata for balances: %w", err)
 			}
 
+			waitForRequest(b.RateLimiter)
 			response, err := b.Client.CallContract(context.Background(), ethereum.CallMsg{To: &b.ContractAddress, Data: callData}, opts.BlockNumber)
 			if err != nil {
-				return fmt.Errorf("error calling balances: %w", err)
+				return fmt.Errorf("error calling balances: %w", rocketpool.WrapStateNotAvailableError(err))
 			}
 
 			var subBalances []*big.Int
@@ -85,6 +93,13 @@ func (b *BalanceBatcher) GetEthBalances(addresses []common.Address, opts *bind.C
 				balances[i+j] = balance
 			}
 
+			// Make sure the pinned block wasn't reorged out while the batch ran
+			if b.Pin != nil {
+				if err := b.Pin.VerifyCanonical(b.Client); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		})
 	}
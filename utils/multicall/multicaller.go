@@ -7,7 +7,9 @@ package multicall
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -22,10 +24,12 @@ type Call struct {
 	CallData []byte         `json:"call_data"`
 	Contract *rocketpool.Contract
 	output   interface{}
+	resolve  func(result CallResult)
 }
 
 type CallResponse struct {
 	Method        string
+	Target        common.Address
 	Status        bool
 	ReturnDataRaw []byte `json:"returnData"`
 }
@@ -35,14 +39,58 @@ type Result struct {
 	Output  interface{}
 }
 
+// The detailed result of a single call in a multicall, including which contract and method it was for and why it
+// failed if it did
+type CallResult struct {
+	Index        int            `json:"index"`
+	Target       common.Address `json:"target"`
+	Method       string         `json:"method"`
+	Success      bool           `json:"success"`
+	Output       interface{}    `json:"-"`
+	RevertData   []byte         `json:"revertData,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Error        error          `json:"-"`
+}
+
+// Describe a failed call for diagnostics
+func (r CallResult) String() string {
+	if r.Success && r.Error == nil {
+		return fmt.Sprintf("call %d (%s on %s) succeeded", r.Index, r.Method, r.Target.Hex())
+	}
+	if r.Error != nil {
+		return fmt.Sprintf("call %d (%s on %s) could not be decoded: %s", r.Index, r.Method, r.Target.Hex(), r.Error.Error())
+	}
+	if r.RevertReason != "" {
+		return fmt.Sprintf("call %d (%s on %s) reverted: %s", r.Index, r.Method, r.Target.Hex(), r.RevertReason)
+	}
+	return fmt.Sprintf("call %d (%s on %s) reverted with data 0x%x", r.Index, r.Method, r.Target.Hex(), r.RevertData)
+}
+
+// Get the calls that failed or could not be decoded
+func GetFailedCalls(results []CallResult) []CallResult {
+	failed := []CallResult{}
+	for _, result := range results {
+		if !result.Success || result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
 func (call Call) GetMultiCall() MultiCall {
 	return MultiCall{Target: call.Target, CallData: call.CallData}
 }
 
+// Returned (wrapped) by multicalls and balance batches against a block whose state the node doesn't have
+var ErrStateNotAvailable = rocketpool.ErrStateNotAvailable
+
 type MultiCaller struct {
 	Client          rocketpool.ExecutionClient
 	ABI             abi.ABI
 	ContractAddress common.Address
+	Pin             *PinnedBlock
+	Profiler        *Profiler
+	RateLimiter     RateLimiter
 	calls           []Call
 }
 
@@ -60,6 +108,8 @@ func NewMultiCaller(client rocketpool.ExecutionClient, multicallerAddress common
 	}, nil
 }
 
+// Add a call to the batch; output is where the return value is unpacked to after the batch is executed
+// Methods with several return values can be unpacked into a struct pointer or a []interface{} of pointers
 func (caller *MultiCaller) AddCall(contract *rocketpool.Contract, output interface{}, method string, args ...interface{}) error {
 	callData, err := contract.ABI.Pack(method, args...)
 	if err != nil {
@@ -77,15 +127,38 @@ func (caller *MultiCaller) AddCall(contract *rocketpool.Contract, output interfa
 }
 
 func (caller *MultiCaller) Execute(requireSuccess bool, opts *bind.CallOpts) ([]CallResponse, error) {
+	metrics := rocketpool.GetMetrics(caller.Client)
+	if caller.Profiler == nil && metrics == nil {
+		results, err := caller.execute(requireSuccess, opts)
+		return results, rocketpool.WrapStateNotAvailableError(err)
+	}
+	start := time.Now()
+	results, err := caller.execute(requireSuccess, opts)
+	duration := time.Since(start)
+	if caller.Profiler != nil {
+		caller.Profiler.recordBatch(caller.calls, results, duration, err)
+	}
+	if metrics != nil {
+		metrics.ObserveMulticall(len(caller.calls), duration, err)
+	}
+	return results, rocketpool.WrapStateNotAvailableError(err)
+}
+
+// Execute the calls against the requested block, or the pinned block if one is set
+func (caller *MultiCaller) execute(requireSuccess bool, opts *bind.CallOpts) ([]CallResponse, error) {
 	var multiCalls = make([]MultiCall, 0, len(caller.calls))
 	for _, call := range caller.calls {
 		multiCalls = append(multiCalls, call.GetMultiCall())
 	}
+	if caller.Pin != nil {
+		return caller.executePinned(requireSuccess, multiCalls, opts)
+	}
 	callData, err := caller.ABI.Pack("tryAggregate", requireSuccess, multiCalls)
 	if err != nil {
 		return nil, err
 	}
 
+	waitForRequest(caller.RateLimiter)
 	resp, err := caller.Client.CallContract(context.Background(), ethereum.CallMsg{To: &caller.ContractAddress, Data: callData}, opts.BlockNumber)
 	if err != nil {
 		return nil, err
@@ -97,37 +170,107 @@ func (caller *MultiCaller) Execute(requireSuccess bool, opts *bind.CallOpts) ([]
 		return nil, err
 	}
 
+	return caller.getCallResponses(responses[0]), nil
+}
+
+// Execute the calls against the pinned block, failing if the multicall ran against any other block or the pinned block
+// was reorged out while the calls ran
+func (caller *MultiCaller) executePinned(requireSuccess bool, multiCalls []MultiCall, opts *bind.CallOpts) ([]CallResponse, error) {
+	if err := caller.Pin.VerifyCallOpts(opts); err != nil {
+		return nil, err
+	}
+	callData, err := caller.ABI.Pack("tryBlockAndAggregate", requireSuccess, multiCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	waitForRequest(caller.RateLimiter)
+	resp, err := caller.Client.CallContract(context.Background(), ethereum.CallMsg{To: &caller.ContractAddress, Data: callData}, caller.Pin.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := caller.ABI.Unpack("tryBlockAndAggregate", resp)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber, _ := responses[0].(*big.Int)
+	if err := caller.Pin.verifyExecutedBlock(blockNumber); err != nil {
+		return nil, err
+	}
+	if err := caller.Pin.VerifyCanonical(caller.Client); err != nil {
+		return nil, err
+	}
+
+	return caller.getCallResponses(responses[2]), nil
+}
+
+// Convert the raw multicall results into call responses
+func (caller *MultiCaller) getCallResponses(responses interface{}) []CallResponse {
 	results := make([]CallResponse, len(caller.calls))
-	for i, response := range responses[0].([]struct {
+	for i, response := range responses.([]struct {
 		Success    bool   `json:"success"`
 		ReturnData []byte `json:"returnData"`
 	}) {
 		results[i].Method = caller.calls[i].Method
+		results[i].Target = caller.calls[i].Target
 		results[i].ReturnDataRaw = response.ReturnData
 		results[i].Status = response.Success
 	}
-	return results, nil
+	return results
 }
 
 func (caller *MultiCaller) FlexibleCall(requireSuccess bool, opts *bind.CallOpts) ([]Result, error) {
-	res := make([]Result, len(caller.calls))
-	results, err := caller.Execute(requireSuccess, opts)
+	callResults, err := caller.DetailedCall(requireSuccess, opts)
 	if err != nil {
-		caller.calls = []Call{}
 		return nil, err
 	}
+	return getFlexibleResults(callResults)
+}
+
+// Convert detailed call results into flexible results, failing if any successful call's output couldn't be unpacked
+func getFlexibleResults(callResults []CallResult) ([]Result, error) {
+	res := make([]Result, len(callResults))
+	for i, callResult := range callResults {
+		if callResult.Error != nil {
+			return nil, fmt.Errorf("error unpacking %s: %w", callResult.String(), callResult.Error)
+		}
+		res[i].Success = callResult.Success
+		res[i].Output = callResult.Output
+	}
+	return res, nil
+}
+
+// Execute the calls and get a detailed result for each one, including the target, method and decoded revert reason
+// of failed calls; errors unpacking the output of a successful call are recorded on that call's result
+func (caller *MultiCaller) DetailedCall(requireSuccess bool, opts *bind.CallOpts) ([]CallResult, error) {
+	defer func() {
+		caller.calls = []Call{}
+	}()
+	results, err := caller.Execute(requireSuccess, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error executing multicall with %d calls: %w", len(caller.calls), err)
+	}
+	callResults := make([]CallResult, len(caller.calls))
 	for i, call := range caller.calls {
-		callSuccess := results[i].Status
-		if callSuccess {
-			err := call.Contract.ABI.UnpackIntoInterface(call.output, call.Method, results[i].ReturnDataRaw)
-			if err != nil {
-				caller.calls = []Call{}
-				return nil, err
+		callResults[i] = CallResult{
+			Index:   i,
+			Target:  call.Target,
+			Method:  call.Method,
+			Success: results[i].Status,
+			Output:  call.output,
+		}
+		if results[i].Status {
+			callResults[i].Error = unpackOutput(call.Contract.ABI, call.Method, call.output, results[i].ReturnDataRaw)
+		} else {
+			callResults[i].RevertData = results[i].ReturnDataRaw
+			if reason, err := abi.UnpackRevert(results[i].ReturnDataRaw); err == nil {
+				callResults[i].RevertReason = reason
 			}
 		}
-		res[i].Success = callSuccess
-		res[i].Output = call.output
+		if call.resolve != nil {
+			call.resolve(callResults[i])
+		}
 	}
-	caller.calls = []Call{}
-	return res, err
+	return callResults, nil
 }
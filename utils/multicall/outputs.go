@@ -0,0 +1,79 @@
+package multicall
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/rocket-pool/rocketpool-go/utils/internal/abiconv"
+)
+
+// Unpack the return data of a call into its output
+// Methods with several return values can be unpacked into either:
+//   - a pointer to a struct, with fields matched to named return values like the ABI package does, or by position
+//     (in exported field order) when any return value is unnamed
+//   - a []interface{} of pointers, one per return value, in order
+func unpackOutput(contractAbi *abi.ABI, method string, output interface{}, data []byte) error {
+	abiMethod, exists := contractAbi.Methods[method]
+	if !exists || len(abiMethod.Outputs) < 2 {
+		return contractAbi.UnpackIntoInterface(output, method, data)
+	}
+
+	// Unpack into a list of destinations
+	if destinations, ok := output.([]interface{}); ok {
+		if len(destinations) != len(abiMethod.Outputs) {
+			return fmt.Errorf("%s returns %d values but %d destinations were provided", method, len(abiMethod.Outputs), len(destinations))
+		}
+		values, err := abiMethod.Outputs.Unpack(data)
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			destination := reflect.ValueOf(destinations[i])
+			if destination.Kind() != reflect.Pointer || destination.IsNil() {
+				return fmt.Errorf("destination %d of %s must be a non-nil pointer, not %T", i, method, destinations[i])
+			}
+			if err := abiconv.SetValue(destination.Elem(), value); err != nil {
+				return fmt.Errorf("error setting return value %d of %s: %w", i, method, err)
+			}
+		}
+		return nil
+	}
+
+	// Use the ABI package's name matching unless the struct needs to be filled by position
+	destination := reflect.ValueOf(output)
+	if destination.Kind() != reflect.Pointer || destination.Elem().Kind() != reflect.Struct || outputsAreNamed(abiMethod.Outputs) {
+		return contractAbi.UnpackIntoInterface(output, method, data)
+	}
+	values, err := abiMethod.Outputs.Unpack(data)
+	if err != nil {
+		return err
+	}
+	fields := []reflect.Value{}
+	structValue := destination.Elem()
+	for i := 0; i < structValue.NumField(); i++ {
+		if structValue.Type().Field(i).IsExported() {
+			fields = append(fields, structValue.Field(i))
+		}
+	}
+	if len(fields) != len(values) {
+		return fmt.Errorf("%s returns %d values but %s has %d exported fields", method, len(values), structValue.Type(), len(fields))
+	}
+	for i, value := range values {
+		if err := abiconv.SetValue(fields[i], value); err != nil {
+			return fmt.Errorf("error setting return value %d of %s: %w", i, method, err)
+		}
+	}
+	return nil
+}
+
+// Check if every return value has a name the ABI package can match to a struct field
+func outputsAreNamed(outputs abi.Arguments) bool {
+	for _, output := range outputs {
+		if output.Name == "" || abi.ToCamelCase(output.Name) == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package multicall
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution block that every batched query must be answered against
+// Used when a set of queries has to describe exactly one block (e.g. a rewards interval boundary), so a reorg or a
+// load-balanced provider answering from a different block fails the query instead of silently mixing state
+type PinnedBlock struct {
+	Number *big.Int
+	Hash   common.Hash
+}
+
+// Create a new pinned block, checking that the block at the given number is the expected one
+func NewPinnedBlock(client rocketpool.ExecutionClient, blockNumber uint64, expectedHash common.Hash) (*PinnedBlock, error) {
+	pin := &PinnedBlock{
+		Number: big.NewInt(0).SetUint64(blockNumber),
+		Hash:   expectedHash,
+	}
+	if err := pin.VerifyCanonical(client); err != nil {
+		return nil, err
+	}
+	return pin, nil
+}
+
+// Get call options that target the pinned block
+func (pin *PinnedBlock) GetCallOpts() *bind.CallOpts {
+	return &bind.CallOpts{
+		BlockNumber: big.NewInt(0).Set(pin.Number),
+	}
+}
+
+// Check that a set of call options targets the pinned block
+func (pin *PinnedBlock) VerifyCallOpts(opts *bind.CallOpts) error {
+	if opts == nil || opts.BlockNumber == nil {
+		return fmt.Errorf("query must target pinned block %s but no block number was provided", pin.Number.String())
+	}
+	if opts.BlockNumber.Cmp(pin.Number) != 0 {
+		return fmt.Errorf("query targets block %s but is pinned to block %s", opts.BlockNumber.String(), pin.Number.String())
+	}
+	return nil
+}
+
+// Check that the pinned block is still the canonical block at its height
+// Header.Hash() can't be used here, since older header types don't hash every field newer forks add (e.g. the
+// withdrawals root); instead, the block with the pinned hash is looked up and compared with the canonical block
+func (pin *PinnedBlock) VerifyCanonical(client rocketpool.ExecutionClient) error {
+	pinned, err := client.HeaderByHash(context.Background(), pin.Hash)
+	if err != nil {
+		return fmt.Errorf("error getting header for pinned block %s: %w", pin.Hash.Hex(), err)
+	}
+	if pinned.Number == nil || pinned.Number.Cmp(pin.Number) != 0 {
+		return fmt.Errorf("block %s is at height %v but is pinned to height %s", pin.Hash.Hex(), pinned.Number, pin.Number.String())
+	}
+	canonical, err := client.HeaderByNumber(context.Background(), pin.Number)
+	if err != nil {
+		return fmt.Errorf("error getting header for block %s: %w", pin.Number.String(), err)
+	}
+	if !isSameHeader(pinned, canonical) {
+		return fmt.Errorf("block %s is no longer canonical at height %s", pin.Hash.Hex(), pin.Number.String())
+	}
+	return nil
+}
+
+// Check if two headers at the same height are for the same block, using fields the client reports directly
+func isSameHeader(a *types.Header, b *types.Header) bool {
+	return a.ParentHash == b.ParentHash &&
+		a.Root == b.Root &&
+		a.TxHash == b.TxHash &&
+		a.ReceiptHash == b.ReceiptHash &&
+		a.Time == b.Time
+}
+
+// Check the block number a multicall reported executing against
+func (pin *PinnedBlock) verifyExecutedBlock(blockNumber *big.Int) error {
+	if blockNumber == nil || blockNumber.Cmp(pin.Number) != 0 {
+		return fmt.Errorf("multicall executed against block %v but is pinned to block %s", blockNumber, pin.Number.String())
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package multicall
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Profiler settings
+const DefaultMaxSlowBatches int = 100
+
+// The statistics of a single contract method across every profiled multicall
+// A multicall runs all of its calls in one request, so each call is attributed an equal share of its batch's latency
+type CallStats struct {
+	Contract     string         `json:"contract"`
+	Address      common.Address `json:"address"`
+	Method       string         `json:"method"`
+	Calls        uint64         `json:"calls"`
+	Batches      uint64         `json:"batches"`
+	FailedCalls  uint64         `json:"failedCalls"`
+	TotalTime    time.Duration  `json:"totalTime"`
+	MaxBatchTime time.Duration  `json:"maxBatchTime"` // Latency of the slowest batch that included this method
+}
+
+// Get the average attributed latency per call
+func (s CallStats) GetAverageTime() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Calls)
+}
+
+// A multicall that took longer than the profiler's slow threshold
+type SlowBatch struct {
+	Duration  time.Duration  `json:"duration"`
+	CallCount int            `json:"callCount"`
+	Methods   map[string]int `json:"methods"` // Call counts by "contract.method"
+	Error     string         `json:"error,omitempty"`
+}
+
+// Records per-contract and per-method call counts and latencies of the multicalls it's attached to
+// Attach it to a MultiCaller's Profiler field to opt in; it's safe to share between multicallers running concurrently
+type Profiler struct {
+	SlowThreshold  time.Duration // Batches slower than this are recorded individually; 0 disables slow batch tracking
+	MaxSlowBatches int
+
+	names       map[common.Address]string
+	stats       map[profilerKey]*CallStats
+	slowBatches []SlowBatch
+	batchCount  uint64
+	totalTime   time.Duration
+	lock        sync.Mutex
+}
+
+type profilerKey struct {
+	address common.Address
+	method  string
+}
+
+// Create new profiler
+func NewProfiler() *Profiler {
+	return &Profiler{
+		MaxSlowBatches: DefaultMaxSlowBatches,
+		names:          map[common.Address]string{},
+		stats:          map[profilerKey]*CallStats{},
+	}
+}
+
+// Set the name to report for a contract address
+func (p *Profiler) SetContractName(address common.Address, name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.names[address] = name
+	for key, stats := range p.stats {
+		if key.address == address {
+			stats.Contract = name
+		}
+	}
+}
+
+// Get the statistics of every contract method, slowest total time first
+func (p *Profiler) GetStats() []CallStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	stats := make([]CallStats, 0, len(p.stats))
+	for _, entry := range p.stats {
+		stats = append(stats, *entry)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalTime != stats[j].TotalTime {
+			return stats[i].TotalTime > stats[j].TotalTime
+		}
+		if stats[i].Contract != stats[j].Contract {
+			return stats[i].Contract < stats[j].Contract
+		}
+		return stats[i].Method < stats[j].Method
+	})
+	return stats
+}
+
+// Get the batches that took longer than the slow threshold, in the order they ran
+func (p *Profiler) GetSlowBatches() []SlowBatch {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	slowBatches := make([]SlowBatch, len(p.slowBatches))
+	copy(slowBatches, p.slowBatches)
+	return slowBatches
+}
+
+// Clear all of the recorded statistics, keeping the contract names
+func (p *Profiler) Reset() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.stats = map[profilerKey]*CallStats{}
+	p.slowBatches = nil
+	p.batchCount = 0
+	p.totalTime = 0
+}
+
+// Write a human-readable report of the recorded statistics
+func (p *Profiler) WriteReport(w io.Writer) error {
+	stats := p.GetStats()
+	slowBatches := p.GetSlowBatches()
+	p.lock.Lock()
+	batchCount := p.batchCount
+	totalTime := p.totalTime
+	p.lock.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d multicalls, %s total\n\n", batchCount, totalTime)
+	fmt.Fprintln(tw, "CONTRACT\tMETHOD\tCALLS\tBATCHES\tFAILED\tTOTAL\tSHARE\tAVG/CALL\tMAX BATCH")
+	for _, entry := range stats {
+		share := 0.0
+		if totalTime > 0 {
+			share = float64(entry.TotalTime) / float64(totalTime) * 100
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\t%.1f%%\t%s\t%s\n",
+			entry.Contract, entry.Method, entry.Calls, entry.Batches, entry.FailedCalls,
+			entry.TotalTime, share, entry.GetAverageTime(), entry.MaxBatchTime)
+	}
+	if len(slowBatches) > 0 {
+		fmt.Fprintf(tw, "\n%d slow multicalls\n", len(slowBatches))
+		for _, batch := range slowBatches {
+			fmt.Fprintf(tw, "%s\t%d calls\t%s\n", batch.Duration, batch.CallCount, batch.Error)
+		}
+	}
+	return tw.Flush()
+}
+
+// Record a multicall that was executed
+func (p *Profiler) recordBatch(calls []Call, responses []CallResponse, duration time.Duration, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.batchCount++
+	p.totalTime += duration
+	if len(calls) == 0 {
+		return
+	}
+
+	share := duration / time.Duration(len(calls))
+	seen := map[profilerKey]bool{}
+	for i, call := range calls {
+		key := profilerKey{
+			address: call.Target,
+			method:  call.Method,
+		}
+		entry, exists := p.stats[key]
+		if !exists {
+			entry = &CallStats{
+				Contract: p.getName(call.Target),
+				Address:  call.Target,
+				Method:   call.Method,
+			}
+			p.stats[key] = entry
+		}
+		entry.Calls++
+		entry.TotalTime += share
+		if err != nil || (i < len(responses) && !responses[i].Status) {
+			entry.FailedCalls++
+		}
+		if !seen[key] {
+			seen[key] = true
+			entry.Batches++
+			if duration > entry.MaxBatchTime {
+				entry.MaxBatchTime = duration
+			}
+		}
+	}
+
+	// Record slow batches
+	if p.SlowThreshold <= 0 || duration < p.SlowThreshold || len(p.slowBatches) >= p.MaxSlowBatches {
+		return
+	}
+	batch := SlowBatch{
+		Duration:  duration,
+		CallCount: len(calls),
+		Methods:   map[string]int{},
+	}
+	for _, call := range calls {
+		batch.Methods[fmt.Sprintf("%s.%s", p.getName(call.Target), call.Method)]++
+	}
+	if err != nil {
+		batch.Error = err.Error()
+	}
+	p.slowBatches = append(p.slowBatches, batch)
+}
+
+// Get the reported name of a contract, falling back to its address
+func (p *Profiler) getName(address common.Address) string {
+	if name, exists := p.names[address]; exists {
+		return name
+	}
+	return address.Hex()
+}
@@ -0,0 +1,16 @@
+package multicall
+
+// Limits how often the batchers send requests to the execution client
+// It's called before every multicall or balances request is sent, including retries of batches that were split, so a
+// logical batch that's executed as several requests waits once for each of them
+type RateLimiter interface {
+	// Block until the next request is allowed
+	WaitForRequest()
+}
+
+// Wait for the rate limiter if one is set
+func waitForRequest(limiter RateLimiter) {
+	if limiter != nil {
+		limiter.WaitForRequest()
+	}
+}
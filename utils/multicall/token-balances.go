@@ -0,0 +1,100 @@
+package multicall
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// The number of balanceOf calls in each multicall
+const tokenBalanceBatchSize int = 1000
+
+// Gets the ERC-20 token balances of many addresses at once by batching balanceOf calls into multicalls
+type TokenBalanceBatcher struct {
+	Client             rocketpool.ExecutionClient
+	MulticallerAddress common.Address
+	Pin                *PinnedBlock
+	Profiler           *Profiler
+	RateLimiter        RateLimiter
+}
+
+// Create a new token balance batcher
+func NewTokenBalanceBatcher(client rocketpool.ExecutionClient, multicallerAddress common.Address) *TokenBalanceBatcher {
+	return &TokenBalanceBatcher{
+		Client:             client,
+		MulticallerAddress: multicallerAddress,
+	}
+}
+
+// Get the balance of each address for a single token
+func (b *TokenBalanceBatcher) GetTokenBalances(token *rocketpool.Contract, addresses []common.Address, opts *bind.CallOpts) ([]*big.Int, error) {
+	balances, err := b.GetMultiTokenBalances([]*rocketpool.Contract{token}, addresses, opts)
+	if err != nil {
+		return nil, err
+	}
+	return balances[0], nil
+}
+
+// Get the balance of each address for several tokens, indexed by token then address
+// The tokens' ABIs must include the ERC-20 balanceOf method
+func (b *TokenBalanceBatcher) GetMultiTokenBalances(tokens []*rocketpool.Contract, addresses []common.Address, opts *bind.CallOpts) ([][]*big.Int, error) {
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	balances := make([][]*big.Int, len(tokens))
+	for i := range tokens {
+		balances[i] = make([]*big.Int, len(addresses))
+	}
+	if len(tokens) == 0 {
+		return balances, nil
+	}
+
+	// Sync
+	count := len(addresses)
+	var wg errgroup.Group
+	wg.SetLimit(threadLimit)
+
+	// Run the getters in batches, with a call per token for each address
+	batchSize := tokenBalanceBatchSize / len(tokens)
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for i := 0; i < count; i += batchSize {
+		i := i
+		max := i + batchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := NewMultiCaller(b.Client, b.MulticallerAddress)
+			if err != nil {
+				return err
+			}
+			mc.Pin = b.Pin
+			mc.Profiler = b.Profiler
+			mc.RateLimiter = b.RateLimiter
+			for j, token := range tokens {
+				for k := i; k < max; k++ {
+					if err := mc.AddCall(token, &balances[j][k], "balanceOf", addresses[k]); err != nil {
+						return fmt.Errorf("error adding balance call for token %s: %w", token.Address.Hex(), err)
+					}
+				}
+			}
+			if _, err := mc.FlexibleCall(true, opts); err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting token balances: %w", err)
+	}
+
+	return balances, nil
+}
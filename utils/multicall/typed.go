@@ -0,0 +1,136 @@
+package multicall
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The output of a typed call, available once the multicall it was added to has been executed
+type Future[T any] struct {
+	value    T
+	resolved bool
+	result   CallResult
+}
+
+// Add a call whose output is unpacked into a T, checking that the method's return values can be unpacked into a T
+// before the call is added instead of failing (or silently leaving the output unset) when the multicall is executed
+// Methods with several return values need a struct T, matched to them the same way AddCall matches struct outputs
+func AddCallTyped[T any](caller *MultiCaller, contract *rocketpool.Contract, method string, args ...interface{}) (*Future[T], error) {
+	abiMethod, exists := contract.ABI.Methods[method]
+	if !exists {
+		return nil, fmt.Errorf("error adding call [%s]: method not found", method)
+	}
+	if err := checkOutputType(abiMethod.Outputs, reflect.TypeOf((*T)(nil)).Elem()); err != nil {
+		return nil, fmt.Errorf("error adding call [%s]: %w", method, err)
+	}
+
+	future := &Future[T]{}
+	if err := caller.AddCall(contract, &future.value, method, args...); err != nil {
+		return nil, err
+	}
+	call := &caller.calls[len(caller.calls)-1]
+	call.resolve = func(result CallResult) {
+		future.resolved = true
+		future.result = result
+	}
+	return future, nil
+}
+
+// Check if the multicall the call was added to has been executed
+func (f *Future[T]) IsResolved() bool {
+	return f.resolved
+}
+
+// Get the output of the call, or an error if it hasn't been executed, reverted or could not be unpacked
+func (f *Future[T]) Get() (T, error) {
+	var empty T
+	if !f.resolved {
+		return empty, errors.New("the call has not been executed")
+	}
+	if !f.result.Success || f.result.Error != nil {
+		return empty, errors.New(f.result.String())
+	}
+	return f.value, nil
+}
+
+// Get the output of the call, or the zero value of T if it hasn't been executed, reverted or could not be unpacked
+func (f *Future[T]) Value() T {
+	value, _ := f.Get()
+	return value
+}
+
+// Check that a method's return values can be unpacked into the given type
+func checkOutputType(outputs abi.Arguments, outputType reflect.Type) error {
+	switch len(outputs) {
+	case 0:
+		return errors.New("method has no return values")
+	case 1:
+		if !isCompatibleType(outputs[0].Type.GetType(), outputType, false) {
+			return fmt.Errorf("return value of type %s can't be unpacked into %s", outputs[0].Type.String(), outputType)
+		}
+		return nil
+	}
+
+	if outputType.Kind() != reflect.Struct {
+		return fmt.Errorf("method has %d return values, which must be unpacked into a struct rather than %s", len(outputs), outputType)
+	}
+
+	// Named return values are matched to fields by name
+	if outputsAreNamed(outputs) {
+		for _, output := range outputs {
+			field, exists := outputType.FieldByName(abi.ToCamelCase(output.Name))
+			if !exists {
+				return fmt.Errorf("%s has no field for return value %s", outputType, output.Name)
+			}
+			if !isCompatibleType(output.Type.GetType(), field.Type, false) {
+				return fmt.Errorf("return value %s of type %s can't be unpacked into field %s of type %s", output.Name, output.Type.String(), field.Name, field.Type)
+			}
+		}
+		return nil
+	}
+
+	// Unnamed return values are matched to exported fields by position
+	fields := []reflect.StructField{}
+	for i := 0; i < outputType.NumField(); i++ {
+		if outputType.Field(i).IsExported() {
+			fields = append(fields, outputType.Field(i))
+		}
+	}
+	if len(fields) != len(outputs) {
+		return fmt.Errorf("method has %d return values but %s has %d exported fields", len(outputs), outputType, len(fields))
+	}
+	for i, output := range outputs {
+		if !isCompatibleType(output.Type.GetType(), fields[i].Type, true) {
+			return fmt.Errorf("return value %d of type %s can't be unpacked into field %s of type %s", i, output.Type.String(), fields[i].Name, fields[i].Type)
+		}
+	}
+	return nil
+}
+
+// Check if an unpacked ABI value can be stored in the given type
+// Values are only converted to other types (e.g. a uint8 to a named uint8 type) when unpacking by position
+func isCompatibleType(abiType reflect.Type, outputType reflect.Type, convert bool) bool {
+	if abiType.AssignableTo(outputType) {
+		return true
+	}
+	switch abiType.Kind() {
+	case reflect.Struct:
+		// Tuples are copied field by field when they're unpacked
+		return outputType.Kind() == reflect.Struct
+	case reflect.Slice:
+		return outputType.Kind() == reflect.Slice && isCompatibleType(abiType.Elem(), outputType.Elem(), convert)
+	case reflect.Array:
+		if outputType.Kind() == reflect.Array && abiType.Len() == outputType.Len() && isCompatibleType(abiType.Elem(), outputType.Elem(), convert) {
+			return true
+		}
+	case reflect.Ptr:
+		// Big integers can't be converted to anything but themselves
+		return false
+	}
+	return convert && abiType.ConvertibleTo(outputType)
+}
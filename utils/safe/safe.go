@@ -0,0 +1,145 @@
+package safe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Canonical deployments of the Safe v1.3.0 multisend contracts
+// MultiSendCallOnly rejects delegate calls in the batch, so prefer it unless a batch needs them
+var (
+	MultiSendAddress         = common.HexToAddress("0xA238CBeb142c10Ef7Ad8442C6D1f9E89e07e7761")
+	MultiSendCallOnlyAddress = common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+)
+
+const multiSendAbi string = `[{"type":"function","name":"multiSend","stateMutability":"payable","inputs":[{"name":"transactions","type":"bytes"}],"outputs":[]}]`
+
+// How a Safe executes a transaction
+type Operation uint8
+
+const (
+	Operation_Call         Operation = 0
+	Operation_DelegateCall Operation = 1
+)
+
+// A transaction for a Safe to execute
+type Transaction struct {
+	To        common.Address `json:"to"`
+	Value     *big.Int       `json:"value"`
+	Data      hexutil.Bytes  `json:"data"`
+	Operation Operation      `json:"operation"`
+}
+
+// The Safe services expect the value as a decimal string
+type transactionJson struct {
+	To        common.Address `json:"to"`
+	Value     string         `json:"value"`
+	Data      hexutil.Bytes  `json:"data"`
+	Operation Operation      `json:"operation"`
+}
+
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	value := "0"
+	if t.Value != nil {
+		value = t.Value.String()
+	}
+	return json.Marshal(transactionJson{
+		To:        t.To,
+		Value:     value,
+		Data:      t.Data,
+		Operation: t.Operation,
+	})
+}
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var raw transactionJson
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	value, ok := big.NewInt(0).SetString(raw.Value, 10)
+	if !ok {
+		return fmt.Errorf("invalid transaction value %s", raw.Value)
+	}
+	t.To = raw.To
+	t.Value = value
+	t.Data = raw.Data
+	t.Operation = raw.Operation
+	return nil
+}
+
+// Create a Safe call from an unsigned transaction
+func NewTransaction(tx rocketpool.UnsignedTransaction) Transaction {
+	value := big.NewInt(0)
+	if tx.Value != nil {
+		value.Set(tx.Value)
+	}
+	return Transaction{
+		To:        tx.To,
+		Value:     value,
+		Data:      tx.Data,
+		Operation: Operation_Call,
+	}
+}
+
+// Create Safe calls from binding transactions, built as if sent by the Safe with the given options
+// The options' From must be the Safe address; each call sends the value its transaction sets (e.g. a deposit's bond),
+// or the options' value if it doesn't set one
+func NewTransactions(safeOpts *bind.TransactOpts, txs ...rocketpool.BatchTransaction) ([]Transaction, error) {
+	safeTxs := make([]Transaction, len(txs))
+	for i, tx := range txs {
+		unsignedTx, err := tx.ToUnsignedTx(safeOpts)
+		if err != nil {
+			return nil, err
+		}
+		safeTxs[i] = NewTransaction(unsignedTx)
+	}
+	return safeTxs, nil
+}
+
+// Encode the transactions into a single delegate call to a multisend contract, which the Safe executes in order
+// If any transaction fails, the whole batch reverts
+func NewMultiSendTransaction(multiSendAddress common.Address, txs []Transaction) (Transaction, error) {
+	if len(txs) == 0 {
+		return Transaction{}, fmt.Errorf("a multisend needs at least one transaction")
+	}
+	mcAbi, err := abi.JSON(strings.NewReader(multiSendAbi))
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	// Each transaction is packed as operation (1 byte), to (20 bytes), value (32 bytes), data length (32 bytes), data
+	packed := []byte{}
+	for i, tx := range txs {
+		if multiSendAddress == MultiSendCallOnlyAddress && tx.Operation != Operation_Call {
+			return Transaction{}, fmt.Errorf("transaction %d is a delegate call, which MultiSendCallOnly doesn't support", i)
+		}
+		value := tx.Value
+		if value == nil {
+			value = big.NewInt(0)
+		}
+		packed = append(packed, byte(tx.Operation))
+		packed = append(packed, tx.To.Bytes()...)
+		packed = append(packed, common.LeftPadBytes(value.Bytes(), 32)...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(int64(len(tx.Data))).Bytes(), 32)...)
+		packed = append(packed, tx.Data...)
+	}
+	data, err := mcAbi.Pack("multiSend", packed)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("error encoding multisend: %w", err)
+	}
+
+	return Transaction{
+		To:        multiSendAddress,
+		Value:     big.NewInt(0),
+		Data:      data,
+		Operation: Operation_DelegateCall,
+	}, nil
+}
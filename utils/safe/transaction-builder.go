@@ -0,0 +1,43 @@
+package safe
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The batch file format version understood by the Safe Transaction Builder app
+const TransactionBuilderVersion string = "1.0"
+
+// Metadata of a Transaction Builder batch
+type TransactionBuilderMeta struct {
+	Name                    string         `json:"name"`
+	Description             string         `json:"description"`
+	CreatedFromSafeAddress  common.Address `json:"createdFromSafeAddress"`
+	CreatedFromOwnerAddress string         `json:"createdFromOwnerAddress"`
+}
+
+// A batch of transactions that can be loaded into the Safe Transaction Builder app, which bundles them into a multisend
+type TransactionBuilderBatch struct {
+	Version      string                 `json:"version"`
+	ChainID      string                 `json:"chainId"`
+	CreatedAt    int64                  `json:"createdAt"` // Milliseconds since the epoch
+	Meta         TransactionBuilderMeta `json:"meta"`
+	Transactions []Transaction          `json:"transactions"`
+}
+
+// Create a Transaction Builder batch for a Safe
+func NewTransactionBuilderBatch(chainID uint64, safeAddress common.Address, name string, description string, txs []Transaction) TransactionBuilderBatch {
+	return TransactionBuilderBatch{
+		Version:   TransactionBuilderVersion,
+		ChainID:   strconv.FormatUint(chainID, 10),
+		CreatedAt: time.Now().UnixMilli(),
+		Meta: TransactionBuilderMeta{
+			Name:                   name,
+			Description:            description,
+			CreatedFromSafeAddress: safeAddress,
+		},
+		Transactions: txs,
+	}
+}
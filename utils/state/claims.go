@@ -0,0 +1,78 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// Check if every node has claimed its rewards for each of the given intervals, in the same order as the intervals
+func GetAllNodeClaimStatuses(rp *rocketpool.RocketPool, contracts *NetworkContracts, intervals []uint64) (map[common.Address][]bool, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+
+	// Get the list of node addresses
+	addresses, err := getNodeAddressesFast(rp, contracts, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node addresses: %w", err)
+	}
+	rocketDistributorMainnet, err := rp.GetContract("rocketMerkleDistributorMainnet", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
+	statuses := make([][]bool, len(addresses))
+	for i := range statuses {
+		statuses[i] = make([]bool, len(intervals))
+	}
+	intervalsBig := make([]*big.Int, len(intervals))
+	for i, interval := range intervals {
+		intervalsBig[i] = new(big.Int).SetUint64(interval)
+	}
+
+	// Run the getters in batches, with a call per node and interval pair
+	count := len(addresses) * len(intervals)
+	batchSize := getBatchSize(contracts.getQueryOptions().NodeAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
+		i := i
+		max := i + batchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := contracts.newMultiCaller(rp)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				node := j / len(intervals)
+				interval := j % len(intervals)
+				mc.AddCall(rocketDistributorMainnet, &statuses[node][interval], "isClaimed", intervalsBig[interval], addresses[node])
+			}
+			_, err = contracts.executeBatch(mc, true, opts)
+			if err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting rewards claim statuses: %w", err)
+	}
+
+	results := make(map[common.Address][]bool, len(addresses))
+	for i, address := range addresses {
+		results[address] = statuses[i]
+	}
+	return results, nil
+}
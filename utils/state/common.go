@@ -5,10 +5,6 @@ import (
 	"time"
 )
 
-const (
-	threadLimit int = 10
-)
-
 // Global constants
 var zero = big.NewInt(0)
 
@@ -2,23 +2,39 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/go-version"
+	"github.com/rocket-pool/rocketpool-go/governance/snapshot"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/utils/beacon"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 )
 
 // Container for network contracts
 type NetworkContracts struct {
 	// Non-RP Utility
-	BalanceBatcher *multicall.BalanceBatcher
-	Multicaller    *multicall.MultiCaller
-	ElBlockNumber  *big.Int
+	BalanceBatcher      *multicall.BalanceBatcher
+	TokenBalanceBatcher *multicall.TokenBalanceBatcher
+	Multicaller         *multicall.MultiCaller
+	ElBlockNumber       *big.Int
+
+	// Load settings for the bulk getters; set these before the container is shared between goroutines
+	QueryOptions     *QueryOptions
+	queryOptionsOnce sync.Once
+
+	// The snapshot every query is locked to, if the contracts were created in consistent mode or at a block tag
+	Snapshot *Snapshot
+
+	// True if the requested block's state wasn't available and the container was created at the latest block instead
+	FellBackToLatestBlock bool
 
 	// Network version
 	Version *version.Version
@@ -52,17 +68,193 @@ type NetworkContracts struct {
 	// Houston
 	RocketDAOProtocolProposal *rocketpool.Contract
 	RocketDAOProtocolVerifier *rocketpool.Contract
+
+	// The Snapshot signalling address registry isn't in RocketStorage, so it's only loaded by SetSignerRegistry
+	SignerRegistry *snapshot.SignerRegistry
+
+	// Contract names by address, for profiler reports
+	contractNames map[common.Address]string
+
+	// The network contracts that have been looked up, whether or not they were deployed at the container's block
+	rp       *rocketpool.RocketPool
+	resolved map[string]bool
+	lock     sync.Mutex
+}
+
+// Returned (wrapped) by the state getters when the node doesn't have the state of the container's block
+var ErrStateNotAvailable = multicall.ErrStateNotAvailable
+
+// Returned (wrapped) by the state getters when a contract they need wasn't deployed at the container's block
+var ErrContractUnavailable = rocketpool.ErrNotDeployed
+
+// A network contract that wasn't deployed at the container's block, such as an Atlas contract on a block from before
+// Atlas
+type ContractUnavailableError struct {
+	Name        string
+	Upgrade     string // The upgrade that added the contract
+	BlockNumber *big.Int
+}
+
+func (e *ContractUnavailableError) Error() string {
+	return fmt.Sprintf("%s (added in %s) is not deployed at block %s", e.Name, e.Upgrade, e.BlockNumber.String())
+}
+func (e *ContractUnavailableError) Is(target error) bool {
+	return target == ErrContractUnavailable
 }
 
 type contractArtifacts struct {
 	name       string
+	upgrade    string
 	address    common.Address
 	abiEncoded string
 	contract   **rocketpool.Contract
 }
 
+// The execution and consensus state a consistent set of network contracts is locked to
+type Snapshot struct {
+	ElBlockNumber   uint64      `json:"elBlockNumber"`
+	ElBlockHash     common.Hash `json:"elBlockHash"`
+	BeaconStateRoot common.Hash `json:"beaconStateRoot"` // Only set for snapshots of a Beacon slot, verified against the slot's block header
+}
+
+// Get the Beacon state ID to query validator balances and performance with, so the consensus data describes the same
+// slot as the execution state
+// Only snapshots of a Beacon slot have one; others return an error rather than falling back to the head state
+func (s *Snapshot) GetBeaconStateID() (string, error) {
+	if s.BeaconStateRoot == (common.Hash{}) {
+		return "", fmt.Errorf("snapshot of block %d isn't locked to a Beacon slot", s.ElBlockNumber)
+	}
+	return s.BeaconStateRoot.Hex(), nil
+}
+
 // Get a new network contracts container
 func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, opts *bind.CallOpts) (*NetworkContracts, error) {
+	return newNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, nil, opts)
+}
+
+// Get a new network contracts container using the multicall and balance batcher addresses of the contract manager's
+// chain profile
+func NewNetworkContractsFromProfile(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*NetworkContracts, error) {
+	if rp.ChainProfile == nil {
+		return nil, fmt.Errorf("contract manager wasn't created from a chain profile")
+	}
+	profile := rp.ChainProfile
+	if profile.MulticallAddress == (common.Address{}) || profile.BalanceBatcherAddress == (common.Address{}) {
+		return nil, fmt.Errorf("chain profile %s is missing the multicall or balance batcher address", profile.Name)
+	}
+	return NewNetworkContracts(rp, profile.MulticallAddress, profile.BalanceBatcherAddress, opts)
+}
+
+// Get a new network contracts container, falling back to the latest block if the node doesn't have the state of the
+// requested block (e.g. a non-archive node); only use this when the queries don't need historical state
+// Queries that later fail because the node pruned the state while they ran still return ErrStateNotAvailable
+func NewNetworkContractsWithFallback(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, opts *bind.CallOpts) (*NetworkContracts, error) {
+	contracts, err := newNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, nil, opts)
+	if err == nil || !errors.Is(err, ErrStateNotAvailable) || opts == nil {
+		return contracts, err
+	}
+	contracts, err = newNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	contracts.FellBackToLatestBlock = true
+	return contracts, nil
+}
+
+// Get a new network contracts container locked to a snapshot, for queries that must reflect exactly one block such as
+// rewards tree generation at an interval boundary
+// elBlockHash is the hash of the execution block; every multicall and balance batch is checked against it, and any
+// query for a different block or a reorged block fails. Use NewConsistentNetworkContractsForSlot to lock to the block
+// a Beacon slot references.
+func NewConsistentNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, elBlockNumber uint64, elBlockHash common.Hash) (*NetworkContracts, error) {
+	pin, err := multicall.NewPinnedBlock(rp.Client, elBlockNumber, elBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("error locking to snapshot block %d: %w", elBlockNumber, err)
+	}
+	contracts, err := newNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, pin, pin.GetCallOpts())
+	if err != nil {
+		return nil, err
+	}
+	contracts.Snapshot = &Snapshot{
+		ElBlockNumber: elBlockNumber,
+		ElBlockHash:   elBlockHash,
+	}
+	return contracts, nil
+}
+
+// Get a new network contracts container locked to the execution block whose state a Beacon slot reflects
+// If the slot was missed, this is the block of the latest slot before it that had one
+// The slot's Beacon state root is verified against its block header and recorded in the snapshot; pass
+// Snapshot.GetBeaconStateID to the Beacon balance and performance queries so they describe the same slot. The mapper's
+// provider must be able to get block headers (e.g. HttpBlockProvider).
+func NewConsistentNetworkContractsForSlot(rp *rocketpool.RocketPool, mapper *beacon.BlockMapper, multicallerAddress common.Address, balanceBatcherAddress common.Address, slot uint64) (*NetworkContracts, error) {
+	info, err := mapper.GetElBlockForSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	if err := mapper.VerifyStateRoot(info); err != nil {
+		return nil, err
+	}
+	contracts, err := NewConsistentNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, info.ElBlockNumber, info.ElBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("error locking to snapshot for Beacon state root %s: %w", info.BeaconStateRoot.Hex(), err)
+	}
+	contracts.Snapshot.BeaconStateRoot = info.BeaconStateRoot
+	return contracts, nil
+}
+
+// Get a new network contracts container locked to the block a tag resolves to, such as the finalized block, so state
+// used for oDAO submissions can't be invalidated by a reorg
+// Every query is checked against the block's hash like NewConsistentNetworkContracts; the snapshot has no Beacon state root
+func NewNetworkContractsAtTag(rp *rocketpool.RocketPool, caller eth.RpcCaller, multicallerAddress common.Address, balanceBatcherAddress common.Address, tag eth.BlockTag) (*NetworkContracts, error) {
+	block, err := eth.GetBlockRefByTag(caller, tag)
+	if err != nil {
+		return nil, err
+	}
+	return NewConsistentNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, block.Number, block.Hash)
+}
+
+// Get a new network contracts container that only loads the contracts the network version check needs, and resolves
+// each other contract the first time a bulk getter needs it
+// This is cheaper for one-off queries, and lets the getters run against blocks from before some contracts existed;
+// getters that need a contract that wasn't deployed at the block return a ContractUnavailableError
+func NewLazyNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, opts *bind.CallOpts) (*NetworkContracts, error) {
+	contracts, err := newNetworkContractsContainer(rp, multicallerAddress, balanceBatcherAddress, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	err = contracts.getCurrentVersion(rp)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contract version: %w", err)
+	}
+	return contracts, nil
+}
+
+// Create a network contracts container, optionally pinned to a single block
+// Contracts that weren't deployed at the block are left nil, and the getters that need them return a
+// ContractUnavailableError
+func newNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, pin *multicall.PinnedBlock, opts *bind.CallOpts) (*NetworkContracts, error) {
+	contracts, err := newNetworkContractsContainer(rp, multicallerAddress, balanceBatcherAddress, pin, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load all of the contracts
+	err = contracts.loadContracts(contracts.getContractArtifacts())
+	if err != nil {
+		return nil, err
+	}
+
+	err = contracts.getCurrentVersion(rp)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contract version: %w", err)
+	}
+
+	return contracts, nil
+}
+
+// Create a network contracts container with its utility contracts but none of the network contracts loaded
+func newNetworkContractsContainer(rp *rocketpool.RocketPool, multicallerAddress common.Address, balanceBatcherAddress common.Address, pin *multicall.PinnedBlock, opts *bind.CallOpts) (*NetworkContracts, error) {
 	// Get the latest block number if it's not provided
 	if opts == nil {
 		latestElBlock, err := rp.Client.BlockNumber(context.Background())
@@ -78,6 +270,10 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 	contracts := &NetworkContracts{
 		RocketStorage: rp.RocketStorageContract,
 		ElBlockNumber: opts.BlockNumber,
+		QueryOptions:  NewDefaultQueryOptions(),
+		rp:            rp,
+		contractNames: map[common.Address]string{},
+		resolved:      map[string]bool{},
 	}
 
 	// Create the multicaller
@@ -86,138 +282,279 @@ func NewNetworkContracts(rp *rocketpool.RocketPool, multicallerAddress common.Ad
 	if err != nil {
 		return nil, err
 	}
+	contracts.Multicaller.Pin = pin
+	contracts.Multicaller.RateLimiter = queryRateLimiter{contracts: contracts}
 
 	// Create the balance batcher
 	contracts.BalanceBatcher, err = multicall.NewBalanceBatcher(rp.Client, balanceBatcherAddress)
 	if err != nil {
 		return nil, err
 	}
+	contracts.BalanceBatcher.Pin = pin
+	contracts.BalanceBatcher.RateLimiter = queryRateLimiter{contracts: contracts}
 
+	// Create the token balance batcher
+	contracts.TokenBalanceBatcher = multicall.NewTokenBalanceBatcher(rp.Client, multicallerAddress)
+	contracts.TokenBalanceBatcher.Pin = pin
+	contracts.TokenBalanceBatcher.RateLimiter = queryRateLimiter{contracts: contracts}
+
+	return contracts, nil
+}
+
+// Get the artifacts of every network contract the container holds
+func (c *NetworkContracts) getContractArtifacts() []contractArtifacts {
 	// Create the contract wrappers for Redstone
 	wrappers := []contractArtifacts{
 		{
 			name:     "rocketDAONodeTrusted",
-			contract: &contracts.RocketDAONodeTrusted,
+			contract: &c.RocketDAONodeTrusted,
 		}, {
 			name:     "rocketDAONodeTrustedSettingsMinipool",
-			contract: &contracts.RocketDAONodeTrustedSettingsMinipool,
+			contract: &c.RocketDAONodeTrustedSettingsMinipool,
 		}, {
 			name:     "rocketDAOProtocolSettingsMinipool",
-			contract: &contracts.RocketDAOProtocolSettingsMinipool,
+			contract: &c.RocketDAOProtocolSettingsMinipool,
 		}, {
 			name:     "rocketDAOProtocolSettingsNetwork",
-			contract: &contracts.RocketDAOProtocolSettingsNetwork,
+			contract: &c.RocketDAOProtocolSettingsNetwork,
 		}, {
 			name:     "rocketDAOProtocolSettingsNode",
-			contract: &contracts.RocketDAOProtocolSettingsNode,
+			contract: &c.RocketDAOProtocolSettingsNode,
 		}, {
 			name:     "rocketDepositPool",
-			contract: &contracts.RocketDepositPool,
+			contract: &c.RocketDepositPool,
 		}, {
 			name:     "rocketMinipoolManager",
-			contract: &contracts.RocketMinipoolManager,
+			contract: &c.RocketMinipoolManager,
 		}, {
 			name:     "rocketMinipoolQueue",
-			contract: &contracts.RocketMinipoolQueue,
+			contract: &c.RocketMinipoolQueue,
 		}, {
 			name:     "rocketNetworkBalances",
-			contract: &contracts.RocketNetworkBalances,
+			contract: &c.RocketNetworkBalances,
 		}, {
 			name:     "rocketNetworkFees",
-			contract: &contracts.RocketNetworkFees,
+			contract: &c.RocketNetworkFees,
 		}, {
 			name:     "rocketNetworkPrices",
-			contract: &contracts.RocketNetworkPrices,
+			contract: &c.RocketNetworkPrices,
 		}, {
 			name:     "rocketNodeDeposit",
-			contract: &contracts.RocketNodeDeposit,
+			contract: &c.RocketNodeDeposit,
 		}, {
 			name:     "rocketNodeDistributorFactory",
-			contract: &contracts.RocketNodeDistributorFactory,
+			contract: &c.RocketNodeDistributorFactory,
 		}, {
 			name:     "rocketNodeManager",
-			contract: &contracts.RocketNodeManager,
+			contract: &c.RocketNodeManager,
 		}, {
 			name:     "rocketNodeStaking",
-			contract: &contracts.RocketNodeStaking,
+			contract: &c.RocketNodeStaking,
 		}, {
 			name:     "rocketRewardsPool",
-			contract: &contracts.RocketRewardsPool,
+			contract: &c.RocketRewardsPool,
 		}, {
 			name:     "rocketSmoothingPool",
-			contract: &contracts.RocketSmoothingPool,
+			contract: &c.RocketSmoothingPool,
 		}, {
 			name:     "rocketTokenRETH",
-			contract: &contracts.RocketTokenRETH,
+			contract: &c.RocketTokenRETH,
 		}, {
 			name:     "rocketTokenRPL",
-			contract: &contracts.RocketTokenRPL,
+			contract: &c.RocketTokenRPL,
 		}, {
 			name:     "rocketTokenRPLFixedSupply",
-			contract: &contracts.RocketTokenRPLFixedSupply,
+			contract: &c.RocketTokenRPLFixedSupply,
 		},
 	}
+	for i := range wrappers {
+		wrappers[i].upgrade = "Redstone"
+	}
 
 	// Atlas wrappers
 	wrappers = append(wrappers, contractArtifacts{
 		name:     "rocketMinipoolBondReducer",
-		contract: &contracts.RocketMinipoolBondReducer,
+		upgrade:  "Atlas",
+		contract: &c.RocketMinipoolBondReducer,
 	})
 
 	// Houston wrappers
 	wrappers = append(wrappers, contractArtifacts{
 		name:     "rocketDAOProtocolProposal",
-		contract: &contracts.RocketDAOProtocolProposal,
+		upgrade:  "Houston",
+		contract: &c.RocketDAOProtocolProposal,
 	}, contractArtifacts{
 		name:     "rocketDAOProtocolVerifier",
-		contract: &contracts.RocketDAOProtocolVerifier,
+		upgrade:  "Houston",
+		contract: &c.RocketDAOProtocolVerifier,
 	})
 
+	return wrappers
+}
+
+// Make sure the given network contracts are loaded, loading any that haven't been yet
+// Returns a ContractUnavailableError for the first contract that wasn't deployed at the container's block
+func (c *NetworkContracts) Require(names ...string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Get the contracts that haven't been resolved yet
+	artifacts := map[string]contractArtifacts{}
+	for _, wrapper := range c.getContractArtifacts() {
+		artifacts[wrapper.name] = wrapper
+	}
+	wrappers := []contractArtifacts{}
+	for _, name := range names {
+		wrapper, exists := artifacts[name]
+		if !exists {
+			return fmt.Errorf("%s is not a network contract held by the container", name)
+		}
+		if !c.resolved[name] {
+			wrappers = append(wrappers, wrapper)
+		}
+	}
+
+	// Load them
+	if len(wrappers) > 0 {
+		err := c.loadContracts(wrappers)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check that they exist
+	for _, name := range names {
+		wrapper := artifacts[name]
+		if *wrapper.contract == nil {
+			return &ContractUnavailableError{
+				Name:        name,
+				Upgrade:     wrapper.upgrade,
+				BlockNumber: c.ElBlockNumber,
+			}
+		}
+	}
+	return nil
+}
+
+// Load the addresses and ABIs of network contracts with a single multicall, and set them in the container
+// Contracts without an address at the container's block are left nil
+func (c *NetworkContracts) loadContracts(wrappers []contractArtifacts) error {
+	mc, err := c.newMultiCaller(c.rp)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: c.ElBlockNumber,
+	}
+
 	// Add the address and ABI getters to multicall
 	for i, wrapper := range wrappers {
 		// Add the address getter
-		contracts.Multicaller.AddCall(contracts.RocketStorage, &wrappers[i].address, "getAddress", [32]byte(crypto.Keccak256Hash([]byte("contract.address"), []byte(wrapper.name))))
+		mc.AddCall(c.RocketStorage, &wrappers[i].address, "getAddress", [32]byte(storage.ContractAddressKey(wrapper.name)))
 
 		// Add the ABI getter
-		contracts.Multicaller.AddCall(contracts.RocketStorage, &wrappers[i].abiEncoded, "getString", [32]byte(crypto.Keccak256Hash([]byte("contract.abi"), []byte(wrapper.name))))
+		mc.AddCall(c.RocketStorage, &wrappers[i].abiEncoded, "getString", [32]byte(storage.ContractAbiKey(wrapper.name)))
 	}
 
 	// Run the multi-getter
-	_, err = contracts.Multicaller.FlexibleCall(true, opts)
+	_, err = c.executeBatch(mc, true, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error executing multicall for contract retrieval: %w", err)
+		return fmt.Errorf("error executing multicall for contract retrieval: %w", err)
 	}
 
 	// Postprocess the contracts
 	for i, wrapper := range wrappers {
+		c.resolved[wrapper.name] = true
+		if wrapper.address == (common.Address{}) {
+			continue
+		}
+
 		// Decode the ABI
 		abi, err := rocketpool.DecodeAbi(wrapper.abiEncoded)
 		if err != nil {
-			return nil, fmt.Errorf("error decoding ABI for %s: %w", wrapper.name, err)
+			return fmt.Errorf("error decoding ABI for %s: %w", wrapper.name, err)
 		}
 
 		// Create the contract binding
 		contract := &rocketpool.Contract{
-			Contract: bind.NewBoundContract(wrapper.address, *abi, rp.Client, rp.Client, rp.Client),
+			Contract: bind.NewBoundContract(wrapper.address, *abi, c.rp.Client, c.rp.Client, c.rp.Client),
 			Address:  &wrappers[i].address,
 			ABI:      abi,
-			Client:   rp.Client,
+			Client:   c.rp.Client,
+			Name:     wrapper.name,
 		}
 
 		// Set the contract in the main wrapper object
 		*wrappers[i].contract = contract
+		c.contractNames[wrapper.address] = wrapper.name
+		if c.Multicaller.Profiler != nil {
+			c.Multicaller.Profiler.SetContractName(wrapper.address, wrapper.name)
+		}
 	}
+	return nil
+}
 
-	err = contracts.getCurrentVersion(rp)
+// Load the Snapshot signalling address registry, so the node details include each node's signalling address
+func (c *NetworkContracts) SetSignerRegistry(rp *rocketpool.RocketPool, address common.Address) error {
+	registry, err := snapshot.NewSignerRegistry(address, rp.Client)
 	if err != nil {
-		return nil, fmt.Errorf("error getting network contract version: %w", err)
+		return err
+	}
+	c.SignerRegistry = registry
+	c.contractNames[address] = "rocketSignerRegistry"
+	if c.Multicaller.Profiler != nil {
+		c.Multicaller.Profiler.SetContractName(address, "rocketSignerRegistry")
 	}
+	return nil
+}
 
-	return contracts, nil
+// Create a multicaller for a single batch, locked to the same block as the container's multicaller
+func (c *NetworkContracts) newMultiCaller(rp *rocketpool.RocketPool) (*multicall.MultiCaller, error) {
+	mc, err := multicall.NewMultiCaller(rp.Client, c.Multicaller.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	mc.Pin = c.Multicaller.Pin
+	mc.Profiler = c.Multicaller.Profiler
+	mc.RateLimiter = c.Multicaller.RateLimiter
+	return mc, nil
+}
+
+// Execute a batch of calls through an adaptive batcher locked to the same block as the container's multicaller, so a
+// batch the provider rejects as too large is split and retried instead of failing the whole getter
+// The batcher applies the container's rate limit to every request it sends, including the retries
+func (c *NetworkContracts) executeBatch(mc *multicall.MultiCaller, requireSuccess bool, opts *bind.CallOpts) ([]multicall.Result, error) {
+	batcher := multicall.NewAdaptiveBatcher(c.rp.Client, c.Multicaller.ContractAddress)
+	batcher.Pin = c.Multicaller.Pin
+	batcher.Profiler = c.Multicaller.Profiler
+	batcher.RateLimiter = c.Multicaller.RateLimiter
+	batcher.ThreadLimit = 1 // The getters already run their batches concurrently
+	batcher.AddCallsFrom(mc)
+	return batcher.FlexibleCall(requireSuccess, opts)
+}
+
+// Enable the multicall profiler for every bulk getter that uses the container, and get it for reporting
+// Calls made before this is called aren't recorded
+func (c *NetworkContracts) EnableProfiler() *multicall.Profiler {
+	if c.Multicaller.Profiler != nil {
+		return c.Multicaller.Profiler
+	}
+	profiler := multicall.NewProfiler()
+	profiler.SetContractName(*c.RocketStorage.Address, "rocketStorage")
+	for address, name := range c.contractNames {
+		profiler.SetContractName(address, name)
+	}
+	c.Multicaller.Profiler = profiler
+	c.TokenBalanceBatcher.Profiler = profiler
+	return profiler
 }
 
 // Get the current version of the network
 func (c *NetworkContracts) getCurrentVersion(rp *rocketpool.RocketPool) error {
+	err := c.Require("rocketNodeStaking", "rocketNodeManager")
+	if err != nil {
+		return err
+	}
 	opts := &bind.CallOpts{
 		BlockNumber: c.ElBlockNumber,
 	}
@@ -0,0 +1,153 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Typical gas used by distributeBalance, used to estimate the cost of each distribution
+const (
+	SkimDistributionGas uint64 = 100000
+	ExitDistributionGas uint64 = 150000
+)
+
+// Minipool balances of at least this much are treated as a full exit by distributeBalance
+// The contract compares its totalBalance, which is its ETH balance minus the node refund, so this is compared to a
+// minipool's DistributableBalance rather than its full balance
+var exitDistributionThreshold = big.NewInt(0).Mul(big.NewInt(8), oneEth)
+
+// The kind of balance distribution
+type DistributionType string
+
+const (
+	DistributionType_Skim DistributionType = "skim" // Beacon Chain rewards (partial withdrawals)
+	DistributionType_Exit DistributionType = "exit" // The validator's full balance after it exited
+)
+
+// A potential distribution of a minipool's balance
+type DistributionCandidate struct {
+	MinipoolAddress      common.Address   `json:"minipool_address"`
+	NodeAddress          common.Address   `json:"node_address"`
+	Type                 DistributionType `json:"type"`
+	DistributableBalance *big.Int         `json:"distributable_balance"`
+	NodeShare            *big.Int         `json:"node_share"`
+	NodeRefund           *big.Int         `json:"node_refund"`
+	NodeTotal            *big.Int         `json:"node_total"` // The node share plus the refund
+	UserShare            *big.Int         `json:"user_share"`
+	GasLimit             uint64           `json:"gas_limit"`
+	GasCost              *big.Int         `json:"gas_cost"`
+	Profit               *big.Int         `json:"profit"` // The node total minus the gas cost; may be negative
+}
+
+// A minipool that can't be distributed
+type SkippedDistribution struct {
+	MinipoolAddress common.Address `json:"minipool_address"`
+	Reason          string         `json:"reason"`
+}
+
+// A plan of which minipool distributions are worth submitting at a gas price
+type DistributionPlan struct {
+	GasPrice       *big.Int                `json:"gas_price"`
+	Profitable     []DistributionCandidate `json:"profitable"`   // Sorted by profit, highest first
+	Unprofitable   []DistributionCandidate `json:"unprofitable"` // Sorted by profit, highest first
+	Skipped        []SkippedDistribution   `json:"skipped"`
+	TotalNodeTotal *big.Int                `json:"total_node_total"` // Across the profitable distributions
+	TotalGasCost   *big.Int                `json:"total_gas_cost"`   // Across the profitable distributions
+}
+
+// Plan the balance distributions of a set of minipools at the given gas price (in wei)
+// beaconBalances are the Beacon Chain balances of each minipool's validator, in wei; a minipool whose contract balance
+// looks like a full exit while its validator still has a Beacon balance is skipped, since distributing it would
+// treat the rewards as the validator's full balance. A nil Beacon balance is unknown, so such minipools are skipped too.
+// The shares come from NativeMinipoolDetails, so the details must have been loaded with their balance shares
+func PlanMinipoolDistributions(minipoolDetails []*NativeMinipoolDetails, beaconBalances []*big.Int, gasPrice *big.Int) (*DistributionPlan, error) {
+	if len(minipoolDetails) != len(beaconBalances) {
+		return nil, fmt.Errorf("got %d minipools but %d beacon balances", len(minipoolDetails), len(beaconBalances))
+	}
+
+	plan := &DistributionPlan{
+		GasPrice:       big.NewInt(0).Set(gasPrice),
+		Profitable:     []DistributionCandidate{},
+		Unprofitable:   []DistributionCandidate{},
+		Skipped:        []SkippedDistribution{},
+		TotalNodeTotal: big.NewInt(0),
+		TotalGasCost:   big.NewInt(0),
+	}
+	for i, details := range minipoolDetails {
+		candidate, reason := getDistributionCandidate(details, beaconBalances[i], gasPrice)
+		if reason != "" {
+			plan.Skipped = append(plan.Skipped, SkippedDistribution{
+				MinipoolAddress: details.MinipoolAddress,
+				Reason:          reason,
+			})
+			continue
+		}
+		if candidate.Profit.Sign() > 0 {
+			plan.Profitable = append(plan.Profitable, candidate)
+			plan.TotalNodeTotal.Add(plan.TotalNodeTotal, candidate.NodeTotal)
+			plan.TotalGasCost.Add(plan.TotalGasCost, candidate.GasCost)
+		} else {
+			plan.Unprofitable = append(plan.Unprofitable, candidate)
+		}
+	}
+
+	sortByProfit(plan.Profitable)
+	sortByProfit(plan.Unprofitable)
+	return plan, nil
+}
+
+// Get a minipool's distribution, or the reason it can't be distributed
+func getDistributionCandidate(details *NativeMinipoolDetails, beaconBalance *big.Int, gasPrice *big.Int) (DistributionCandidate, string) {
+	if details.Version < 3 {
+		return DistributionCandidate{}, fmt.Sprintf("minipool version %d does not support distributing its balance", details.Version)
+	}
+	if details.Status != types.Staking {
+		return DistributionCandidate{}, fmt.Sprintf("minipool is in %s status", details.Status.String())
+	}
+	if details.Finalised {
+		return DistributionCandidate{}, "minipool is already finalised"
+	}
+	if details.DistributableBalance == nil || details.NodeShareOfBalance == nil || details.UserShareOfBalance == nil {
+		return DistributionCandidate{}, "minipool balance shares have not been loaded"
+	}
+	if details.DistributableBalance.Sign() <= 0 {
+		return DistributionCandidate{}, "minipool has no balance to distribute"
+	}
+
+	candidate := DistributionCandidate{
+		MinipoolAddress:      details.MinipoolAddress,
+		NodeAddress:          details.NodeAddress,
+		Type:                 DistributionType_Skim,
+		DistributableBalance: details.DistributableBalance,
+		NodeShare:            details.NodeShareOfBalance,
+		NodeRefund:           details.NodeRefundBalance,
+		UserShare:            details.UserShareOfBalance,
+		GasLimit:             SkimDistributionGas,
+	}
+	if details.DistributableBalance.Cmp(exitDistributionThreshold) >= 0 {
+		if beaconBalance == nil {
+			return DistributionCandidate{}, "minipool balance looks like a full exit but its validator's Beacon Chain balance is unknown"
+		}
+		if beaconBalance.Sign() > 0 {
+			return DistributionCandidate{}, "minipool balance looks like a full exit but its validator still has a Beacon Chain balance"
+		}
+		candidate.Type = DistributionType_Exit
+		candidate.GasLimit = ExitDistributionGas
+	}
+
+	candidate.NodeTotal = big.NewInt(0).Add(candidate.NodeShare, candidate.NodeRefund)
+	candidate.GasCost = big.NewInt(0).Mul(gasPrice, big.NewInt(0).SetUint64(candidate.GasLimit))
+	candidate.Profit = big.NewInt(0).Sub(candidate.NodeTotal, candidate.GasCost)
+	return candidate, ""
+}
+
+// Sort distribution candidates by profit, highest first
+func sortByProfit(candidates []DistributionCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Profit.Cmp(candidates[j].Profit) > 0
+	})
+}
@@ -0,0 +1,181 @@
+package state
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Denominator for converting wei to ETH
+var weiPerEth = big.NewInt(1e18)
+
+// A single flattened column of an exported record
+type exportField struct {
+	name  string
+	value interface{}
+}
+
+// Write minipool details as CSV, with one row per minipool
+func WriteMinipoolDetailsCsv(w io.Writer, details []NativeMinipoolDetails) error {
+	return writeCsv(w, details)
+}
+
+// Write node details as CSV, with one row per node
+func WriteNodeDetailsCsv(w io.Writer, details []NativeNodeDetails) error {
+	return writeCsv(w, details)
+}
+
+// Write Oracle DAO member details as CSV, with one row per member
+func WriteOracleDaoMemberDetailsCsv(w io.Writer, details []OracleDaoMemberDetails) error {
+	return writeCsv(w, details)
+}
+
+// Serialize minipool details to flat JSON records
+func MarshalMinipoolDetailsJson(details []NativeMinipoolDetails) ([]byte, error) {
+	return marshalJson(details)
+}
+
+// Serialize node details to flat JSON records
+func MarshalNodeDetailsJson(details []NativeNodeDetails) ([]byte, error) {
+	return marshalJson(details)
+}
+
+// Serialize Oracle DAO member details to flat JSON records
+func MarshalOracleDaoMemberDetailsJson(details []OracleDaoMemberDetails) ([]byte, error) {
+	return marshalJson(details)
+}
+
+// Write a slice of structs as CSV, using the flattened fields of the first element as the header
+func writeCsv(w io.Writer, records interface{}) error {
+	writer := csv.NewWriter(w)
+	slice := reflect.ValueOf(records)
+	for i := 0; i < slice.Len(); i++ {
+		fields := getExportFields(slice.Index(i))
+		if i == 0 {
+			header := make([]string, len(fields))
+			for j, field := range fields {
+				header[j] = field.name
+			}
+			if err := writer.Write(header); err != nil {
+				return fmt.Errorf("error writing CSV header: %w", err)
+			}
+		}
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = formatCsvValue(field.value)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("error writing CSV row %d: %w", i, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Serialize a slice of structs as an array of flat JSON objects
+func marshalJson(records interface{}) ([]byte, error) {
+	slice := reflect.ValueOf(records)
+	objects := make([]map[string]interface{}, slice.Len())
+	for i := range objects {
+		fields := getExportFields(slice.Index(i))
+		objects[i] = make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			objects[i][field.name] = field.value
+		}
+	}
+	return json.Marshal(objects)
+}
+
+// Flatten the exported fields of a struct into named columns
+// big.Int values are split into a wei column (exact integer string) and an ETH column (exact decimal string)
+func getExportFields(record reflect.Value) []exportField {
+	recordType := record.Type()
+	fields := []exportField{}
+	for i := 0; i < recordType.NumField(); i++ {
+		fieldType := recordType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		name := getExportName(fieldType)
+		if name == "" {
+			continue
+		}
+
+		value := record.Field(i).Interface()
+		switch typedValue := value.(type) {
+		case *big.Int:
+			fields = append(fields,
+				exportField{name: name + "_wei", value: formatWei(typedValue)},
+				exportField{name: name + "_eth", value: formatEth(typedValue)},
+			)
+		case time.Time:
+			fields = append(fields, exportField{name: name, value: formatTime(typedValue)})
+		case time.Duration:
+			fields = append(fields, exportField{name: name, value: typedValue.Seconds()})
+		case fmt.Stringer:
+			fields = append(fields, exportField{name: name, value: typedValue.String()})
+		default:
+			fields = append(fields, exportField{name: name, value: value})
+		}
+	}
+	return fields
+}
+
+// Get the export name of a field from its JSON tag, falling back to the field name; empty if the field is excluded
+func getExportName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Format a wei amount as an integer string
+func formatWei(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+// Format a wei amount as an exact ETH decimal string without trailing zeros
+func formatEth(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	eth := new(big.Rat).SetFrac(value, weiPerEth).FloatString(18)
+	eth = strings.TrimRight(eth, "0")
+	return strings.TrimSuffix(eth, ".")
+}
+
+// Format a time as RFC 3339, leaving zero times empty
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+// Format a flattened value for a CSV cell
+func formatCsvValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
@@ -0,0 +1,167 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The effect of a proposed RPL stake threshold on a single node
+type NodeStakeImpact struct {
+	NodeAddress               common.Address `json:"node_address"`
+	RplStake                  *big.Int       `json:"rpl_stake"`
+	EthMatched                *big.Int       `json:"eth_matched"`
+	EthProvided               *big.Int       `json:"eth_provided"`
+	CurrentThreshold          *big.Int       `json:"current_threshold"`
+	ProposedThreshold         *big.Int       `json:"proposed_threshold"`
+	CurrentEffectiveRplStake  *big.Int       `json:"current_effective_rpl_stake"`
+	ProposedEffectiveRplStake *big.Int       `json:"proposed_effective_rpl_stake"`
+	RplShortfall              *big.Int       `json:"rpl_shortfall"` // RPL the node would need to stake to meet the proposed minimum
+	ActiveMinipools           int            `json:"active_minipools"`
+}
+
+// The estimated effect of changing one of the per-minipool RPL stake settings
+type StakeThresholdImpact struct {
+	CurrentFraction  *big.Int `json:"current_fraction"`
+	ProposedFraction *big.Int `json:"proposed_fraction"`
+	RplPrice         *big.Int `json:"rpl_price"`
+
+	// Nodes whose standing or effective stake would change, with the totals across them
+	AffectedNodes             []NodeStakeImpact `json:"affected_nodes"`
+	AffectedMinipools         int               `json:"affected_minipools"`
+	TotalRplShortfall         *big.Int          `json:"total_rpl_shortfall"`
+	CurrentTotalEffectiveRpl  *big.Int          `json:"current_total_effective_rpl"`
+	ProposedTotalEffectiveRpl *big.Int          `json:"proposed_total_effective_rpl"`
+
+	// Number of nodes with borrowed ETH whose RPL stake is below the minimum under the current and proposed settings
+	NodesBelowCurrentMinimum  int `json:"nodes_below_current_minimum"`
+	NodesBelowProposedMinimum int `json:"nodes_below_proposed_minimum"`
+}
+
+// Estimate the impact of changing the minimum RPL stake per minipool (as a fraction of borrowed ETH, in wei)
+// Affected nodes are the ones that meet the current minimum but would fall below the proposed one, or vice versa
+func (s *NetworkState) EstimateMinimumRplStakeImpact(proposedFraction *big.Int) *StakeThresholdImpact {
+	return s.estimateStakeImpact(true, proposedFraction)
+}
+
+// Estimate the impact of changing the maximum RPL stake per minipool (as a fraction of borrowed ETH, in wei)
+// Affected nodes are the ones whose effective RPL stake would change
+func (s *NetworkState) EstimateMaximumRplStakeImpact(proposedFraction *big.Int) *StakeThresholdImpact {
+	return s.estimateStakeImpact(false, proposedFraction)
+}
+
+// Compare every node's standing under the current settings and with either the minimum or maximum fraction replaced
+// Like the protocol, the minimum is a fraction of the ETH the node has borrowed and the maximum is a fraction of the ETH
+// it has provided
+func (s *NetworkState) estimateStakeImpact(isMinimum bool, proposedFraction *big.Int) *StakeThresholdImpact {
+	rplPrice := s.NetworkDetails.RplPrice
+	currentMin := s.NetworkDetails.MinCollateralFraction
+	currentMax := s.NetworkDetails.MaxCollateralFraction
+	proposedMin := currentMin
+	proposedMax := currentMax
+	currentFraction := currentMax
+	if isMinimum {
+		proposedMin = proposedFraction
+		currentFraction = currentMin
+	} else {
+		proposedMax = proposedFraction
+	}
+	impact := &StakeThresholdImpact{
+		CurrentFraction:           big.NewInt(0).Set(currentFraction),
+		ProposedFraction:          big.NewInt(0).Set(proposedFraction),
+		RplPrice:                  big.NewInt(0).Set(rplPrice),
+		AffectedNodes:             []NodeStakeImpact{},
+		TotalRplShortfall:         big.NewInt(0),
+		CurrentTotalEffectiveRpl:  big.NewInt(0),
+		ProposedTotalEffectiveRpl: big.NewInt(0),
+	}
+
+	for _, node := range s.NodeDetails {
+		if node.EthMatched == nil || node.EthMatched.Sign() == 0 {
+			continue
+		}
+		ethProvided := node.EthProvided
+		if ethProvided == nil {
+			ethProvided = big.NewInt(0)
+		}
+		currentMinStake := getRplStakeForFraction(node.EthMatched, currentMin, rplPrice)
+		proposedMinStake := getRplStakeForFraction(node.EthMatched, proposedMin, rplPrice)
+		currentMaxStake := getRplStakeForFraction(ethProvided, currentMax, rplPrice)
+		proposedMaxStake := getRplStakeForFraction(ethProvided, proposedMax, rplPrice)
+		currentEffective := getEffectiveRplStake(node.RplStake, currentMinStake, currentMaxStake)
+		proposedEffective := getEffectiveRplStake(node.RplStake, proposedMinStake, proposedMaxStake)
+		impact.CurrentTotalEffectiveRpl.Add(impact.CurrentTotalEffectiveRpl, currentEffective)
+		impact.ProposedTotalEffectiveRpl.Add(impact.ProposedTotalEffectiveRpl, proposedEffective)
+
+		belowCurrent := node.RplStake.Cmp(currentMinStake) < 0
+		belowProposed := node.RplStake.Cmp(proposedMinStake) < 0
+		if belowCurrent {
+			impact.NodesBelowCurrentMinimum++
+		}
+		if belowProposed {
+			impact.NodesBelowProposedMinimum++
+		}
+		if belowCurrent == belowProposed && currentEffective.Cmp(proposedEffective) == 0 {
+			continue
+		}
+
+		nodeImpact := NodeStakeImpact{
+			NodeAddress:               node.NodeAddress,
+			RplStake:                  node.RplStake,
+			EthMatched:                node.EthMatched,
+			EthProvided:               ethProvided,
+			CurrentEffectiveRplStake:  currentEffective,
+			ProposedEffectiveRplStake: proposedEffective,
+			RplShortfall:              big.NewInt(0),
+			ActiveMinipools:           s.getActiveMinipoolCount(node.NodeAddress),
+		}
+		if isMinimum {
+			nodeImpact.CurrentThreshold = currentMinStake
+			nodeImpact.ProposedThreshold = proposedMinStake
+		} else {
+			nodeImpact.CurrentThreshold = currentMaxStake
+			nodeImpact.ProposedThreshold = proposedMaxStake
+		}
+		if belowProposed {
+			nodeImpact.RplShortfall.Sub(proposedMinStake, node.RplStake)
+			impact.TotalRplShortfall.Add(impact.TotalRplShortfall, nodeImpact.RplShortfall)
+		}
+		impact.AffectedMinipools += nodeImpact.ActiveMinipools
+		impact.AffectedNodes = append(impact.AffectedNodes, nodeImpact)
+	}
+
+	return impact
+}
+
+// Get the number of a node's minipools that haven't been finalised or dissolved
+func (s *NetworkState) getActiveMinipoolCount(nodeAddress common.Address) int {
+	count := 0
+	for _, mpd := range s.MinipoolDetailsByNode[nodeAddress] {
+		if !mpd.Finalised && mpd.Status != types.Dissolved {
+			count++
+		}
+	}
+	return count
+}
+
+// Get the RPL stake that corresponds to a fraction of an amount of ETH at the given RPL price
+func getRplStakeForFraction(ethAmount *big.Int, fraction *big.Int, rplPrice *big.Int) *big.Int {
+	stake := big.NewInt(0)
+	if rplPrice == nil || rplPrice.Sign() == 0 {
+		return stake
+	}
+	stake.Mul(ethAmount, fraction)
+	return stake.Div(stake, rplPrice)
+}
+
+// Get a node's effective RPL stake given its minimum and maximum stake
+func getEffectiveRplStake(rplStake *big.Int, minStake *big.Int, maxStake *big.Int) *big.Int {
+	if rplStake.Cmp(minStake) < 0 {
+		return big.NewInt(0)
+	}
+	if rplStake.Cmp(maxStake) > 0 {
+		return big.NewInt(0).Set(maxStake)
+	}
+	return big.NewInt(0).Set(rplStake)
+}
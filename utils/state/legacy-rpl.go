@@ -0,0 +1,103 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+)
+
+// An address that still holds fixed-supply (legacy) RPL
+type LegacyRPLHolder struct {
+	Address   common.Address `json:"address"`
+	Balance   *big.Int       `json:"balance"`
+	Allowance *big.Int       `json:"allowance"` // The amount the RPL token contract is approved to spend for the swap
+}
+
+// The result of scanning a set of addresses for fixed-supply RPL that hasn't been swapped for new RPL
+type LegacyRPLMigrationReport struct {
+	ScannedAddressCount int               `json:"scanned_address_count"`
+	TotalBalance        *big.Int          `json:"total_balance"`
+	Holders             []LegacyRPLHolder `json:"holders"` // Sorted by balance, largest first
+}
+
+// Get the transactions the holder must send to swap all of their fixed-supply RPL for new RPL
+func (h LegacyRPLHolder) GetSwapTransactions(rp *rocketpool.RocketPool, contracts *NetworkContracts) []rocketpool.BatchTransaction {
+	return tokens.NewSwapFixedSupplyRPLTransactions(rp, *contracts.RocketTokenRPL.Address, h.Balance, h.Allowance)
+}
+
+// Scan every node for fixed-supply RPL that hasn't been swapped
+func GetNodeLegacyRPLMigrationReport(rp *rocketpool.RocketPool, contracts *NetworkContracts) (LegacyRPLMigrationReport, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	err := contracts.Require("rocketTokenRPL", "rocketTokenRPLFixedSupply")
+	if err != nil {
+		return LegacyRPLMigrationReport{}, err
+	}
+	addresses, err := getNodeAddressesFast(rp, contracts, opts)
+	if err != nil {
+		return LegacyRPLMigrationReport{}, fmt.Errorf("error getting node addresses: %w", err)
+	}
+	return GetLegacyRPLMigrationReport(rp, contracts, addresses)
+}
+
+// Scan the given addresses for fixed-supply RPL that hasn't been swapped
+func GetLegacyRPLMigrationReport(rp *rocketpool.RocketPool, contracts *NetworkContracts, addresses []common.Address) (LegacyRPLMigrationReport, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	err := contracts.Require("rocketTokenRPL", "rocketTokenRPLFixedSupply")
+	if err != nil {
+		return LegacyRPLMigrationReport{}, err
+	}
+
+	// Get the balances
+	balances, err := contracts.TokenBalanceBatcher.GetTokenBalances(contracts.RocketTokenRPLFixedSupply, addresses, opts)
+	if err != nil {
+		return LegacyRPLMigrationReport{}, fmt.Errorf("error getting fixed-supply RPL balances: %w", err)
+	}
+	report := LegacyRPLMigrationReport{
+		ScannedAddressCount: len(addresses),
+		TotalBalance:        big.NewInt(0),
+		Holders:             []LegacyRPLHolder{},
+	}
+	for i, balance := range balances {
+		if balance.Sign() > 0 {
+			report.Holders = append(report.Holders, LegacyRPLHolder{
+				Address: addresses[i],
+				Balance: balance,
+			})
+			report.TotalBalance.Add(report.TotalBalance, balance)
+		}
+	}
+
+	// Get the holders' allowances for the swap
+	batchSize := getBatchSize(contracts.getQueryOptions().NodeAddressBatchSize)
+	for i := 0; i < len(report.Holders); i += batchSize {
+		max := i + batchSize
+		if max > len(report.Holders) {
+			max = len(report.Holders)
+		}
+		mc, err := contracts.newMultiCaller(rp)
+		if err != nil {
+			return LegacyRPLMigrationReport{}, err
+		}
+		for j := i; j < max; j++ {
+			holder := &report.Holders[j]
+			mc.AddCall(contracts.RocketTokenRPLFixedSupply, &holder.Allowance, "allowance", holder.Address, *contracts.RocketTokenRPL.Address)
+		}
+		if _, err := contracts.executeBatch(mc, true, opts); err != nil {
+			return LegacyRPLMigrationReport{}, fmt.Errorf("error getting fixed-supply RPL allowances: %w", err)
+		}
+	}
+
+	sort.SliceStable(report.Holders, func(i, j int) bool {
+		return report.Holders[i].Balance.Cmp(report.Holders[j].Balance) > 0
+	})
+	return report, nil
+}
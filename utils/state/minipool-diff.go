@@ -0,0 +1,260 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+)
+
+// A minipool whose status changed between two blocks
+type MinipoolStatusChange struct {
+	MinipoolAddress common.Address       `json:"minipool_address"`
+	PreviousStatus  types.MinipoolStatus `json:"previous_status"`
+	Status          types.MinipoolStatus `json:"status"`
+}
+
+// The changes to the minipool set between two blocks
+type MinipoolDiff struct {
+	FromBlock     uint64                 `json:"from_block"`
+	ToBlock       uint64                 `json:"to_block"`
+	Created       []common.Address       `json:"created"`
+	Destroyed     []common.Address       `json:"destroyed"`
+	Dissolved     []common.Address       `json:"dissolved"`
+	Finalised     []common.Address       `json:"finalised"`
+	StatusChanged []MinipoolStatusChange `json:"status_changed"`
+}
+
+// The status of a single minipool at one block
+type minipoolDiffState struct {
+	exists    bool
+	status    uint8
+	finalised bool
+}
+
+// Get the minipools that were created, destroyed, dissolved, finalised or changed status between the blocks of two
+// contract containers, without crawling the whole minipool set at either block.
+// Candidates are found through the minipool manager and minipool events in (from, to]; only those are queried at both blocks.
+// Minipools created after the first block report their status change from Initialised.
+func GetMinipoolDiff(rp *rocketpool.RocketPool, from *NetworkContracts, to *NetworkContracts, intervalSize *big.Int) (MinipoolDiff, error) {
+	fromBlock := from.ElBlockNumber.Uint64()
+	toBlock := to.ElBlockNumber.Uint64()
+	if toBlock < fromBlock {
+		return MinipoolDiff{}, fmt.Errorf("end block %d is before start block %d", toBlock, fromBlock)
+	}
+	diff := MinipoolDiff{
+		FromBlock:     fromBlock,
+		ToBlock:       toBlock,
+		Created:       []common.Address{},
+		Destroyed:     []common.Address{},
+		Dissolved:     []common.Address{},
+		Finalised:     []common.Address{},
+		StatusChanged: []MinipoolStatusChange{},
+	}
+	if toBlock == fromBlock {
+		return diff, nil
+	}
+	err := to.Require("rocketMinipoolManager")
+	if err != nil {
+		return MinipoolDiff{}, err
+	}
+	startBlock := big.NewInt(0).SetUint64(fromBlock + 1)
+	endBlock := big.NewInt(0).SetUint64(toBlock)
+
+	// Get the created and destroyed minipools from the manager, including its previous deployments
+	managerAbi := to.RocketMinipoolManager.ABI
+	createdEvent, hasCreated := managerAbi.Events["MinipoolCreated"]
+	destroyedEvent, hasDestroyed := managerAbi.Events["MinipoolDestroyed"]
+	if !hasCreated || !hasDestroyed {
+		return MinipoolDiff{}, fmt.Errorf("minipool manager ABI is missing the MinipoolCreated or MinipoolDestroyed event")
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: to.ElBlockNumber,
+	}
+	managerLogs, err := eth.FilterContractLogs(rp, "rocketMinipoolManager", eth.FilterQuery{
+		FromBlock: startBlock,
+		ToBlock:   endBlock,
+		Topics:    [][]common.Hash{{createdEvent.ID, destroyedEvent.ID}},
+	}, intervalSize, opts)
+	if err != nil {
+		return MinipoolDiff{}, fmt.Errorf("error getting minipool manager logs: %w", err)
+	}
+
+	created := map[common.Address]bool{}
+	candidates := map[common.Address]bool{}
+	for _, log := range managerLogs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		address := common.BytesToAddress(log.Topics[1].Bytes())
+		switch log.Topics[0] {
+		case createdEvent.ID:
+			if !created[address] {
+				diff.Created = append(diff.Created, address)
+			}
+			created[address] = true
+		case destroyedEvent.ID:
+			diff.Destroyed = append(diff.Destroyed, address)
+		}
+		candidates[address] = true
+	}
+
+	// Get the minipools that emitted status or withdrawal events; the latest minipool ABI carries both
+	mp, err := minipool.NewMinipoolFromVersion(rp, common.Address{}, 3, opts)
+	if err != nil {
+		return MinipoolDiff{}, fmt.Errorf("error creating minipool binding: %w", err)
+	}
+	eventIDs := []common.Hash{}
+	for _, name := range []string{"StatusUpdated", "EtherWithdrawalProcessed"} {
+		if event, exists := mp.GetContract().ABI.Events[name]; exists {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+	minipoolLogs, err := eth.GetLogs(rp, nil, [][]common.Hash{eventIDs}, intervalSize, startBlock, endBlock, nil)
+	if err != nil {
+		return MinipoolDiff{}, fmt.Errorf("error getting minipool logs: %w", err)
+	}
+	for _, log := range minipoolLogs {
+		candidates[log.Address] = true
+	}
+
+	// Get the state of every candidate at both blocks; events from contracts that aren't minipools fall out here
+	addresses := make([]common.Address, 0, len(candidates))
+	for address := range candidates {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	var wg errgroup.Group
+	var previousStates []minipoolDiffState
+	var states []minipoolDiffState
+	wg.Go(func() error {
+		var err error
+		previousStates, err = getMinipoolDiffStates(rp, from, addresses)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		states, err = getMinipoolDiffStates(rp, to, addresses)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return MinipoolDiff{}, err
+	}
+
+	// Compare the states
+	for i, address := range addresses {
+		previous := previousStates[i]
+		current := states[i]
+		if !current.exists {
+			continue
+		}
+		if !previous.exists {
+			previous = minipoolDiffState{
+				status: uint8(types.Initialized),
+			}
+		}
+		if current.status != previous.status {
+			diff.StatusChanged = append(diff.StatusChanged, MinipoolStatusChange{
+				MinipoolAddress: address,
+				PreviousStatus:  types.MinipoolStatus(previous.status),
+				Status:          types.MinipoolStatus(current.status),
+			})
+			if types.MinipoolStatus(current.status) == types.Dissolved {
+				diff.Dissolved = append(diff.Dissolved, address)
+			}
+		}
+		if current.finalised && !previous.finalised {
+			diff.Finalised = append(diff.Finalised, address)
+		}
+	}
+	return diff, nil
+}
+
+// Get the existence, status and finalised flag of a set of minipools at the block of a contract container
+func getMinipoolDiffStates(rp *rocketpool.RocketPool, contracts *NetworkContracts, addresses []common.Address) ([]minipoolDiffState, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+
+	// Sync
+	var wg errgroup.Group
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
+
+	// Check which candidates exist first, since calling a contract that isn't a minipool would fail
+	count := len(addresses)
+	states := make([]minipoolDiffState, count)
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
+		i := i
+		max := i + batchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := contracts.newMultiCaller(rp)
+			if err != nil {
+				return err
+			}
+			for j := i; j < max; j++ {
+				mc.AddCall(contracts.RocketMinipoolManager, &states[j].exists, "getMinipoolExists", addresses[j])
+			}
+			if _, err := contracts.executeBatch(mc, true, opts); err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error checking minipool existence: %w", err)
+	}
+
+	// Get the status of the minipools that exist
+	existing := []int{}
+	for i, state := range states {
+		if state.exists {
+			existing = append(existing, i)
+		}
+	}
+	count = len(existing)
+	for i := 0; i < count; i += batchSize {
+		i := i
+		max := i + batchSize
+		if max > count {
+			max = count
+		}
+
+		wg.Go(func() error {
+			mc, err := contracts.newMultiCaller(rp)
+			if err != nil {
+				return err
+			}
+			for _, index := range existing[i:max] {
+				mp, err := minipool.NewMinipoolFromVersion(rp, addresses[index], 3, opts)
+				if err != nil {
+					return fmt.Errorf("error creating minipool binding for %s: %w", addresses[index].Hex(), err)
+				}
+				mc.AddCall(mp.GetContract(), &states[index].status, "getStatus")
+				mc.AddCall(mp.GetContract(), &states[index].finalised, "getFinalised")
+			}
+			if _, err := contracts.executeBatch(mc, true, opts); err != nil {
+				return fmt.Errorf("error executing multicall: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting minipool statuses: %w", err)
+	}
+
+	return states, nil
+}
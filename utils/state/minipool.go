@@ -7,21 +7,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	minipoolBatchSize              int = 100
-	minipoolCompleteShareBatchSize int = 500
-	minipoolAddressBatchSize       int = 1000
-	minipoolVersionBatchSize       int = 500
-)
-
 // Complete details for a minipool
 type NativeMinipoolDetails struct {
 	// Redstone
@@ -96,11 +89,26 @@ func (details *NativeMinipoolDetails) IsEligibleForBonuses(eligibleEnd time.Time
 	return lastBondReductionTime.Before(eligibleEnd)
 }
 
+// Get a bond reducer for the minipool from its details and the network's bond reduction window
+func (details *NativeMinipoolDetails) GetBondReducer(rp *rocketpool.RocketPool, network *NetworkDetails) *minipool.BondReducer {
+	reduceBondTime := time.Unix(0, 0)
+	if details.ReduceBondTime != nil {
+		reduceBondTime = time.Unix(details.ReduceBondTime.Int64(), 0)
+	}
+	return minipool.NewBondReducerFromDetails(rp, details.MinipoolAddress, details.Status, details.Finalised,
+		reduceBondTime, details.ReduceBondCancelled, details.ReduceBondValue,
+		network.BondReductionWindowStart, network.BondReductionWindowLength)
+}
+
 // Gets the details for a minipool using the efficient multicall contract
 func GetNativeMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts, minipoolAddress common.Address) (NativeMinipoolDetails, error) {
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketMinipoolManager")
+	if err != nil {
+		return NativeMinipoolDetails{}, err
+	}
 
 	details := NativeMinipoolDetails{}
 	details.MinipoolAddress = minipoolAddress
@@ -110,6 +118,12 @@ func GetNativeMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContr
 		return NativeMinipoolDetails{}, fmt.Errorf("error getting minipool version: %w", err)
 	}
 	details.Version = version
+	if version >= 3 {
+		err = contracts.Require("rocketMinipoolBondReducer")
+		if err != nil {
+			return NativeMinipoolDetails{}, err
+		}
+	}
 	addMinipoolDetailsCalls(rp, contracts, contracts.Multicaller, &details, opts)
 
 	_, err = contracts.Multicaller.FlexibleCall(true, opts)
@@ -127,6 +141,10 @@ func GetNodeNativeMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkC
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketMinipoolManager")
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the list of minipool addresses for this node
 	addresses, err := getNodeMinipoolAddressesFast(rp, contracts, nodeAddress, opts)
@@ -149,6 +167,10 @@ func GetAllNativeMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkCo
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketMinipoolManager")
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the list of all minipool addresses
 	addresses, err := getAllMinipoolAddressesFast(rp, contracts, opts)
@@ -173,18 +195,19 @@ func CalculateCompleteMinipoolShares(rp *rocketpool.RocketPool, contracts *Netwo
 	}
 
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	count := len(minipoolDetails)
-	for i := 0; i < count; i += minipoolCompleteShareBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolCompleteShareBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolCompleteShareBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -222,7 +245,7 @@ func CalculateCompleteMinipoolShares(rp *rocketpool.RocketPool, contracts *Netwo
 					details.UserShareOfBalanceIncludingBeacon = big.NewInt(0)
 				}
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -276,28 +299,29 @@ func getNodeMinipoolAddressesFast(rp *rocketpool.RocketPool, contracts *NetworkC
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	addresses := make([]common.Address, minipoolCount)
 
 	// Run the getters in batches
 	count := int(minipoolCount)
-	for i := 0; i < count; i += minipoolAddressBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolAddressBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
 			for j := i; j < max; j++ {
 				mc.AddCall(contracts.RocketMinipoolManager, &addresses[j], "getNodeMinipoolAt", nodeAddress, big.NewInt(int64(j)))
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -322,28 +346,29 @@ func getAllMinipoolAddressesFast(rp *rocketpool.RocketPool, contracts *NetworkCo
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	addresses := make([]common.Address, minipoolCount)
 
 	// Run the getters in batches
 	count := int(minipoolCount)
-	for i := 0; i < count; i += minipoolAddressBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolAddressBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
 			for j := i; j < max; j++ {
 				mc.AddCall(contracts.RocketMinipoolManager, &addresses[j], "getMinipoolAt", big.NewInt(int64(j)))
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -362,21 +387,22 @@ func getAllMinipoolAddressesFast(rp *rocketpool.RocketPool, contracts *NetworkCo
 func getMinipoolVersionsFast(rp *rocketpool.RocketPool, contracts *NetworkContracts, addresses []common.Address, opts *bind.CallOpts) ([]uint8, error) {
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 
 	// Run the getters in batches
 	count := len(addresses)
 	versions := make([]uint8, count)
-	for i := 0; i < count; i += minipoolVersionBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolVersionBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolVersionBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -387,7 +413,7 @@ func getMinipoolVersionsFast(rp *rocketpool.RocketPool, contracts *NetworkContra
 				}
 				mc.AddCall(contract, &versions[j], "version")
 			}
-			results, err := mc.FlexibleCall(false, opts) // Allow calls to fail - necessary for Prater
+			results, err := contracts.executeBatch(mc, false, opts) // Allow calls to fail - necessary for Prater
 			for j, result := range results {
 				if !result.Success {
 					versions[j+i] = 1 // Anything that failed the version check didn't have the method yet so it must be v1
@@ -411,6 +437,17 @@ func getMinipoolVersionsFast(rp *rocketpool.RocketPool, contracts *NetworkContra
 func getBulkMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts, addresses []common.Address, versions []uint8, opts *bind.CallOpts) ([]NativeMinipoolDetails, error) {
 	minipoolDetails := make([]NativeMinipoolDetails, len(addresses))
 
+	// v3 minipools need the bond reducer
+	for _, version := range versions {
+		if version >= 3 {
+			err := contracts.Require("rocketMinipoolBondReducer")
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
 	// Get the balances of the minipools
 	balances, err := contracts.BalanceBatcher.GetEthBalances(addresses, opts)
 	if err != nil {
@@ -422,18 +459,19 @@ func getBulkMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContrac
 
 	// Round 1: most of the details
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	count := len(addresses)
-	for i := 0; i < count; i += minipoolBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().MinipoolBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -446,7 +484,7 @@ func getBulkMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContrac
 
 				addMinipoolDetailsCalls(rp, contracts, mc, details, opts)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -461,17 +499,17 @@ func getBulkMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContrac
 
 	// Round 2: NodeShare and UserShare once the refund amount has been populated
 	var wg2 errgroup.Group
-	wg2.SetLimit(threadLimit)
-	for i := 0; i < count; i += minipoolBatchSize {
+	wg2.SetLimit(contracts.getQueryOptions().getThreadLimit())
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg2.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -480,7 +518,7 @@ func getBulkMinipoolDetails(rp *rocketpool.RocketPool, contracts *NetworkContrac
 				details.Version = versions[j]
 				addMinipoolShareCalls(rp, mc, details, opts)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -558,11 +596,8 @@ func addMinipoolDetailsCalls(rp *rocketpool.RocketPool, contracts *NetworkContra
 		mc.AddCall(contracts.RocketMinipoolBondReducer, &details.ReduceBondValue, "getReduceBondValue", address)
 	}
 
-	penaltyCountKey := crypto.Keccak256Hash([]byte("network.penalties.penalty"), address.Bytes())
-	mc.AddCall(contracts.RocketStorage, &details.PenaltyCount, "getUint", penaltyCountKey)
-
-	penaltyRatekey := crypto.Keccak256Hash([]byte("minipool.penalty.rate"), address.Bytes())
-	mc.AddCall(contracts.RocketStorage, &details.PenaltyRate, "getUint", penaltyRatekey)
+	storage.AddGetUintCall(rp, mc, &details.PenaltyCount, storage.MinipoolPenaltyCountKey(address))
+	storage.AddGetUintCall(rp, mc, &details.PenaltyRate, storage.MinipoolPenaltyRateKey(address))
 
 	// Query the minipool manager using the delegate-invariant function
 	mc.AddCall(contracts.RocketMinipoolManager, &details.DepositTypeRaw, "getMinipoolDepositType", address)
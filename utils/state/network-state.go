@@ -0,0 +1,144 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+)
+
+// A complete snapshot of the protocol's state at a single execution block
+type NetworkState struct {
+	// Block info
+	ElBlockNumber uint64    `json:"el_block_number"`
+	Snapshot      *Snapshot `json:"snapshot,omitempty"`
+
+	// Network details, including settings, prices, balances and the current reward interval
+	NetworkDetails *NetworkDetails `json:"network_details"`
+
+	// Node details
+	NodeDetails          []NativeNodeDetails                   `json:"node_details"`
+	NodeDetailsByAddress map[common.Address]*NativeNodeDetails `json:"-"`
+
+	// Minipool details
+	MinipoolDetails          []NativeMinipoolDetails                     `json:"minipool_details"`
+	MinipoolDetailsByAddress map[common.Address]*NativeMinipoolDetails   `json:"-"`
+	MinipoolDetailsByNode    map[common.Address][]*NativeMinipoolDetails `json:"-"`
+
+	// Oracle DAO details
+	OracleDaoMemberDetails []OracleDaoMemberDetails `json:"oracle_dao_member_details"`
+
+	// DAO settings
+	ProtocolDaoSettings *psettings.ProtocolDaoSettingsDetails `json:"protocol_dao_settings"`
+	OracleDaoSettings   *tnsettings.OracleDaoSettingsDetails  `json:"oracle_dao_settings"`
+}
+
+// Create a snapshot of the entire network state at the block the contracts were created for
+func NewNetworkState(rp *rocketpool.RocketPool, contracts *NetworkContracts) (*NetworkState, error) {
+	state := &NetworkState{
+		ElBlockNumber: contracts.ElBlockNumber.Uint64(),
+		Snapshot:      contracts.Snapshot,
+	}
+
+	// Network details
+	var err error
+	state.NetworkDetails, err = NewNetworkDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+
+	// Node details
+	state.NodeDetails, err = GetAllNativeNodeDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting all node details: %w", err)
+	}
+
+	// Minipool details
+	state.MinipoolDetails, err = GetAllNativeMinipoolDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting all minipool details: %w", err)
+	}
+
+	// Oracle DAO details
+	state.OracleDaoMemberDetails, err = GetAllOracleDaoMemberDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Oracle DAO member details: %w", err)
+	}
+
+	// DAO settings
+	state.ProtocolDaoSettings, state.OracleDaoSettings, err = getDaoSettings(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting DAO settings: %w", err)
+	}
+
+	// Create the lookups
+	state.buildLookups()
+
+	// Calculate the average fees and distributor shares of each node
+	for i, node := range state.NodeDetails {
+		err = state.NodeDetails[i].CalculateAverageFeeAndDistributorShares(state.MinipoolDetailsByNode[node.NodeAddress])
+		if err != nil {
+			return nil, fmt.Errorf("error calculating average fee and distributor shares for node %s: %w", node.NodeAddress.Hex(), err)
+		}
+	}
+
+	return state, nil
+}
+
+// Get the Protocol DAO and Oracle DAO settings with a single multicall
+func getDaoSettings(rp *rocketpool.RocketPool, contracts *NetworkContracts) (*psettings.ProtocolDaoSettingsDetails, *tnsettings.OracleDaoSettingsDetails, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	mc, err := contracts.newMultiCaller(rp)
+	if err != nil {
+		return nil, nil, err
+	}
+	protocolSettings := &psettings.ProtocolDaoSettingsDetails{}
+	if err := psettings.AddProtocolDaoSettingsCalls(rp, mc, protocolSettings, opts); err != nil {
+		return nil, nil, err
+	}
+	oracleDaoSettings := &tnsettings.OracleDaoSettingsDetails{}
+	if err := tnsettings.AddOracleDaoSettingsCalls(rp, mc, oracleDaoSettings, opts); err != nil {
+		return nil, nil, err
+	}
+	if _, err := contracts.executeBatch(mc, true, opts); err != nil {
+		return nil, nil, fmt.Errorf("error executing multicall: %w", err)
+	}
+	return protocolSettings, oracleDaoSettings, nil
+}
+
+// Build the address lookups for nodes and minipools
+func (s *NetworkState) buildLookups() {
+	s.NodeDetailsByAddress = make(map[common.Address]*NativeNodeDetails, len(s.NodeDetails))
+	for i, node := range s.NodeDetails {
+		s.NodeDetailsByAddress[node.NodeAddress] = &s.NodeDetails[i]
+	}
+
+	s.MinipoolDetailsByAddress = make(map[common.Address]*NativeMinipoolDetails, len(s.MinipoolDetails))
+	s.MinipoolDetailsByNode = make(map[common.Address][]*NativeMinipoolDetails, len(s.NodeDetails))
+	for i, mpd := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[mpd.MinipoolAddress] = &s.MinipoolDetails[i]
+		s.MinipoolDetailsByNode[mpd.NodeAddress] = append(s.MinipoolDetailsByNode[mpd.NodeAddress], &s.MinipoolDetails[i])
+	}
+}
+
+// Get the details of a node, if it exists in the snapshot
+func (s *NetworkState) GetNodeDetails(nodeAddress common.Address) (*NativeNodeDetails, bool) {
+	details, exists := s.NodeDetailsByAddress[nodeAddress]
+	return details, exists
+}
+
+// Get the details of a minipool, if it exists in the snapshot
+func (s *NetworkState) GetMinipoolDetails(minipoolAddress common.Address) (*NativeMinipoolDetails, bool) {
+	details, exists := s.MinipoolDetailsByAddress[minipoolAddress]
+	return details, exists
+}
+
+// Get the details of all of a node's minipools
+func (s *NetworkState) GetNodeMinipoolDetails(nodeAddress common.Address) []*NativeMinipoolDetails {
+	return s.MinipoolDetailsByNode[nodeAddress]
+}
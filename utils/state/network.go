@@ -10,13 +10,26 @@ import (
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"github.com/rocket-pool/rocketpool-go/utils/multicall"
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	networkEffectiveStakeBatchSize int = 250
-)
+// The network contracts the network details getter uses
+var networkDetailsContracts = []string{
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAOProtocolSettingsMinipool",
+	"rocketDAOProtocolSettingsNetwork",
+	"rocketDAOProtocolSettingsNode",
+	"rocketDepositPool",
+	"rocketMinipoolQueue",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPrices",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+}
 
 type NetworkDetails struct {
 	// Redstone
@@ -71,6 +84,10 @@ func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts) (
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require(networkDetailsContracts...)
+	if err != nil {
+		return nil, err
+	}
 
 	details := &NetworkDetails{}
 
@@ -136,7 +153,7 @@ func NewNetworkDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts) (
 	contracts.Multicaller.AddCall(contracts.RocketDAOProtocolSettingsNetwork, &pricesSubmissionFrequency, "getSubmitPricesFrequency")
 	contracts.Multicaller.AddCall(contracts.RocketDAOProtocolSettingsNetwork, &balancesSubmissionFrequency, "getSubmitBalancesFrequency")
 
-	_, err := contracts.Multicaller.FlexibleCall(true, opts)
+	_, err = contracts.Multicaller.FlexibleCall(true, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error executing multicall: %w", err)
 	}
@@ -185,6 +202,10 @@ func GetTotalEffectiveRplStake(rp *rocketpool.RocketPool, contracts *NetworkCont
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketNodeStaking")
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the list of node addresses
 	addresses, err := getNodeAddressesFast(rp, contracts, opts)
@@ -197,19 +218,20 @@ func GetTotalEffectiveRplStake(rp *rocketpool.RocketPool, contracts *NetworkCont
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 
 	// Run the getters in batches
-	for i := 0; i < count; i += networkEffectiveStakeBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().EffectiveStakeBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + networkEffectiveStakeBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -218,7 +240,7 @@ func GetTotalEffectiveRplStake(rp *rocketpool.RocketPool, contracts *NetworkCont
 				mc.AddCall(contracts.RocketNodeStaking, &minimumStakes[j], "getNodeMinimumRPLStake", address)
 				mc.AddCall(contracts.RocketNodeStaking, &effectiveStakes[j], "getNodeEffectiveRPLStake", address)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -15,10 +15,17 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	legacyNodeBatchSize  int = 100
-	nodeAddressBatchSize int = 1000
-)
+// The network contracts the node details getters use
+var nodeDetailsContracts = []string{
+	"rocketMinipoolManager",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+}
 
 // Complete details for a node
 type NativeNodeDetails struct {
@@ -34,22 +41,29 @@ type NativeNodeDetails struct {
 	MaximumRPLStake                  *big.Int       `json:"maximum_rpl_stake"`
 	EthMatched                       *big.Int       `json:"eth_matched"`
 	EthMatchedLimit                  *big.Int       `json:"eth_matched_limit"`
+	EthProvided                      *big.Int       `json:"eth_provided"` // ETH the node has bonded to its minipools
 	MinipoolCount                    *big.Int       `json:"minipool_count"`
 	BalanceETH                       *big.Int       `json:"balance_eth"`
 	BalanceRETH                      *big.Int       `json:"balance_reth"`
 	BalanceRPL                       *big.Int       `json:"balance_rpl"`
 	BalanceOldRPL                    *big.Int       `json:"balance_old_rpl"`
 	DepositCreditBalance             *big.Int       `json:"deposit_credit_balance"`
+	DepositEthBalance                *big.Int       `json:"deposit_eth_balance"`          // ETH staked on behalf of the node
+	UsableCreditAndBalance           *big.Int       `json:"usable_credit_and_balance"`    // Credit and ETH balance usable for deposits
 	DistributorBalanceUserETH        *big.Int       `json:"distributor_balance_user_eth"` // Must call CalculateAverageFeeAndDistributorShares to get this
 	DistributorBalanceNodeETH        *big.Int       `json:"distributor_balance_node_eth"` // Must call CalculateAverageFeeAndDistributorShares to get this
 	WithdrawalAddress                common.Address `json:"withdrawal_address"`
 	PendingWithdrawalAddress         common.Address `json:"pending_withdrawal_address"`
+	IsRPLWithdrawalAddressSet        bool           `json:"is_rpl_withdrawal_address_set"`
+	RPLWithdrawalAddress             common.Address `json:"rpl_withdrawal_address"`
+	PendingRPLWithdrawalAddress      common.Address `json:"pending_rpl_withdrawal_address"`
 	SmoothingPoolRegistrationState   bool           `json:"smoothing_pool_registration_state"`
 	SmoothingPoolRegistrationChanged *big.Int       `json:"smoothing_pool_registration_changed"`
 	NodeAddress                      common.Address `json:"node_address"`
 	AverageNodeFee                   *big.Int       `json:"average_node_fee"` // Must call CalculateAverageFeeAndDistributorShares to get this
 	CollateralisationRatio           *big.Int       `json:"collateralisation_ratio"`
 	DistributorBalance               *big.Int       `json:"distributor_balance"`
+	SignallingAddress                common.Address `json:"signalling_address"` // Only loaded if the contracts have a signer registry
 }
 
 func timeMax(a, b time.Time) time.Time {
@@ -88,6 +102,10 @@ func GetNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require(nodeDetailsContracts...)
+	if err != nil {
+		return NativeNodeDetails{}, err
+	}
 	details := NativeNodeDetails{
 		NodeAddress:               nodeAddress,
 		AverageNodeFee:            big.NewInt(0),
@@ -97,8 +115,9 @@ func GetNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts
 	}
 
 	addNodeDetailsCalls(contracts, contracts.Multicaller, &details, nodeAddress)
+	addNodeTokenBalanceCalls(contracts, contracts.Multicaller, &details, nodeAddress)
 
-	_, err := contracts.Multicaller.FlexibleCall(true, opts)
+	_, err = contracts.Multicaller.FlexibleCall(true, opts)
 	if err != nil {
 		return NativeNodeDetails{}, fmt.Errorf("error executing multicall: %w", err)
 	}
@@ -131,6 +150,10 @@ func GetAllNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContra
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require(nodeDetailsContracts...)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the list of node addresses
 	addresses, err := getNodeAddressesFast(rp, contracts, opts)
@@ -142,19 +165,20 @@ func GetAllNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContra
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 
 	// Run the getters in batches
-	for i := 0; i < count; i += legacyNodeBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().NodeBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + legacyNodeBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -169,7 +193,7 @@ func GetAllNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContra
 
 				addNodeDetailsCalls(contracts, mc, details, address)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -192,6 +216,21 @@ func GetAllNativeNodeDetails(rp *rocketpool.RocketPool, contracts *NetworkContra
 		distributorAddresses[i] = details.FeeDistributorAddress
 	}
 
+	// Get the token balances of the nodes
+	tokenBalances, err := contracts.TokenBalanceBatcher.GetMultiTokenBalances([]*rocketpool.Contract{
+		contracts.RocketTokenRETH,
+		contracts.RocketTokenRPL,
+		contracts.RocketTokenRPLFixedSupply,
+	}, addresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node token balances: %w", err)
+	}
+	for i := range nodeDetails {
+		nodeDetails[i].BalanceRETH = tokenBalances[0][i]
+		nodeDetails[i].BalanceRPL = tokenBalances[1][i]
+		nodeDetails[i].BalanceOldRPL = tokenBalances[2][i]
+	}
+
 	// Get the balances of the distributors
 	balances, err = contracts.BalanceBatcher.GetEthBalances(distributorAddresses, opts)
 	if err != nil {
@@ -288,28 +327,29 @@ func getNodeAddressesFast(rp *rocketpool.RocketPool, contracts *NetworkContracts
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	addresses := make([]common.Address, nodeCount)
 
 	// Run the getters in batches
 	count := int(nodeCount)
-	for i := 0; i < count; i += nodeAddressBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().NodeAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + nodeAddressBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
 			for j := i; j < max; j++ {
 				mc.AddCall(contracts.RocketNodeManager, &addresses[j], "getNodeAt", big.NewInt(int64(j)))
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -339,9 +379,6 @@ func addNodeDetailsCalls(contracts *NetworkContracts, mc *multicall.MultiCaller,
 	mc.AddCall(contracts.RocketNodeStaking, &details.EthMatched, "getNodeETHMatched", address)
 	mc.AddCall(contracts.RocketNodeStaking, &details.EthMatchedLimit, "getNodeETHMatchedLimit", address)
 	mc.AddCall(contracts.RocketMinipoolManager, &details.MinipoolCount, "getNodeMinipoolCount", address)
-	mc.AddCall(contracts.RocketTokenRETH, &details.BalanceRETH, "balanceOf", address)
-	mc.AddCall(contracts.RocketTokenRPL, &details.BalanceRPL, "balanceOf", address)
-	mc.AddCall(contracts.RocketTokenRPLFixedSupply, &details.BalanceOldRPL, "balanceOf", address)
 	mc.AddCall(contracts.RocketStorage, &details.WithdrawalAddress, "getNodeWithdrawalAddress", address)
 	mc.AddCall(contracts.RocketStorage, &details.PendingWithdrawalAddress, "getNodePendingWithdrawalAddress", address)
 	mc.AddCall(contracts.RocketNodeManager, &details.SmoothingPoolRegistrationState, "getSmoothingPoolRegistrationState", address)
@@ -350,4 +387,23 @@ func addNodeDetailsCalls(contracts *NetworkContracts, mc *multicall.MultiCaller,
 	// Atlas
 	mc.AddCall(contracts.RocketNodeDeposit, &details.DepositCreditBalance, "getNodeDepositCredit", address)
 	mc.AddCall(contracts.RocketNodeStaking, &details.CollateralisationRatio, "getNodeETHCollateralisationRatio", address)
+	mc.AddCall(contracts.RocketNodeStaking, &details.EthProvided, "getNodeETHProvided", address)
+
+	// Houston
+	mc.AddCall(contracts.RocketNodeManager, &details.IsRPLWithdrawalAddressSet, "getNodeRPLWithdrawalAddressIsSet", address)
+	mc.AddCall(contracts.RocketNodeManager, &details.RPLWithdrawalAddress, "getNodeRPLWithdrawalAddress", address)
+	mc.AddCall(contracts.RocketNodeManager, &details.PendingRPLWithdrawalAddress, "getNodePendingRPLWithdrawalAddress", address)
+	mc.AddCall(contracts.RocketNodeDeposit, &details.DepositEthBalance, "getNodeEthBalance", address)
+	mc.AddCall(contracts.RocketNodeDeposit, &details.UsableCreditAndBalance, "getNodeUsableCreditAndBalance", address)
+	if contracts.SignerRegistry != nil {
+		contracts.SignerRegistry.AddNodeToSignerCall(mc, &details.SignallingAddress, address)
+	}
+}
+
+// Add the calls for the node's token balances to the multicaller
+// The bulk getter uses the token balance batcher instead, which fits many more nodes in each multicall
+func addNodeTokenBalanceCalls(contracts *NetworkContracts, mc *multicall.MultiCaller, details *NativeNodeDetails, address common.Address) {
+	mc.AddCall(contracts.RocketTokenRETH, &details.BalanceRETH, "balanceOf", address)
+	mc.AddCall(contracts.RocketTokenRPL, &details.BalanceRPL, "balanceOf", address)
+	mc.AddCall(contracts.RocketTokenRPLFixedSupply, &details.BalanceOldRPL, "balanceOf", address)
 }
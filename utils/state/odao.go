@@ -13,11 +13,6 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	oDaoAddressBatchSize int = 1000
-	oDaoDetailsBatchSize int = 50
-)
-
 type OracleDaoMemberDetails struct {
 	Address             common.Address `json:"address"`
 	Exists              bool           `json:"exists"`
@@ -37,13 +32,17 @@ func GetOracleDaoMemberDetails(rp *rocketpool.RocketPool, contracts *NetworkCont
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketDAONodeTrusted")
+	if err != nil {
+		return OracleDaoMemberDetails{}, err
+	}
 
 	details := OracleDaoMemberDetails{}
 	details.Address = memberAddress
 
 	addOracleDaoMemberDetailsCalls(contracts, contracts.Multicaller, &details)
 
-	_, err := contracts.Multicaller.FlexibleCall(true, opts)
+	_, err = contracts.Multicaller.FlexibleCall(true, opts)
 	if err != nil {
 		return OracleDaoMemberDetails{}, fmt.Errorf("error executing multicall: %w", err)
 	}
@@ -58,6 +57,10 @@ func GetAllOracleDaoMemberDetails(rp *rocketpool.RocketPool, contracts *NetworkC
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketDAONodeTrusted")
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the list of all minipool addresses
 	addresses, err := getOdaoAddresses(rp, contracts, opts)
@@ -79,28 +82,29 @@ func getOdaoAddresses(rp *rocketpool.RocketPool, contracts *NetworkContracts, op
 
 	// Sync
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	addresses := make([]common.Address, memberCount)
 
 	// Run the getters in batches
 	count := int(memberCount)
-	for i := 0; i < count; i += minipoolAddressBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().OracleDaoAddressBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + oDaoAddressBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
 			for j := i; j < max; j++ {
 				mc.AddCall(contracts.RocketDAONodeTrusted, &addresses[j], "getMemberAt", big.NewInt(int64(j)))
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
@@ -121,18 +125,19 @@ func getOracleDaoDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts,
 
 	// Get the details in batches
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	count := len(addresses)
-	for i := 0; i < count; i += minipoolBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().OracleDaoDetailsBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + minipoolBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -144,7 +149,7 @@ func getOracleDaoDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts,
 
 				addOracleDaoMemberDetailsCalls(contracts, mc, details)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
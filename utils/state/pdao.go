@@ -14,10 +14,6 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	pDaoPropDetailsBatchSize int = 50
-)
-
 // Proposal details
 type protocolDaoProposalDetailsRaw struct {
 	ID                   uint64
@@ -54,6 +50,10 @@ func GetProtocolDaoProposalDetails(rp *rocketpool.RocketPool, contracts *Network
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketDAOProtocolProposal", "rocketDAOProtocolVerifier")
+	if err != nil {
+		return protocol.ProtocolDaoProposalDetails{}, err
+	}
 
 	details := protocol.ProtocolDaoProposalDetails{}
 	rawDetails := protocolDaoProposalDetailsRaw{}
@@ -61,7 +61,7 @@ func GetProtocolDaoProposalDetails(rp *rocketpool.RocketPool, contracts *Network
 
 	addProposalCalls(contracts, contracts.Multicaller, &rawDetails)
 
-	_, err := contracts.Multicaller.FlexibleCall(true, opts)
+	_, err = contracts.Multicaller.FlexibleCall(true, opts)
 	if err != nil {
 		return details, fmt.Errorf("error executing multicall: %w", err)
 	}
@@ -76,6 +76,10 @@ func GetAllProtocolDaoProposalDetails(rp *rocketpool.RocketPool, contracts *Netw
 	opts := &bind.CallOpts{
 		BlockNumber: contracts.ElBlockNumber,
 	}
+	err := contracts.Require("rocketDAOProtocolProposal", "rocketDAOProtocolVerifier")
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the number of proposals available
 	propCount, err := protocol.GetTotalProposalCount(rp, opts)
@@ -97,18 +101,19 @@ func getProposalDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts,
 
 	// Get the details in batches
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(contracts.getQueryOptions().getThreadLimit())
 	count := len(propDetailsRaw)
-	for i := 0; i < count; i += pDaoPropDetailsBatchSize {
+	batchSize := getBatchSize(contracts.getQueryOptions().ProtocolDaoProposalBatchSize)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + pDaoPropDetailsBatchSize
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			var err error
-			mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+			mc, err := contracts.newMultiCaller(rp)
 			if err != nil {
 				return err
 			}
@@ -119,7 +124,7 @@ func getProposalDetails(rp *rocketpool.RocketPool, contracts *NetworkContracts,
 
 				addProposalCalls(contracts, mc, details)
 			}
-			_, err = mc.FlexibleCall(true, opts)
+			_, err = contracts.executeBatch(mc, true, opts)
 			if err != nil {
 				return fmt.Errorf("error executing multicall: %w", err)
 			}
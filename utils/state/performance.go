@@ -0,0 +1,94 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/beacon"
+)
+
+// A summary of the Beacon Chain performance of a node's minipools
+type NodePerformanceSummary struct {
+	NodeAddress          common.Address          `json:"node_address"`
+	MinipoolCount        int                     `json:"minipool_count"`
+	StakingMinipoolCount int                     `json:"staking_minipool_count"`
+	ActiveValidatorCount int                     `json:"active_validator_count"`
+	AverageEffectiveness float64                 `json:"average_effectiveness"` // The mean attestation effectiveness of the validators that had attestation duties
+	ProposalsExpected    uint64                  `json:"proposals_expected"`
+	ProposalsMissed      uint64                  `json:"proposals_missed"`
+	SlashedValidators    []types.ValidatorPubkey `json:"slashed_validators"`
+}
+
+// Get a performance summary for each node, joining the minipool details with the Beacon Chain performance of their
+// validators
+// Only staking minipools are looked up with the provider; every node with a minipool gets a summary
+func GetNodePerformanceSummaries(ctx context.Context, provider beacon.PerformanceProvider, minipools []NativeMinipoolDetails) (map[common.Address]*NodePerformanceSummary, error) {
+	// Get the performance of the staking minipools
+	pubkeys := []types.ValidatorPubkey{}
+	for _, mpd := range minipools {
+		if mpd.Status == types.Staking {
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+	performances := map[types.ValidatorPubkey]beacon.ValidatorPerformance{}
+	if len(pubkeys) > 0 {
+		results, err := provider.GetValidatorPerformance(ctx, pubkeys)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator performance: %w", err)
+		}
+		for _, result := range results {
+			performances[result.Pubkey] = result
+		}
+	}
+
+	// Build the summaries
+	summaries := map[common.Address]*NodePerformanceSummary{}
+	effectivenessTotals := map[common.Address]float64{}
+	effectivenessCounts := map[common.Address]int{}
+	for _, mpd := range minipools {
+		summary, exists := summaries[mpd.NodeAddress]
+		if !exists {
+			summary = &NodePerformanceSummary{
+				NodeAddress:       mpd.NodeAddress,
+				SlashedValidators: []types.ValidatorPubkey{},
+			}
+			summaries[mpd.NodeAddress] = summary
+		}
+		summary.MinipoolCount++
+		if mpd.Status != types.Staking {
+			continue
+		}
+		summary.StakingMinipoolCount++
+
+		performance, exists := performances[mpd.Pubkey]
+		if !exists {
+			if mpd.Slashed {
+				summary.SlashedValidators = append(summary.SlashedValidators, mpd.Pubkey)
+			}
+			continue
+		}
+		if performance.Active {
+			summary.ActiveValidatorCount++
+		}
+		if performance.Slashed || mpd.Slashed {
+			summary.SlashedValidators = append(summary.SlashedValidators, mpd.Pubkey)
+		}
+		summary.ProposalsExpected += performance.ProposalsExpected
+		summary.ProposalsMissed += performance.ProposalsMissed
+		if effectiveness, ok := performance.GetAttestationEffectiveness(); ok {
+			effectivenessTotals[mpd.NodeAddress] += effectiveness
+			effectivenessCounts[mpd.NodeAddress]++
+		}
+	}
+	for address, count := range effectivenessCounts {
+		summaries[address].AverageEffectiveness = effectivenessTotals[address] / float64(count)
+	}
+	return summaries, nil
+}
+
+// Get a performance summary for each node in the network state
+func (s *NetworkState) GetNodePerformanceSummaries(ctx context.Context, provider beacon.PerformanceProvider) (map[common.Address]*NodePerformanceSummary, error) {
+	return GetNodePerformanceSummaries(ctx, provider, s.MinipoolDetails)
+}
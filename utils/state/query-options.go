@@ -0,0 +1,107 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// Settings that control how much load the bulk state getters put on the execution client
+type QueryOptions struct {
+	// The maximum number of batches to run concurrently
+	ThreadLimit int
+
+	// The maximum number of multicall and balance requests to send per second; 0 means unlimited
+	// Every request counts, including each part of a batch that had to be split to fit the provider's limits
+	RequestsPerSecond float64
+
+	// Batch sizes
+	MinipoolBatchSize              int
+	MinipoolCompleteShareBatchSize int
+	MinipoolAddressBatchSize       int
+	MinipoolVersionBatchSize       int
+	NodeBatchSize                  int
+	NodeAddressBatchSize           int
+	OracleDaoAddressBatchSize      int
+	OracleDaoDetailsBatchSize      int
+	ProtocolDaoProposalBatchSize   int
+	EffectiveStakeBatchSize        int
+
+	limiterLock sync.Mutex
+	nextRequest time.Time
+}
+
+// Get the default query options
+func NewDefaultQueryOptions() *QueryOptions {
+	return &QueryOptions{
+		ThreadLimit:                    10,
+		RequestsPerSecond:              0,
+		MinipoolBatchSize:              100,
+		MinipoolCompleteShareBatchSize: 500,
+		MinipoolAddressBatchSize:       1000,
+		MinipoolVersionBatchSize:       500,
+		NodeBatchSize:                  100,
+		NodeAddressBatchSize:           1000,
+		OracleDaoAddressBatchSize:      1000,
+		OracleDaoDetailsBatchSize:      50,
+		ProtocolDaoProposalBatchSize:   50,
+		EffectiveStakeBatchSize:        250,
+	}
+}
+
+// Get the query options for a contracts container, falling back to the defaults if they haven't been set
+// The getters run concurrently, so the defaults are only set once
+func (c *NetworkContracts) getQueryOptions() *QueryOptions {
+	c.queryOptionsOnce.Do(func() {
+		if c.QueryOptions == nil {
+			c.QueryOptions = NewDefaultQueryOptions()
+		}
+	})
+	return c.QueryOptions
+}
+
+// Get the thread limit, guarding against invalid values
+func (o *QueryOptions) getThreadLimit() int {
+	if o.ThreadLimit < 1 {
+		return 1
+	}
+	return o.ThreadLimit
+}
+
+// Get a batch size, guarding against invalid values
+func getBatchSize(size int) int {
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// Block until the next request is allowed by the rate limit
+func (o *QueryOptions) waitForRequest() {
+	if o.RequestsPerSecond <= 0 {
+		return
+	}
+	interval := time.Duration(float64(time.Second) / o.RequestsPerSecond)
+
+	// Reserve the next slot
+	o.limiterLock.Lock()
+	now := time.Now()
+	slot := o.nextRequest
+	if slot.Before(now) {
+		slot = now
+	}
+	o.nextRequest = slot.Add(interval)
+	o.limiterLock.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+// Applies a container's request rate limit to its multicallers and batchers
+// The query options are read on every request, so they can still be replaced after the container is created
+type queryRateLimiter struct {
+	contracts *NetworkContracts
+}
+
+// Block until the next request is allowed by the container's rate limit
+func (l queryRateLimiter) WaitForRequest() {
+	l.contracts.getQueryOptions().waitForRequest()
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Create a rewards estimator for the current interval from the network state
+// Pass the RPL inflation parameters (see tokens.GetInflationCalc) to include RPL that hasn't been minted yet
+func (s *NetworkState) NewRewardsEstimator(inflation *tokens.InflationCalc) *rewards.Estimator {
+	totalEffectiveStake := big.NewInt(0)
+	for _, node := range s.NodeDetails {
+		totalEffectiveStake.Add(totalEffectiveStake, node.EffectiveRPLStake)
+	}
+	smoothingPoolMinipoolCount := uint64(0)
+	for _, mpd := range s.MinipoolDetails {
+		node, exists := s.NodeDetailsByAddress[mpd.NodeAddress]
+		if exists && node.SmoothingPoolRegistrationState && mpd.Status == types.Staking {
+			smoothingPoolMinipoolCount++
+		}
+	}
+
+	return &rewards.Estimator{
+		IntervalStart:              s.NetworkDetails.IntervalStart,
+		IntervalDuration:           s.NetworkDetails.IntervalDuration,
+		Inflation:                  inflation,
+		PendingRPLRewards:          s.NetworkDetails.PendingRPLRewards,
+		NodeOperatorRewardsPercent: s.NetworkDetails.NodeOperatorRewardsPercent,
+		TotalEffectiveRPLStake:     totalEffectiveStake,
+		SmoothingPoolBalance:       s.NetworkDetails.SmoothingPoolBalance,
+		SmoothingPoolMinipoolCount: smoothingPoolMinipoolCount,
+	}
+}
+
+// Project a node's rewards for the current interval with an estimator created from the state
+// Returns false if the node doesn't exist in the state
+func (s *NetworkState) EstimateNodeRewards(estimator *rewards.Estimator, nodeAddress common.Address, at time.Time) (rewards.NodeRewardsEstimate, bool) {
+	node, exists := s.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return rewards.NodeRewardsEstimate{}, false
+	}
+	minipools := []rewards.EstimatorMinipool{}
+	for _, mpd := range s.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Status == types.Staking {
+			minipools = append(minipools, rewards.EstimatorMinipool{
+				NodeDepositBalance: mpd.NodeDepositBalance,
+				NodeFee:            mpd.NodeFee,
+			})
+		}
+	}
+	return estimator.EstimateNodeRewards(node.RplStake, node.EffectiveRPLStake, node.SmoothingPoolRegistrationState, minipools, at), true
+}
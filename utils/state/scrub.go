@@ -0,0 +1,159 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/beacon"
+)
+
+// The amount of the prelaunch deposit of Atlas (v3) minipools, in gwei
+const prelaunchDepositAmountGwei uint64 = 1e9
+
+// The result of checking a prelaunch minipool's Beacon deposits against its expected withdrawal credentials
+type ScrubRecommendation struct {
+	MinipoolAddress     common.Address           `json:"minipool_address"`
+	NodeAddress         common.Address           `json:"node_address"`
+	Pubkey              types.ValidatorPubkey    `json:"pubkey"`
+	Version             uint8                    `json:"version"`
+	ExpectedCredentials common.Hash              `json:"expected_credentials"`
+	Deposits            []utils.ValidatedDeposit `json:"deposits"`
+	BeaconCredentials   *common.Hash             `json:"beacon_credentials"` // Nil if the validator isn't on the Beacon Chain yet or it wasn't checked
+	DepositFound        bool                     `json:"deposit_found"`      // A deposit with a valid signature was found
+	ShouldScrub         bool                     `json:"should_scrub"`
+	Reason              string                   `json:"reason"`
+}
+
+// Get the transaction that votes to scrub the minipool; only Oracle DAO members can submit it
+func (r ScrubRecommendation) GetVoteScrubTransaction(rp *rocketpool.RocketPool) (rocketpool.BatchTransaction, error) {
+	mp, err := minipool.NewMinipoolFromVersion(rp, r.MinipoolAddress, r.Version, nil)
+	if err != nil {
+		return rocketpool.BatchTransaction{}, err
+	}
+	return rocketpool.BatchTransaction{
+		Name:     fmt.Sprintf("vote to scrub minipool %s", r.MinipoolAddress.Hex()),
+		Estimate: mp.EstimateVoteScrubGas,
+		Submit:   mp.VoteScrub,
+		Describe: mp.GetContract().DescribeCall("voteScrub"),
+	}, nil
+}
+
+// Get the vote scrub transactions for the minipools recommended for scrubbing
+func GetVoteScrubTransactions(rp *rocketpool.RocketPool, recommendations []ScrubRecommendation) ([]rocketpool.BatchTransaction, error) {
+	txs := []rocketpool.BatchTransaction{}
+	for _, recommendation := range recommendations {
+		if !recommendation.ShouldScrub {
+			continue
+		}
+		tx, err := recommendation.GetVoteScrubTransaction(rp)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// Check the Beacon deposits of every prelaunch minipool and recommend which ones to scrub
+// Deposit signatures are checked for the chain with the given genesis fork version.
+// Deposits are scanned from depositStartBlock (e.g. the deposit contract's deployment block) in chunks of intervalSize blocks.
+// If fetcher is nil, the validators' withdrawal credentials on the Beacon Chain aren't checked.
+func GetScrubRecommendations(rp *rocketpool.RocketPool, contracts *NetworkContracts, fetcher *beacon.BalanceFetcher, beaconStateID string, genesisForkVersion [4]byte, depositStartBlock *big.Int, intervalSize *big.Int) ([]ScrubRecommendation, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+
+	// Get the prelaunch minipools
+	details, err := GetAllNativeMinipoolDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool details: %w", err)
+	}
+	prelaunch := []NativeMinipoolDetails{}
+	for _, mpd := range details {
+		if mpd.Status == types.Prelaunch && !mpd.IsVacant {
+			prelaunch = append(prelaunch, mpd)
+		}
+	}
+	if len(prelaunch) == 0 {
+		return []ScrubRecommendation{}, nil
+	}
+
+	// Get their deposits
+	expectedCredentials := make(map[types.ValidatorPubkey]common.Hash, len(prelaunch))
+	pubkeyList := make([]types.ValidatorPubkey, len(prelaunch))
+	for i, mpd := range prelaunch {
+		expectedCredentials[mpd.Pubkey] = mpd.WithdrawalCredentials
+		pubkeyList[i] = mpd.Pubkey
+	}
+	validations, err := utils.ValidateDeposits(rp, expectedCredentials, genesisForkVersion, depositStartBlock, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon deposits: %w", err)
+	}
+
+	// Get their withdrawal credentials on the Beacon Chain
+	var beaconCredentials map[types.ValidatorPubkey]common.Hash
+	if fetcher != nil {
+		beaconCredentials, err = fetcher.GetWithdrawalCredentialsByPubkey(beaconStateID, pubkeyList)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon withdrawal credentials: %w", err)
+		}
+	}
+
+	// Check each minipool
+	recommendations := make([]ScrubRecommendation, len(prelaunch))
+	for i, mpd := range prelaunch {
+		recommendation := ScrubRecommendation{
+			MinipoolAddress:     mpd.MinipoolAddress,
+			NodeAddress:         mpd.NodeAddress,
+			Pubkey:              mpd.Pubkey,
+			Version:             mpd.Version,
+			ExpectedCredentials: mpd.WithdrawalCredentials,
+			Deposits:            validations[mpd.Pubkey].Deposits,
+		}
+		if credentials, exists := beaconCredentials[mpd.Pubkey]; exists {
+			recommendation.BeaconCredentials = &credentials
+		}
+		checkPrelaunchDeposits(&recommendation, validations[mpd.Pubkey])
+		recommendations[i] = recommendation
+	}
+
+	// Return
+	return recommendations, nil
+}
+
+// Check a prelaunch minipool's deposits and set its recommendation
+// The first deposit with a valid signature sets the validator's withdrawal credentials, so it's the one that matters
+func checkPrelaunchDeposits(recommendation *ScrubRecommendation, validation utils.DepositValidation) {
+	if validation.InitialDeposit == nil {
+		// The deposit may be outside of the scanned range, so don't recommend a scrub
+		if len(validation.Deposits) == 0 {
+			recommendation.Reason = "no deposit found for the minipool's pubkey"
+		} else {
+			recommendation.Reason = "no deposit with a valid signature found for the minipool's pubkey"
+		}
+		return
+	}
+	recommendation.DepositFound = true
+
+	first := validation.InitialDeposit
+	if validation.CredentialsMismatch {
+		recommendation.ShouldScrub = true
+		recommendation.Reason = fmt.Sprintf("initial deposit in transaction %s has withdrawal credentials %s instead of %s", first.TxHash.Hex(), first.WithdrawalCredentials.Hex(), recommendation.ExpectedCredentials.Hex())
+		return
+	}
+	if recommendation.Version >= 3 && first.Amount != prelaunchDepositAmountGwei {
+		recommendation.ShouldScrub = true
+		recommendation.Reason = fmt.Sprintf("initial deposit in transaction %s is %d gwei instead of %d gwei", first.TxHash.Hex(), first.Amount, prelaunchDepositAmountGwei)
+		return
+	}
+	if recommendation.BeaconCredentials != nil && *recommendation.BeaconCredentials != recommendation.ExpectedCredentials {
+		recommendation.ShouldScrub = true
+		recommendation.Reason = fmt.Sprintf("validator has withdrawal credentials %s on the Beacon Chain instead of %s", recommendation.BeaconCredentials.Hex(), recommendation.ExpectedCredentials.Hex())
+	}
+}